@@ -34,10 +34,58 @@ func setDefaults() {
 	viper.SetDefault("log.dir", "logs")
 	viper.SetDefault("jwt.auth_expire", 30)
 	viper.SetDefault("jwt.refresh_expire", 150)
+	viper.SetDefault("jwt.algorithm", "HS512")
+	viper.SetDefault("jwt.rotation_interval", "720h")
+	viper.SetDefault("jwt.rotation_grace", "24h")
 	viper.SetDefault("sms.provider", "mock")
 	viper.SetDefault("email.provider", "mock")
 	viper.SetDefault("ark_api.provider", "mock")
 	viper.SetDefault("es.bootstrap_indexes", true)
+	viper.SetDefault("im.recall_window", "2m")
+	viper.SetDefault("feed.big_v_threshold", 5000)
+	viper.SetDefault("recommend.candidate_days", 7)
+	viper.SetDefault("recommend.candidate_size", 1000)
+	viper.SetDefault("recommend.result_size", 200)
+	viper.SetDefault("recommend.freshness_half_life", "24h")
+	viper.SetDefault("recommend.weights.interest", 0.6)
+	viper.SetDefault("recommend.weights.hot", 0.25)
+	viper.SetDefault("recommend.weights.freshness", 0.15)
+	viper.SetDefault("oauth.state_ttl", "10m")
+	viper.SetDefault("oauth.github.enabled", false)
+	viper.SetDefault("oauth.oidc.enabled", false)
+	viper.SetDefault("oauth.oidc.name", "oidc")
+	viper.SetDefault("two_factor.issuer", "LinkMe")
+	viper.SetDefault("two_factor.challenge_ttl", "5m")
+	viper.SetDefault("two_factor.recovery_codes", 10)
+	viper.SetDefault("two_factor.enforce_roles", []string{"admin"})
+	viper.SetDefault("password_reset.token_ttl", "15m")
+	viper.SetDefault("password_reset.cooldown", "1m")
+	viper.SetDefault("password_reset.max_attempts", 5)
+	viper.SetDefault("password_reset.attempt_window", "15m")
+	viper.SetDefault("account.deletion_grace", "168h")
+	viper.SetDefault("account.deletion_mode", "anonymize")
+	viper.SetDefault("account.export_dir", "data/exports")
+	viper.SetDefault("account.export_ttl", "24h")
+	viper.SetDefault("reputation.points.post_approved", 10)
+	viper.SetDefault("reputation.points.like_received", 2)
+	viper.SetDefault("reputation.points.collect_received", 3)
+	viper.SetDefault("reputation.points.comment", 1)
+	viper.SetDefault("reputation.points.violation", -20)
+	viper.SetDefault("registration.mode", "open")
+	viper.SetDefault("registration.invite_min_level", 3)
+	viper.SetDefault("registration.invite_max_uses", 5)
+	viper.SetDefault("registration.invite_max_active", 5)
+	viper.SetDefault("registration.invite_ttl", "168h")
+	viper.SetDefault("login_guard.free_attempts", 3)
+	viper.SetDefault("login_guard.base_delay", "1s")
+	viper.SetDefault("login_guard.max_delay", "30s")
+	viper.SetDefault("login_guard.max_attempts", 10)
+	viper.SetDefault("login_guard.ip_free_attempts", 20)
+	viper.SetDefault("login_guard.ip_max_attempts", 100)
+	viper.SetDefault("login_guard.lock_duration", "15m")
+	viper.SetDefault("login_guard.failure_window", "15m")
+	viper.SetDefault("login_guard.notify", true)
+	viper.SetDefault("audit.retention", "4320h")
 	viper.SetDefault("cors.allow_all", false)
 	viper.SetDefault("cors.allow_origins", []string{
 		"http://localhost:3000",
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"errors"
+	"time"
+
+	"github.com/GoSimplicity/LinkMe/internal/api/req"
+	. "github.com/GoSimplicity/LinkMe/internal/constants"
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/service"
+	. "github.com/GoSimplicity/LinkMe/pkg/ginp"
+	"github.com/gin-gonic/gin"
+)
+
+// AccessTokenHandler 个人访问令牌，供机器人与脚本调用接口
+type AccessTokenHandler struct {
+	svc service.AccessTokenService
+}
+
+func NewAccessTokenHandler(svc service.AccessTokenService) *AccessTokenHandler {
+	return &AccessTokenHandler{
+		svc: svc,
+	}
+}
+
+func (ah *AccessTokenHandler) RegisterRoutes(server *gin.Engine) {
+	tokenGroup := server.Group("/api/tokens")
+	tokenGroup.POST("/create", WrapBody(ah.Create)) // 创建令牌
+	tokenGroup.GET("/list", WrapQuery(ah.List))     // 我的令牌
+	tokenGroup.POST("/revoke", WrapBody(ah.Revoke)) // 撤销令牌
+}
+
+// Create 创建令牌，明文仅在此时返回一次
+func (ah *AccessTokenHandler) Create(ctx *gin.Context, req req.CreateAccessTokenReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: CreateAccessTokenErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+	if req.ExpireDays < 0 {
+		return Result{
+			Code: CreateAccessTokenErrorCode,
+			Msg:  "有效天数不能为负数",
+		}, nil
+	}
+
+	plain, token, err := ah.svc.Create(ctx, uc.Uid, req.Name, req.Scopes, time.Duration(req.ExpireDays)*24*time.Hour)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidScopes) ||
+			errors.Is(err, service.ErrModerateNotAllowed) ||
+			errors.Is(err, service.ErrAccessTokenLimit) {
+			return Result{Code: CreateAccessTokenErrorCode, Msg: err.Error()}, nil
+		}
+		return Result{
+			Code: CreateAccessTokenErrorCode,
+			Msg:  CreateAccessTokenErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  CreateAccessTokenSuccessMsg,
+		Data: gin.H{
+			"token": plain,
+			"info":  token,
+		},
+	}, nil
+}
+
+// List 获取我的令牌，不包含明文
+func (ah *AccessTokenHandler) List(ctx *gin.Context, _ req.ListAccessTokensReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: ListAccessTokensErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	tokens, err := ah.svc.List(ctx, uc.Uid)
+	if err != nil {
+		return Result{
+			Code: ListAccessTokensErrorCode,
+			Msg:  ListAccessTokensErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  ListAccessTokensSuccessMsg,
+		Data: tokens,
+	}, nil
+}
+
+// Revoke 撤销令牌
+func (ah *AccessTokenHandler) Revoke(ctx *gin.Context, req req.RevokeAccessTokenReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: RevokeAccessTokenErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	if err := ah.svc.Revoke(ctx, uc.Uid, req.ID); err != nil {
+		if errors.Is(err, service.ErrAccessTokenNotFound) {
+			return Result{Code: RevokeAccessTokenErrorCode, Msg: err.Error()}, nil
+		}
+		return Result{
+			Code: RevokeAccessTokenErrorCode,
+			Msg:  RevokeAccessTokenErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  RevokeAccessTokenSuccessMsg,
+	}, nil
+}
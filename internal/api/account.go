@@ -0,0 +1,202 @@
+package api
+
+import (
+	"errors"
+	"path/filepath"
+
+	"github.com/GoSimplicity/LinkMe/internal/api/req"
+	. "github.com/GoSimplicity/LinkMe/internal/constants"
+	"github.com/GoSimplicity/LinkMe/internal/service"
+	"github.com/GoSimplicity/LinkMe/pkg/apiresponse"
+	. "github.com/GoSimplicity/LinkMe/pkg/ginp"
+	ijwt "github.com/GoSimplicity/LinkMe/utils/jwt"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// AccountHandler 账号注销与个人数据导出
+type AccountHandler struct {
+	svc      service.AccountService
+	tokenSvc service.AccessTokenService
+	ijwt     ijwt.Handler
+	l        *zap.Logger
+}
+
+func NewAccountHandler(svc service.AccountService, tokenSvc service.AccessTokenService, j ijwt.Handler, l *zap.Logger) *AccountHandler {
+	return &AccountHandler{
+		svc:      svc,
+		tokenSvc: tokenSvc,
+		ijwt:     j,
+		l:        l,
+	}
+}
+
+func (ah *AccountHandler) RegisterRoutes(server *gin.Engine) {
+	userGroup := server.Group("/api/user")
+	userGroup.DELETE("/write_off", WrapBody(ah.WriteOff))            // 申请注销，冷静期后清理数据
+	userGroup.GET("/write_off/status", WrapQuery(ah.DeletionStatus)) // 注销申请状态
+	userGroup.POST("/write_off/cancel", WrapBody(ah.CancelDeletion)) // 冷静期内撤销注销
+	userGroup.POST("/data_export", WrapBody(ah.RequestExport))       // 申请导出个人数据
+	userGroup.GET("/data_export/status", WrapQuery(ah.ExportStatus)) // 导出任务状态
+	userGroup.GET("/data_export/download", ah.DownloadExport)        // 下载导出文件
+}
+
+// WriteOff 申请注销，成功后下线全部会话
+func (ah *AccountHandler) WriteOff(ctx *gin.Context, req req.DeleteUserReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: AccountDeletionErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	deletion, err := ah.svc.RequestDeletion(ctx, uc.Uid, req.Username, req.Password)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidUserOrPassword) || errors.Is(err, service.ErrDeletionPending) {
+			return Result{Code: AccountDeletionErrorCode, Msg: err.Error()}, nil
+		}
+		return Result{
+			Code: AccountDeletionErrorCode,
+			Msg:  AccountDeletionErrorMsg,
+		}, err
+	}
+
+	// 注销申请后强制下线，冷静期内需登录后调用 /write_off/cancel 撤销，会话清理失败只记录日志
+	if err := ah.ijwt.ClearUserSessions(ctx, uc.Uid); err != nil {
+		ah.l.Error("申请注销后清除会话失败", zap.Int64("uid", uc.Uid), zap.Error(err))
+	}
+	if err := ah.tokenSvc.RevokeAll(ctx, uc.Uid); err != nil {
+		ah.l.Error("申请注销后撤销访问令牌失败", zap.Int64("uid", uc.Uid), zap.Error(err))
+	}
+
+	return Result{
+		Code: RequestsOK,
+		Msg:  AccountDeletionSuccessMsg,
+		Data: deletion,
+	}, nil
+}
+
+// DeletionStatus 获取注销申请状态
+func (ah *AccountHandler) DeletionStatus(ctx *gin.Context, _ req.DeletionStatusReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: AccountDeletionStatusErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	deletion, err := ah.svc.DeletionStatus(ctx, uc.Uid)
+	if err != nil {
+		return Result{
+			Code: AccountDeletionStatusErrorCode,
+			Msg:  AccountDeletionStatusErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  AccountDeletionStatusMsg,
+		Data: deletion,
+	}, nil
+}
+
+// CancelDeletion 撤销注销申请
+func (ah *AccountHandler) CancelDeletion(ctx *gin.Context, _ req.CancelDeletionReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: AccountDeletionCancelErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	if err := ah.svc.CancelDeletion(ctx, uc.Uid); err != nil {
+		if errors.Is(err, service.ErrNoPendingDeletion) {
+			return Result{Code: AccountDeletionCancelErrorCode, Msg: err.Error()}, nil
+		}
+		return Result{
+			Code: AccountDeletionCancelErrorCode,
+			Msg:  AccountDeletionCancelErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  AccountDeletionCancelMsg,
+	}, nil
+}
+
+// RequestExport 申请导出个人数据
+func (ah *AccountHandler) RequestExport(ctx *gin.Context, _ req.DataExportReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: DataExportErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	export, err := ah.svc.RequestExport(ctx, uc.Uid)
+	if err != nil {
+		if errors.Is(err, service.ErrExportInProgress) {
+			return Result{Code: DataExportErrorCode, Msg: err.Error()}, nil
+		}
+		return Result{
+			Code: DataExportErrorCode,
+			Msg:  DataExportErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  DataExportSuccessMsg,
+		Data: export,
+	}, nil
+}
+
+// ExportStatus 获取最近一次导出任务状态
+func (ah *AccountHandler) ExportStatus(ctx *gin.Context, _ req.DataExportStatusReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: DataExportStatusErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	export, err := ah.svc.ExportStatus(ctx, uc.Uid)
+	if err != nil {
+		if errors.Is(err, service.ErrExportNotReady) {
+			return Result{Code: DataExportStatusErrorCode, Msg: err.Error()}, nil
+		}
+		return Result{
+			Code: DataExportStatusErrorCode,
+			Msg:  DataExportStatusErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  DataExportStatusSuccessMsg,
+		Data: export,
+	}, nil
+}
+
+// DownloadExport 下载导出文件
+func (ah *AccountHandler) DownloadExport(ctx *gin.Context) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	path, err := ah.svc.ExportFile(ctx, uc.Uid)
+	if err != nil {
+		if errors.Is(err, service.ErrExportNotReady) {
+			apiresponse.ErrorWithMessage(ctx, err.Error())
+			return
+		}
+		ah.l.Error("获取导出文件失败", zap.Int64("uid", uc.Uid), zap.Error(err))
+		apiresponse.ErrorWithMessage(ctx, DataExportDownloadErrorMsg)
+		return
+	}
+
+	ctx.FileAttachment(path, filepath.Base(path))
+}
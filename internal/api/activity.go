@@ -11,11 +11,11 @@ import (
 )
 
 type ActivityHandler struct {
-	ce  *casbin.Enforcer
+	ce  *casbin.SyncedEnforcer
 	svc service.ActivityService
 }
 
-func NewActivityHandler(svc service.ActivityService, ce *casbin.Enforcer) *ActivityHandler {
+func NewActivityHandler(svc service.ActivityService, ce *casbin.SyncedEnforcer) *ActivityHandler {
 	return &ActivityHandler{
 		svc: svc,
 		ce:  ce,
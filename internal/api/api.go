@@ -6,30 +6,36 @@ import (
 	"github.com/GoSimplicity/LinkMe/internal/api/req"
 	"github.com/GoSimplicity/LinkMe/internal/domain"
 	"github.com/GoSimplicity/LinkMe/internal/service"
+	"github.com/GoSimplicity/LinkMe/middleware"
 	"github.com/GoSimplicity/LinkMe/pkg/apiresponse"
+	"github.com/casbin/casbin/v2"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
 
 type ApiHandler struct {
 	svc service.ApiService
+	ce  *casbin.SyncedEnforcer
 	l   *zap.Logger
 }
 
-func NewApiHandler(svc service.ApiService, l *zap.Logger) *ApiHandler {
+func NewApiHandler(svc service.ApiService, ce *casbin.SyncedEnforcer, l *zap.Logger) *ApiHandler {
 	return &ApiHandler{
 		svc: svc,
+		ce:  ce,
 		l:   l,
 	}
 }
 
 func (h *ApiHandler) RegisterRoutes(server *gin.Engine) {
-	apiGroup := server.Group("/api/apis")
+	casbinMiddleware := middleware.NewCasbinMiddleware(h.ce)
+	apiGroup := server.Group("/api/apis", casbinMiddleware.CheckCasbin())
 
 	apiGroup.POST("/list", h.ListApis)
 	apiGroup.POST("/create", h.CreateAPI)
 	apiGroup.POST("/update", h.UpdateAPI)
 	apiGroup.DELETE("/:id", h.DeleteAPI)
+	apiGroup.GET("/uncovered", h.ListUncoveredApis) // 未配置权限策略的路由
 }
 
 // ListApis 获取API列表
@@ -132,3 +138,18 @@ func (a *ApiHandler) DeleteAPI(c *gin.Context) {
 
 	apiresponse.Success(c)
 }
+
+// ListUncoveredApis 获取没有配置任何权限策略的路由
+func (a *ApiHandler) ListUncoveredApis(c *gin.Context) {
+	apis, err := a.svc.ListUncoveredApis(c.Request.Context())
+	if err != nil {
+		a.l.Error("获取未配置权限的路由失败", zap.Error(err))
+		apiresponse.Error(c)
+		return
+	}
+
+	apiresponse.SuccessWithData(c, gin.H{
+		"list":  apis,
+		"total": len(apis),
+	})
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/GoSimplicity/LinkMe/internal/api/req"
+	. "github.com/GoSimplicity/LinkMe/internal/constants"
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/service"
+	"github.com/GoSimplicity/LinkMe/middleware"
+	. "github.com/GoSimplicity/LinkMe/pkg/ginp"
+	"github.com/casbin/casbin/v2"
+	"github.com/gin-gonic/gin"
+)
+
+// AuditHandler 管理员与版主操作的审计记录查询
+type AuditHandler struct {
+	svc service.AuditService
+	ce  *casbin.SyncedEnforcer
+}
+
+func NewAuditHandler(svc service.AuditService, ce *casbin.SyncedEnforcer) *AuditHandler {
+	return &AuditHandler{
+		svc: svc,
+		ce:  ce,
+	}
+}
+
+func (ah *AuditHandler) RegisterRoutes(server *gin.Engine) {
+	casbinMiddleware := middleware.NewCasbinMiddleware(ah.ce)
+	auditGroup := server.Group("/api/audit", casbinMiddleware.CheckCasbin())
+	auditGroup.POST("/logs", WrapBody(ah.ListLogs)) // 审计记录
+}
+
+// ListLogs 按操作人、操作类型、对象与时间范围分页查询审计记录
+func (ah *AuditHandler) ListLogs(ctx *gin.Context, req req.ListAuditLogsReq) (Result, error) {
+	logs, total, err := ah.svc.List(ctx, domain.AuditFilter{
+		ActorID:    req.ActorID,
+		Action:     req.Action,
+		TargetType: req.TargetType,
+		TargetID:   req.TargetID,
+		StartTime:  req.StartTime,
+		EndTime:    req.EndTime,
+	}, domain.Pagination{
+		Page: req.Page,
+		Size: req.Size,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidAuditQuery) {
+			return Result{Code: ListAuditLogsErrorCode, Msg: err.Error()}, nil
+		}
+		return Result{
+			Code: ListAuditLogsErrorCode,
+			Msg:  ListAuditLogsErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  ListAuditLogsSuccessMsg,
+		Data: gin.H{
+			"list":  logs,
+			"total": total,
+		},
+	}, nil
+}
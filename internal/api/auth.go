@@ -23,15 +23,17 @@ func requireUser(ctx *gin.Context) (ijwt.UserClaims, bool) {
 }
 
 func currentUserID(ctx *gin.Context) int64 {
+	claims, _ := currentClaims(ctx)
+	return claims.Uid
+}
+
+// currentClaims 获取当前登录用户的令牌信息，未登录时返回 false
+func currentClaims(ctx *gin.Context) (ijwt.UserClaims, bool) {
 	user, exists := ctx.Get("user")
 	if !exists {
-		return 0
+		return ijwt.UserClaims{}, false
 	}
 
 	claims, ok := user.(ijwt.UserClaims)
-	if !ok {
-		return 0
-	}
-
-	return claims.Uid
+	return claims, ok
 }
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/GoSimplicity/LinkMe/internal/api/req"
+	. "github.com/GoSimplicity/LinkMe/internal/constants"
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/service"
+	"github.com/GoSimplicity/LinkMe/middleware"
+	. "github.com/GoSimplicity/LinkMe/pkg/ginp"
+	"github.com/casbin/casbin/v2"
+	"github.com/gin-gonic/gin"
+)
+
+// BadgeHandler 勋章
+type BadgeHandler struct {
+	svc service.BadgeService
+	ce  *casbin.SyncedEnforcer
+}
+
+func NewBadgeHandler(svc service.BadgeService, ce *casbin.SyncedEnforcer) *BadgeHandler {
+	return &BadgeHandler{
+		svc: svc,
+		ce:  ce,
+	}
+}
+
+func (bh *BadgeHandler) RegisterRoutes(server *gin.Engine) {
+	casbinMiddleware := middleware.NewCasbinMiddleware(bh.ce)
+	badgeGroup := server.Group("/api/badges")
+	badgeGroup.GET("", WrapQuery(bh.ListBadges))                                           // 已启用的勋章
+	badgeGroup.GET("/user", WrapQuery(bh.GetUserBadges))                                   // 用户已获得的勋章
+	badgeGroup.GET("/manage", casbinMiddleware.CheckCasbin(), WrapQuery(bh.ListAllBadges)) // 全部勋章（管理员使用）
+	badgeGroup.POST("/create", casbinMiddleware.CheckCasbin(), WrapBody(bh.CreateBadge))   // 创建勋章（管理员使用）
+	badgeGroup.POST("/update", casbinMiddleware.CheckCasbin(), WrapBody(bh.UpdateBadge))   // 更新勋章（管理员使用）
+}
+
+// ListBadges 获取已启用的勋章
+func (bh *BadgeHandler) ListBadges(ctx *gin.Context, _ req.ListBadgesReq) (Result, error) {
+	return bh.listBadges(ctx, false)
+}
+
+// ListAllBadges 获取包括已停用在内的全部勋章
+func (bh *BadgeHandler) ListAllBadges(ctx *gin.Context, _ req.ListBadgesReq) (Result, error) {
+	return bh.listBadges(ctx, true)
+}
+
+func (bh *BadgeHandler) listBadges(ctx *gin.Context, includeDisabled bool) (Result, error) {
+	badges, err := bh.svc.ListBadges(ctx, includeDisabled)
+	if err != nil {
+		return Result{
+			Code: ListBadgesErrorCode,
+			Msg:  ListBadgesErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  ListBadgesSuccessMsg,
+		Data: badges,
+	}, nil
+}
+
+// CreateBadge 创建勋章
+func (bh *BadgeHandler) CreateBadge(ctx *gin.Context, req req.CreateBadgeReq) (Result, error) {
+	id, err := bh.svc.CreateBadge(ctx, domain.Badge{
+		Name:        req.Name,
+		Description: req.Description,
+		Icon:        req.Icon,
+		Rule:        domain.BadgeRule(req.Rule),
+		Threshold:   req.Threshold,
+		Enabled:     req.Enabled,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidBadge) {
+			return Result{Code: CreateBadgeErrorCode, Msg: err.Error()}, nil
+		}
+		return Result{
+			Code: CreateBadgeErrorCode,
+			Msg:  CreateBadgeErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  CreateBadgeSuccessMsg,
+		Data: id,
+	}, nil
+}
+
+// UpdateBadge 更新勋章，停用的勋章不再授予
+func (bh *BadgeHandler) UpdateBadge(ctx *gin.Context, req req.UpdateBadgeReq) (Result, error) {
+	err := bh.svc.UpdateBadge(ctx, domain.Badge{
+		ID:          req.ID,
+		Name:        req.Name,
+		Description: req.Description,
+		Icon:        req.Icon,
+		Rule:        domain.BadgeRule(req.Rule),
+		Threshold:   req.Threshold,
+		Enabled:     req.Enabled,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidBadge) || errors.Is(err, service.ErrBadgeNotFound) {
+			return Result{Code: UpdateBadgeErrorCode, Msg: err.Error()}, nil
+		}
+		return Result{
+			Code: UpdateBadgeErrorCode,
+			Msg:  UpdateBadgeErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  UpdateBadgeSuccessMsg,
+	}, nil
+}
+
+// GetUserBadges 获取用户已获得的勋章，未指定用户时返回当前用户
+func (bh *BadgeHandler) GetUserBadges(ctx *gin.Context, req req.GetUserBadgesReq) (Result, error) {
+	uid := req.UserID
+	if uid == 0 {
+		uc, ok := requireUser(ctx)
+		if !ok {
+			return Result{
+				Code: GetUserBadgesErrorCode,
+				Msg:  "未登录或登录已过期",
+			}, nil
+		}
+		uid = uc.Uid
+	}
+
+	badges, err := bh.svc.GetUserBadges(ctx, uid)
+	if err != nil {
+		return Result{
+			Code: GetUserBadgesErrorCode,
+			Msg:  GetUserBadgesErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  GetUserBadgesSuccessMsg,
+		Data: badges,
+	}, nil
+}
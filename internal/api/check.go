@@ -1,6 +1,8 @@
 package api
 
 import (
+	"errors"
+
 	"github.com/GoSimplicity/LinkMe/internal/api/req"
 	"github.com/GoSimplicity/LinkMe/internal/domain"
 	"github.com/GoSimplicity/LinkMe/internal/service"
@@ -41,6 +43,10 @@ func (ch *CheckHandler) ApproveCheck(ctx *gin.Context) {
 	}
 
 	err := ch.svc.ApproveCheck(ctx, req.CheckID, req.Remark, uc.Uid)
+	if errors.Is(err, service.ErrModerateForbidden) {
+		apiresponse.ForbiddenError(ctx, err.Error())
+		return
+	}
 	if err != nil {
 		apiresponse.ErrorWithData(ctx, err)
 		return
@@ -63,6 +69,10 @@ func (ch *CheckHandler) RejectCheck(ctx *gin.Context) {
 	}
 
 	err := ch.svc.RejectCheck(ctx, req.CheckID, req.Remark, uc.Uid)
+	if errors.Is(err, service.ErrModerateForbidden) {
+		apiresponse.ForbiddenError(ctx, err.Error())
+		return
+	}
 	if err != nil {
 		apiresponse.ErrorWithData(ctx, err)
 		return
@@ -78,14 +88,20 @@ func (ch *CheckHandler) ListChecks(ctx *gin.Context) {
 		apiresponse.ErrorWithData(ctx, err)
 		return
 	}
-	if _, ok := requireUser(ctx); !ok {
+	uc, ok := requireUser(ctx)
+	if !ok {
 		return
 	}
 
 	checks, err := ch.svc.ListChecks(ctx, domain.Pagination{
 		Page: req.Page,
 		Size: req.Size,
+		Uid:  uc.Uid,
 	})
+	if errors.Is(err, service.ErrModerateForbidden) {
+		apiresponse.ForbiddenError(ctx, err.Error())
+		return
+	}
 	if err != nil {
 		apiresponse.ErrorWithData(ctx, err)
 		return
@@ -101,11 +117,16 @@ func (ch *CheckHandler) CheckDetail(ctx *gin.Context) {
 		apiresponse.ErrorWithData(ctx, err)
 		return
 	}
-	if _, ok := requireUser(ctx); !ok {
+	uc, ok := requireUser(ctx)
+	if !ok {
 		return
 	}
 
-	check, err := ch.svc.CheckDetail(ctx, req.CheckID)
+	check, err := ch.svc.CheckDetail(ctx, req.CheckID, uc.Uid)
+	if errors.Is(err, service.ErrModerateForbidden) {
+		apiresponse.ForbiddenError(ctx, err.Error())
+		return
+	}
 	if err != nil {
 		apiresponse.ErrorWithData(ctx, err)
 		return
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"errors"
+
 	"github.com/GoSimplicity/LinkMe/internal/api/req"
 	. "github.com/GoSimplicity/LinkMe/internal/constants"
 	"github.com/GoSimplicity/LinkMe/internal/domain"
@@ -58,6 +60,12 @@ func (ch *CommentHandler) CreateComment(ctx *gin.Context, req req.CreateCommentR
 	}
 
 	err := ch.svc.CreateComment(ctx, comment)
+	if errors.Is(err, service.ErrPrivateAccount) {
+		return Result{
+			Code: CreateCommentErrorCode,
+			Msg:  err.Error(),
+		}, nil
+	}
 	if err != nil {
 		return Result{
 			Code: CreateCommentErrorCode,
@@ -72,7 +80,13 @@ func (ch *CommentHandler) CreateComment(ctx *gin.Context, req req.CreateCommentR
 
 // ListComments 列出评论处理器方法
 func (ch *CommentHandler) ListComments(ctx *gin.Context, req req.ListCommentsReq) (Result, error) {
-	comments, err := ch.svc.ListComments(ctx, req.PostId, req.MinId, req.Limit)
+	comments, err := ch.svc.ListComments(ctx, req.PostId, req.MinId, req.Limit, currentUserID(ctx))
+	if errors.Is(err, service.ErrPrivateAccount) {
+		return Result{
+			Code: ListCommentErrorCode,
+			Msg:  err.Error(),
+		}, nil
+	}
 	if err != nil {
 		return Result{
 			Code: ListCommentErrorCode,
@@ -88,14 +102,21 @@ func (ch *CommentHandler) ListComments(ctx *gin.Context, req req.ListCommentsReq
 
 // DeleteComment 删除评论处理器方法
 func (ch *CommentHandler) DeleteComment(ctx *gin.Context, req req.DeleteCommentReq) (Result, error) {
-	if _, ok := requireUser(ctx); !ok {
+	uc, ok := requireUser(ctx)
+	if !ok {
 		return Result{
 			Code: DeleteCommentErrorCode,
 			Msg:  "未登录或登录已过期",
 		}, nil
 	}
 
-	err := ch.svc.DeleteComment(ctx, req.CommentId)
+	err := ch.svc.DeleteComment(ctx, req.CommentId, uc.Uid)
+	if errors.Is(err, service.ErrModerateForbidden) {
+		return Result{
+			Code: DeleteCommentErrorCode,
+			Msg:  err.Error(),
+		}, nil
+	}
 	if err != nil {
 		return Result{
 			Code: DeleteCommentErrorCode,
@@ -110,7 +131,13 @@ func (ch *CommentHandler) DeleteComment(ctx *gin.Context, req req.DeleteCommentR
 
 // GetMoreCommentReply 获取更多评论回复处理器方法
 func (ch *CommentHandler) GetMoreCommentReply(ctx *gin.Context, req req.GetMoreCommentReplyReq) (Result, error) {
-	comments, err := ch.svc.GetMoreCommentsReply(ctx, req.RootId, req.MaxId, req.Limit)
+	comments, err := ch.svc.GetMoreCommentsReply(ctx, req.RootId, req.MaxId, req.Limit, currentUserID(ctx))
+	if errors.Is(err, service.ErrPrivateAccount) {
+		return Result{
+			Code: GetMoreCommentReplyErrorCode,
+			Msg:  err.Error(),
+		}, nil
+	}
 	if err != nil {
 		return Result{
 			Code: GetMoreCommentReplyErrorCode,
@@ -125,7 +152,13 @@ func (ch *CommentHandler) GetMoreCommentReply(ctx *gin.Context, req req.GetMoreC
 }
 
 func (ch *CommentHandler) GetTopCommentReply(ctx *gin.Context, req req.GetTopCommentReplyReq) (Result, error) {
-	comments, err := ch.svc.GetTopCommentsReply(ctx, req.PostId)
+	comments, err := ch.svc.GetTopCommentsReply(ctx, req.PostId, currentUserID(ctx))
+	if errors.Is(err, service.ErrPrivateAccount) {
+		return Result{
+			Code: GetTopCommentReplyErrorCode,
+			Msg:  err.Error(),
+		}, nil
+	}
 	if err != nil {
 		return Result{
 			Code: GetTopCommentReplyErrorCode,
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"github.com/GoSimplicity/LinkMe/internal/api/req"
+	. "github.com/GoSimplicity/LinkMe/internal/constants"
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/service"
+	. "github.com/GoSimplicity/LinkMe/pkg/ginp"
+	"github.com/gin-gonic/gin"
+)
+
+// FeedHandler 信息流处理器
+type FeedHandler struct {
+	svc          service.FeedService
+	recommendSvc service.RecommendService
+}
+
+func NewFeedHandler(svc service.FeedService, recommendSvc service.RecommendService) *FeedHandler {
+	return &FeedHandler{
+		svc:          svc,
+		recommendSvc: recommendSvc,
+	}
+}
+
+func (fh *FeedHandler) RegisterRoutes(server *gin.Engine) {
+	feedGroup := server.Group("/api/feed")
+	feedGroup.POST("/timeline", WrapBody(fh.Timeline)) // 关注流
+	feedGroup.POST("/for_you", WrapBody(fh.ForYou))    // 个性化推荐
+}
+
+// Timeline 获取当前用户的关注流
+func (fh *FeedHandler) Timeline(ctx *gin.Context, req req.TimelineReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: GetTimelineErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	timeline, err := fh.svc.Timeline(ctx, uc.Uid, req.Cursor, req.Limit)
+	if err != nil {
+		return Result{
+			Code: GetTimelineErrorCode,
+			Msg:  GetTimelineErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  GetTimelineSuccessMsg,
+		Data: timeline,
+	}, nil
+}
+
+// ForYou 获取当前用户的个性化推荐流
+func (fh *FeedHandler) ForYou(ctx *gin.Context, req req.ForYouReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: GetForYouErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	posts, err := fh.recommendSvc.ForYou(ctx, uc.Uid, domain.Pagination{
+		Page: req.Page,
+		Size: req.Size,
+	})
+	if err != nil {
+		return Result{
+			Code: GetForYouErrorCode,
+			Msg:  GetForYouErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  GetForYouSuccessMsg,
+		Data: posts,
+	}, nil
+}
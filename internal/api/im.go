@@ -0,0 +1,174 @@
+package api
+
+import (
+	"github.com/GoSimplicity/LinkMe/internal/api/req"
+	. "github.com/GoSimplicity/LinkMe/internal/constants"
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/service"
+	. "github.com/GoSimplicity/LinkMe/pkg/ginp"
+	"github.com/gin-gonic/gin"
+)
+
+// IMHandler 私信处理器
+type IMHandler struct {
+	svc service.IMService
+}
+
+func NewIMHandler(svc service.IMService) *IMHandler {
+	return &IMHandler{
+		svc: svc,
+	}
+}
+
+func (ih *IMHandler) RegisterRoutes(server *gin.Engine) {
+	imGroup := server.Group("/api/im")
+	imGroup.POST("/send", WrapBody(ih.SendMessage))                // 发送私信
+	imGroup.POST("/conversations", WrapBody(ih.ListConversations)) // 会话列表
+	imGroup.POST("/messages", WrapBody(ih.ListMessages))           // 历史消息
+	imGroup.POST("/read", WrapBody(ih.MarkRead))                   // 标记已读
+	imGroup.POST("/recall", WrapBody(ih.RecallMessage))            // 撤回消息
+	imGroup.GET("/unread", WrapQuery(ih.UnreadCount))              // 未读总数
+}
+
+// SendMessage 发送私信
+func (ih *IMHandler) SendMessage(ctx *gin.Context, req req.SendMessageReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: SendMessageErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	msg, err := ih.svc.SendMessage(ctx, uc.Uid, req.ReceiverID, req.Content)
+	if err != nil {
+		return Result{
+			Code: SendMessageErrorCode,
+			Msg:  SendMessageErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  SendMessageSuccessMsg,
+		Data: msg,
+	}, nil
+}
+
+// ListConversations 获取会话列表
+func (ih *IMHandler) ListConversations(ctx *gin.Context, req req.ListConversationsReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: ListConversationsErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	conversations, err := ih.svc.ListConversations(ctx, uc.Uid, domain.Pagination{
+		Page: req.Page,
+		Size: req.Size,
+	})
+	if err != nil {
+		return Result{
+			Code: ListConversationsErrorCode,
+			Msg:  ListConversationsErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  ListConversationsSuccessMsg,
+		Data: conversations,
+	}, nil
+}
+
+// ListMessages 获取会话历史消息
+func (ih *IMHandler) ListMessages(ctx *gin.Context, req req.ListMessagesReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: ListMessagesErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	msgs, err := ih.svc.ListMessages(ctx, uc.Uid, req.ConversationID, req.BeforeID, req.Limit)
+	if err != nil {
+		return Result{
+			Code: ListMessagesErrorCode,
+			Msg:  ListMessagesErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  ListMessagesSuccessMsg,
+		Data: msgs,
+	}, nil
+}
+
+// MarkRead 标记会话已读
+func (ih *IMHandler) MarkRead(ctx *gin.Context, req req.MarkReadReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: MarkReadErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	if err := ih.svc.MarkRead(ctx, uc.Uid, req.ConversationID, req.MessageID); err != nil {
+		return Result{
+			Code: MarkReadErrorCode,
+			Msg:  MarkReadErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  MarkReadSuccessMsg,
+	}, nil
+}
+
+// RecallMessage 撤回消息
+func (ih *IMHandler) RecallMessage(ctx *gin.Context, req req.RecallMessageReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: RecallMessageErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	if err := ih.svc.RecallMessage(ctx, uc.Uid, req.MessageID); err != nil {
+		return Result{
+			Code: RecallMessageErrorCode,
+			Msg:  RecallMessageErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  RecallMessageSuccessMsg,
+	}, nil
+}
+
+// UnreadCount 获取未读消息总数
+func (ih *IMHandler) UnreadCount(ctx *gin.Context, _ req.UnreadCountReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: UnreadCountErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	count, err := ih.svc.UnreadCount(ctx, uc.Uid)
+	if err != nil {
+		return Result{
+			Code: UnreadCountErrorCode,
+			Msg:  UnreadCountErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  UnreadCountSuccessMsg,
+		Data: count,
+	}, nil
+}
@@ -0,0 +1,206 @@
+package api
+
+import (
+	"errors"
+	"time"
+
+	"github.com/GoSimplicity/LinkMe/internal/api/req"
+	. "github.com/GoSimplicity/LinkMe/internal/constants"
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/service"
+	"github.com/GoSimplicity/LinkMe/middleware"
+	. "github.com/GoSimplicity/LinkMe/pkg/ginp"
+	"github.com/casbin/casbin/v2"
+	"github.com/gin-gonic/gin"
+)
+
+// InviteHandler 邀请码与注册模式
+type InviteHandler struct {
+	svc service.InviteService
+	ce  *casbin.SyncedEnforcer
+}
+
+func NewInviteHandler(svc service.InviteService, ce *casbin.SyncedEnforcer) *InviteHandler {
+	return &InviteHandler{
+		svc: svc,
+		ce:  ce,
+	}
+}
+
+func (ih *InviteHandler) RegisterRoutes(server *gin.Engine) {
+	casbinMiddleware := middleware.NewCasbinMiddleware(ih.ce)
+	inviteGroup := server.Group("/api/invites")
+	inviteGroup.GET("/mode", WrapQuery(ih.GetRegistrationMode))    // 当前注册模式
+	inviteGroup.POST("/create", WrapBody(ih.CreateCode))           // 生成邀请码
+	inviteGroup.POST("/list", WrapBody(ih.ListCodes))              // 我的邀请码
+	inviteGroup.POST("/disable", WrapBody(ih.DisableCode))         // 停用我的邀请码
+	inviteGroup.POST("/invitations", WrapBody(ih.ListInvitations)) // 我邀请的用户
+	adminGroup := inviteGroup.Group("/admin", casbinMiddleware.CheckCasbin())
+	adminGroup.POST("/create", WrapBody(ih.AdminCreateCode))           // 管理员生成邀请码
+	adminGroup.POST("/list", WrapBody(ih.AdminListCodes))              // 全部邀请码
+	adminGroup.POST("/disable", WrapBody(ih.AdminDisableCode))         // 停用任意邀请码
+	adminGroup.POST("/invitations", WrapBody(ih.AdminListInvitations)) // 查询邀请关系
+}
+
+// GetRegistrationMode 获取注册模式，供注册页面判断是否需要填写邀请码
+func (ih *InviteHandler) GetRegistrationMode(_ *gin.Context, _ req.GetRegistrationModeReq) (Result, error) {
+	return Result{
+		Code: RequestsOK,
+		Msg:  GetRegistrationModeMsg,
+		Data: gin.H{"mode": ih.svc.Mode()},
+	}, nil
+}
+
+// CreateCode 生成邀请码
+func (ih *InviteHandler) CreateCode(ctx *gin.Context, req req.CreateInviteCodeReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: CreateInviteCodeErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	code, err := ih.svc.CreateCode(ctx, uc.Uid, req.MaxUses, time.Duration(req.ExpireHours)*time.Hour)
+	if err != nil {
+		if errors.Is(err, service.ErrInviteNotAllowed) || errors.Is(err, service.ErrInviteLimitReached) {
+			return Result{Code: CreateInviteCodeErrorCode, Msg: err.Error()}, nil
+		}
+		return Result{
+			Code: CreateInviteCodeErrorCode,
+			Msg:  CreateInviteCodeErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  CreateInviteCodeSuccessMsg,
+		Data: code,
+	}, nil
+}
+
+// ListCodes 获取我的邀请码
+func (ih *InviteHandler) ListCodes(ctx *gin.Context, req req.ListInviteCodesReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: ListInviteCodesErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+	return ih.listCodes(ctx, uc.Uid, req)
+}
+
+// DisableCode 停用我的邀请码
+func (ih *InviteHandler) DisableCode(ctx *gin.Context, req req.DisableInviteCodeReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: DisableInviteCodeErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+	return ih.disableCode(ctx, req.ID, uc.Uid)
+}
+
+// ListInvitations 获取我邀请的用户
+func (ih *InviteHandler) ListInvitations(ctx *gin.Context, req req.ListInvitationsReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: ListInvitationsErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+	return ih.listInvitations(ctx, uc.Uid, 0, req)
+}
+
+// AdminCreateCode 管理员生成邀请码，可不限次数与有效期
+func (ih *InviteHandler) AdminCreateCode(ctx *gin.Context, req req.CreateInviteCodeReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: CreateInviteCodeErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	code, err := ih.svc.CreateAdminCode(ctx, uc.Uid, req.MaxUses, time.Duration(req.ExpireHours)*time.Hour)
+	if err != nil {
+		return Result{
+			Code: CreateInviteCodeErrorCode,
+			Msg:  CreateInviteCodeErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  CreateInviteCodeSuccessMsg,
+		Data: code,
+	}, nil
+}
+
+// AdminListCodes 获取全部邀请码，可按创建者筛选
+func (ih *InviteHandler) AdminListCodes(ctx *gin.Context, req req.ListInviteCodesReq) (Result, error) {
+	return ih.listCodes(ctx, req.CreatorID, req)
+}
+
+// AdminDisableCode 停用任意邀请码
+func (ih *InviteHandler) AdminDisableCode(ctx *gin.Context, req req.DisableInviteCodeReq) (Result, error) {
+	return ih.disableCode(ctx, req.ID, 0)
+}
+
+// AdminListInvitations 查询邀请关系，用于追溯滥用账号的邀请人
+func (ih *InviteHandler) AdminListInvitations(ctx *gin.Context, req req.ListInvitationsReq) (Result, error) {
+	return ih.listInvitations(ctx, req.InviterID, req.InviteeID, req)
+}
+
+func (ih *InviteHandler) listCodes(ctx *gin.Context, creatorId int64, req req.ListInviteCodesReq) (Result, error) {
+	codes, err := ih.svc.ListCodes(ctx, creatorId, domain.Pagination{
+		Page: req.Page,
+		Size: req.Size,
+	})
+	if err != nil {
+		return Result{
+			Code: ListInviteCodesErrorCode,
+			Msg:  ListInviteCodesErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  ListInviteCodesSuccessMsg,
+		Data: codes,
+	}, nil
+}
+
+func (ih *InviteHandler) disableCode(ctx *gin.Context, id, creatorId int64) (Result, error) {
+	if err := ih.svc.DisableCode(ctx, id, creatorId); err != nil {
+		if errors.Is(err, service.ErrInviteCodeNotFound) {
+			return Result{Code: DisableInviteCodeErrorCode, Msg: err.Error()}, nil
+		}
+		return Result{
+			Code: DisableInviteCodeErrorCode,
+			Msg:  DisableInviteCodeErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  DisableInviteCodeSuccessMsg,
+	}, nil
+}
+
+func (ih *InviteHandler) listInvitations(ctx *gin.Context, inviterId, inviteeId int64, req req.ListInvitationsReq) (Result, error) {
+	invitations, err := ih.svc.ListInvitations(ctx, inviterId, inviteeId, domain.Pagination{
+		Page: req.Page,
+		Size: req.Size,
+	})
+	if err != nil {
+		return Result{
+			Code: ListInvitationsErrorCode,
+			Msg:  ListInvitationsErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  ListInvitationsSuccessMsg,
+		Data: invitations,
+	}, nil
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/GoSimplicity/LinkMe/pkg/apiresponse"
+	ijwt "github.com/GoSimplicity/LinkMe/utils/jwt"
+	"github.com/gin-gonic/gin"
+)
+
+// JWKSHandler 公开访问令牌的验签公钥，供其他服务校验 LinkMe 签发的令牌
+type JWKSHandler struct {
+	ijwt ijwt.Handler
+}
+
+func NewJWKSHandler(j ijwt.Handler) *JWKSHandler {
+	return &JWKSHandler{
+		ijwt: j,
+	}
+}
+
+func (jh *JWKSHandler) RegisterRoutes(server *gin.Engine) {
+	server.GET("/.well-known/jwks.json", jh.JWKS) // 验签公钥
+}
+
+// JWKS 按 RFC 7517 格式直接返回公钥集合，不使用统一的响应结构
+func (jh *JWKSHandler) JWKS(ctx *gin.Context) {
+	jwks, err := jh.ijwt.JWKS(ctx)
+	if err != nil {
+		apiresponse.InternalServerErrorWithDetails(ctx, nil, "获取公钥失败")
+		return
+	}
+
+	// 轮换后的新公钥最迟在缓存过期后被其他服务获取，旧公钥在宽限期内保留
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, jwks)
+}
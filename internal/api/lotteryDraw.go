@@ -1,6 +1,8 @@
 package api
 
 import (
+	"errors"
+
 	"github.com/GoSimplicity/LinkMe/internal/api/req"
 	. "github.com/GoSimplicity/LinkMe/internal/constants"
 	"github.com/GoSimplicity/LinkMe/internal/domain"
@@ -11,12 +13,14 @@ import (
 
 // LotteryDrawHandler 负责处理抽奖和秒杀相关的请求
 type LotteryDrawHandler struct {
-	svc service.LotteryDrawService
+	svc           service.LotteryDrawService
+	reputationSvc service.ReputationService
 }
 
-func NewLotteryDrawHandler(svc service.LotteryDrawService) *LotteryDrawHandler {
+func NewLotteryDrawHandler(svc service.LotteryDrawService, reputationSvc service.ReputationService) *LotteryDrawHandler {
 	return &LotteryDrawHandler{
-		svc: svc,
+		svc:           svc,
+		reputationSvc: reputationSvc,
 	}
 }
 
@@ -68,6 +72,16 @@ func (lh *LotteryDrawHandler) ListLotteryDraws(ctx *gin.Context, req req.ListLot
 
 // CreateLotteryDraw 创建新的抽奖活动
 func (lh *LotteryDrawHandler) CreateLotteryDraw(ctx *gin.Context, req req.CreateLotteryDrawReq) (Result, error) {
+	if err := lh.checkCreatePrivilege(ctx); err != nil {
+		if errors.Is(err, service.ErrLotteryNotAllowed) {
+			return Result{Code: ServerRequestError, Msg: err.Error()}, nil
+		}
+		return Result{
+			Code: ServerRequestError,
+			Msg:  CreateLotteryDrawError,
+		}, err
+	}
+
 	input := domain.LotteryDraw{
 		Name:        req.Name,
 		Description: req.Description,
@@ -160,6 +174,16 @@ func (lh *LotteryDrawHandler) ListKillEvents(ctx *gin.Context, req req.GetAllSec
 
 // CreateSecondKillEvent 创建新的秒杀活动
 func (lh *LotteryDrawHandler) CreateSecondKillEvent(ctx *gin.Context, req req.CreateSecondKillEventReq) (Result, error) {
+	if err := lh.checkCreatePrivilege(ctx); err != nil {
+		if errors.Is(err, service.ErrLotteryNotAllowed) {
+			return Result{Code: ServerRequestError, Msg: err.Error()}, nil
+		}
+		return Result{
+			Code: ServerRequestError,
+			Msg:  CreateSecondKillEventError,
+		}, err
+	}
+
 	input := domain.SecondKillEvent{
 		Name:        req.Name,
 		Description: req.Description,
@@ -221,3 +245,19 @@ func (lh *LotteryDrawHandler) ParticipateSecondKill(ctx *gin.Context, req req.Pa
 		Msg:  ParticipateSecondKillSuccess,
 	}, nil
 }
+
+// checkCreatePrivilege 创建活动需达到对应等级
+func (lh *LotteryDrawHandler) checkCreatePrivilege(ctx *gin.Context) error {
+	uc, ok := currentClaims(ctx)
+	if !ok || uc.Uid == 0 {
+		return service.ErrLotteryNotAllowed
+	}
+	allowed, err := lh.reputationSvc.HasPrivilege(ctx, uc.Uid, domain.PrivilegeCreateLottery)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return service.ErrLotteryNotAllowed
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"github.com/GoSimplicity/LinkMe/internal/api/req"
+	. "github.com/GoSimplicity/LinkMe/internal/constants"
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/service"
+	. "github.com/GoSimplicity/LinkMe/pkg/ginp"
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	svc service.NotificationService
+}
+
+func NewNotificationHandler(svc service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		svc: svc,
+	}
+}
+
+func (nh *NotificationHandler) RegisterRoutes(server *gin.Engine) {
+	notificationGroup := server.Group("/api/user/notification")
+	notificationGroup.GET("/settings", WrapQuery(nh.GetSettings))          // 获取通知设置
+	notificationGroup.POST("/preferences", WrapBody(nh.UpdatePreferences)) // 更新通知偏好
+	notificationGroup.POST("/quiet_hours", WrapBody(nh.UpdateQuietHours))  // 更新免打扰时段
+	notificationGroup.POST("/list", WrapBody(nh.ListNotifications))        // 获取站内信列表
+}
+
+// GetSettings 获取当前用户的通知设置
+func (nh *NotificationHandler) GetSettings(ctx *gin.Context, _ req.GetNotificationSettingsReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: GetNotificationSettingsErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	settings, err := nh.svc.GetSettings(ctx, uc.Uid)
+	if err != nil {
+		return Result{
+			Code: GetNotificationSettingsErrorCode,
+			Msg:  GetNotificationSettingsErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  GetNotificationSettingsSuccessMsg,
+		Data: settings,
+	}, nil
+}
+
+// UpdatePreferences 更新当前用户的通知偏好
+func (nh *NotificationHandler) UpdatePreferences(ctx *gin.Context, req req.UpdateNotificationPreferencesReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: UpdateNotificationPrefsErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	prefs := make([]domain.NotificationPreference, 0, len(req.Preferences))
+	for _, p := range req.Preferences {
+		prefs = append(prefs, domain.NotificationPreference{
+			Type:    domain.NotificationType(p.Type),
+			Channel: domain.NotificationChannel(p.Channel),
+			Enabled: p.Enabled,
+		})
+	}
+
+	if err := nh.svc.UpdatePreferences(ctx, uc.Uid, prefs); err != nil {
+		return Result{
+			Code: UpdateNotificationPrefsErrorCode,
+			Msg:  UpdateNotificationPrefsErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  UpdateNotificationPrefsSuccessMsg,
+	}, nil
+}
+
+// UpdateQuietHours 更新当前用户的免打扰时段
+func (nh *NotificationHandler) UpdateQuietHours(ctx *gin.Context, req req.UpdateQuietHoursReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: UpdateQuietHoursErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	if err := nh.svc.UpdateQuietHours(ctx, uc.Uid, domain.QuietHours{
+		Enabled:  req.Enabled,
+		Start:    req.Start,
+		End:      req.End,
+		Timezone: req.Timezone,
+	}); err != nil {
+		return Result{
+			Code: UpdateQuietHoursErrorCode,
+			Msg:  UpdateQuietHoursErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  UpdateQuietHoursSuccessMsg,
+	}, nil
+}
+
+// ListNotifications 获取当前用户的站内信
+func (nh *NotificationHandler) ListNotifications(ctx *gin.Context, req req.ListNotificationsReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: ListNotificationsErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	notifications, err := nh.svc.ListNotifications(ctx, uc.Uid, domain.Pagination{
+		Page: req.Page,
+		Size: req.Size,
+	})
+	if err != nil {
+		return Result{
+			Code: ListNotificationsErrorCode,
+			Msg:  ListNotificationsErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  ListNotificationsSuccessMsg,
+		Data: notifications,
+	}, nil
+}
@@ -0,0 +1,208 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/GoSimplicity/LinkMe/internal/api/req"
+	. "github.com/GoSimplicity/LinkMe/internal/constants"
+	"github.com/GoSimplicity/LinkMe/internal/repository/dao"
+	"github.com/GoSimplicity/LinkMe/internal/service"
+	. "github.com/GoSimplicity/LinkMe/pkg/ginp"
+	"github.com/GoSimplicity/LinkMe/pkg/oauth"
+	ijwt "github.com/GoSimplicity/LinkMe/utils/jwt"
+	"github.com/gin-gonic/gin"
+)
+
+// OAuthHandler 第三方登录处理器
+type OAuthHandler struct {
+	svc    service.OAuthService
+	tfaSvc service.TwoFactorService
+	ijwt   ijwt.Handler
+}
+
+func NewOAuthHandler(svc service.OAuthService, tfaSvc service.TwoFactorService, j ijwt.Handler) *OAuthHandler {
+	return &OAuthHandler{
+		svc:    svc,
+		tfaSvc: tfaSvc,
+		ijwt:   j,
+	}
+}
+
+func (oh *OAuthHandler) RegisterRoutes(server *gin.Engine) {
+	oauthGroup := server.Group("/api/oauth")
+	oauthGroup.GET("/providers", WrapQuery(oh.ListProviders))       // 已启用的第三方登录方式
+	oauthGroup.GET("/identities", WrapQuery(oh.ListIdentities))     // 已绑定的第三方账号
+	oauthGroup.GET("/:provider/authorize", WrapParam(oh.Authorize)) // 获取第三方登录授权地址
+	oauthGroup.POST("/:provider/bind", WrapParam(oh.Bind))          // 获取绑定第三方账号的授权地址
+	oauthGroup.POST("/:provider/callback", WrapBody(oh.Callback))   // 授权回调，登录、自动注册或绑定
+	oauthGroup.POST("/:provider/unbind", WrapParam(oh.Unbind))      // 解绑第三方账号
+}
+
+// ListProviders 获取已启用的第三方登录方式
+func (oh *OAuthHandler) ListProviders(_ *gin.Context, _ req.ListOAuthProvidersReq) (Result, error) {
+	return Result{
+		Code: RequestsOK,
+		Msg:  OAuthProvidersSuccessMsg,
+		Data: oh.svc.Providers(),
+	}, nil
+}
+
+// Authorize 获取第三方登录授权地址
+func (oh *OAuthHandler) Authorize(ctx *gin.Context, req req.OAuthProviderReq) (Result, error) {
+	url, err := oh.svc.AuthURL(ctx, req.Provider, 0)
+	if err != nil {
+		if errors.Is(err, oauth.ErrProviderNotFound) {
+			return Result{Code: OAuthAuthorizeErrorCode, Msg: err.Error()}, nil
+		}
+		return Result{
+			Code: OAuthAuthorizeErrorCode,
+			Msg:  OAuthAuthorizeErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  OAuthAuthorizeSuccessMsg,
+		Data: gin.H{"url": url},
+	}, nil
+}
+
+// Bind 已登录用户获取绑定第三方账号的授权地址
+func (oh *OAuthHandler) Bind(ctx *gin.Context, req req.OAuthProviderReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: OAuthAuthorizeErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	url, err := oh.svc.AuthURL(ctx, req.Provider, uc.Uid)
+	if err != nil {
+		if errors.Is(err, oauth.ErrProviderNotFound) {
+			return Result{Code: OAuthAuthorizeErrorCode, Msg: err.Error()}, nil
+		}
+		return Result{
+			Code: OAuthAuthorizeErrorCode,
+			Msg:  OAuthAuthorizeErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  OAuthAuthorizeSuccessMsg,
+		Data: gin.H{"url": url},
+	}, nil
+}
+
+// Callback 处理第三方授权回调，登录或自动注册时签发令牌
+func (oh *OAuthHandler) Callback(ctx *gin.Context, req req.OAuthCallbackReq) (Result, error) {
+	result, err := oh.svc.Callback(ctx, ctx.Param("provider"), req.Code, req.State)
+	if err != nil {
+		switch {
+		case errors.Is(err, oauth.ErrProviderNotFound),
+			errors.Is(err, oauth.ErrExchangeFailed),
+			errors.Is(err, service.ErrInvalidOAuthState),
+			errors.Is(err, service.ErrIdentityLinked),
+			errors.Is(err, service.ErrInvalidUserOrPassword),
+			errors.Is(err, service.ErrOAuthRegistrationClosed):
+			return Result{Code: OAuthCallbackErrorCode, Msg: err.Error()}, nil
+		}
+		return Result{
+			Code: OAuthCallbackErrorCode,
+			Msg:  OAuthCallbackErrorMsg,
+		}, err
+	}
+
+	if result.Linked {
+		return Result{
+			Code: RequestsOK,
+			Msg:  OAuthBindSuccessMsg,
+			Data: result,
+		}, nil
+	}
+
+	// 开启两步验证的账号需通过 /api/user/login_2fa 完成登录
+	challenge, required, err := oh.tfaSvc.BeginLogin(ctx, result.Uid)
+	if err != nil {
+		return Result{
+			Code: OAuthCallbackErrorCode,
+			Msg:  OAuthCallbackErrorMsg,
+		}, err
+	}
+	if required {
+		return Result{
+			Code: RequestsOK,
+			Msg:  TwoFactorChallengeSuccessMsg,
+			Data: gin.H{
+				"twoFactorRequired": true,
+				"challenge":         challenge,
+				"registered":        result.Registered,
+			},
+		}, nil
+	}
+
+	jwtToken, refreshToken, err := oh.ijwt.SetLoginToken(ctx, result.Uid)
+	if err != nil {
+		return Result{
+			Code: OAuthCallbackErrorCode,
+			Msg:  OAuthCallbackErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  OAuthLoginSuccessMsg,
+		Data: gin.H{
+			"accessToken":  jwtToken,
+			"refreshToken": refreshToken,
+			"registered":   result.Registered,
+		},
+	}, nil
+}
+
+// Unbind 解绑第三方账号
+func (oh *OAuthHandler) Unbind(ctx *gin.Context, req req.OAuthProviderReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: OAuthUnbindErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	if err := oh.svc.Unlink(ctx, uc.Uid, req.Provider); err != nil {
+		if errors.Is(err, service.ErrLastLoginMethod) || errors.Is(err, dao.ErrIdentityNotFound) {
+			return Result{Code: OAuthUnbindErrorCode, Msg: err.Error()}, nil
+		}
+		return Result{
+			Code: OAuthUnbindErrorCode,
+			Msg:  OAuthUnbindErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  OAuthUnbindSuccessMsg,
+	}, nil
+}
+
+// ListIdentities 获取当前用户绑定的第三方账号
+func (oh *OAuthHandler) ListIdentities(ctx *gin.Context, _ req.ListOAuthProvidersReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: OAuthIdentitiesErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	identities, err := oh.svc.ListIdentities(ctx, uc.Uid)
+	if err != nil {
+		return Result{
+			Code: OAuthIdentitiesErrorCode,
+			Msg:  OAuthIdentitiesErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  OAuthIdentitiesSuccessMsg,
+		Data: identities,
+	}, nil
+}
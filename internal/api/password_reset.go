@@ -0,0 +1,110 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/GoSimplicity/LinkMe/internal/api/req"
+	. "github.com/GoSimplicity/LinkMe/internal/constants"
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/service"
+	. "github.com/GoSimplicity/LinkMe/pkg/ginp"
+	ijwt "github.com/GoSimplicity/LinkMe/utils/jwt"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// PasswordResetHandler 忘记密码
+type PasswordResetHandler struct {
+	svc      service.PasswordResetService
+	tokenSvc service.AccessTokenService
+	ijwt     ijwt.Handler
+	l        *zap.Logger
+}
+
+func NewPasswordResetHandler(svc service.PasswordResetService, tokenSvc service.AccessTokenService, j ijwt.Handler, l *zap.Logger) *PasswordResetHandler {
+	return &PasswordResetHandler{
+		svc:      svc,
+		tokenSvc: tokenSvc,
+		ijwt:     j,
+		l:        l,
+	}
+}
+
+func (ph *PasswordResetHandler) RegisterRoutes(server *gin.Engine) {
+	passwordGroup := server.Group("/api/user/password")
+	passwordGroup.POST("/forgot", WrapBody(ph.Forgot)) // 发送找回密码验证码
+	passwordGroup.POST("/verify", WrapBody(ph.Verify)) // 校验验证码，获取重置令牌
+	passwordGroup.POST("/reset", WrapBody(ph.Reset))   // 使用重置令牌设置新密码
+}
+
+// Forgot 发送找回密码验证码
+func (ph *PasswordResetHandler) Forgot(ctx *gin.Context, req req.ForgotPasswordReq) (Result, error) {
+	if err := ph.svc.RequestReset(ctx, domain.ResetChannel(req.Channel), req.Account); err != nil {
+		if errors.Is(err, service.ErrInvalidResetChannel) || errors.Is(err, service.ErrResetTooFrequent) {
+			return Result{Code: PasswordForgotErrorCode, Msg: err.Error()}, nil
+		}
+		return Result{
+			Code: PasswordForgotErrorCode,
+			Msg:  PasswordForgotErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  PasswordForgotSuccessMsg,
+	}, nil
+}
+
+// Verify 校验找回密码验证码
+func (ph *PasswordResetHandler) Verify(ctx *gin.Context, req req.VerifyResetCodeReq) (Result, error) {
+	token, err := ph.svc.VerifyCode(ctx, domain.ResetChannel(req.Channel), req.Account, req.Code)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidResetChannel) ||
+			errors.Is(err, service.ErrInvalidResetCode) ||
+			errors.Is(err, service.ErrResetAttemptsExceeded) {
+			return Result{Code: PasswordVerifyErrorCode, Msg: err.Error()}, nil
+		}
+		return Result{
+			Code: PasswordVerifyErrorCode,
+			Msg:  PasswordVerifyErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  PasswordVerifySuccessMsg,
+		Data: gin.H{"resetToken": token},
+	}, nil
+}
+
+// Reset 重置密码，成功后使该用户的全部会话失效
+func (ph *PasswordResetHandler) Reset(ctx *gin.Context, req req.ResetPasswordReq) (Result, error) {
+	if req.NewPassword != req.ConfirmPassword {
+		return Result{
+			Code: PasswordResetErrorCode,
+			Msg:  UserPasswordMismatchError,
+		}, nil
+	}
+
+	uid, err := ph.svc.ResetPassword(ctx, req.ResetToken, req.NewPassword)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidResetToken) || errors.Is(err, service.ErrInvalidPasswordFormat) {
+			return Result{Code: PasswordResetErrorCode, Msg: err.Error()}, nil
+		}
+		return Result{
+			Code: PasswordResetErrorCode,
+			Msg:  PasswordResetErrorMsg,
+		}, err
+	}
+
+	// 密码已修改，会话与访问令牌失效失败只记录日志
+	if err := ph.ijwt.ClearUserSessions(ctx, uid); err != nil {
+		ph.l.Error("重置密码后清除会话失败", zap.Int64("uid", uid), zap.Error(err))
+	}
+	if err := ph.tokenSvc.RevokeAll(ctx, uid); err != nil {
+		ph.l.Error("重置密码后撤销访问令牌失败", zap.Int64("uid", uid), zap.Error(err))
+	}
+
+	return Result{
+		Code: RequestsOK,
+		Msg:  PasswordResetSuccessMsg,
+	}, nil
+}
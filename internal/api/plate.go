@@ -12,14 +12,16 @@ import (
 )
 
 type PlateHandler struct {
-	svc service.PlateService
-	ce  *casbin.Enforcer
+	svc          service.PlateService
+	moderatorSvc service.ModeratorService
+	ce           *casbin.SyncedEnforcer
 }
 
-func NewPlateHandler(svc service.PlateService, ce *casbin.Enforcer) *PlateHandler {
+func NewPlateHandler(svc service.PlateService, moderatorSvc service.ModeratorService, ce *casbin.SyncedEnforcer) *PlateHandler {
 	return &PlateHandler{
-		svc: svc,
-		ce:  ce,
+		svc:          svc,
+		moderatorSvc: moderatorSvc,
+		ce:           ce,
 	}
 }
 
@@ -31,6 +33,17 @@ func (h *PlateHandler) RegisterRoutes(server *gin.Engine) {
 	permissionGroup.POST("/update", h.UpdatePlate)
 	permissionGroup.DELETE("/delete/:plateId", h.DeletePlate)
 	permissionGroup.POST("/list", h.ListPlate)
+	permissionGroup.POST("/moderator/appoint", h.AppointModerator) // 任命板块版主
+	permissionGroup.POST("/moderator/remove", h.RemoveModerator)   // 移除板块版主
+
+	// 订阅相关接口面向所有登录用户，不经过权限校验
+	subscribeGroup := server.Group("/api/plate")
+	subscribeGroup.POST("/subscribe", h.Subscribe)       // 订阅板块
+	subscribeGroup.POST("/unsubscribe", h.Unsubscribe)   // 取消订阅
+	subscribeGroup.POST("/subscribed", h.ListSubscribed) // 我的板块
+	subscribeGroup.POST("/feed", h.PlateFeed)            // 板块帖子流
+	subscribeGroup.POST("/moderators", h.ListModerators) // 板块版主列表
+	subscribeGroup.POST("/moderated", h.ListModerated)   // 我担任版主的板块
 }
 
 func (h *PlateHandler) CreatePlate(ctx *gin.Context) {
@@ -97,6 +110,71 @@ func (h *PlateHandler) ListPlate(ctx *gin.Context) {
 	plates, err := h.svc.ListPlate(ctx, domain.Pagination{
 		Page: req.Page,
 		Size: req.Size,
+		Uid:  currentUserID(ctx),
+	})
+	if err != nil {
+		apiresponse.ErrorWithMessage(ctx, err.Error())
+		return
+	}
+	apiresponse.SuccessWithData(ctx, plates)
+}
+
+// Subscribe 订阅板块
+func (h *PlateHandler) Subscribe(ctx *gin.Context) {
+	var req req.SubscribePlateReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apiresponse.ErrorWithMessage(ctx, "无效的请求参数")
+		return
+	}
+
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Subscribe(ctx, req.PlateID, uc.Uid); err != nil {
+		apiresponse.ErrorWithMessage(ctx, err.Error())
+		return
+	}
+	apiresponse.Success(ctx)
+}
+
+// Unsubscribe 取消订阅板块
+func (h *PlateHandler) Unsubscribe(ctx *gin.Context) {
+	var req req.SubscribePlateReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apiresponse.ErrorWithMessage(ctx, "无效的请求参数")
+		return
+	}
+
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Unsubscribe(ctx, req.PlateID, uc.Uid); err != nil {
+		apiresponse.ErrorWithMessage(ctx, err.Error())
+		return
+	}
+	apiresponse.Success(ctx)
+}
+
+// ListSubscribed 获取当前用户订阅的板块
+func (h *PlateHandler) ListSubscribed(ctx *gin.Context) {
+	var req req.ListSubscribedPlatesReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apiresponse.ErrorWithMessage(ctx, "无效的请求参数")
+		return
+	}
+
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	plates, err := h.svc.ListSubscribedPlates(ctx, uc.Uid, domain.Pagination{
+		Page: req.Page,
+		Size: req.Size,
 	})
 	if err != nil {
 		apiresponse.ErrorWithMessage(ctx, err.Error())
@@ -104,3 +182,89 @@ func (h *PlateHandler) ListPlate(ctx *gin.Context) {
 	}
 	apiresponse.SuccessWithData(ctx, plates)
 }
+
+// PlateFeed 获取板块帖子流，未指定板块时合并当前用户订阅的全部板块
+func (h *PlateHandler) PlateFeed(ctx *gin.Context) {
+	var req req.PlateFeedReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apiresponse.ErrorWithMessage(ctx, "无效的请求参数")
+		return
+	}
+
+	uid := currentUserID(ctx)
+	if req.PlateID <= 0 {
+		uc, ok := requireUser(ctx)
+		if !ok {
+			return
+		}
+		uid = uc.Uid
+	}
+
+	timeline, err := h.svc.PlateFeed(ctx, uid, req.PlateID, req.Cursor, req.Limit)
+	if err != nil {
+		apiresponse.ErrorWithMessage(ctx, err.Error())
+		return
+	}
+	apiresponse.SuccessWithData(ctx, timeline)
+}
+
+// AppointModerator 任命板块版主
+func (h *PlateHandler) AppointModerator(ctx *gin.Context) {
+	var req req.PlateModeratorReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apiresponse.ErrorWithMessage(ctx, "无效的请求参数")
+		return
+	}
+
+	if err := h.moderatorSvc.Appoint(ctx, req.PlateID, req.Uid); err != nil {
+		apiresponse.ErrorWithMessage(ctx, err.Error())
+		return
+	}
+	apiresponse.Success(ctx)
+}
+
+// RemoveModerator 移除板块版主
+func (h *PlateHandler) RemoveModerator(ctx *gin.Context) {
+	var req req.PlateModeratorReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apiresponse.ErrorWithMessage(ctx, "无效的请求参数")
+		return
+	}
+
+	if err := h.moderatorSvc.Remove(ctx, req.PlateID, req.Uid); err != nil {
+		apiresponse.ErrorWithMessage(ctx, err.Error())
+		return
+	}
+	apiresponse.Success(ctx)
+}
+
+// ListModerators 获取板块的版主
+func (h *PlateHandler) ListModerators(ctx *gin.Context) {
+	var req req.ListPlateModeratorsReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apiresponse.ErrorWithMessage(ctx, "无效的请求参数")
+		return
+	}
+
+	moderators, err := h.moderatorSvc.ListModerators(ctx, req.PlateID)
+	if err != nil {
+		apiresponse.ErrorWithMessage(ctx, err.Error())
+		return
+	}
+	apiresponse.SuccessWithData(ctx, moderators)
+}
+
+// ListModerated 获取当前用户担任版主的板块ID
+func (h *PlateHandler) ListModerated(ctx *gin.Context) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	plates, err := h.moderatorSvc.ModeratedPlates(ctx, uc.Uid)
+	if err != nil {
+		apiresponse.ErrorWithMessage(ctx, err.Error())
+		return
+	}
+	apiresponse.SuccessWithData(ctx, plates)
+}
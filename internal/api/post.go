@@ -370,6 +370,7 @@ func (ph *PostHandler) GetPostsByPlate(ctx *gin.Context) {
 	posts, err := ph.svc.GetPostsByPlate(ctx, req.PlateId, domain.Pagination{
 		Page: req.Page,
 		Size: req.Size,
+		Uid:  currentUserID(ctx),
 	})
 	if err != nil {
 		apiresponse.ErrorWithMessage(ctx, err.Error())
@@ -30,7 +30,7 @@ func (rh *RankingHandler) RegisterRoutes(server *gin.Engine) {
 
 // GetRanking 获取排行榜
 func (rh *RankingHandler) GetRanking(ctx *gin.Context) {
-	dp, err := rh.svc.GetTopN(ctx)
+	dp, err := rh.svc.GetTopN(ctx, currentUserID(ctx))
 	if err != nil {
 		apiresponse.ErrorWithData(ctx, err)
 		return
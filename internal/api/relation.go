@@ -15,12 +15,25 @@ type RelationHandler struct {
 
 func (r *RelationHandler) RegisterRoutes(server *gin.Engine) {
 	relationGroup := server.Group("/api/relations")
-	relationGroup.POST("/list_follower", WrapBody(r.ListFollowerRelations)) // 查看用户关系列表
-	relationGroup.POST("/list_followee", WrapBody(r.ListFolloweeRelations)) // 查看用户关系信息
-	relationGroup.GET("/get_followee_count", WrapQuery(r.GetFolloweeCount)) // 获取关注者数量
-	relationGroup.GET("/get_follower_count", WrapQuery(r.GetFollowerCount)) // 获取粉丝数量
-	relationGroup.POST("/follow", WrapBody(r.FollowUser))                   // 关注
-	relationGroup.POST("/cancel_follow", WrapBody(r.CancelFollowUser))      // 关注
+	relationGroup.POST("/list_follower", WrapBody(r.ListFollowerRelations))         // 查看用户关系列表
+	relationGroup.POST("/list_followee", WrapBody(r.ListFolloweeRelations))         // 查看用户关系信息
+	relationGroup.GET("/get_followee_count", WrapQuery(r.GetFolloweeCount))         // 获取关注者数量
+	relationGroup.GET("/get_follower_count", WrapQuery(r.GetFollowerCount))         // 获取粉丝数量
+	relationGroup.POST("/follow", WrapBody(r.FollowUser))                           // 关注
+	relationGroup.POST("/cancel_follow", WrapBody(r.CancelFollowUser))              // 关注
+	relationGroup.POST("/block", WrapBody(r.BlockUser))                             // 拉黑
+	relationGroup.POST("/unblock", WrapBody(r.UnblockUser))                         // 取消拉黑
+	relationGroup.POST("/mute", WrapBody(r.MuteUser))                               // 屏蔽
+	relationGroup.POST("/unmute", WrapBody(r.UnmuteUser))                           // 取消屏蔽
+	relationGroup.POST("/list_blocked", WrapBody(r.ListBlockedUsers))               // 拉黑列表
+	relationGroup.POST("/list_muted", WrapBody(r.ListMutedUsers))                   // 屏蔽列表
+	relationGroup.POST("/list_friends", WrapBody(r.ListFriends))                    // 互关好友列表
+	relationGroup.POST("/social_proof", WrapBody(r.GetSocialProof))                 // 你关注的人中也关注了TA
+	relationGroup.POST("/suggestions", WrapBody(r.SuggestUsers))                    // 推荐关注
+	relationGroup.POST("/set_privacy", WrapBody(r.SetPrivacy))                      // 设置私密账号
+	relationGroup.POST("/list_follow_requests", WrapBody(r.ListFollowRequests))     // 待处理的关注请求
+	relationGroup.POST("/approve_follow_request", WrapBody(r.ApproveFollowRequest)) // 通过关注请求
+	relationGroup.POST("/reject_follow_request", WrapBody(r.RejectFollowRequest))   // 拒绝关注请求
 }
 
 func NewRelationHandler(svc service.RelationService) *RelationHandler {
@@ -77,15 +90,24 @@ func (r *RelationHandler) FollowUser(ctx *gin.Context, req req.FollowUserReq) (R
 		}, nil
 	}
 
-	if err := r.svc.FollowUser(ctx, uc.Uid, req.FolloweeID); err != nil {
+	pending, err := r.svc.FollowUser(ctx, uc.Uid, req.FolloweeID)
+	if err != nil {
 		return Result{
 			Code: FollowUserERRORCode,
 			Msg:  FollowUserERRORMsg,
 		}, err
 	}
+	if pending {
+		return Result{
+			Code: RequestsOK,
+			Msg:  FollowRequestSentMsg,
+			Data: gin.H{"pending": true},
+		}, nil
+	}
 	return Result{
 		Code: RequestsOK,
 		Msg:  FollowUserSuccessMsg,
+		Data: gin.H{"pending": false},
 	}, nil
 }
 
@@ -141,3 +163,313 @@ func (r *RelationHandler) GetFollowerCount(ctx *gin.Context, req req.GetFollower
 		Data: count,
 	}, nil
 }
+
+// BlockUser 拉黑用户，拉黑后双方互相取消关注
+func (r *RelationHandler) BlockUser(ctx *gin.Context, req req.BlockUserReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: BlockUserErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	if err := r.svc.BlockUser(ctx, uc.Uid, req.TargetID); err != nil {
+		return Result{
+			Code: BlockUserErrorCode,
+			Msg:  BlockUserErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  BlockUserSuccessMsg,
+	}, nil
+}
+
+// UnblockUser 取消拉黑
+func (r *RelationHandler) UnblockUser(ctx *gin.Context, req req.BlockUserReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: UnblockUserErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	if err := r.svc.UnblockUser(ctx, uc.Uid, req.TargetID); err != nil {
+		return Result{
+			Code: UnblockUserErrorCode,
+			Msg:  UnblockUserErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  UnblockUserSuccessMsg,
+	}, nil
+}
+
+// MuteUser 屏蔽用户，屏蔽后不再看到对方的内容
+func (r *RelationHandler) MuteUser(ctx *gin.Context, req req.BlockUserReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: MuteUserErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	if err := r.svc.MuteUser(ctx, uc.Uid, req.TargetID); err != nil {
+		return Result{
+			Code: MuteUserErrorCode,
+			Msg:  MuteUserErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  MuteUserSuccessMsg,
+	}, nil
+}
+
+// UnmuteUser 取消屏蔽
+func (r *RelationHandler) UnmuteUser(ctx *gin.Context, req req.BlockUserReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: UnmuteUserErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	if err := r.svc.UnmuteUser(ctx, uc.Uid, req.TargetID); err != nil {
+		return Result{
+			Code: UnmuteUserErrorCode,
+			Msg:  UnmuteUserErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  UnmuteUserSuccessMsg,
+	}, nil
+}
+
+// ListBlockedUsers 获取拉黑列表
+func (r *RelationHandler) ListBlockedUsers(ctx *gin.Context, req req.ListBlockedUsersReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: ListBlockedUsersErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	users, err := r.svc.ListBlockedUsers(ctx, uc.Uid, domain.Pagination{
+		Page: req.Page,
+		Size: req.Size,
+	})
+	if err != nil {
+		return Result{
+			Code: ListBlockedUsersErrorCode,
+			Msg:  ListBlockedUsersErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  ListBlockedUsersSuccessMsg,
+		Data: users,
+	}, nil
+}
+
+// ListMutedUsers 获取屏蔽列表
+func (r *RelationHandler) ListMutedUsers(ctx *gin.Context, req req.ListBlockedUsersReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: ListMutedUsersErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	users, err := r.svc.ListMutedUsers(ctx, uc.Uid, domain.Pagination{
+		Page: req.Page,
+		Size: req.Size,
+	})
+	if err != nil {
+		return Result{
+			Code: ListMutedUsersErrorCode,
+			Msg:  ListMutedUsersErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  ListMutedUsersSuccessMsg,
+		Data: users,
+	}, nil
+}
+
+// ListFriends 获取互相关注的好友列表
+func (r *RelationHandler) ListFriends(ctx *gin.Context, req req.ListFriendsReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: ListFriendsErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	friends, err := r.svc.ListFriends(ctx, uc.Uid, domain.Pagination{
+		Page: req.Page,
+		Size: req.Size,
+	})
+	if err != nil {
+		return Result{
+			Code: ListFriendsErrorCode,
+			Msg:  ListFriendsErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  ListFriendsSuccessMsg,
+		Data: friends,
+	}, nil
+}
+
+// GetSocialProof 查看他人主页时获取共同关注
+func (r *RelationHandler) GetSocialProof(ctx *gin.Context, req req.GetSocialProofReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: GetSocialProofErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	proof, err := r.svc.GetSocialProof(ctx, uc.Uid, req.UserID)
+	if err != nil {
+		return Result{
+			Code: GetSocialProofErrorCode,
+			Msg:  GetSocialProofErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  GetSocialProofSuccessMsg,
+		Data: proof,
+	}, nil
+}
+
+// SuggestUsers 获取推荐关注的用户
+func (r *RelationHandler) SuggestUsers(ctx *gin.Context, req req.SuggestUsersReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: SuggestUsersErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	users, err := r.svc.SuggestUsers(ctx, uc.Uid, req.Limit)
+	if err != nil {
+		return Result{
+			Code: SuggestUsersErrorCode,
+			Msg:  SuggestUsersErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  SuggestUsersSuccessMsg,
+		Data: users,
+	}, nil
+}
+
+// SetPrivacy 设置私密账号，开启后关注需经本人同意
+func (r *RelationHandler) SetPrivacy(ctx *gin.Context, req req.SetPrivacyReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: SetPrivacyErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	if err := r.svc.SetPrivate(ctx, uc.Uid, req.IsPrivate); err != nil {
+		return Result{
+			Code: SetPrivacyErrorCode,
+			Msg:  SetPrivacyErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  SetPrivacySuccessMsg,
+	}, nil
+}
+
+// ListFollowRequests 获取待处理的关注请求
+func (r *RelationHandler) ListFollowRequests(ctx *gin.Context, req req.ListFollowRequestsReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: ListFollowRequestsErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	requests, err := r.svc.ListFollowRequests(ctx, uc.Uid, domain.Pagination{
+		Page: req.Page,
+		Size: req.Size,
+	})
+	if err != nil {
+		return Result{
+			Code: ListFollowRequestsErrorCode,
+			Msg:  ListFollowRequestsErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  ListFollowRequestsSuccessMsg,
+		Data: requests,
+	}, nil
+}
+
+// ApproveFollowRequest 通过关注请求
+func (r *RelationHandler) ApproveFollowRequest(ctx *gin.Context, req req.HandleFollowRequestReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: ApproveFollowRequestErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	if err := r.svc.ApproveFollowRequest(ctx, uc.Uid, req.FollowerID); err != nil {
+		return Result{
+			Code: ApproveFollowRequestErrorCode,
+			Msg:  ApproveFollowRequestErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  ApproveFollowRequestSuccessMsg,
+	}, nil
+}
+
+// RejectFollowRequest 拒绝关注请求
+func (r *RelationHandler) RejectFollowRequest(ctx *gin.Context, req req.HandleFollowRequestReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: RejectFollowRequestErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	if err := r.svc.RejectFollowRequest(ctx, uc.Uid, req.FollowerID); err != nil {
+		return Result{
+			Code: RejectFollowRequestErrorCode,
+			Msg:  RejectFollowRequestErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  RejectFollowRequestSuccessMsg,
+	}, nil
+}
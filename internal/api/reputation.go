@@ -0,0 +1,135 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/GoSimplicity/LinkMe/internal/api/req"
+	. "github.com/GoSimplicity/LinkMe/internal/constants"
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/service"
+	"github.com/GoSimplicity/LinkMe/middleware"
+	. "github.com/GoSimplicity/LinkMe/pkg/ginp"
+	"github.com/casbin/casbin/v2"
+	"github.com/gin-gonic/gin"
+)
+
+// ReputationHandler 积分与等级
+type ReputationHandler struct {
+	svc service.ReputationService
+	ce  *casbin.SyncedEnforcer
+}
+
+func NewReputationHandler(svc service.ReputationService, ce *casbin.SyncedEnforcer) *ReputationHandler {
+	return &ReputationHandler{
+		svc: svc,
+		ce:  ce,
+	}
+}
+
+func (rh *ReputationHandler) RegisterRoutes(server *gin.Engine) {
+	casbinMiddleware := middleware.NewCasbinMiddleware(rh.ce)
+	reputationGroup := server.Group("/api/reputation")
+	reputationGroup.GET("", WrapQuery(rh.GetReputation))                                          // 积分与等级
+	reputationGroup.POST("/logs", WrapBody(rh.ListLogs))                                          // 我的积分流水
+	reputationGroup.GET("/levels", WrapQuery(rh.ListLevels))                                      // 等级与权限配置
+	reputationGroup.POST("/levels/save", casbinMiddleware.CheckCasbin(), WrapBody(rh.SaveLevels)) // 保存等级配置（管理员使用）
+}
+
+// GetReputation 获取用户积分与等级，未指定用户时返回当前用户
+func (rh *ReputationHandler) GetReputation(ctx *gin.Context, req req.GetReputationReq) (Result, error) {
+	uid := req.UserID
+	if uid == 0 {
+		uc, ok := requireUser(ctx)
+		if !ok {
+			return Result{
+				Code: GetReputationErrorCode,
+				Msg:  "未登录或登录已过期",
+			}, nil
+		}
+		uid = uc.Uid
+	}
+
+	rep, err := rh.svc.GetReputation(ctx, uid)
+	if err != nil {
+		return Result{
+			Code: GetReputationErrorCode,
+			Msg:  GetReputationErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  GetReputationSuccessMsg,
+		Data: rep,
+	}, nil
+}
+
+// ListLogs 获取当前用户的积分流水
+func (rh *ReputationHandler) ListLogs(ctx *gin.Context, req req.ListReputationLogsReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: ListReputationLogsErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	logs, err := rh.svc.ListLogs(ctx, uc.Uid, domain.Pagination{
+		Page: req.Page,
+		Size: req.Size,
+	})
+	if err != nil {
+		return Result{
+			Code: ListReputationLogsErrorCode,
+			Msg:  ListReputationLogsErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  ListReputationLogsMsg,
+		Data: logs,
+	}, nil
+}
+
+// ListLevels 获取等级配置
+func (rh *ReputationHandler) ListLevels(ctx *gin.Context, _ req.ListLevelsReq) (Result, error) {
+	levels, err := rh.svc.ListLevels(ctx)
+	if err != nil {
+		return Result{
+			Code: ListLevelsErrorCode,
+			Msg:  ListLevelsErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  ListLevelsSuccessMsg,
+		Data: levels,
+	}, nil
+}
+
+// SaveLevels 整体替换等级配置
+func (rh *ReputationHandler) SaveLevels(ctx *gin.Context, req req.SaveLevelsReq) (Result, error) {
+	levels := make([]domain.ReputationLevel, 0, len(req.Levels))
+	for _, l := range req.Levels {
+		levels = append(levels, domain.ReputationLevel{
+			Level:            l.Level,
+			Name:             l.Name,
+			MinPoints:        l.MinPoints,
+			CanPostLinks:     l.CanPostLinks,
+			CanCreateLottery: l.CanCreateLottery,
+		})
+	}
+
+	if err := rh.svc.SaveLevels(ctx, levels); err != nil {
+		if errors.Is(err, domain.ErrInvalidLevels) {
+			return Result{Code: SaveLevelsErrorCode, Msg: err.Error()}, nil
+		}
+		return Result{
+			Code: SaveLevelsErrorCode,
+			Msg:  SaveLevelsErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  SaveLevelsSuccessMsg,
+	}, nil
+}
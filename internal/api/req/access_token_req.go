@@ -0,0 +1,13 @@
+package req
+
+type CreateAccessTokenReq struct {
+	Name       string   `json:"name" binding:"required,max=64"`
+	Scopes     []string `json:"scopes" binding:"required"` // 可选值为 posts:read、posts:write、moderate
+	ExpireDays int      `json:"expireDays"`                // 有效天数，为0时永不过期
+}
+
+type ListAccessTokensReq struct{}
+
+type RevokeAccessTokenReq struct {
+	ID int64 `json:"id" binding:"required"`
+}
@@ -0,0 +1,9 @@
+package req
+
+type DeletionStatusReq struct{}
+
+type CancelDeletionReq struct{}
+
+type DataExportReq struct{}
+
+type DataExportStatusReq struct{}
@@ -0,0 +1,12 @@
+package req
+
+type ListAuditLogsReq struct {
+	ActorID    int64  `json:"actorId"`    // 操作人ID，为0时不筛选
+	Action     string `json:"action"`     // 操作类型，如 role.update
+	TargetType string `json:"targetType"` // 对象类型，如 role、user、plate
+	TargetID   string `json:"targetId"`   // 对象ID，需同时指定对象类型
+	StartTime  int64  `json:"startTime"`  // 起始时间，毫秒时间戳，包含
+	EndTime    int64  `json:"endTime"`    // 结束时间，毫秒时间戳，不包含
+	Page       int    `json:"page"`
+	Size       *int64 `json:"size"`
+}
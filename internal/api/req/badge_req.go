@@ -0,0 +1,26 @@
+package req
+
+type ListBadgesReq struct{}
+
+type CreateBadgeReq struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	Icon        string `json:"icon"`
+	Rule        string `json:"rule" binding:"required"` // approved_posts、likes_received、active_streak、lottery_participations
+	Threshold   int64  `json:"threshold" binding:"required"`
+	Enabled     bool   `json:"enabled"`
+}
+
+type UpdateBadgeReq struct {
+	ID          int64  `json:"id" binding:"required"`
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	Icon        string `json:"icon"`
+	Rule        string `json:"rule" binding:"required"`
+	Threshold   int64  `json:"threshold" binding:"required"`
+	Enabled     bool   `json:"enabled"`
+}
+
+type GetUserBadgesReq struct {
+	UserID int64 `form:"userId"` // 为空时查询当前用户
+}
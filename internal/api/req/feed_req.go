@@ -0,0 +1,11 @@
+package req
+
+type TimelineReq struct {
+	Cursor uint `json:"cursor,omitempty"` // 上一页返回的游标，首页为 0
+	Limit  int  `json:"limit,omitempty"`  // 每页数量
+}
+
+type ForYouReq struct {
+	Page int    `json:"page,omitempty"` // 当前页码
+	Size *int64 `json:"size,omitempty"` // 每页数据量
+}
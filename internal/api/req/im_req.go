@@ -0,0 +1,29 @@
+package req
+
+type SendMessageReq struct {
+	ReceiverID int64  `json:"receiverId"` // 接收者ID
+	Content    string `json:"content"`    // 消息内容
+}
+
+type ListConversationsReq struct {
+	Page int    `json:"page,omitempty"` // 当前页码
+	Size *int64 `json:"size,omitempty"` // 每页数据量
+}
+
+type ListMessagesReq struct {
+	ConversationID int64 `json:"conversationId,string"`     // 会话ID
+	BeforeID       int64 `json:"beforeId,string,omitempty"` // 翻页游标，返回ID小于该值的消息
+	Limit          int   `json:"limit,omitempty"`           // 拉取条数
+}
+
+type MarkReadReq struct {
+	ConversationID int64 `json:"conversationId,string"`      // 会话ID
+	MessageID      int64 `json:"messageId,string,omitempty"` // 已读到的消息ID，为空时标记全部已读
+}
+
+type RecallMessageReq struct {
+	MessageID int64 `json:"messageId,string"` // 消息ID
+}
+
+type UnreadCountReq struct {
+}
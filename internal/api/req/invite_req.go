@@ -0,0 +1,25 @@
+package req
+
+type GetRegistrationModeReq struct{}
+
+type CreateInviteCodeReq struct {
+	MaxUses     int `json:"maxUses"`     // 可使用次数，普通用户不超过配置上限；管理员为0时不限次数
+	ExpireHours int `json:"expireHours"` // 有效小时数，普通用户不超过配置上限；管理员为0时永不过期
+}
+
+type ListInviteCodesReq struct {
+	CreatorID int64  `json:"creatorId"` // 仅管理员接口使用，为0时获取全部
+	Page      int    `json:"page"`
+	Size      *int64 `json:"size"`
+}
+
+type DisableInviteCodeReq struct {
+	ID int64 `json:"id" binding:"required"`
+}
+
+type ListInvitationsReq struct {
+	InviterID int64  `json:"inviterId"` // 仅管理员接口使用
+	InviteeID int64  `json:"inviteeId"` // 仅管理员接口使用，可用于追溯某个账号的邀请人
+	Page      int    `json:"page"`
+	Size      *int64 `json:"size"`
+}
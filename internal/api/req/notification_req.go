@@ -0,0 +1,26 @@
+package req
+
+type GetNotificationSettingsReq struct {
+}
+
+type NotificationPreferenceReq struct {
+	Type    string `json:"type"`    // 通知类型 follow/like/comment/mention/moderation
+	Channel string `json:"channel"` // 通知渠道 in_app/email/sms/push
+	Enabled bool   `json:"enabled"` // 是否开启
+}
+
+type UpdateNotificationPreferencesReq struct {
+	Preferences []NotificationPreferenceReq `json:"preferences"`
+}
+
+type UpdateQuietHoursReq struct {
+	Enabled  bool   `json:"enabled"`  // 是否开启免打扰
+	Start    string `json:"start"`    // 开始时间 HH:MM
+	End      string `json:"end"`      // 结束时间 HH:MM
+	Timezone string `json:"timezone"` // 时区，如 Asia/Shanghai
+}
+
+type ListNotificationsReq struct {
+	Page int    `json:"page,omitempty"` // 当前页码
+	Size *int64 `json:"size,omitempty"` // 每页数据量
+}
@@ -0,0 +1,12 @@
+package req
+
+type OAuthProviderReq struct {
+	Provider string `uri:"provider" binding:"required"` // 登录方式，如 github、oidc
+}
+
+type OAuthCallbackReq struct {
+	Code  string `json:"code"`  // 第三方返回的授权码
+	State string `json:"state"` // 发起授权时生成的状态
+}
+
+type ListOAuthProvidersReq struct{}
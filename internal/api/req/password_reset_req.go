@@ -0,0 +1,18 @@
+package req
+
+type ForgotPasswordReq struct {
+	Channel string `json:"channel"` // email 或 sms
+	Account string `json:"account"` // 已验证的邮箱或绑定的手机号
+}
+
+type VerifyResetCodeReq struct {
+	Channel string `json:"channel"`
+	Account string `json:"account"`
+	Code    string `json:"code"`
+}
+
+type ResetPasswordReq struct {
+	ResetToken      string `json:"resetToken"`
+	NewPassword     string `json:"newPassword"`
+	ConfirmPassword string `json:"confirmPassword"`
+}
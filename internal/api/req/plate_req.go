@@ -18,3 +18,27 @@ type ListPlateReq struct {
 	Page int    `json:"page,omitempty"` // 当前页码
 	Size *int64 `json:"size,omitempty"` // 每页数据量
 }
+
+type SubscribePlateReq struct {
+	PlateID int64 `json:"plateId"`
+}
+
+type ListSubscribedPlatesReq struct {
+	Page int    `json:"page,omitempty"` // 当前页码
+	Size *int64 `json:"size,omitempty"` // 每页数据量
+}
+
+type PlateFeedReq struct {
+	PlateID int64 `json:"plateId,omitempty"` // 板块ID，为 0 时获取全部订阅板块的帖子
+	Cursor  uint  `json:"cursor,omitempty"`  // 上一页返回的游标，首页为 0
+	Limit   int   `json:"limit,omitempty"`   // 每页数量
+}
+
+type PlateModeratorReq struct {
+	PlateID int64 `json:"plateId" binding:"required"`
+	Uid     int64 `json:"uid" binding:"required"` // 被任命或移除的用户ID
+}
+
+type ListPlateModeratorsReq struct {
+	PlateID int64 `json:"plateId" binding:"required"`
+}
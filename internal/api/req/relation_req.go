@@ -34,3 +34,38 @@ type GetFolloweeCountReq struct {
 type GetFollowerCountReq struct {
 	UserID int64 `json:"userId"`
 }
+
+type BlockUserReq struct {
+	TargetID int64 `json:"targetId"` // 目标用户
+}
+
+type ListBlockedUsersReq struct {
+	Page int    `json:"page,omitempty"` // 当前页码
+	Size *int64 `json:"size,omitempty"` // 每页数据量
+}
+
+type ListFriendsReq struct {
+	Page int    `json:"page,omitempty"` // 当前页码
+	Size *int64 `json:"size,omitempty"` // 每页数据量
+}
+
+type GetSocialProofReq struct {
+	UserID int64 `json:"userId"` // 被查看主页的用户
+}
+
+type SuggestUsersReq struct {
+	Limit int `json:"limit,omitempty"` // 推荐数量
+}
+
+type SetPrivacyReq struct {
+	IsPrivate bool `json:"isPrivate"` // 是否设为私密账号
+}
+
+type ListFollowRequestsReq struct {
+	Page int    `json:"page,omitempty"` // 当前页码
+	Size *int64 `json:"size,omitempty"` // 每页数据量
+}
+
+type HandleFollowRequestReq struct {
+	FollowerID int64 `json:"followerId"` // 发起请求的用户
+}
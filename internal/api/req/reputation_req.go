@@ -0,0 +1,24 @@
+package req
+
+type GetReputationReq struct {
+	UserID int64 `form:"userId"` // 为空时查询当前用户
+}
+
+type ListReputationLogsReq struct {
+	Page int    `json:"page"`
+	Size *int64 `json:"size"`
+}
+
+type ListLevelsReq struct{}
+
+type ReputationLevelReq struct {
+	Level            int    `json:"level"`
+	Name             string `json:"name"`
+	MinPoints        int64  `json:"minPoints"`
+	CanPostLinks     bool   `json:"canPostLinks"`
+	CanCreateLottery bool   `json:"canCreateLottery"`
+}
+
+type SaveLevelsReq struct {
+	Levels []ReputationLevelReq `json:"levels" binding:"required"`
+}
@@ -0,0 +1,13 @@
+package req
+
+type ListSecurityEventsReq struct {
+	UserID int64  `json:"userId"` // 仅管理员接口使用，为0时获取全部
+	Type   string `json:"type"`   // login_locked、new_device、unlocked、token_reused，为空时获取全部
+	Page   int    `json:"page"`
+	Size   *int64 `json:"size"`
+}
+
+type UnlockLoginReq struct {
+	Kind  string `json:"kind" binding:"required"` // username、email、phone、ip
+	Value string `json:"value" binding:"required"`
+}
@@ -0,0 +1,9 @@
+package req
+
+type ListSessionsReq struct{}
+
+type RevokeSessionReq struct {
+	Ssid string `json:"ssid" binding:"required"` // 要下线的会话
+}
+
+type RevokeOtherSessionsReq struct{}
@@ -0,0 +1,9 @@
+package req
+
+type TwoFactorStatusReq struct{}
+
+type TwoFactorSetupReq struct{}
+
+type TwoFactorCodeReq struct {
+	Code string `json:"code"` // 认证器验证码，关闭时也可以使用恢复码
+}
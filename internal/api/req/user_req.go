@@ -4,6 +4,7 @@ type SignUpReq struct {
 	Username        string `json:"username"`
 	Password        string `json:"password"`
 	ConfirmPassword string `json:"confirmPassword"`
+	InviteCode      string `json:"inviteCode"` // 邀请码，邀请注册模式下必填
 }
 
 type LoginReq struct {
@@ -47,6 +48,28 @@ type UpdateProfileAdminReq struct {
 	Phone    string `json:"phone"`    // 手机号
 }
 
+type LoginTwoFactorReq struct {
+	ChallengeToken string `json:"challengeToken"` // 密码校验通过后返回的登录挑战
+	Code           string `json:"code"`           // 认证器验证码或恢复码
+}
+
+type LoginEmailReq struct {
+	Email    string `json:"email"`
+	Password string `json:"password"` // 密码与验证码二选一
+	Code     string `json:"code"`     // 通过 purpose 为 login 的邮箱验证码登录
+}
+
+type SendEmailCodeReq struct {
+	Email   string `json:"email"`   // purpose 为 change 时忽略，验证码发送到当前已验证的邮箱
+	Purpose string `json:"purpose"` // verify、login 或 change
+}
+
+type VerifyEmailReq struct {
+	Email   string `json:"email"`
+	Code    string `json:"code"`    // 发送到新邮箱的验证码
+	OldCode string `json:"oldCode"` // 已有验证邮箱时，发送到原邮箱的换绑验证码
+}
+
 type LoginSMSReq struct {
 	Number string `json:"number"`
 	Code   string `json:"code"`
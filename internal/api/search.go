@@ -41,7 +41,7 @@ func (s *SearchHandler) SearchUser(ctx *gin.Context, req req.SearchReq) (Result,
 }
 
 func (s *SearchHandler) SearchPost(ctx *gin.Context, req req.SearchReq) (Result, error) {
-	posts, err := s.svc.SearchPosts(ctx, req.Expression)
+	posts, err := s.svc.SearchPosts(ctx, currentUserID(ctx), req.Expression)
 	if err != nil {
 		return Result{
 			Code: SearchPostERRORCode,
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/GoSimplicity/LinkMe/internal/api/req"
+	. "github.com/GoSimplicity/LinkMe/internal/constants"
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/service"
+	"github.com/GoSimplicity/LinkMe/middleware"
+	. "github.com/GoSimplicity/LinkMe/pkg/ginp"
+	"github.com/casbin/casbin/v2"
+	"github.com/gin-gonic/gin"
+)
+
+// SecurityHandler 登录安全事件与锁定管理
+type SecurityHandler struct {
+	svc service.LoginGuardService
+	ce  *casbin.SyncedEnforcer
+}
+
+func NewSecurityHandler(svc service.LoginGuardService, ce *casbin.SyncedEnforcer) *SecurityHandler {
+	return &SecurityHandler{
+		svc: svc,
+		ce:  ce,
+	}
+}
+
+func (sh *SecurityHandler) RegisterRoutes(server *gin.Engine) {
+	casbinMiddleware := middleware.NewCasbinMiddleware(sh.ce)
+	securityGroup := server.Group("/api/security")
+	securityGroup.POST("/events", WrapBody(sh.ListEvents)) // 我的安全事件
+	adminGroup := securityGroup.Group("/admin", casbinMiddleware.CheckCasbin())
+	adminGroup.POST("/events", WrapBody(sh.AdminListEvents)) // 全部安全事件
+	adminGroup.POST("/unlock", WrapBody(sh.AdminUnlock))     // 解除登录锁定
+}
+
+// ListEvents 获取我的安全事件
+func (sh *SecurityHandler) ListEvents(ctx *gin.Context, req req.ListSecurityEventsReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: ListSecurityEventsErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+	return sh.listEvents(ctx, uc.Uid, req)
+}
+
+// AdminListEvents 获取全部安全事件，可按用户与类型筛选
+func (sh *SecurityHandler) AdminListEvents(ctx *gin.Context, req req.ListSecurityEventsReq) (Result, error) {
+	return sh.listEvents(ctx, req.UserID, req)
+}
+
+// AdminUnlock 解除账号、手机号或IP的登录锁定
+func (sh *SecurityHandler) AdminUnlock(ctx *gin.Context, req req.UnlockLoginReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: UnlockLoginErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	err := sh.svc.Unlock(ctx, uc.Uid, domain.LoginSubject{
+		Kind:  domain.LoginSubjectKind(req.Kind),
+		Value: req.Value,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidLoginSubject) {
+			return Result{Code: UnlockLoginErrorCode, Msg: err.Error()}, nil
+		}
+		return Result{
+			Code: UnlockLoginErrorCode,
+			Msg:  UnlockLoginErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  UnlockLoginSuccessMsg,
+	}, nil
+}
+
+func (sh *SecurityHandler) listEvents(ctx *gin.Context, uid int64, req req.ListSecurityEventsReq) (Result, error) {
+	events, err := sh.svc.ListEvents(ctx, uid, domain.SecurityEventType(req.Type), domain.Pagination{
+		Page: req.Page,
+		Size: req.Size,
+	})
+	if err != nil {
+		return Result{
+			Code: ListSecurityEventsErrorCode,
+			Msg:  ListSecurityEventsErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  ListSecurityEventsSuccessMsg,
+		Data: events,
+	}, nil
+}
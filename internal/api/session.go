@@ -0,0 +1,103 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/GoSimplicity/LinkMe/internal/api/req"
+	. "github.com/GoSimplicity/LinkMe/internal/constants"
+	. "github.com/GoSimplicity/LinkMe/pkg/ginp"
+	ijwt "github.com/GoSimplicity/LinkMe/utils/jwt"
+	"github.com/gin-gonic/gin"
+)
+
+// SessionHandler 登录会话与设备管理
+type SessionHandler struct {
+	ijwt ijwt.Handler
+}
+
+func NewSessionHandler(j ijwt.Handler) *SessionHandler {
+	return &SessionHandler{
+		ijwt: j,
+	}
+}
+
+func (sh *SessionHandler) RegisterRoutes(server *gin.Engine) {
+	sessionGroup := server.Group("/api/user/sessions")
+	sessionGroup.GET("", WrapQuery(sh.ListSessions))               // 当前有效的登录会话
+	sessionGroup.POST("/revoke", WrapBody(sh.RevokeSession))       // 下线指定会话
+	sessionGroup.POST("/revoke_others", WrapBody(sh.RevokeOthers)) // 下线除当前会话外的全部会话
+}
+
+// ListSessions 获取当前用户的登录会话
+func (sh *SessionHandler) ListSessions(ctx *gin.Context, _ req.ListSessionsReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: ListSessionsErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	sessions, err := sh.ijwt.ListSessions(ctx, uc.Uid)
+	if err != nil {
+		return Result{
+			Code: ListSessionsErrorCode,
+			Msg:  ListSessionsErrorMsg,
+		}, err
+	}
+	for i := range sessions {
+		sessions[i].Current = sessions[i].Ssid == uc.Ssid
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  ListSessionsSuccessMsg,
+		Data: sessions,
+	}, nil
+}
+
+// RevokeSession 下线指定会话
+func (sh *SessionHandler) RevokeSession(ctx *gin.Context, req req.RevokeSessionReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: RevokeSessionErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	if err := sh.ijwt.RevokeSession(ctx, uc.Uid, req.Ssid); err != nil {
+		if errors.Is(err, ijwt.ErrSessionNotFound) {
+			return Result{Code: RevokeSessionErrorCode, Msg: err.Error()}, nil
+		}
+		return Result{
+			Code: RevokeSessionErrorCode,
+			Msg:  RevokeSessionErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  RevokeSessionSuccessMsg,
+	}, nil
+}
+
+// RevokeOthers 下线除当前会话外的全部会话
+func (sh *SessionHandler) RevokeOthers(ctx *gin.Context, _ req.RevokeOtherSessionsReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: RevokeOtherSessionsErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	if err := sh.ijwt.RevokeOtherSessions(ctx, uc.Uid, uc.Ssid); err != nil {
+		return Result{
+			Code: RevokeOtherSessionsErrorCode,
+			Msg:  RevokeOtherSessionsErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  RevokeOtherSessionsSuccessMsg,
+	}, nil
+}
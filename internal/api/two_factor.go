@@ -0,0 +1,169 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/GoSimplicity/LinkMe/internal/api/req"
+	. "github.com/GoSimplicity/LinkMe/internal/constants"
+	"github.com/GoSimplicity/LinkMe/internal/service"
+	. "github.com/GoSimplicity/LinkMe/pkg/ginp"
+	"github.com/gin-gonic/gin"
+)
+
+// TwoFactorHandler 两步验证管理
+type TwoFactorHandler struct {
+	svc service.TwoFactorService
+}
+
+func NewTwoFactorHandler(svc service.TwoFactorService) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		svc: svc,
+	}
+}
+
+func (th *TwoFactorHandler) RegisterRoutes(server *gin.Engine) {
+	tfaGroup := server.Group("/api/user/2fa")
+	tfaGroup.GET("/status", WrapQuery(th.Status))                          // 两步验证状态
+	tfaGroup.POST("/setup", WrapBody(th.Setup))                            // 生成密钥与绑定二维码地址
+	tfaGroup.POST("/enable", WrapBody(th.Enable))                          // 验证首个验证码并启用
+	tfaGroup.POST("/disable", WrapBody(th.Disable))                        // 关闭两步验证
+	tfaGroup.POST("/recovery_codes", WrapBody(th.RegenerateRecoveryCodes)) // 重新生成恢复码
+}
+
+// Status 获取两步验证状态
+func (th *TwoFactorHandler) Status(ctx *gin.Context, _ req.TwoFactorStatusReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: TwoFactorStatusErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	status, err := th.svc.Status(ctx, uc.Uid)
+	if err != nil {
+		return Result{
+			Code: TwoFactorStatusErrorCode,
+			Msg:  TwoFactorStatusErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  TwoFactorStatusSuccessMsg,
+		Data: status,
+	}, nil
+}
+
+// Setup 生成两步验证密钥
+func (th *TwoFactorHandler) Setup(ctx *gin.Context, _ req.TwoFactorSetupReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: TwoFactorSetupErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	setup, err := th.svc.Setup(ctx, uc.Uid)
+	if err != nil {
+		if errors.Is(err, service.ErrTwoFactorEnabled) {
+			return Result{Code: TwoFactorSetupErrorCode, Msg: err.Error()}, nil
+		}
+		return Result{
+			Code: TwoFactorSetupErrorCode,
+			Msg:  TwoFactorSetupErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  TwoFactorSetupSuccessMsg,
+		Data: setup,
+	}, nil
+}
+
+// Enable 启用两步验证，返回仅展示一次的恢复码
+func (th *TwoFactorHandler) Enable(ctx *gin.Context, req req.TwoFactorCodeReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: TwoFactorEnableErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	codes, err := th.svc.Enable(ctx, uc.Uid, req.Code)
+	if err != nil {
+		if isTwoFactorUserError(err) {
+			return Result{Code: TwoFactorEnableErrorCode, Msg: err.Error()}, nil
+		}
+		return Result{
+			Code: TwoFactorEnableErrorCode,
+			Msg:  TwoFactorEnableErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  TwoFactorEnableSuccessMsg,
+		Data: gin.H{"recoveryCodes": codes},
+	}, nil
+}
+
+// Disable 关闭两步验证
+func (th *TwoFactorHandler) Disable(ctx *gin.Context, req req.TwoFactorCodeReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: TwoFactorDisableErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	if err := th.svc.Disable(ctx, uc.Uid, req.Code); err != nil {
+		if isTwoFactorUserError(err) {
+			return Result{Code: TwoFactorDisableErrorCode, Msg: err.Error()}, nil
+		}
+		return Result{
+			Code: TwoFactorDisableErrorCode,
+			Msg:  TwoFactorDisableErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  TwoFactorDisableSuccessMsg,
+	}, nil
+}
+
+// RegenerateRecoveryCodes 重新生成恢复码
+func (th *TwoFactorHandler) RegenerateRecoveryCodes(ctx *gin.Context, req req.TwoFactorCodeReq) (Result, error) {
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return Result{
+			Code: TwoFactorRecoveryErrorCode,
+			Msg:  "未登录或登录已过期",
+		}, nil
+	}
+
+	codes, err := th.svc.RegenerateRecoveryCodes(ctx, uc.Uid, req.Code)
+	if err != nil {
+		if isTwoFactorUserError(err) {
+			return Result{Code: TwoFactorRecoveryErrorCode, Msg: err.Error()}, nil
+		}
+		return Result{
+			Code: TwoFactorRecoveryErrorCode,
+			Msg:  TwoFactorRecoveryErrorMsg,
+		}, err
+	}
+	return Result{
+		Code: RequestsOK,
+		Msg:  TwoFactorRecoverySuccessMsg,
+		Data: gin.H{"recoveryCodes": codes},
+	}, nil
+}
+
+// isTwoFactorUserError 判断是否为可直接提示给用户的错误
+func isTwoFactorUserError(err error) bool {
+	return errors.Is(err, service.ErrTwoFactorEnabled) ||
+		errors.Is(err, service.ErrTwoFactorNotEnabled) ||
+		errors.Is(err, service.ErrTwoFactorRequired) ||
+		errors.Is(err, service.ErrInvalidTwoFactorCode)
+}
@@ -14,23 +14,32 @@ import (
 	ijwt "github.com/GoSimplicity/LinkMe/utils/jwt"
 	"github.com/casbin/casbin/v2"
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
 type UserHandler struct {
 	svc           service.UserService
+	tfaSvc        service.TwoFactorService
+	guardSvc      service.LoginGuardService
+	tokenSvc      service.AccessTokenService
 	ijwt          ijwt.Handler
-	ce            *casbin.Enforcer
+	ce            *casbin.SyncedEnforcer
 	smsProducer   sms.Producer
 	emailProducer email.Producer
+	l             *zap.Logger
 }
 
-func NewUserHandler(svc service.UserService, j ijwt.Handler, smsProducer sms.Producer, emailProducer email.Producer, ce *casbin.Enforcer) *UserHandler {
+func NewUserHandler(svc service.UserService, tfaSvc service.TwoFactorService, guardSvc service.LoginGuardService, tokenSvc service.AccessTokenService, j ijwt.Handler, smsProducer sms.Producer, emailProducer email.Producer, ce *casbin.SyncedEnforcer, l *zap.Logger) *UserHandler {
 	return &UserHandler{
 		svc:           svc,
+		tfaSvc:        tfaSvc,
+		guardSvc:      guardSvc,
+		tokenSvc:      tokenSvc,
 		ijwt:          j,
 		ce:            ce,
 		smsProducer:   smsProducer,
 		emailProducer: emailProducer,
+		l:             l,
 	}
 }
 
@@ -42,12 +51,15 @@ func (uh *UserHandler) RegisterRoutes(server *gin.Engine) {
 	userGroup.POST("/signup", uh.SignUp)                    // 用户注册
 	userGroup.POST("/login", uh.Login)                      // 用户登录
 	userGroup.POST("/login_sms", uh.LoginSMS)               // 短信登录
+	userGroup.POST("/login_2fa", uh.LoginTwoFactor)         // 两步验证登录
+	userGroup.POST("/login_email", uh.LoginEmail)           // 邮箱登录
 	userGroup.POST("/send_sms", uh.SendSMS)                 // 发送短信验证码
 	userGroup.POST("/send_email", uh.SendEmail)             // 发送邮件验证码
+	userGroup.POST("/email/send_code", uh.SendEmailCode)    // 发送邮箱验证、登录或换绑验证码
+	userGroup.POST("/email/verify", uh.VerifyEmail)         // 验证并绑定邮箱
 	userGroup.POST("/logout", uh.Logout)                    // 用户登出
 	userGroup.POST("/refresh_token", uh.RefreshToken)       // 刷新令牌
 	userGroup.POST("/change_password", uh.ChangePassword)   // 修改密码
-	userGroup.DELETE("/write_off", uh.WriteOff)             // 注销用户
 	userGroup.GET("/profile", uh.GetProfile)                // 获取用户资料
 	userGroup.POST("/profile/update", uh.UpdateProfile)     // 更新用户资料(管理员)
 	userGroup.POST("/update_profile", uh.UpdateProfileByID) // 更新用户资料
@@ -73,7 +85,7 @@ func (uh *UserHandler) SignUp(ctx *gin.Context) {
 	err := uh.svc.SignUp(ctx.Request.Context(), domain.User{
 		Username: req.Username,
 		Password: req.Password,
-	})
+	}, req.InviteCode)
 
 	if err != nil {
 		apiresponse.ErrorWithData(ctx, err)
@@ -91,9 +103,16 @@ func (uh *UserHandler) Login(ctx *gin.Context) {
 		return
 	}
 
+	attempt := newLoginAttempt(ctx, domain.LoginSubjectUsername, req.Username)
+	if err := uh.guardSvc.Check(ctx, attempt); err != nil {
+		loginBlocked(ctx, err)
+		return
+	}
+
 	// 登录验证
 	du, err := uh.svc.Login(ctx, req.Username, req.Password)
 	if err != nil {
+		uh.guardSvc.RecordFailure(ctx, attempt)
 		if errors.Is(err, service.ErrInvalidUserOrPassword) {
 			apiresponse.ErrorWithMessage(ctx, UserLoginFailure)
 			return
@@ -101,9 +120,100 @@ func (uh *UserHandler) Login(ctx *gin.Context) {
 		apiresponse.ErrorWithMessage(ctx, UserLoginFailure)
 		return
 	}
+	uh.guardSvc.RecordSuccess(ctx, du.ID, attempt)
+
+	// 开启两步验证的账号先返回登录挑战，验证通过后再签发令牌
+	uh.issueLoginToken(ctx, du.ID)
+}
+
+// LoginEmail 邮箱登录，支持密码或邮箱验证码
+func (uh *UserHandler) LoginEmail(ctx *gin.Context) {
+	var req req.LoginEmailReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apiresponse.ErrorWithMessage(ctx, "无效的请求参数")
+		return
+	}
+
+	attempt := newLoginAttempt(ctx, domain.LoginSubjectEmail, utils.NormalizeEmail(req.Email))
+	if err := uh.guardSvc.Check(ctx, attempt); err != nil {
+		loginBlocked(ctx, err)
+		return
+	}
 
-	// 生成令牌
-	jwtToken, refreshToken, err := uh.ijwt.SetLoginToken(ctx, du.ID)
+	var (
+		du  domain.User
+		err error
+	)
+	if req.Code != "" {
+		du, err = uh.svc.LoginByEmailCode(ctx, req.Email, req.Code)
+	} else {
+		du, err = uh.svc.LoginByEmail(ctx, req.Email, req.Password)
+	}
+	if err != nil {
+		uh.guardSvc.RecordFailure(ctx, attempt)
+		if errors.Is(err, service.ErrInvalidEmailCode) {
+			apiresponse.ErrorWithMessage(ctx, err.Error())
+			return
+		}
+		apiresponse.ErrorWithMessage(ctx, UserLoginFailure)
+		return
+	}
+	uh.guardSvc.RecordSuccess(ctx, du.ID, attempt)
+
+	uh.issueLoginToken(ctx, du.ID)
+}
+
+// LoginTwoFactor 两步验证登录
+func (uh *UserHandler) LoginTwoFactor(ctx *gin.Context) {
+	var req req.LoginTwoFactorReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apiresponse.ErrorWithMessage(ctx, "无效的请求参数")
+		return
+	}
+
+	uid, recoveryCodes, err := uh.tfaSvc.CompleteLogin(ctx, req.ChallengeToken, req.Code)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidTwoFactorCode) || errors.Is(err, service.ErrTwoFactorChallenge) {
+			apiresponse.ErrorWithMessage(ctx, err.Error())
+			return
+		}
+		apiresponse.ErrorWithMessage(ctx, UserLoginFailure)
+		return
+	}
+
+	jwtToken, refreshToken, err := uh.ijwt.SetLoginToken(ctx, uid)
+	if err != nil {
+		apiresponse.ErrorWithMessage(ctx, UserLoginFailure)
+		return
+	}
+
+	data := gin.H{
+		"accessToken":  jwtToken,
+		"refreshToken": refreshToken,
+	}
+	// 登录时完成绑定的账号返回恢复码，仅展示这一次
+	if len(recoveryCodes) > 0 {
+		data["recoveryCodes"] = recoveryCodes
+	}
+	apiresponse.SuccessWithData(ctx, data)
+}
+
+// issueLoginToken 签发登录令牌，需要两步验证时返回登录挑战
+func (uh *UserHandler) issueLoginToken(ctx *gin.Context, uid int64) {
+	challenge, required, err := uh.tfaSvc.BeginLogin(ctx, uid)
+	if err != nil {
+		apiresponse.ErrorWithMessage(ctx, UserLoginFailure)
+		return
+	}
+	if required {
+		apiresponse.SuccessWithData(ctx, gin.H{
+			"twoFactorRequired": true,
+			"challenge":         challenge,
+		})
+		return
+	}
+
+	jwtToken, refreshToken, err := uh.ijwt.SetLoginToken(ctx, uid)
 	if err != nil {
 		apiresponse.ErrorWithMessage(ctx, UserLoginFailure)
 		return
@@ -115,6 +225,24 @@ func (uh *UserHandler) Login(ctx *gin.Context) {
 	})
 }
 
+// newLoginAttempt 构造登录尝试，用于失败计数与新设备识别
+func newLoginAttempt(ctx *gin.Context, kind domain.LoginSubjectKind, account string) domain.LoginAttempt {
+	return domain.LoginAttempt{
+		Account:   domain.LoginSubject{Kind: kind, Value: account},
+		IP:        ctx.ClientIP(),
+		UserAgent: ctx.GetHeader("User-Agent"),
+	}
+}
+
+// loginBlocked 登录被锁定或需要等待时返回原因
+func loginBlocked(ctx *gin.Context, err error) {
+	if errors.Is(err, service.ErrLoginLocked) || errors.Is(err, service.ErrLoginTooFrequent) {
+		apiresponse.ErrorWithMessage(ctx, err.Error())
+		return
+	}
+	apiresponse.ErrorWithMessage(ctx, UserLoginFailure)
+}
+
 // Logout 用户登出
 func (uh *UserHandler) Logout(ctx *gin.Context) {
 	if err := uh.ijwt.ClearToken(ctx); err != nil {
@@ -125,7 +253,7 @@ func (uh *UserHandler) Logout(ctx *gin.Context) {
 	apiresponse.Success(ctx)
 }
 
-// RefreshToken 刷新令牌
+// RefreshToken 刷新令牌，返回新的 accessToken 与轮换后的 refreshToken，客户端需替换保存的长Token
 func (uh *UserHandler) RefreshToken(ctx *gin.Context) {
 	var req req.RefreshTokenReq
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -133,14 +261,16 @@ func (uh *UserHandler) RefreshToken(ctx *gin.Context) {
 		return
 	}
 
-	var rc ijwt.RefreshClaims
-
-	// 验证refresh token
-	if ok, claims, err := uh.ijwt.VerifyRefreshToken(ctx, req.RefreshToken); !ok || err != nil {
+	// 验证并轮换refresh token，旧Token随即失效
+	rc, refreshToken, err := uh.ijwt.RotateRefreshToken(ctx, req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, ijwt.ErrRefreshTokenReused) {
+			uh.guardSvc.ReportTokenReuse(ctx, rc.Uid, ctx.ClientIP(), ctx.GetHeader("User-Agent"))
+			apiresponse.ErrorWithMessage(ctx, err.Error())
+			return
+		}
 		apiresponse.ErrorWithMessage(ctx, UserRefreshTokenFailure)
 		return
-	} else {
-		rc = *claims
 	}
 
 	// 刷新令牌
@@ -150,7 +280,10 @@ func (uh *UserHandler) RefreshToken(ctx *gin.Context) {
 		return
 	}
 
-	apiresponse.SuccessWithData(ctx, tokenStr)
+	apiresponse.SuccessWithData(ctx, map[string]string{
+		"accessToken":  tokenStr,
+		"refreshToken": refreshToken,
+	})
 }
 
 // SendSMS 发送短信验证码
@@ -187,7 +320,7 @@ func (uh *UserHandler) ChangePassword(ctx *gin.Context) {
 		return
 	}
 
-	err := uh.svc.ChangePassword(ctx.Request.Context(), req.Username, req.Password, req.NewPassword, req.ConfirmPassword)
+	uid, err := uh.svc.ChangePassword(ctx.Request.Context(), req.Username, req.Password, req.NewPassword, req.ConfirmPassword)
 	if err != nil {
 		if errors.Is(err, service.ErrInvalidUserOrPassword) {
 			apiresponse.ErrorWithMessage(ctx, UserPasswordChangeFailure)
@@ -197,6 +330,20 @@ func (uh *UserHandler) ChangePassword(ctx *gin.Context) {
 		return
 	}
 
+	// 修改密码后使其他会话失效，当前登录的会话保留；
+	// 密码已修改成功，失效失败只记录日志，避免客户端误以为修改失败
+	var keepSsid string
+	if uc, ok := currentClaims(ctx); ok && uc.Uid == uid {
+		keepSsid = uc.Ssid
+	}
+	if err := uh.ijwt.RevokeOtherSessions(ctx, uid, keepSsid); err != nil {
+		uh.l.Error("修改密码后清除其他会话失败", zap.Int64("uid", uid), zap.Error(err))
+	}
+	// 旧密码可能已泄露，个人访问令牌一并撤销
+	if err := uh.tokenSvc.RevokeAll(ctx, uid); err != nil {
+		uh.l.Error("修改密码后撤销访问令牌失败", zap.Int64("uid", uid), zap.Error(err))
+	}
+
 	apiresponse.Success(ctx)
 }
 
@@ -216,32 +363,94 @@ func (uh *UserHandler) SendEmail(ctx *gin.Context) {
 	apiresponse.Success(ctx)
 }
 
-// WriteOff 注销用户
-func (uh *UserHandler) WriteOff(ctx *gin.Context) {
-	var req req.DeleteUserReq
+// SendEmailCode 按用途发送邮箱验证码
+func (uh *UserHandler) SendEmailCode(ctx *gin.Context) {
+	var req req.SendEmailCodeReq
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		apiresponse.ErrorWithMessage(ctx, "无效的请求参数")
 		return
 	}
 
-	uc, ok := requireUser(ctx)
-	if !ok {
+	purpose := domain.EmailPurpose(req.Purpose)
+	// 重置密码的验证码由 /api/user/password/forgot 发送
+	if !purpose.Valid() || purpose == domain.EmailPurposeReset {
+		apiresponse.ErrorWithMessage(ctx, "无效的验证码用途")
 		return
 	}
 
-	// 删除用户
-	if err := uh.svc.DeleteUser(ctx, req.Username, req.Password, uc.Uid); err != nil {
-		if errors.Is(err, service.ErrInvalidUserOrPassword) {
-			apiresponse.ErrorWithMessage(ctx, UserDeletedFailure)
+	address := utils.NormalizeEmail(req.Email)
+	switch purpose {
+	case domain.EmailPurposeVerify:
+		if _, ok := requireUser(ctx); !ok {
+			return
+		}
+	case domain.EmailPurposeChange:
+		// 换绑验证码只发送到当前已验证的邮箱
+		uc, ok := requireUser(ctx)
+		if !ok {
+			return
+		}
+		profile, err := uh.svc.GetProfileByUserID(ctx, uc.Uid)
+		if err != nil {
+			apiresponse.ErrorWithMessage(ctx, "发送邮件验证码失败")
+			return
+		}
+		if !profile.EmailVerified {
+			apiresponse.ErrorWithMessage(ctx, "当前邮箱未验证")
 			return
 		}
-		apiresponse.ErrorWithMessage(ctx, UserDeletedFailure)
+		address = utils.NormalizeEmail(profile.Email)
+	}
+
+	if !utils.IsValidEmail(address) {
+		apiresponse.ErrorWithMessage(ctx, UserEmailFormatError)
 		return
 	}
 
-	// 清除令牌
-	if err := uh.ijwt.ClearToken(ctx); err != nil {
-		apiresponse.ErrorWithMessage(ctx, "清除令牌失败")
+	if err := uh.svc.CheckEmailCodeCooldown(ctx, address, purpose); err != nil {
+		if errors.Is(err, service.ErrEmailCodeTooFrequent) {
+			apiresponse.ErrorWithMessage(ctx, err.Error())
+			return
+		}
+		apiresponse.ErrorWithMessage(ctx, "发送邮件验证码失败")
+		return
+	}
+
+	if err := uh.emailProducer.ProduceEmail(ctx, email.EmailEvent{Email: address, Purpose: purpose}); err != nil {
+		apiresponse.ErrorWithMessage(ctx, "发送邮件验证码失败")
+		return
+	}
+
+	apiresponse.Success(ctx)
+}
+
+// VerifyEmail 验证并绑定邮箱
+func (uh *UserHandler) VerifyEmail(ctx *gin.Context) {
+	var req req.VerifyEmailReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		apiresponse.ErrorWithMessage(ctx, "无效的请求参数")
+		return
+	}
+
+	uc, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	if !utils.IsValidEmail(utils.NormalizeEmail(req.Email)) {
+		apiresponse.ErrorWithMessage(ctx, UserEmailFormatError)
+		return
+	}
+
+	if err := uh.svc.VerifyEmail(ctx, uc.Uid, req.Email, req.Code, req.OldCode); err != nil {
+		switch {
+		case errors.Is(err, service.ErrEmailExists):
+			apiresponse.ErrorWithMessage(ctx, UserEmailConflictError)
+		case errors.Is(err, service.ErrInvalidEmailCode), errors.Is(err, service.ErrEmailReverifyRequired):
+			apiresponse.ErrorWithMessage(ctx, err.Error())
+		default:
+			apiresponse.ErrorWithMessage(ctx, UserProfileUpdateFailure)
+		}
 		return
 	}
 
@@ -307,22 +516,21 @@ func (uh *UserHandler) LoginSMS(ctx *gin.Context) {
 		return
 	}
 
-	du, err := uh.svc.LoginBySMS(ctx, req.Number, req.Code)
-	if err != nil {
-		apiresponse.ErrorWithMessage(ctx, UserLoginFailure)
+	attempt := newLoginAttempt(ctx, domain.LoginSubjectPhone, req.Number)
+	if err := uh.guardSvc.Check(ctx, attempt); err != nil {
+		loginBlocked(ctx, err)
 		return
 	}
 
-	jwtToken, refreshToken, err := uh.ijwt.SetLoginToken(ctx, du.ID)
+	du, err := uh.svc.LoginBySMS(ctx, req.Number, req.Code)
 	if err != nil {
+		uh.guardSvc.RecordFailure(ctx, attempt)
 		apiresponse.ErrorWithMessage(ctx, UserLoginFailure)
 		return
 	}
+	uh.guardSvc.RecordSuccess(ctx, du.ID, attempt)
 
-	apiresponse.SuccessWithData(ctx, map[string]string{
-		"accessToken":  jwtToken,
-		"refreshToken": refreshToken,
-	})
+	uh.issueLoginToken(ctx, du.ID)
 }
 
 // ListUser 获取用户列表（管理员使用）
@@ -0,0 +1,13 @@
+package constants
+
+const (
+	CreateAccessTokenErrorCode  = 419001
+	ListAccessTokensErrorCode   = 419002
+	RevokeAccessTokenErrorCode  = 419003
+	CreateAccessTokenSuccessMsg = "Access token created successfully"
+	CreateAccessTokenErrorMsg   = "Failed to create access token"
+	ListAccessTokensSuccessMsg  = "Access tokens retrieved successfully"
+	ListAccessTokensErrorMsg    = "Failed to list access tokens"
+	RevokeAccessTokenSuccessMsg = "Access token revoked successfully"
+	RevokeAccessTokenErrorMsg   = "Failed to revoke access token"
+)
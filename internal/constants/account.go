@@ -0,0 +1,20 @@
+package constants
+
+const (
+	AccountDeletionErrorCode       = 415001
+	AccountDeletionStatusErrorCode = 415002
+	AccountDeletionCancelErrorCode = 415003
+	DataExportErrorCode            = 415004
+	DataExportStatusErrorCode      = 415005
+	AccountDeletionSuccessMsg      = "Account deletion scheduled successfully"
+	AccountDeletionErrorMsg        = "Failed to schedule account deletion"
+	AccountDeletionStatusMsg       = "Account deletion status retrieved successfully"
+	AccountDeletionStatusErrorMsg  = "Failed to get account deletion status"
+	AccountDeletionCancelMsg       = "Account deletion cancelled successfully"
+	AccountDeletionCancelErrorMsg  = "Failed to cancel account deletion"
+	DataExportSuccessMsg           = "Data export requested successfully"
+	DataExportErrorMsg             = "Failed to request data export"
+	DataExportStatusSuccessMsg     = "Data export status retrieved successfully"
+	DataExportStatusErrorMsg       = "Failed to get data export status"
+	DataExportDownloadErrorMsg     = "Failed to download data export"
+)
@@ -0,0 +1,7 @@
+package constants
+
+const (
+	ListAuditLogsErrorCode  = 421001
+	ListAuditLogsSuccessMsg = "Audit logs retrieved successfully"
+	ListAuditLogsErrorMsg   = "Failed to list audit logs"
+)
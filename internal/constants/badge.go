@@ -0,0 +1,16 @@
+package constants
+
+const (
+	ListBadgesErrorCode     = 417001
+	CreateBadgeErrorCode    = 417002
+	UpdateBadgeErrorCode    = 417003
+	GetUserBadgesErrorCode  = 417004
+	ListBadgesSuccessMsg    = "Badges retrieved successfully"
+	ListBadgesErrorMsg      = "Failed to list badges"
+	CreateBadgeSuccessMsg   = "Badge created successfully"
+	CreateBadgeErrorMsg     = "Failed to create badge"
+	UpdateBadgeSuccessMsg   = "Badge updated successfully"
+	UpdateBadgeErrorMsg     = "Failed to update badge"
+	GetUserBadgesSuccessMsg = "User badges retrieved successfully"
+	GetUserBadgesErrorMsg   = "Failed to get user badges"
+)
@@ -0,0 +1,10 @@
+package constants
+
+const (
+	GetTimelineErrorCode  = 410001
+	GetTimelineSuccessMsg = "Timeline retrieved successfully"
+	GetTimelineErrorMsg   = "Failed to get timeline"
+	GetForYouErrorCode    = 410002
+	GetForYouSuccessMsg   = "Recommendations retrieved successfully"
+	GetForYouErrorMsg     = "Failed to get recommendations"
+)
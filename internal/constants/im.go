@@ -0,0 +1,22 @@
+package constants
+
+const (
+	SendMessageErrorCode        = 409001
+	ListConversationsErrorCode  = 409002
+	ListMessagesErrorCode       = 409003
+	MarkReadErrorCode           = 409004
+	RecallMessageErrorCode      = 409005
+	UnreadCountErrorCode        = 409006
+	SendMessageSuccessMsg       = "Message sent successfully"
+	SendMessageErrorMsg         = "Failed to send message"
+	ListConversationsSuccessMsg = "Conversations retrieved successfully"
+	ListConversationsErrorMsg   = "Failed to list conversations"
+	ListMessagesSuccessMsg      = "Messages retrieved successfully"
+	ListMessagesErrorMsg        = "Failed to list messages"
+	MarkReadSuccessMsg          = "Conversation marked as read"
+	MarkReadErrorMsg            = "Failed to mark conversation as read"
+	RecallMessageSuccessMsg     = "Message recalled successfully"
+	RecallMessageErrorMsg       = "Failed to recall message"
+	UnreadCountSuccessMsg       = "Unread count retrieved successfully"
+	UnreadCountErrorMsg         = "Failed to get unread count"
+)
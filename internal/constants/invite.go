@@ -0,0 +1,18 @@
+package constants
+
+const (
+	GetRegistrationModeErrorCode = 418001
+	CreateInviteCodeErrorCode    = 418002
+	ListInviteCodesErrorCode     = 418003
+	DisableInviteCodeErrorCode   = 418004
+	ListInvitationsErrorCode     = 418005
+	GetRegistrationModeMsg       = "Registration mode retrieved successfully"
+	CreateInviteCodeSuccessMsg   = "Invite code created successfully"
+	CreateInviteCodeErrorMsg     = "Failed to create invite code"
+	ListInviteCodesSuccessMsg    = "Invite codes retrieved successfully"
+	ListInviteCodesErrorMsg      = "Failed to list invite codes"
+	DisableInviteCodeSuccessMsg  = "Invite code disabled successfully"
+	DisableInviteCodeErrorMsg    = "Failed to disable invite code"
+	ListInvitationsSuccessMsg    = "Invitations retrieved successfully"
+	ListInvitationsErrorMsg      = "Failed to list invitations"
+)
@@ -0,0 +1,16 @@
+package constants
+
+const (
+	GetNotificationSettingsErrorCode  = 408001
+	UpdateNotificationPrefsErrorCode  = 408002
+	UpdateQuietHoursErrorCode         = 408003
+	ListNotificationsErrorCode        = 408004
+	GetNotificationSettingsSuccessMsg = "Notification settings retrieved successfully"
+	GetNotificationSettingsErrorMsg   = "Failed to get notification settings"
+	UpdateNotificationPrefsSuccessMsg = "Notification preferences updated successfully"
+	UpdateNotificationPrefsErrorMsg   = "Failed to update notification preferences"
+	UpdateQuietHoursSuccessMsg        = "Quiet hours updated successfully"
+	UpdateQuietHoursErrorMsg          = "Failed to update quiet hours"
+	ListNotificationsSuccessMsg       = "Notifications retrieved successfully"
+	ListNotificationsErrorMsg         = "Failed to list notifications"
+)
@@ -0,0 +1,18 @@
+package constants
+
+const (
+	OAuthAuthorizeErrorCode   = 411001
+	OAuthCallbackErrorCode    = 411002
+	OAuthUnbindErrorCode      = 411003
+	OAuthIdentitiesErrorCode  = 411004
+	OAuthAuthorizeSuccessMsg  = "Authorization url generated successfully"
+	OAuthAuthorizeErrorMsg    = "Failed to generate authorization url"
+	OAuthLoginSuccessMsg      = "Logged in successfully"
+	OAuthBindSuccessMsg       = "Account linked successfully"
+	OAuthCallbackErrorMsg     = "Failed to handle oauth callback"
+	OAuthUnbindSuccessMsg     = "Account unlinked successfully"
+	OAuthUnbindErrorMsg       = "Failed to unlink account"
+	OAuthIdentitiesSuccessMsg = "Linked accounts retrieved successfully"
+	OAuthIdentitiesErrorMsg   = "Failed to get linked accounts"
+	OAuthProvidersSuccessMsg  = "Providers retrieved successfully"
+)
@@ -0,0 +1,13 @@
+package constants
+
+const (
+	PasswordForgotErrorCode  = 413001
+	PasswordVerifyErrorCode  = 413002
+	PasswordResetErrorCode   = 413003
+	PasswordForgotSuccessMsg = "Reset code sent if the account exists"
+	PasswordForgotErrorMsg   = "Failed to send reset code"
+	PasswordVerifySuccessMsg = "Reset code verified successfully"
+	PasswordVerifyErrorMsg   = "Failed to verify reset code"
+	PasswordResetSuccessMsg  = "Password reset successfully"
+	PasswordResetErrorMsg    = "Failed to reset password"
+)
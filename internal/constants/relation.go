@@ -20,3 +20,52 @@ const (
 	FollowUserSuccessMsg       = "User followed successfully"
 	CancelFollowUserSuccessMsg = "User unfollowed successfully"
 )
+
+const (
+	BlockUserErrorCode         = 407007
+	UnblockUserErrorCode       = 407008
+	MuteUserErrorCode          = 407009
+	UnmuteUserErrorCode        = 407010
+	ListBlockedUsersErrorCode  = 407011
+	ListMutedUsersErrorCode    = 407012
+	BlockUserErrorMsg          = "Failed to block user"
+	UnblockUserErrorMsg        = "Failed to unblock user"
+	MuteUserErrorMsg           = "Failed to mute user"
+	UnmuteUserErrorMsg         = "Failed to unmute user"
+	ListBlockedUsersErrorMsg   = "Failed to list blocked users"
+	ListMutedUsersErrorMsg     = "Failed to list muted users"
+	BlockUserSuccessMsg        = "User blocked successfully"
+	UnblockUserSuccessMsg      = "User unblocked successfully"
+	MuteUserSuccessMsg         = "User muted successfully"
+	UnmuteUserSuccessMsg       = "User unmuted successfully"
+	ListBlockedUsersSuccessMsg = "Blocked users retrieved successfully"
+	ListMutedUsersSuccessMsg   = "Muted users retrieved successfully"
+)
+
+const (
+	ListFriendsErrorCode     = 407013
+	GetSocialProofErrorCode  = 407014
+	SuggestUsersErrorCode    = 407015
+	ListFriendsErrorMsg      = "Failed to list friends"
+	GetSocialProofErrorMsg   = "Failed to get social proof"
+	SuggestUsersErrorMsg     = "Failed to suggest users"
+	ListFriendsSuccessMsg    = "Friends retrieved successfully"
+	GetSocialProofSuccessMsg = "Social proof retrieved successfully"
+	SuggestUsersSuccessMsg   = "Suggested users retrieved successfully"
+)
+
+const (
+	SetPrivacyErrorCode            = 407016
+	ListFollowRequestsErrorCode    = 407017
+	ApproveFollowRequestErrorCode  = 407018
+	RejectFollowRequestErrorCode   = 407019
+	SetPrivacyErrorMsg             = "Failed to update privacy"
+	ListFollowRequestsErrorMsg     = "Failed to list follow requests"
+	ApproveFollowRequestErrorMsg   = "Failed to approve follow request"
+	RejectFollowRequestErrorMsg    = "Failed to reject follow request"
+	SetPrivacySuccessMsg           = "Privacy updated successfully"
+	ListFollowRequestsSuccessMsg   = "Follow requests retrieved successfully"
+	ApproveFollowRequestSuccessMsg = "Follow request approved successfully"
+	RejectFollowRequestSuccessMsg  = "Follow request rejected successfully"
+	FollowRequestSentMsg           = "Follow request sent successfully"
+)
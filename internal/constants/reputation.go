@@ -0,0 +1,16 @@
+package constants
+
+const (
+	GetReputationErrorCode      = 416001
+	ListReputationLogsErrorCode = 416002
+	ListLevelsErrorCode         = 416003
+	SaveLevelsErrorCode         = 416004
+	GetReputationSuccessMsg     = "Reputation retrieved successfully"
+	GetReputationErrorMsg       = "Failed to get reputation"
+	ListReputationLogsMsg       = "Reputation logs retrieved successfully"
+	ListReputationLogsErrorMsg  = "Failed to list reputation logs"
+	ListLevelsSuccessMsg        = "Levels retrieved successfully"
+	ListLevelsErrorMsg          = "Failed to list levels"
+	SaveLevelsSuccessMsg        = "Levels saved successfully"
+	SaveLevelsErrorMsg          = "Failed to save levels"
+)
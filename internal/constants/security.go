@@ -0,0 +1,10 @@
+package constants
+
+const (
+	ListSecurityEventsErrorCode  = 420001
+	UnlockLoginErrorCode         = 420002
+	ListSecurityEventsSuccessMsg = "Security events retrieved successfully"
+	ListSecurityEventsErrorMsg   = "Failed to list security events"
+	UnlockLoginSuccessMsg        = "Login unlocked successfully"
+	UnlockLoginErrorMsg          = "Failed to unlock login"
+)
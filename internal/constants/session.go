@@ -0,0 +1,13 @@
+package constants
+
+const (
+	ListSessionsErrorCode         = 414001
+	RevokeSessionErrorCode        = 414002
+	RevokeOtherSessionsErrorCode  = 414003
+	ListSessionsSuccessMsg        = "Sessions retrieved successfully"
+	ListSessionsErrorMsg          = "Failed to list sessions"
+	RevokeSessionSuccessMsg       = "Session revoked successfully"
+	RevokeSessionErrorMsg         = "Failed to revoke session"
+	RevokeOtherSessionsErrorMsg   = "Failed to revoke other sessions"
+	RevokeOtherSessionsSuccessMsg = "Other sessions revoked successfully"
+)
@@ -0,0 +1,20 @@
+package constants
+
+const (
+	TwoFactorStatusErrorCode     = 412001
+	TwoFactorSetupErrorCode      = 412002
+	TwoFactorEnableErrorCode     = 412003
+	TwoFactorDisableErrorCode    = 412004
+	TwoFactorRecoveryErrorCode   = 412005
+	TwoFactorStatusSuccessMsg    = "Two-factor status retrieved successfully"
+	TwoFactorStatusErrorMsg      = "Failed to get two-factor status"
+	TwoFactorSetupSuccessMsg     = "Two-factor secret generated successfully"
+	TwoFactorSetupErrorMsg       = "Failed to generate two-factor secret"
+	TwoFactorEnableSuccessMsg    = "Two-factor authentication enabled successfully"
+	TwoFactorEnableErrorMsg      = "Failed to enable two-factor authentication"
+	TwoFactorDisableSuccessMsg   = "Two-factor authentication disabled successfully"
+	TwoFactorDisableErrorMsg     = "Failed to disable two-factor authentication"
+	TwoFactorRecoverySuccessMsg  = "Recovery codes regenerated successfully"
+	TwoFactorRecoveryErrorMsg    = "Failed to regenerate recovery codes"
+	TwoFactorChallengeSuccessMsg = "Two-factor verification required"
+)
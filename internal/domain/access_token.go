@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// AccessTokenPrefix 个人访问令牌的固定前缀，用于与 JWT 区分
+const AccessTokenPrefix = "lmp_"
+
+const (
+	ScopePostsRead  = "posts:read"  // 读取帖子
+	ScopePostsWrite = "posts:write" // 创建、编辑、发布与删除帖子，点赞与收藏
+	ScopeModerate   = "moderate"    // 审核帖子与评论，需账号拥有全站审核权限或担任版主，版主仅能审核所管理的板块
+)
+
+// AccessTokenScopes 所有支持的令牌权限
+var AccessTokenScopes = []string{ScopePostsRead, ScopePostsWrite, ScopeModerate}
+
+var (
+	// ErrInvalidScopes 表示令牌权限为空或包含不支持的权限
+	ErrInvalidScopes = errors.New("令牌权限无效，可选值为 posts:read、posts:write、moderate")
+	// ErrAccessTokenInvalid 表示令牌不存在、已撤销或已过期
+	ErrAccessTokenInvalid = errors.New("访问令牌无效或已过期")
+)
+
+// AccessToken 个人访问令牌，只保存哈希，明文仅在创建时返回一次
+type AccessToken struct {
+	ID         int64    `json:"id"`
+	UserID     int64    `json:"userId"`
+	Name       string   `json:"name"`
+	Prefix     string   `json:"prefix"` // 令牌前几位，用于区分不同令牌
+	Scopes     []string `json:"scopes"`
+	ExpiresAt  int64    `json:"expiresAt"` // 0 表示永不过期
+	LastUsedAt int64    `json:"lastUsedAt"`
+	Revoked    bool     `json:"revoked"`
+	CreatedAt  int64    `json:"createdAt"`
+}
+
+// Active 判断令牌在 now 时刻是否可用
+func (t AccessToken) Active(now int64) bool {
+	return !t.Revoked && (t.ExpiresAt == 0 || now < t.ExpiresAt)
+}
+
+// HasScope 判断令牌是否拥有指定权限
+func (t AccessToken) HasScope(scope string) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeScopes 去重并校验令牌权限
+func NormalizeScopes(scopes []string) ([]string, error) {
+	res := make([]string, 0, len(scopes))
+	seen := make(map[string]bool, len(scopes))
+	for _, s := range scopes {
+		s = strings.TrimSpace(s)
+		if seen[s] {
+			continue
+		}
+		valid := false
+		for _, scope := range AccessTokenScopes {
+			if s == scope {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, ErrInvalidScopes
+		}
+		seen[s] = true
+		res = append(res, s)
+	}
+	if len(res) == 0 {
+		return nil, ErrInvalidScopes
+	}
+	return res, nil
+}
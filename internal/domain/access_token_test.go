@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeScopes(t *testing.T) {
+	got, err := NormalizeScopes([]string{"posts:read", " posts:write ", "posts:read"})
+	if err != nil {
+		t.Fatalf("NormalizeScopes returned error: %v", err)
+	}
+	if want := []string{ScopePostsRead, ScopePostsWrite}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("NormalizeScopes = %v, want %v", got, want)
+	}
+
+	for _, scopes := range [][]string{nil, {"admin"}, {"posts:read", "posts:delete"}} {
+		if _, err := NormalizeScopes(scopes); err == nil {
+			t.Errorf("NormalizeScopes(%v) should fail", scopes)
+		}
+	}
+}
+
+func TestAccessTokenActive(t *testing.T) {
+	const now = 1_000
+	cases := []struct {
+		token AccessToken
+		want  bool
+	}{
+		{AccessToken{}, true},
+		{AccessToken{ExpiresAt: now + 1}, true},
+		{AccessToken{ExpiresAt: now}, false},
+		{AccessToken{Revoked: true}, false},
+	}
+	for _, c := range cases {
+		if got := c.token.Active(now); got != c.want {
+			t.Errorf("Active(%+v) = %v, want %v", c.token, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package domain
+
+// DeletionStatus 注销申请状态
+type DeletionStatus uint8
+
+const (
+	DeletionPending   DeletionStatus = iota + 1 // 冷静期内，可撤销
+	DeletionCancelled                           // 用户已撤销
+	DeletionCompleted                           // 数据已清理
+)
+
+// AccountDeletion 账号注销申请
+type AccountDeletion struct {
+	UserID      int64          `json:"userId"`
+	Status      DeletionStatus `json:"status"`
+	RequestedAt int64          `json:"requestedAt"`
+	ScheduledAt int64          `json:"scheduledAt"` // 冷静期结束时间，到期后由定时任务清理
+	CompletedAt int64          `json:"completedAt"`
+}
+
+// Pending 是否处于冷静期
+func (d AccountDeletion) Pending() bool {
+	return d.Status == DeletionPending
+}
+
+// ExportStatus 数据导出任务状态
+type ExportStatus uint8
+
+const (
+	ExportPending ExportStatus = iota + 1 // 排队中
+	ExportRunning                         // 生成中
+	ExportReady                           // 可下载
+	ExportFailed                          // 生成失败
+	ExportExpired                         // 文件已过期删除
+)
+
+// DataExport 个人数据导出任务
+type DataExport struct {
+	ID         int64        `json:"id"`
+	UserID     int64        `json:"userId"`
+	Status     ExportStatus `json:"status"`
+	FilePath   string       `json:"-"`
+	CreatedAt  int64        `json:"createdAt"`
+	FinishedAt int64        `json:"finishedAt"`
+	ExpiresAt  int64        `json:"expiresAt"` // 文件过期时间，过期后需重新申请
+}
+
+// UserDataArchive 导出给用户的个人数据
+type UserDataArchive struct {
+	ExportedAt  int64            `json:"exportedAt"`
+	User        ArchiveUser      `json:"user"`
+	Posts       []ArchivePost    `json:"posts"`
+	Comments    []ArchiveComment `json:"comments"`
+	Likes       []uint           `json:"likes"`       // 点赞的帖子ID
+	Collections []uint           `json:"collections"` // 收藏的帖子ID
+	Followees   []int64          `json:"followees"`
+	Followers   []int64          `json:"followers"`
+	History     []History        `json:"history"`
+}
+
+// ArchiveUser 导出的账号资料，不包含密码等凭据
+type ArchiveUser struct {
+	ID        int64  `json:"id"`
+	Username  string `json:"username"`
+	CreatedAt int64  `json:"createdAt"`
+	RealName  string `json:"realName"`
+	Avatar    string `json:"avatar"`
+	About     string `json:"about"`
+	Birthday  string `json:"birthday"`
+	Email     string `json:"email"`
+	Phone     string `json:"phone"`
+}
+
+// ArchivePost 导出的帖子
+type ArchivePost struct {
+	ID        uint   `json:"id"`
+	Title     string `json:"title"`
+	Content   string `json:"content"`
+	PlateID   int64  `json:"plateId"`
+	Tags      string `json:"tags"`
+	Status    uint8  `json:"status"`
+	CreatedAt int64  `json:"createdAt"`
+	UpdatedAt int64  `json:"updatedAt"`
+}
+
+// ArchiveComment 导出的评论
+type ArchiveComment struct {
+	ID        int64  `json:"id"`
+	PostID    int64  `json:"postId"`
+	Content   string `json:"content"`
+	CreatedAt int64  `json:"createdAt"`
+}
@@ -0,0 +1,57 @@
+package domain
+
+import "strings"
+
+// apiMethods HTTP请求方法与API记录中方法编号的对应关系
+var apiMethods = map[string]int{
+	"GET":     1,
+	"POST":    2,
+	"PUT":     3,
+	"DELETE":  4,
+	"PATCH":   5,
+	"OPTIONS": 6,
+	"HEAD":    7,
+}
+
+// RoutePrefix 需要同步到API注册表的路由前缀
+const RoutePrefix = "/api/"
+
+// Route 服务实际注册的路由
+type Route struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+}
+
+// Key 路由的稳定标识
+func (r Route) Key() string {
+	return r.Method + " " + r.Path
+}
+
+// MethodCode 返回路由方法在API记录中的编号
+func (r Route) MethodCode() (int, bool) {
+	code, ok := apiMethods[r.Method]
+	return code, ok
+}
+
+// Syncable 判断路由是否需要同步到API注册表
+func (r Route) Syncable() bool {
+	_, ok := r.MethodCode()
+	return ok && strings.HasPrefix(r.Path, RoutePrefix)
+}
+
+// ApiMethodName 返回方法编号对应的HTTP请求方法
+func ApiMethodName(code int) string {
+	for name, c := range apiMethods {
+		if c == code {
+			return name
+		}
+	}
+	return ""
+}
+
+// RouteSyncResult 路由同步结果
+type RouteSyncResult struct {
+	Total   int   `json:"total"`   // 同步的路由数量
+	Created int   `json:"created"` // 新增的API记录
+	Stale   int64 `json:"stale"`   // 新标记为失效的API记录
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "testing"
+
+func TestRouteSyncable(t *testing.T) {
+	cases := []struct {
+		route Route
+		want  bool
+	}{
+		{Route{Method: "POST", Path: "/api/posts/create"}, true},
+		{Route{Method: "DELETE", Path: "/api/plate/delete/:plateId"}, true},
+		{Route{Method: "GET", Path: "/healthz"}, false},
+		{Route{Method: "GET", Path: "/.well-known/jwks.json"}, false},
+		{Route{Method: "CONNECT", Path: "/api/posts/list"}, false},
+	}
+	for _, c := range cases {
+		if got := c.route.Syncable(); got != c.want {
+			t.Errorf("%s Syncable() = %v, want %v", c.route.Key(), got, c.want)
+		}
+	}
+}
+
+func TestApiMethodName(t *testing.T) {
+	for name := range apiMethods {
+		code, ok := Route{Method: name}.MethodCode()
+		if !ok || ApiMethodName(code) != name {
+			t.Errorf("method %s round trip failed", name)
+		}
+	}
+	if ApiMethodName(0) != "" {
+		t.Errorf("unknown code should map to empty method")
+	}
+}
@@ -0,0 +1,112 @@
+package domain
+
+import "context"
+
+// 审计操作
+const (
+	AuditRoleCreate        = "role.create"
+	AuditRoleUpdate        = "role.update"
+	AuditRoleDelete        = "role.delete"
+	AuditPermissionRole    = "permission.assign_role"
+	AuditPermissionUser    = "permission.assign_user"
+	AuditPermissionUsers   = "permission.assign_users"
+	AuditMenuCreate        = "menu.create"
+	AuditMenuUpdate        = "menu.update"
+	AuditMenuDelete        = "menu.delete"
+	AuditApiCreate         = "api.create"
+	AuditApiUpdate         = "api.update"
+	AuditApiDelete         = "api.delete"
+	AuditPlateCreate       = "plate.create"
+	AuditPlateUpdate       = "plate.update"
+	AuditPlateDelete       = "plate.delete"
+	AuditModeratorAppoint  = "moderator.appoint"
+	AuditModeratorRemove   = "moderator.remove"
+	AuditUserUpdateProfile = "user.update_profile"
+	AuditCheckApprove      = "check.approve"
+	AuditCheckReject       = "check.reject"
+	AuditCommentDelete     = "comment.delete"
+	AuditLoginUnlock       = "security.unlock"
+	AuditBadgeCreate       = "badge.create"
+	AuditBadgeUpdate       = "badge.update"
+	AuditInviteCreate      = "invite.create"
+	AuditInviteDisable     = "invite.disable"
+	AuditReputationLevels  = "reputation.save_levels"
+)
+
+// 审计对象类型
+const (
+	AuditTargetRole           = "role"
+	AuditTargetUser           = "user"
+	AuditTargetMenu           = "menu"
+	AuditTargetApi            = "api"
+	AuditTargetPlate          = "plate"
+	AuditTargetCheck          = "check"
+	AuditTargetComment        = "comment"
+	AuditTargetLoginSubject   = "login_subject"
+	AuditTargetPlateModerator = "plate_moderator"
+	AuditTargetBadge          = "badge"
+	AuditTargetInviteCode     = "invite_code"
+	AuditTargetReputation     = "reputation_levels"
+)
+
+// AuditLog 管理员与版主操作的审计记录，Before/After 为操作前后的 JSON 快照
+type AuditLog struct {
+	ID         int64  `json:"id"`
+	ActorID    int64  `json:"actorId"`
+	Action     string `json:"action"`
+	TargetType string `json:"targetType"`
+	TargetID   string `json:"targetId"`
+	Before     string `json:"before,omitempty"`
+	After      string `json:"after,omitempty"`
+	IP         string `json:"ip"`
+	RequestID  string `json:"requestId"`
+	CreatedAt  int64  `json:"createdAt"`
+}
+
+// AuditEntry 待记录的审计事件，Before/After 会被序列化为 JSON，ActorID 为0时取请求中的登录用户
+type AuditEntry struct {
+	ActorID    int64
+	Action     string
+	TargetType string
+	TargetID   string
+	Before     interface{}
+	After      interface{}
+}
+
+// AuditFilter 审计记录查询条件，零值表示不筛选
+type AuditFilter struct {
+	ActorID    int64
+	Action     string
+	TargetType string
+	TargetID   string
+	StartTime  int64 // 毫秒时间戳，包含
+	EndTime    int64 // 毫秒时间戳，不包含
+}
+
+// AuditMeta 发起操作的请求信息
+type AuditMeta struct {
+	ActorID   int64
+	IP        string
+	RequestID string
+}
+
+// AuditMetaKey gin 上下文中保存请求信息的键，处理器直接传入 *gin.Context 时使用
+const AuditMetaKey = "audit_meta"
+
+type auditMetaKey struct{}
+
+// WithAuditMeta 将请求信息写入上下文
+func WithAuditMeta(ctx context.Context, meta AuditMeta) context.Context {
+	return context.WithValue(ctx, auditMetaKey{}, meta)
+}
+
+// AuditMetaFromContext 读取请求信息，兼容 *gin.Context 与请求上下文
+func AuditMetaFromContext(ctx context.Context) AuditMeta {
+	if meta, ok := ctx.Value(auditMetaKey{}).(AuditMeta); ok {
+		return meta
+	}
+	if meta, ok := ctx.Value(AuditMetaKey).(AuditMeta); ok {
+		return meta
+	}
+	return AuditMeta{}
+}
@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAuditMetaFromContext(t *testing.T) {
+	if meta := AuditMetaFromContext(context.Background()); meta != (AuditMeta{}) {
+		t.Fatalf("expected empty meta, got %+v", meta)
+	}
+
+	want := AuditMeta{ActorID: 7, IP: "10.0.0.1", RequestID: "abc"}
+	if meta := AuditMetaFromContext(WithAuditMeta(context.Background(), want)); meta != want {
+		t.Fatalf("typed key: got %+v", meta)
+	}
+	// *gin.Context 通过字符串键读取
+	ctx := context.WithValue(context.Background(), AuditMetaKey, want)
+	if meta := AuditMetaFromContext(ctx); meta != want {
+		t.Fatalf("string key: got %+v", meta)
+	}
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// BadgeRule 勋章的获得条件
+type BadgeRule string
+
+const (
+	BadgeRuleApprovedPosts         BadgeRule = "approved_posts"         // 审核通过的帖子数
+	BadgeRuleLikesReceived         BadgeRule = "likes_received"         // 帖子累计获得的点赞数
+	BadgeRuleActiveStreak          BadgeRule = "active_streak"          // 最长连续活跃天数
+	BadgeRuleLotteryParticipations BadgeRule = "lottery_participations" // 参与抽奖的次数
+)
+
+// BadgeRules 所有支持的勋章条件
+var BadgeRules = []BadgeRule{
+	BadgeRuleApprovedPosts,
+	BadgeRuleLikesReceived,
+	BadgeRuleActiveStreak,
+	BadgeRuleLotteryParticipations,
+}
+
+// ErrInvalidBadge 表示勋章定义不合法
+var ErrInvalidBadge = errors.New("勋章定义无效：名称不能为空，条件需为支持的类型且门槛大于0")
+
+// Badge 勋章定义，统计值达到门槛即授予
+type Badge struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Icon        string    `json:"icon"` // 图标URL
+	Rule        BadgeRule `json:"rule"`
+	Threshold   int64     `json:"threshold"`
+	Enabled     bool      `json:"enabled"` // 停用后不再授予，已获得的勋章保留
+	CreatedAt   int64     `json:"createdAt"`
+	UpdatedAt   int64     `json:"updatedAt"`
+}
+
+// UserBadge 用户已获得的勋章
+type UserBadge struct {
+	BadgeID     int64  `json:"badgeId"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Icon        string `json:"icon"`
+	AwardedAt   int64  `json:"awardedAt"`
+}
+
+// DefaultBadges 首次使用时写入的内置勋章
+var DefaultBadges = []Badge{
+	{Name: "初出茅庐", Description: "第一篇帖子审核通过", Rule: BadgeRuleApprovedPosts, Threshold: 1, Enabled: true},
+	{Name: "人气作者", Description: "帖子累计获得100个赞", Rule: BadgeRuleLikesReceived, Threshold: 100, Enabled: true},
+	{Name: "坚持不懈", Description: "连续活跃30天", Rule: BadgeRuleActiveStreak, Threshold: 30, Enabled: true},
+	{Name: "幸运儿", Description: "第一次参与抽奖", Rule: BadgeRuleLotteryParticipations, Threshold: 1, Enabled: true},
+}
+
+// Validate 校验勋章定义
+func (b Badge) Validate() error {
+	if b.Name == "" || b.Threshold <= 0 || !b.Rule.Valid() {
+		return ErrInvalidBadge
+	}
+	return nil
+}
+
+// Reached 判断统计值是否满足勋章条件
+func (b Badge) Reached(value int64) bool {
+	return b.Enabled && b.Threshold > 0 && value >= b.Threshold
+}
+
+// Valid 判断是否为支持的勋章条件
+func (r BadgeRule) Valid() bool {
+	for _, rule := range BadgeRules {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// LongestStreak 计算最长连续活跃天数，days 为按升序排列且不重复的 2006-01-02 格式日期
+func LongestStreak(days []string) int64 {
+	var longest, current int64
+	var prev time.Time
+	for _, d := range days {
+		day, err := time.Parse(time.DateOnly, d)
+		if err != nil {
+			continue
+		}
+		if current > 0 && day.Sub(prev) == 24*time.Hour {
+			current++
+		} else {
+			current = 1
+		}
+		prev = day
+		if current > longest {
+			longest = current
+		}
+	}
+	return longest
+}
@@ -0,0 +1,50 @@
+package domain
+
+import "testing"
+
+func TestLongestStreak(t *testing.T) {
+	cases := []struct {
+		days []string
+		want int64
+	}{
+		{nil, 0},
+		{[]string{"2024-01-01"}, 1},
+		{[]string{"2024-01-01", "2024-01-02", "2024-01-04"}, 2},
+		{[]string{"2024-02-27", "2024-02-28", "2024-02-29", "2024-03-01", "2024-03-05"}, 4},
+		{[]string{"2024-01-01", "2024-01-03", "2024-01-04", "2024-01-05", "2024-01-07"}, 3},
+	}
+	for _, c := range cases {
+		if got := LongestStreak(c.days); got != c.want {
+			t.Errorf("LongestStreak(%v) = %d, want %d", c.days, got, c.want)
+		}
+	}
+}
+
+func TestBadgeReached(t *testing.T) {
+	b := Badge{Name: "人气作者", Rule: BadgeRuleLikesReceived, Threshold: 100, Enabled: true}
+	if b.Reached(99) || !b.Reached(100) {
+		t.Fatal("badge should be reached exactly at its threshold")
+	}
+	b.Enabled = false
+	if b.Reached(1000) {
+		t.Fatal("disabled badge should never be reached")
+	}
+}
+
+func TestBadgeValidate(t *testing.T) {
+	for _, b := range DefaultBadges {
+		if err := b.Validate(); err != nil {
+			t.Fatalf("default badge %q should be valid: %v", b.Name, err)
+		}
+	}
+	invalid := []Badge{
+		{Name: "", Rule: BadgeRuleApprovedPosts, Threshold: 1},
+		{Name: "x", Rule: "unknown", Threshold: 1},
+		{Name: "x", Rule: BadgeRuleActiveStreak, Threshold: 0},
+	}
+	for _, b := range invalid {
+		if err := b.Validate(); err == nil {
+			t.Errorf("badge %+v should be invalid", b)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "regexp"
+
 type Comment struct {
 	Id            int64
 	UserId        int64
@@ -14,3 +16,26 @@ type Comment struct {
 	UpdatedAt     int64
 	Status        uint8 // 评论的审核状态
 }
+
+// 单条评论最多提醒的用户数
+const maxMentions = 10
+
+var mentionRegexp = regexp.MustCompile(`@([a-zA-Z0-9]{6,})`)
+
+// Mentions 提取评论中 @ 的用户名，去重后最多返回 maxMentions 个
+func (c Comment) Mentions() []string {
+	matches := mentionRegexp.FindAllStringSubmatch(c.Content, -1)
+	seen := make(map[string]struct{}, len(matches))
+	usernames := make([]string, 0, len(matches))
+	for _, m := range matches {
+		if _, ok := seen[m[1]]; ok {
+			continue
+		}
+		seen[m[1]] = struct{}{}
+		usernames = append(usernames, m[1])
+		if len(usernames) >= maxMentions {
+			break
+		}
+	}
+	return usernames
+}
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCommentMentions(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{"none", "hello world", []string{}},
+		{"single", "hi @alice123 look", []string{"alice123"}},
+		{"dedup", "@alice123 @bob4567 @alice123", []string{"alice123", "bob4567"}},
+		{"too short", "@bob", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (Comment{Content: tt.content}).Mentions(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Mentions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
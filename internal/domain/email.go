@@ -0,0 +1,20 @@
+package domain
+
+// EmailPurpose 邮箱验证码用途，不同用途的验证码互不通用
+type EmailPurpose string
+
+const (
+	EmailPurposeVerify EmailPurpose = "verify" // 绑定并验证邮箱
+	EmailPurposeLogin  EmailPurpose = "login"  // 邮箱验证码登录
+	EmailPurposeChange EmailPurpose = "change" // 更换已验证的邮箱前验证原邮箱
+	EmailPurposeReset  EmailPurpose = "reset"  // 忘记密码
+)
+
+// Valid 判断是否为支持的验证码用途
+func (p EmailPurpose) Valid() bool {
+	switch p {
+	case EmailPurposeVerify, EmailPurposeLogin, EmailPurposeChange, EmailPurposeReset:
+		return true
+	}
+	return false
+}
@@ -55,5 +55,5 @@ func (e *EmailConsumer) HandleMessage(msg *sarama.ConsumerMessage, emailEvent Em
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
-	return e.repo.SendCode(ctx, emailEvent.Email)
+	return e.repo.SendCode(ctx, emailEvent.Email, emailEvent.Purpose)
 }
@@ -3,6 +3,8 @@ package email
 import (
 	"context"
 	"encoding/json"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
 	"github.com/IBM/sarama"
 	"go.uber.org/zap"
 )
@@ -15,7 +17,8 @@ type Producer interface {
 
 // EmailEvent 代表单个短信验证码事件
 type EmailEvent struct {
-	Email string
+	Email   string
+	Purpose domain.EmailPurpose `json:",omitempty"` // 验证码用途，为空时沿用旧的通用验证码
 }
 
 // SaramaSyncProducer 实现Producer接口的结构体
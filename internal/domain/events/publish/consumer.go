@@ -19,22 +19,24 @@ const (
 )
 
 type PublishPostEventConsumer struct {
-	repo    repository.PostRepository
-	client  sarama.Client
-	l       *zap.Logger
-	dlqProd sarama.SyncProducer // 死信队列生产者
+	repo     repository.PostRepository
+	feedRepo repository.FeedRepository
+	client   sarama.Client
+	l        *zap.Logger
+	dlqProd  sarama.SyncProducer // 死信队列生产者
 }
 
 type consumerGroupHandler struct {
 	consumer *PublishPostEventConsumer
 }
 
-func NewPublishPostEventConsumer(repo repository.PostRepository, client sarama.Client, dlqProd sarama.SyncProducer, l *zap.Logger) *PublishPostEventConsumer {
+func NewPublishPostEventConsumer(repo repository.PostRepository, feedRepo repository.FeedRepository, client sarama.Client, dlqProd sarama.SyncProducer, l *zap.Logger) *PublishPostEventConsumer {
 	return &PublishPostEventConsumer{
-		repo:    repo,
-		client:  client,
-		l:       l,
-		dlqProd: dlqProd,
+		repo:     repo,
+		feedRepo: feedRepo,
+		client:   client,
+		l:        l,
+		dlqProd:  dlqProd,
 	}
 }
 
@@ -234,5 +236,13 @@ func (p *PublishPostEventConsumer) handleEvent(ctx context.Context, event *Publi
 		return fmt.Errorf("更新帖子状态失败: %w", err)
 	}
 
+	// 推送到粉丝的关注流，失败不影响发布结果
+	if err := p.feedRepo.PushPost(ctx, event.PostId, event.Uid); err != nil {
+		p.l.Error("推送关注流失败",
+			zap.Error(err),
+			zap.Uint("post_id", event.PostId),
+			zap.Int64("uid", event.Uid))
+	}
+
 	return nil
 }
@@ -0,0 +1,7 @@
+package domain
+
+// Timeline 关注流的一页数据，Cursor 为下一页的游标，为 0 表示没有更多数据
+type Timeline struct {
+	Posts  []Post `json:"posts"`
+	Cursor uint   `json:"cursor"`
+}
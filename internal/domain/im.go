@@ -0,0 +1,22 @@
+package domain
+
+// Conversation 单聊会话，从当前用户视角展示
+type Conversation struct {
+	ID          int64    `json:"id,string"`   // 会话ID，雪花算法生成
+	PeerID      int64    `json:"peerId"`      // 对方用户ID
+	UnreadCount int64    `json:"unreadCount"` // 未读消息数
+	LastMessage *Message `json:"lastMessage"` // 最后一条消息
+	UpdatedAt   int64    `json:"updatedAt"`   // 最近活跃时间
+}
+
+// Message 私信消息
+type Message struct {
+	ID             int64  `json:"id,string"`             // 消息ID，雪花算法生成
+	ConversationID int64  `json:"conversationId,string"` // 所属会话ID
+	SenderID       int64  `json:"senderId"`              // 发送者ID
+	ReceiverID     int64  `json:"receiverId"`            // 接收者ID
+	Content        string `json:"content"`               // 消息内容，撤回后为空
+	Recalled       bool   `json:"recalled"`              // 是否已撤回
+	Read           bool   `json:"read"`                  // 对方是否已读
+	CreatedAt      int64  `json:"createdAt"`             // 发送时间
+}
@@ -0,0 +1,65 @@
+package domain
+
+import "errors"
+
+// RegistrationMode 注册模式
+type RegistrationMode string
+
+const (
+	RegistrationOpen   RegistrationMode = "open"   // 开放注册
+	RegistrationInvite RegistrationMode = "invite" // 仅限邀请码注册
+	RegistrationClosed RegistrationMode = "closed" // 关闭注册
+)
+
+var (
+	// ErrInviteCodeInvalid 表示邀请码不存在或已停用
+	ErrInviteCodeInvalid = errors.New("邀请码无效")
+	// ErrInviteCodeExpired 表示邀请码已过期
+	ErrInviteCodeExpired = errors.New("邀请码已过期")
+	// ErrInviteCodeExhausted 表示邀请码使用次数已用完
+	ErrInviteCodeExhausted = errors.New("邀请码使用次数已用完")
+)
+
+// ParseRegistrationMode 解析注册模式，无法识别时视为开放注册
+func ParseRegistrationMode(s string) RegistrationMode {
+	switch mode := RegistrationMode(s); mode {
+	case RegistrationInvite, RegistrationClosed:
+		return mode
+	}
+	return RegistrationOpen
+}
+
+// InviteCode 邀请码
+type InviteCode struct {
+	ID        int64  `json:"id"`
+	Code      string `json:"code"`
+	CreatorID int64  `json:"creatorId"`
+	MaxUses   int    `json:"maxUses"` // 0 表示不限次数，仅管理员可创建
+	UsedCount int    `json:"usedCount"`
+	ExpiresAt int64  `json:"expiresAt"` // 0 表示永不过期
+	Disabled  bool   `json:"disabled"`
+	CreatedAt int64  `json:"createdAt"`
+}
+
+// Usable 判断邀请码在 now 时刻是否可用
+func (c InviteCode) Usable(now int64) error {
+	switch {
+	case c.Disabled:
+		return ErrInviteCodeInvalid
+	case c.ExpiresAt > 0 && now >= c.ExpiresAt:
+		return ErrInviteCodeExpired
+	case c.MaxUses > 0 && c.UsedCount >= c.MaxUses:
+		return ErrInviteCodeExhausted
+	}
+	return nil
+}
+
+// Invitation 邀请关系，用于追溯滥用账号的来源
+type Invitation struct {
+	ID        int64  `json:"id"`
+	InviterID int64  `json:"inviterId"`
+	InviteeID int64  `json:"inviteeId"`
+	CodeID    int64  `json:"codeId"`
+	Code      string `json:"code"`
+	CreatedAt int64  `json:"createdAt"`
+}
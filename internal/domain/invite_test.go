@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestInviteCodeUsable(t *testing.T) {
+	const now = 1_000_000
+	cases := []struct {
+		name string
+		code InviteCode
+		want error
+	}{
+		{"unlimited", InviteCode{}, nil},
+		{"remaining uses", InviteCode{MaxUses: 3, UsedCount: 2, ExpiresAt: now + 1}, nil},
+		{"disabled", InviteCode{Disabled: true}, ErrInviteCodeInvalid},
+		{"expired", InviteCode{ExpiresAt: now}, ErrInviteCodeExpired},
+		{"exhausted", InviteCode{MaxUses: 1, UsedCount: 1}, ErrInviteCodeExhausted},
+	}
+	for _, c := range cases {
+		if err := c.code.Usable(now); !errors.Is(err, c.want) {
+			t.Errorf("%s: Usable() = %v, want %v", c.name, err, c.want)
+		}
+	}
+}
+
+func TestParseRegistrationMode(t *testing.T) {
+	cases := map[string]RegistrationMode{
+		"open":    RegistrationOpen,
+		"invite":  RegistrationInvite,
+		"closed":  RegistrationClosed,
+		"":        RegistrationOpen,
+		"unknown": RegistrationOpen,
+	}
+	for in, want := range cases {
+		if got := ParseRegistrationMode(in); got != want {
+			t.Errorf("ParseRegistrationMode(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package domain
+
+import "time"
+
+// LoginSubjectKind 登录失败计数的维度
+type LoginSubjectKind string
+
+const (
+	LoginSubjectUsername LoginSubjectKind = "username" // 用户名密码登录
+	LoginSubjectEmail    LoginSubjectKind = "email"    // 邮箱登录
+	LoginSubjectPhone    LoginSubjectKind = "phone"    // 短信登录
+	LoginSubjectIP       LoginSubjectKind = "ip"       // 来源IP，所有登录方式共用
+)
+
+// Valid 校验计数维度
+func (k LoginSubjectKind) Valid() bool {
+	switch k {
+	case LoginSubjectUsername, LoginSubjectEmail, LoginSubjectPhone, LoginSubjectIP:
+		return true
+	}
+	return false
+}
+
+// LoginSubject 单独计数与锁定的登录对象
+type LoginSubject struct {
+	Kind  LoginSubjectKind `json:"kind"`
+	Value string           `json:"value"`
+}
+
+// LoginAttempt 一次登录尝试
+type LoginAttempt struct {
+	Account   LoginSubject // 用户名、邮箱或手机号
+	IP        string
+	UserAgent string
+}
+
+// Subjects 需要检查与计数的登录对象，账号在前
+func (a LoginAttempt) Subjects() []LoginSubject {
+	subjects := make([]LoginSubject, 0, 2)
+	if a.Account.Value != "" {
+		subjects = append(subjects, a.Account)
+	}
+	if a.IP != "" {
+		subjects = append(subjects, LoginSubject{Kind: LoginSubjectIP, Value: a.IP})
+	}
+	return subjects
+}
+
+// LoginGuardState 登录对象当前的失败状态
+type LoginGuardState struct {
+	Failures      int64 `json:"failures"`
+	NextAttemptAt int64 `json:"nextAttemptAt"` // 在此之前的尝试直接拒绝
+	LockedUntil   int64 `json:"lockedUntil"`   // 0 表示未锁定
+}
+
+// LoginGuardPolicy 渐进延迟与临时锁定策略
+type LoginGuardPolicy struct {
+	FreeAttempts int64         // 不需要等待的失败次数
+	BaseDelay    time.Duration // 超出后的首次等待时间，此后每次失败翻倍
+	MaxDelay     time.Duration // 等待时间上限
+	MaxAttempts  int64         // 达到后锁定，0 表示不锁定
+	LockDuration time.Duration
+}
+
+// Delay 累计失败 failures 次后距下次允许尝试的等待时间
+func (p LoginGuardPolicy) Delay(failures int64) time.Duration {
+	if failures <= p.FreeAttempts || p.BaseDelay <= 0 {
+		return 0
+	}
+	d := p.BaseDelay
+	for i := p.FreeAttempts + 1; i < failures; i++ {
+		if p.MaxDelay > 0 && d >= p.MaxDelay {
+			break
+		}
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return d
+}
+
+// ShouldLock 判断累计失败次数是否达到锁定阈值
+func (p LoginGuardPolicy) ShouldLock(failures int64) bool {
+	return p.MaxAttempts > 0 && failures >= p.MaxAttempts
+}
+
+// SecurityEventType 安全事件类型
+type SecurityEventType string
+
+const (
+	SecurityEventLoginLocked SecurityEventType = "login_locked" // 连续登录失败被临时锁定
+	SecurityEventNewDevice   SecurityEventType = "new_device"   // 新设备登录
+	SecurityEventUnlocked    SecurityEventType = "unlocked"     // 管理员解除锁定
+	SecurityEventTokenReused SecurityEventType = "token_reused" // 已轮换的刷新令牌被再次使用
+)
+
+// SecurityEvent 安全事件，UserID 为0表示无法关联到账号(如按IP锁定)
+type SecurityEvent struct {
+	ID        int64             `json:"id"`
+	UserID    int64             `json:"userId"`
+	Type      SecurityEventType `json:"type"`
+	Subject   LoginSubject      `json:"subject"`
+	IP        string            `json:"ip"`
+	UserAgent string            `json:"userAgent"`
+	Detail    string            `json:"detail"`
+	CreatedAt int64             `json:"createdAt"`
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoginGuardPolicyDelay(t *testing.T) {
+	p := LoginGuardPolicy{
+		FreeAttempts: 3,
+		BaseDelay:    time.Second,
+		MaxDelay:     10 * time.Second,
+		MaxAttempts:  10,
+	}
+	cases := []struct {
+		failures int64
+		want     time.Duration
+	}{
+		{0, 0},
+		{3, 0},
+		{4, time.Second},
+		{5, 2 * time.Second},
+		{6, 4 * time.Second},
+		{7, 8 * time.Second},
+		{8, 10 * time.Second},
+		{100, 10 * time.Second},
+	}
+	for _, c := range cases {
+		if got := p.Delay(c.failures); got != c.want {
+			t.Errorf("Delay(%d) = %v, want %v", c.failures, got, c.want)
+		}
+	}
+
+	if p.ShouldLock(9) || !p.ShouldLock(10) {
+		t.Errorf("ShouldLock should trigger at MaxAttempts")
+	}
+	if (LoginGuardPolicy{}).ShouldLock(1000) {
+		t.Errorf("ShouldLock should never trigger when MaxAttempts is 0")
+	}
+}
+
+func TestLoginAttemptSubjects(t *testing.T) {
+	a := LoginAttempt{
+		Account: LoginSubject{Kind: LoginSubjectUsername, Value: "alice"},
+		IP:      "10.0.0.1",
+	}
+	got := a.Subjects()
+	if len(got) != 2 || got[0] != a.Account || got[1] != (LoginSubject{Kind: LoginSubjectIP, Value: "10.0.0.1"}) {
+		t.Fatalf("Subjects() = %v", got)
+	}
+	if got := (LoginAttempt{IP: "10.0.0.1"}).Subjects(); len(got) != 1 || got[0].Kind != LoginSubjectIP {
+		t.Fatalf("Subjects() without account = %v", got)
+	}
+}
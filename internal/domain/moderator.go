@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// PlateModeratorRole 板块版主角色，在 casbin 的 g2 中按板块域授予
+	PlateModeratorRole = "plate_moderator"
+	// ActModerate 审核内容的操作
+	ActModerate = "moderate"
+	// plateDomainPrefix 板块域前缀
+	plateDomainPrefix = "plate:"
+)
+
+// PlateModerator 板块版主
+type PlateModerator struct {
+	PlateID int64 `json:"plateId"`
+	Uid     int64 `json:"uid"`
+}
+
+// PlateDomain 返回板块对应的 casbin 域
+func PlateDomain(plateId int64) string {
+	return fmt.Sprintf("%s%d", plateDomainPrefix, plateId)
+}
+
+// ParsePlateDomain 从 casbin 域中解析板块ID
+func ParsePlateDomain(dom string) (int64, bool) {
+	if !strings.HasPrefix(dom, plateDomainPrefix) {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(strings.TrimPrefix(dom, plateDomainPrefix), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return id, true
+}
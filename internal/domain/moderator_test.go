@@ -0,0 +1,19 @@
+package domain
+
+import "testing"
+
+func TestPlateDomain(t *testing.T) {
+	dom := PlateDomain(12)
+	if dom != "plate:12" {
+		t.Fatalf("unexpected domain %q", dom)
+	}
+	id, ok := ParsePlateDomain(dom)
+	if !ok || id != 12 {
+		t.Fatalf("parse %q got %d %v", dom, id, ok)
+	}
+	for _, dom := range []string{"", "plate:", "plate:0", "plate:-1", "plate:abc", "post:12", "plate:*"} {
+		if _, ok := ParsePlateDomain(dom); ok {
+			t.Fatalf("expected %q to be invalid", dom)
+		}
+	}
+}
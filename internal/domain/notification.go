@@ -0,0 +1,213 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// NotificationType 通知类型
+type NotificationType string
+
+// NotificationChannel 通知渠道
+type NotificationChannel string
+
+const (
+	NotificationFollow     NotificationType = "follow"     // 关注
+	NotificationLike       NotificationType = "like"       // 点赞
+	NotificationComment    NotificationType = "comment"    // 评论
+	NotificationMention    NotificationType = "mention"    // 提及
+	NotificationModeration NotificationType = "moderation" // 审核
+	NotificationSecurity   NotificationType = "security"   // 安全类通知(验证码、登录提醒等)，不受偏好设置影响
+)
+
+const (
+	ChannelInApp NotificationChannel = "in_app" // 站内信
+	ChannelEmail NotificationChannel = "email"  // 邮件
+	ChannelSMS   NotificationChannel = "sms"    // 短信
+	ChannelPush  NotificationChannel = "push"   // 推送，暂无投递实现，仅保存用户偏好供接入推送服务后使用
+)
+
+var (
+	ErrInvalidNotificationType    = errors.New("invalid notification type")
+	ErrInvalidNotificationChannel = errors.New("invalid notification channel")
+	ErrInvalidQuietHours          = errors.New("invalid quiet hours")
+)
+
+// NotificationTypes 用户可配置的通知类型
+var NotificationTypes = []NotificationType{
+	NotificationFollow,
+	NotificationLike,
+	NotificationComment,
+	NotificationMention,
+	NotificationModeration,
+}
+
+// NotificationChannels 支持的通知渠道
+var NotificationChannels = []NotificationChannel{
+	ChannelInApp,
+	ChannelEmail,
+	ChannelSMS,
+	ChannelPush,
+}
+
+// NotificationPreference 单个通知类型在单个渠道上的开关
+type NotificationPreference struct {
+	Type    NotificationType    `json:"type"`
+	Channel NotificationChannel `json:"channel"`
+	Enabled bool                `json:"enabled"`
+}
+
+// QuietHours 免打扰时段，格式为 HH:MM，允许跨天(如 22:00-08:00)
+type QuietHours struct {
+	Enabled  bool   `json:"enabled"`
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Timezone string `json:"timezone"`
+}
+
+// NotificationSettings 用户的通知设置
+type NotificationSettings struct {
+	UserID      int64                    `json:"userId"`
+	Preferences []NotificationPreference `json:"preferences"`
+	QuietHours  QuietHours               `json:"quietHours"`
+}
+
+// Notification 一条待发送或已发送的通知
+type Notification struct {
+	ID        int64            `json:"id"`
+	UserID    int64            `json:"userId"`
+	Type      NotificationType `json:"type"`
+	Title     string           `json:"title"`
+	Content   string           `json:"content"`
+	CreatedAt int64            `json:"createdAt"`
+}
+
+// Valid 校验通知类型
+func (t NotificationType) Valid() bool {
+	for _, item := range NotificationTypes {
+		if item == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Valid 校验通知渠道
+func (c NotificationChannel) Valid() bool {
+	for _, item := range NotificationChannels {
+		if item == c {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultNotificationEnabled 未设置偏好时的默认值：站内信与推送默认开启，邮件仅开启审核，短信默认关闭
+func DefaultNotificationEnabled(t NotificationType, c NotificationChannel) bool {
+	switch c {
+	case ChannelInApp, ChannelPush:
+		return true
+	case ChannelEmail:
+		return t == NotificationModeration
+	default:
+		return false
+	}
+}
+
+// Validate 校验免打扰时段
+func (q QuietHours) Validate() error {
+	if !q.Enabled {
+		return nil
+	}
+	if _, err := parseClock(q.Start); err != nil {
+		return err
+	}
+	if _, err := parseClock(q.End); err != nil {
+		return err
+	}
+	if q.Timezone != "" {
+		if _, err := time.LoadLocation(q.Timezone); err != nil {
+			return ErrInvalidQuietHours
+		}
+	}
+	return nil
+}
+
+// Contains 判断给定时间是否处于免打扰时段
+func (q QuietHours) Contains(t time.Time) bool {
+	if !q.Enabled {
+		return false
+	}
+	start, err := parseClock(q.Start)
+	if err != nil {
+		return false
+	}
+	end, err := parseClock(q.End)
+	if err != nil || start == end {
+		return false
+	}
+	if q.Timezone != "" {
+		if loc, err := time.LoadLocation(q.Timezone); err == nil {
+			t = t.In(loc)
+		}
+	}
+	now := t.Hour()*60 + t.Minute()
+	if start < end {
+		return now >= start && now < end
+	}
+	// 跨天时段
+	return now >= start || now < end
+}
+
+// Allow 判断通知是否可以通过指定渠道送达
+func (s NotificationSettings) Allow(t NotificationType, c NotificationChannel, now time.Time) bool {
+	if t == NotificationSecurity {
+		return true
+	}
+	// 免打扰时段内只保留站内信
+	if c != ChannelInApp && s.QuietHours.Contains(now) {
+		return false
+	}
+	for _, p := range s.Preferences {
+		if p.Type == t && p.Channel == c {
+			return p.Enabled
+		}
+	}
+	return DefaultNotificationEnabled(t, c)
+}
+
+// parseClock 将 HH:MM 转换为当天的分钟数
+func parseClock(v string) (int, error) {
+	var h, m int
+	if _, err := fmt.Sscanf(v, "%d:%d", &h, &m); err != nil {
+		return 0, ErrInvalidQuietHours
+	}
+	if h < 0 || h > 23 || m < 0 || m > 59 {
+		return 0, ErrInvalidQuietHours
+	}
+	return h*60 + m, nil
+}
+
+// Expand 按类型和渠道补全未设置的偏好，便于前端展示完整矩阵
+func (s NotificationSettings) Expand() NotificationSettings {
+	set := make(map[NotificationType]map[NotificationChannel]bool, len(NotificationTypes))
+	for _, p := range s.Preferences {
+		if set[p.Type] == nil {
+			set[p.Type] = make(map[NotificationChannel]bool, len(NotificationChannels))
+		}
+		set[p.Type][p.Channel] = p.Enabled
+	}
+	prefs := make([]NotificationPreference, 0, len(NotificationTypes)*len(NotificationChannels))
+	for _, t := range NotificationTypes {
+		for _, c := range NotificationChannels {
+			enabled, ok := set[t][c]
+			if !ok {
+				enabled = DefaultNotificationEnabled(t, c)
+			}
+			prefs = append(prefs, NotificationPreference{Type: t, Channel: c, Enabled: enabled})
+		}
+	}
+	s.Preferences = prefs
+	return s
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuietHoursContains(t *testing.T) {
+	at := func(h, m int) time.Time {
+		return time.Date(2024, 1, 1, h, m, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name string
+		q    QuietHours
+		t    time.Time
+		want bool
+	}{
+		{"disabled", QuietHours{Enabled: false, Start: "00:00", End: "23:59"}, at(12, 0), false},
+		{"same day inside", QuietHours{Enabled: true, Start: "13:00", End: "14:00"}, at(13, 30), true},
+		{"same day end exclusive", QuietHours{Enabled: true, Start: "13:00", End: "14:00"}, at(14, 0), false},
+		{"overnight before midnight", QuietHours{Enabled: true, Start: "22:00", End: "08:00"}, at(23, 0), true},
+		{"overnight after midnight", QuietHours{Enabled: true, Start: "22:00", End: "08:00"}, at(7, 59), true},
+		{"overnight outside", QuietHours{Enabled: true, Start: "22:00", End: "08:00"}, at(12, 0), false},
+		{"timezone", QuietHours{Enabled: true, Start: "22:00", End: "08:00", Timezone: "Asia/Shanghai"}, at(15, 0), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.q.Contains(tt.t); got != tt.want {
+				t.Errorf("Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotificationSettingsAllow(t *testing.T) {
+	night := time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)
+	s := NotificationSettings{
+		Preferences: []NotificationPreference{
+			{Type: NotificationLike, Channel: ChannelInApp, Enabled: false},
+			{Type: NotificationComment, Channel: ChannelEmail, Enabled: true},
+		},
+		QuietHours: QuietHours{Enabled: true, Start: "22:00", End: "08:00"},
+	}
+	if s.Allow(NotificationLike, ChannelInApp, night) {
+		t.Error("disabled preference should be respected")
+	}
+	if s.Allow(NotificationComment, ChannelEmail, night) {
+		t.Error("email should be suppressed during quiet hours")
+	}
+	if !s.Allow(NotificationComment, ChannelInApp, night) {
+		t.Error("in-app should be delivered during quiet hours")
+	}
+	if !s.Allow(NotificationSecurity, ChannelSMS, night) {
+		t.Error("security notifications should always be delivered")
+	}
+}
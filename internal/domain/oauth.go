@@ -0,0 +1,25 @@
+package domain
+
+// UserIdentity 用户绑定的第三方账号
+type UserIdentity struct {
+	Provider   string `json:"provider"`   // 登录方式，如 github、oidc
+	Subject    string `json:"-"`          // 第三方账号的唯一标识
+	UserID     int64  `json:"userId"`     // 绑定的用户
+	Username   string `json:"username"`   // 第三方用户名
+	Email      string `json:"email"`      // 第三方邮箱
+	Registered bool   `json:"registered"` // 是否通过第三方登录自动注册，此类用户的最后一个第三方账号不能解绑
+	CreatedAt  int64  `json:"createdAt"`  // 绑定时间
+}
+
+// OAuthState 发起第三方授权时保存的状态，用于回调时防止 CSRF 并区分登录与绑定
+type OAuthState struct {
+	Provider string `json:"provider"`
+	Uid      int64  `json:"uid"` // 大于 0 时为已登录用户绑定第三方账号
+}
+
+// OAuthResult 第三方授权回调的处理结果
+type OAuthResult struct {
+	Uid        int64 `json:"uid"`
+	Registered bool  `json:"registered"` // 首次登录自动注册
+	Linked     bool  `json:"linked"`     // 为已登录用户绑定
+}
@@ -0,0 +1,9 @@
+package domain
+
+// ResetChannel 找回密码时接收验证码的渠道
+type ResetChannel string
+
+const (
+	ResetChannelEmail ResetChannel = "email" // 已验证的邮箱
+	ResetChannelSMS   ResetChannel = "sms"   // 绑定的手机号
+)
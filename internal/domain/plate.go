@@ -1,12 +1,14 @@
 package domain
 
 type Plate struct {
-	ID          int64  `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Uid         int64  `json:"uid"`
-	CreatedAt   int64  `json:"created_at"`
-	UpdatedAt   int64  `json:"updated_at"`
-	DeletedAt   int64  `json:"deleted_at"`
-	Deleted     bool   `json:"deleted"`
+	ID              int64  `json:"id"`
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	Uid             int64  `json:"uid"`
+	CreatedAt       int64  `json:"created_at"`
+	UpdatedAt       int64  `json:"updated_at"`
+	DeletedAt       int64  `json:"deleted_at"`
+	Deleted         bool   `json:"deleted"`
+	SubscriberCount int64  `json:"subscriber_count"` // 订阅人数
+	Subscribed      bool   `json:"subscribed"`       // 当前用户是否已订阅
 }
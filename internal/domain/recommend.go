@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// 用户行为对兴趣的权重
+const (
+	InterestWeightRead    = 1.0
+	InterestWeightLike    = 3.0
+	InterestWeightCollect = 5.0
+)
+
+// RecommendWeights 推荐得分中兴趣、热度、新鲜度三部分的权重
+type RecommendWeights struct {
+	Interest  float64
+	Hot       float64
+	Freshness float64
+	// FreshnessHalfLife 新鲜度半衰期
+	FreshnessHalfLife time.Duration
+}
+
+// InterestProfile 根据阅读、点赞、收藏行为统计的用户兴趣画像
+type InterestProfile struct {
+	Plates  map[int64]float64
+	Authors map[int64]float64
+	Tags    map[string]float64
+	// Seen 已经看过或互动过的帖子，不再推荐
+	Seen  map[uint]struct{}
+	total float64
+}
+
+func NewInterestProfile() *InterestProfile {
+	return &InterestProfile{
+		Plates:  make(map[int64]float64),
+		Authors: make(map[int64]float64),
+		Tags:    make(map[string]float64),
+		Seen:    make(map[uint]struct{}),
+	}
+}
+
+// Add 记录一次对帖子的行为
+func (p *InterestProfile) Add(post Post, weight float64) {
+	p.Seen[post.ID] = struct{}{}
+	if post.PlateID > 0 {
+		p.Plates[post.PlateID] += weight
+	}
+	if post.Uid > 0 {
+		p.Authors[post.Uid] += weight
+	}
+	for _, tag := range splitTags(post.Tags) {
+		p.Tags[tag] += weight
+	}
+	p.total += weight
+}
+
+// Empty 画像中没有任何行为
+func (p *InterestProfile) Empty() bool {
+	return p.total == 0
+}
+
+// Match 帖子与兴趣画像的匹配度，取值 [0, 1]
+func (p *InterestProfile) Match(post Post) float64 {
+	if p.total == 0 {
+		return 0
+	}
+	score := p.Plates[post.PlateID] + p.Authors[post.Uid]
+	for _, tag := range splitTags(post.Tags) {
+		score += p.Tags[tag]
+	}
+	// 板块、作者、标签三个维度各自最多贡献一份总权重
+	return math.Min(score/(3*p.total), 1)
+}
+
+// Freshness 帖子新鲜度，按半衰期指数衰减，取值 (0, 1]
+func Freshness(publishedAt, now time.Time, halfLife time.Duration) float64 {
+	age := now.Sub(publishedAt)
+	if age <= 0 || halfLife <= 0 {
+		return 1
+	}
+	return math.Pow(0.5, float64(age)/float64(halfLife))
+}
+
+func splitTags(tags string) []string {
+	if tags == "" {
+		return nil
+	}
+	parts := strings.Split(tags, ",")
+	result := make([]string, 0, len(parts))
+	for _, t := range parts {
+		if t = strings.TrimSpace(t); t != "" {
+			result = append(result, t)
+		}
+	}
+	return result
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestInterestProfileMatch(t *testing.T) {
+	p := NewInterestProfile()
+	if !p.Empty() || p.Match(Post{PlateID: 1}) != 0 {
+		t.Fatal("empty profile should not match")
+	}
+
+	p.Add(Post{ID: 1, PlateID: 1, Uid: 10, Tags: "go, redis"}, InterestWeightLike)
+	p.Add(Post{ID: 2, PlateID: 2, Uid: 20}, InterestWeightRead)
+
+	if _, ok := p.Seen[1]; !ok {
+		t.Error("post 1 should be marked as seen")
+	}
+	full := p.Match(Post{PlateID: 1, Uid: 10, Tags: "go,redis"})
+	plateOnly := p.Match(Post{PlateID: 1})
+	other := p.Match(Post{PlateID: 3, Uid: 30})
+	if !(full > plateOnly && plateOnly > other) {
+		t.Errorf("unexpected ordering: full=%v plateOnly=%v other=%v", full, plateOnly, other)
+	}
+	if other != 0 || full > 1 {
+		t.Errorf("match out of range: full=%v other=%v", full, other)
+	}
+}
+
+func TestFreshness(t *testing.T) {
+	now := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	if got := Freshness(now, now, 24*time.Hour); got != 1 {
+		t.Errorf("Freshness(now) = %v, want 1", got)
+	}
+	if got := Freshness(now.Add(-24*time.Hour), now, 24*time.Hour); math.Abs(got-0.5) > 1e-9 {
+		t.Errorf("Freshness(one half-life) = %v, want 0.5", got)
+	}
+}
@@ -1,11 +1,92 @@
 package domain
 
+import "sort"
+
 type Relation struct {
 	FolloweeId int64
 	FollowerId int64
+	IsMutual   bool `json:"is_mutual"` // 双方是否互相关注
 }
 
 type RelationStats struct {
 	FollowerCount int64
 	FolloweeCount int64
 }
+
+const (
+	BlockTypeMute  uint8 = 1 // 屏蔽：隐藏对方的帖子与评论
+	BlockTypeBlock uint8 = 2 // 拉黑：禁止对方关注、评论、提及与私信
+)
+
+// UserBlock 屏蔽或拉黑记录
+type UserBlock struct {
+	UserID    int64 `json:"userId"`    // 操作者
+	TargetID  int64 `json:"targetId"`  // 被屏蔽或拉黑的用户
+	Type      uint8 `json:"type"`      // 1 屏蔽 2 拉黑
+	CreatedAt int64 `json:"createdAt"` // 创建时间
+}
+
+// FollowRequest 私密账号收到的待处理关注请求
+type FollowRequest struct {
+	FollowerID int64 `json:"followerId"` // 请求者
+	CreatedAt  int64 `json:"createdAt"`  // 请求时间
+}
+
+// SocialProof 查看他人主页时展示的“你关注的人中也关注了TA”
+type SocialProof struct {
+	Total   int64   `json:"total"`   // 共同关注总数
+	UserIDs []int64 `json:"userIds"` // 部分共同关注用户
+}
+
+// 推荐关注时二度关系与板块活跃度的权重
+const (
+	SuggestWeightMutual = 2.0
+	SuggestWeightPlate  = 1.0
+)
+
+// UserSuggestion 推荐关注的用户
+type UserSuggestion struct {
+	Uid          int64   `json:"uid"`
+	Score        float64 `json:"score"`
+	MutualCount  int64   `json:"mutualCount"`  // 你关注的人中关注了TA的人数
+	SharedPlates int64   `json:"sharedPlates"` // TA 在你关注的板块中近期发帖数
+}
+
+// RankSuggestions 合并二度关系与板块活跃作者，排除 exclude 中的用户后按得分倒序返回最多 limit 个
+func RankSuggestions(mutual, plates map[int64]int64, exclude map[int64]struct{}, limit int) []UserSuggestion {
+	merged := make(map[int64]*UserSuggestion, len(mutual)+len(plates))
+	get := func(uid int64) *UserSuggestion {
+		s, ok := merged[uid]
+		if !ok {
+			s = &UserSuggestion{Uid: uid}
+			merged[uid] = s
+		}
+		return s
+	}
+	for uid, n := range mutual {
+		if _, ok := exclude[uid]; !ok {
+			get(uid).MutualCount = n
+		}
+	}
+	for uid, n := range plates {
+		if _, ok := exclude[uid]; !ok {
+			get(uid).SharedPlates = n
+		}
+	}
+
+	result := make([]UserSuggestion, 0, len(merged))
+	for _, s := range merged {
+		s.Score = SuggestWeightMutual*float64(s.MutualCount) + SuggestWeightPlate*float64(s.SharedPlates)
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		return result[i].Uid < result[j].Uid
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
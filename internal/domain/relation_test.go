@@ -0,0 +1,26 @@
+package domain
+
+import "testing"
+
+func TestRankSuggestions(t *testing.T) {
+	mutual := map[int64]int64{1: 3, 2: 1, 3: 5}
+	plates := map[int64]int64{2: 4, 4: 1, 3: 1}
+	exclude := map[int64]struct{}{3: {}}
+
+	got := RankSuggestions(mutual, plates, exclude, 2)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 suggestions, got %d", len(got))
+	}
+	// 用户2: 1*2+4=6，用户1: 3*2=6，同分按 uid 升序
+	if got[0].Uid != 1 || got[1].Uid != 2 {
+		t.Errorf("unexpected order: %+v", got)
+	}
+	if got[1].MutualCount != 1 || got[1].SharedPlates != 4 {
+		t.Errorf("unexpected counts: %+v", got[1])
+	}
+	for _, s := range RankSuggestions(mutual, plates, exclude, 0) {
+		if s.Uid == 3 {
+			t.Error("excluded user should not be suggested")
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+)
+
+// ReputationEvent 积分变动来源
+type ReputationEvent string
+
+const (
+	ReputationPostApproved    ReputationEvent = "post_approved"    // 帖子审核通过
+	ReputationLikeReceived    ReputationEvent = "like_received"    // 帖子被点赞
+	ReputationCollectReceived ReputationEvent = "collect_received" // 帖子被收藏
+	ReputationComment         ReputationEvent = "comment"          // 评论审核通过
+	ReputationViolation       ReputationEvent = "violation"        // 内容审核未通过
+)
+
+// Privilege 按等级开放的权限
+type Privilege string
+
+const (
+	PrivilegePostLinks     Privilege = "post_links"     // 发布含外链的帖子
+	PrivilegeCreateLottery Privilege = "create_lottery" // 创建抽奖与秒杀活动
+)
+
+var (
+	// ErrInvalidLevels 表示等级配置不合法
+	ErrInvalidLevels = errors.New("等级配置无效：至少包含一个等级，首个等级门槛为0，等级与门槛需严格递增")
+	linkPattern      = regexp.MustCompile(`(?i)(https?://|www\.)\S+`)
+)
+
+// ReputationLevel 等级及其门槛，由管理员配置
+type ReputationLevel struct {
+	Level            int    `json:"level"`
+	Name             string `json:"name"`
+	MinPoints        int64  `json:"minPoints"` // 达到该积分即升至此等级
+	CanPostLinks     bool   `json:"canPostLinks"`
+	CanCreateLottery bool   `json:"canCreateLottery"`
+}
+
+// Allows 判断该等级是否拥有指定权限
+func (l ReputationLevel) Allows(p Privilege) bool {
+	switch p {
+	case PrivilegePostLinks:
+		return l.CanPostLinks
+	case PrivilegeCreateLottery:
+		return l.CanCreateLottery
+	}
+	return false
+}
+
+// DefaultReputationLevels 管理员未配置时使用的默认等级
+var DefaultReputationLevels = []ReputationLevel{
+	{Level: 1, Name: "新手", MinPoints: 0},
+	{Level: 2, Name: "入门", MinPoints: 50, CanPostLinks: true},
+	{Level: 3, Name: "活跃", MinPoints: 200, CanPostLinks: true},
+	{Level: 4, Name: "资深", MinPoints: 500, CanPostLinks: true, CanCreateLottery: true},
+	{Level: 5, Name: "专家", MinPoints: 2000, CanPostLinks: true, CanCreateLottery: true},
+}
+
+// UserReputation 用户积分与当前等级
+type UserReputation struct {
+	UserID        int64           `json:"userId"`
+	Points        int64           `json:"points"`
+	Level         ReputationLevel `json:"level"`
+	NextMinPoints int64           `json:"nextMinPoints"` // 下一等级门槛，已是最高等级时为0
+}
+
+// ReputationLog 积分流水
+type ReputationLog struct {
+	ID        int64           `json:"id"`
+	UserID    int64           `json:"userId"`
+	Event     ReputationEvent `json:"event"`
+	BizID     int64           `json:"bizId"`   // 关联的帖子或审核ID
+	ActorID   int64           `json:"actorId"` // 触发者，如点赞的用户
+	Points    int64           `json:"points"`
+	CreatedAt int64           `json:"createdAt"`
+}
+
+// ValidateLevels 校验等级配置，levels 需按门槛升序排列
+func ValidateLevels(levels []ReputationLevel) error {
+	if len(levels) == 0 || levels[0].MinPoints != 0 {
+		return ErrInvalidLevels
+	}
+	for i := 1; i < len(levels); i++ {
+		if levels[i].Level <= levels[i-1].Level || levels[i].MinPoints <= levels[i-1].MinPoints {
+			return ErrInvalidLevels
+		}
+	}
+	return nil
+}
+
+// LevelFor 根据积分计算等级，levels 需按门槛升序排列，返回当前等级与下一等级门槛
+func LevelFor(points int64, levels []ReputationLevel) (ReputationLevel, int64) {
+	if len(levels) == 0 {
+		levels = DefaultReputationLevels
+	}
+	idx := 0
+	for i, l := range levels {
+		if points >= l.MinPoints {
+			idx = i
+		}
+	}
+	var next int64
+	if idx+1 < len(levels) {
+		next = levels[idx+1].MinPoints
+	}
+	return levels[idx], next
+}
+
+// ContainsLink 判断内容是否包含外链
+func ContainsLink(content string) bool {
+	return linkPattern.MatchString(content)
+}
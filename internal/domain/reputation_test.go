@@ -0,0 +1,57 @@
+package domain
+
+import "testing"
+
+func TestLevelFor(t *testing.T) {
+	cases := []struct {
+		points    int64
+		wantLevel int
+		wantNext  int64
+	}{
+		{-30, 1, 50},
+		{0, 1, 50},
+		{49, 1, 50},
+		{50, 2, 200},
+		{499, 3, 500},
+		{500, 4, 2000},
+		{99999, 5, 0},
+	}
+	for _, c := range cases {
+		level, next := LevelFor(c.points, DefaultReputationLevels)
+		if level.Level != c.wantLevel || next != c.wantNext {
+			t.Errorf("LevelFor(%d) = level %d next %d, want level %d next %d",
+				c.points, level.Level, next, c.wantLevel, c.wantNext)
+		}
+	}
+}
+
+func TestValidateLevels(t *testing.T) {
+	if err := ValidateLevels(DefaultReputationLevels); err != nil {
+		t.Fatalf("default levels should be valid: %v", err)
+	}
+
+	invalid := [][]ReputationLevel{
+		nil,
+		{{Level: 1, MinPoints: 10}},
+		{{Level: 1, MinPoints: 0}, {Level: 2, MinPoints: 0}},
+		{{Level: 2, MinPoints: 0}, {Level: 1, MinPoints: 10}},
+	}
+	for i, levels := range invalid {
+		if err := ValidateLevels(levels); err == nil {
+			t.Errorf("case %d: expected error", i)
+		}
+	}
+}
+
+func TestContainsLink(t *testing.T) {
+	for _, s := range []string{"see https://example.com", "HTTP://a.b", "visit www.example.com now"} {
+		if !ContainsLink(s) {
+			t.Errorf("ContainsLink(%q) = false", s)
+		}
+	}
+	for _, s := range []string{"plain text", "email me at a@b.com", "http:// "} {
+		if ContainsLink(s) {
+			t.Errorf("ContainsLink(%q) = true", s)
+		}
+	}
+}
@@ -27,6 +27,8 @@ type Api struct {
 	Version     string `json:"version"`     // API版本
 	Category    int    `json:"category"`    // API分类(1:系统,2:业务)
 	IsPublic    int    `json:"is_public"`   // 是否公开(0:否,1:是)
+	RouteKey    string `json:"route_key"`   // 自动同步的路由标识，手动创建的API为空
+	IsStale     int    `json:"is_stale"`    // 路由是否已不存在(0:否,1:是)
 	CreateTime  int64  `json:"create_time"` // 创建时间
 	UpdateTime  int64  `json:"update_time"` // 更新时间
 	IsDeleted   int    `json:"is_deleted"`  // 是否删除(0:否,1:是)
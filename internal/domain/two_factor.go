@@ -0,0 +1,28 @@
+package domain
+
+// TwoFactor 用户的两步验证配置
+type TwoFactor struct {
+	UserID  int64
+	Secret  string
+	Enabled bool // 绑定后需验证一次验证码才会启用
+}
+
+// TwoFactorStatus 两步验证状态
+type TwoFactorStatus struct {
+	Enabled           bool  `json:"enabled"`
+	Required          bool  `json:"required"`          // 管理员账号强制开启
+	RecoveryCodesLeft int64 `json:"recoveryCodesLeft"` // 剩余可用的恢复码数量
+}
+
+// TOTPSetup 绑定认证器应用所需的信息
+type TOTPSetup struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"` // otpauth 地址，用于生成二维码
+}
+
+// TwoFactorChallenge 密码校验通过后待完成的两步验证
+type TwoFactorChallenge struct {
+	Token         string     `json:"challengeToken"`
+	SetupRequired bool       `json:"setupRequired"`   // 账号被强制要求开启但尚未绑定
+	Setup         *TOTPSetup `json:"setup,omitempty"` // 需要绑定时返回的绑定信息
+}
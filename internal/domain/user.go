@@ -31,14 +31,20 @@ type User struct {
 }
 
 type Profile struct {
-	ID       int64   `json:"id"`       // 资料ID，主键
-	UserID   int64   `json:"userId"`   // 用户ID，外键，关联到用户
-	RealName string  `json:"realName"` // 真实姓名
-	Avatar   string  `json:"avatar"`   // 头像URL
-	Email    string  `json:"email"`    // 邮箱
-	About    string  `json:"about"`    // 个人简介
-	Birthday string  `json:"birthday"` // 生日
-	Phone    *string `json:"phone"`    // 手机号码，指针类型，允许为空
+	ID            int64       `json:"id"`            // 资料ID，主键
+	UserID        int64       `json:"userId"`        // 用户ID，外键，关联到用户
+	RealName      string      `json:"realName"`      // 真实姓名
+	Avatar        string      `json:"avatar"`        // 头像URL
+	Email         string      `json:"email"`         // 邮箱
+	EmailVerified bool        `json:"emailVerified"` // 邮箱是否已验证，仅已验证的邮箱可用于登录
+	About         string      `json:"about"`         // 个人简介
+	Birthday      string      `json:"birthday"`      // 生日
+	Phone         *string     `json:"phone"`         // 手机号码，指针类型，允许为空
+	IsPrivate     bool        `json:"isPrivate"`     // 私密账号，关注需经本人同意，内容仅对关注者可见
+	Points        int64       `json:"points"`        // 积分，由积分流水汇总
+	Level         int         `json:"level"`         // 根据积分计算的等级
+	LevelName     string      `json:"levelName"`     // 等级名称
+	Badges        []UserBadge `json:"badges"`        // 已获得的勋章
 }
 
 type UserWithProfile struct {
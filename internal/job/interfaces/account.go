@@ -0,0 +1,8 @@
+package interfaces
+
+import "context"
+
+type AccountService interface {
+	PurgeDueAccounts(ctx context.Context) error
+	ProcessDataExports(ctx context.Context) error
+}
@@ -0,0 +1,7 @@
+package interfaces
+
+import "context"
+
+type AuditService interface {
+	PurgeExpired(ctx context.Context) error
+}
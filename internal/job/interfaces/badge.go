@@ -0,0 +1,7 @@
+package interfaces
+
+import "context"
+
+type BadgeService interface {
+	Backfill(ctx context.Context) error
+}
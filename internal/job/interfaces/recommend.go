@@ -0,0 +1,7 @@
+package interfaces
+
+import "context"
+
+type RecommendService interface {
+	ComputeRecommendations(ctx context.Context) error
+}
@@ -8,7 +8,12 @@ import (
 )
 
 const (
-	GetRankingTask = "get_ranking"
+	GetRankingTask     = "get_ranking"
+	GetRecommendTask   = "get_recommend"
+	PurgeAccountsTask  = "purge_accounts"
+	ProcessExportsTask = "process_data_exports"
+	BackfillBadgesTask = "backfill_badges"
+	PurgeAuditLogsTask = "purge_audit_logs"
 )
 
 type TimedScheduler struct {
@@ -30,6 +35,46 @@ func (s *TimedScheduler) RegisterTimedTasks() error {
 		return err
 	}
 
+	// 个性化推荐离线计算任务 - 每30分钟
+	if err := s.registerTask(
+		GetRecommendTask,
+		"@every 30m",
+	); err != nil {
+		return err
+	}
+
+	// 清理注销冷静期已结束的账号 - 每小时
+	if err := s.registerTask(
+		PurgeAccountsTask,
+		"@every 1h",
+	); err != nil {
+		return err
+	}
+
+	// 生成个人数据导出文件 - 每分钟
+	if err := s.registerTask(
+		ProcessExportsTask,
+		"@every 1m",
+	); err != nil {
+		return err
+	}
+
+	// 根据历史数据补发勋章 - 每天
+	if err := s.registerTask(
+		BackfillBadgesTask,
+		"@every 24h",
+	); err != nil {
+		return err
+	}
+
+	// 清理超过保留期限的审计记录 - 每天
+	if err := s.registerTask(
+		PurgeAuditLogsTask,
+		"@every 24h",
+	); err != nil {
+		return err
+	}
+
 	return nil
 }
 
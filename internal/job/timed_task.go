@@ -12,8 +12,12 @@ import (
 )
 
 type TimedTask struct {
-	l   *zap.Logger
-	svc interfaces.RankingService
+	l            *zap.Logger
+	svc          interfaces.RankingService
+	recommendSvc interfaces.RecommendService
+	accountSvc   interfaces.AccountService
+	badgeSvc     interfaces.BadgeService
+	auditSvc     interfaces.AuditService
 }
 
 type TimedPayload struct {
@@ -21,13 +25,26 @@ type TimedPayload struct {
 	LastRunTime time.Time `json:"last_run_time"`
 }
 
-func NewTimedTask(l *zap.Logger, svc interfaces.RankingService) *TimedTask {
+func NewTimedTask(l *zap.Logger, svc interfaces.RankingService, recommendSvc interfaces.RecommendService, accountSvc interfaces.AccountService, badgeSvc interfaces.BadgeService, auditSvc interfaces.AuditService) *TimedTask {
 	return &TimedTask{
-		l:   l,
-		svc: svc,
+		l:            l,
+		svc:          svc,
+		recommendSvc: recommendSvc,
+		accountSvc:   accountSvc,
+		badgeSvc:     badgeSvc,
+		auditSvc:     auditSvc,
 	}
 }
 
+// 任务执行超时时间，未配置的任务默认 10 秒
+var taskTimeouts = map[string]time.Duration{
+	GetRecommendTask:   10 * time.Minute,
+	PurgeAccountsTask:  10 * time.Minute,
+	ProcessExportsTask: 10 * time.Minute,
+	BackfillBadgesTask: 30 * time.Minute,
+	PurgeAuditLogsTask: 10 * time.Minute,
+}
+
 func (t *TimedTask) ProcessTask(ctx context.Context, task *asynq.Task) error {
 	var payload TimedPayload
 
@@ -39,12 +56,21 @@ func (t *TimedTask) ProcessTask(ctx context.Context, task *asynq.Task) error {
 		zap.String("task_name", payload.TaskName),
 		zap.Time("last_run_time", payload.LastRunTime))
 
-	taskCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	timeout, ok := taskTimeouts[payload.TaskName]
+	if !ok {
+		timeout = 10 * time.Second
+	}
+	taskCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	// 定义任务处理映射
 	taskHandlers := map[string]func(context.Context) error{
-		GetRankingTask: t.svc.TopN,
+		GetRankingTask:     t.svc.TopN,
+		GetRecommendTask:   t.recommendSvc.ComputeRecommendations,
+		PurgeAccountsTask:  t.accountSvc.PurgeDueAccounts,
+		ProcessExportsTask: t.accountSvc.ProcessDataExports,
+		BackfillBadgesTask: t.badgeSvc.Backfill,
+		PurgeAuditLogsTask: t.auditSvc.PurgeExpired,
 	}
 
 	// 获取对应的处理函数
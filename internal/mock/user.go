@@ -18,11 +18,11 @@ type MockUserRepository interface {
 
 type mockUserRepository struct {
 	db *gorm.DB
-	ce *casbin.Enforcer
+	ce *casbin.SyncedEnforcer
 	l  *zap.Logger
 }
 
-func NewMockUserRepository(db *gorm.DB, l *zap.Logger, ce *casbin.Enforcer) MockUserRepository {
+func NewMockUserRepository(db *gorm.DB, l *zap.Logger, ce *casbin.SyncedEnforcer) MockUserRepository {
 	return &mockUserRepository{
 		db: db,
 		ce: ce,
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/repository/dao"
+	"go.uber.org/zap"
+)
+
+// ErrAccessTokenNotFound 表示令牌不存在
+var ErrAccessTokenNotFound = dao.ErrAccessTokenNotFound
+
+type AccessTokenRepository interface {
+	// Create 保存令牌，hash 为令牌明文的哈希
+	Create(ctx context.Context, token domain.AccessToken, hash string) (domain.AccessToken, error)
+	CountActive(ctx context.Context, uid int64) (int64, error)
+	FindByHash(ctx context.Context, hash string) (domain.AccessToken, error)
+	ListByUser(ctx context.Context, uid int64) ([]domain.AccessToken, error)
+	Revoke(ctx context.Context, uid, id int64) error
+	RevokeAll(ctx context.Context, uid int64) error
+	TouchLastUsed(ctx context.Context, id int64, interval time.Duration) error
+}
+
+type accessTokenRepository struct {
+	dao dao.AccessTokenDAO
+	l   *zap.Logger
+}
+
+func NewAccessTokenRepository(dao dao.AccessTokenDAO, l *zap.Logger) AccessTokenRepository {
+	return &accessTokenRepository{
+		dao: dao,
+		l:   l,
+	}
+}
+
+func (a *accessTokenRepository) Create(ctx context.Context, token domain.AccessToken, hash string) (domain.AccessToken, error) {
+	created, err := a.dao.Create(ctx, dao.AccessToken{
+		UserID:    token.UserID,
+		Name:      token.Name,
+		TokenHash: hash,
+		Prefix:    token.Prefix,
+		Scopes:    strings.Join(token.Scopes, ","),
+		ExpiresAt: token.ExpiresAt,
+	})
+	if err != nil {
+		return domain.AccessToken{}, err
+	}
+	return toDomainAccessToken(created), nil
+}
+
+func (a *accessTokenRepository) CountActive(ctx context.Context, uid int64) (int64, error) {
+	return a.dao.CountActive(ctx, uid)
+}
+
+func (a *accessTokenRepository) FindByHash(ctx context.Context, hash string) (domain.AccessToken, error) {
+	token, err := a.dao.FindByHash(ctx, hash)
+	if err != nil {
+		return domain.AccessToken{}, err
+	}
+	return toDomainAccessToken(token), nil
+}
+
+func (a *accessTokenRepository) ListByUser(ctx context.Context, uid int64) ([]domain.AccessToken, error) {
+	tokens, err := a.dao.ListByUser(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.AccessToken, 0, len(tokens))
+	for _, t := range tokens {
+		res = append(res, toDomainAccessToken(t))
+	}
+	return res, nil
+}
+
+func (a *accessTokenRepository) Revoke(ctx context.Context, uid, id int64) error {
+	return a.dao.Revoke(ctx, uid, id)
+}
+
+func (a *accessTokenRepository) RevokeAll(ctx context.Context, uid int64) error {
+	return a.dao.RevokeAll(ctx, uid)
+}
+
+func (a *accessTokenRepository) TouchLastUsed(ctx context.Context, id int64, interval time.Duration) error {
+	return a.dao.TouchLastUsed(ctx, id, interval)
+}
+
+func toDomainAccessToken(t dao.AccessToken) domain.AccessToken {
+	var scopes []string
+	if t.Scopes != "" {
+		scopes = strings.Split(t.Scopes, ",")
+	}
+	return domain.AccessToken{
+		ID:         t.ID,
+		UserID:     t.UserID,
+		Name:       t.Name,
+		Prefix:     t.Prefix,
+		Scopes:     scopes,
+		ExpiresAt:  t.ExpiresAt,
+		LastUsedAt: t.LastUsedAt,
+		Revoked:    t.RevokedAt > 0,
+		CreatedAt:  t.CreatedAt,
+	}
+}
@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/repository/cache"
+	"github.com/GoSimplicity/LinkMe/internal/repository/dao"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrDeletionNotFound 表示没有处于冷静期的注销申请
+	ErrDeletionNotFound = dao.ErrDeletionNotFound
+	// ErrExportNotFound 表示没有对应的数据导出任务
+	ErrExportNotFound = dao.ErrExportNotFound
+)
+
+type AccountRepository interface {
+	RequestDeletion(ctx context.Context, uid int64, scheduledAt int64) error
+	FindDeletion(ctx context.Context, uid int64) (domain.AccountDeletion, error)
+	CancelDeletion(ctx context.Context, uid int64) error
+	ListDueDeletions(ctx context.Context, now int64, limit int) ([]domain.AccountDeletion, error)
+	// PurgeUser 清理用户数据，返回被删除的帖子与评论ID
+	PurgeUser(ctx context.Context, uid int64, removeContent bool) ([]uint, []int64, error)
+	CollectUserData(ctx context.Context, uid int64) (domain.UserDataArchive, error)
+
+	CreateExport(ctx context.Context, uid int64) (domain.DataExport, error)
+	FindLatestExport(ctx context.Context, uid int64) (domain.DataExport, error)
+	ListExports(ctx context.Context, status domain.ExportStatus, limit int) ([]domain.DataExport, error)
+	ClaimExport(ctx context.Context, id int64) (bool, error)
+	UpdateExport(ctx context.Context, export domain.DataExport) error
+	ListExpiredExports(ctx context.Context, now int64, limit int) ([]domain.DataExport, error)
+}
+
+type accountRepository struct {
+	dao       dao.AccountDAO
+	userCache cache.UserCache
+	l         *zap.Logger
+}
+
+func NewAccountRepository(dao dao.AccountDAO, userCache cache.UserCache, l *zap.Logger) AccountRepository {
+	return &accountRepository{
+		dao:       dao,
+		userCache: userCache,
+		l:         l,
+	}
+}
+
+func (a *accountRepository) RequestDeletion(ctx context.Context, uid int64, scheduledAt int64) error {
+	return a.dao.UpsertDeletion(ctx, uid, scheduledAt)
+}
+
+func (a *accountRepository) FindDeletion(ctx context.Context, uid int64) (domain.AccountDeletion, error) {
+	d, err := a.dao.FindDeletion(ctx, uid)
+	if err != nil {
+		return domain.AccountDeletion{}, err
+	}
+	return toDomainDeletion(d), nil
+}
+
+func (a *accountRepository) CancelDeletion(ctx context.Context, uid int64) error {
+	return a.dao.CancelDeletion(ctx, uid)
+}
+
+func (a *accountRepository) ListDueDeletions(ctx context.Context, now int64, limit int) ([]domain.AccountDeletion, error) {
+	list, err := a.dao.ListDueDeletions(ctx, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.AccountDeletion, 0, len(list))
+	for _, d := range list {
+		res = append(res, toDomainDeletion(d))
+	}
+	return res, nil
+}
+
+// PurgeUser 清理用户数据，并将用户缓存标记为已删除
+func (a *accountRepository) PurgeUser(ctx context.Context, uid int64, removeContent bool) ([]uint, []int64, error) {
+	purged, err := a.dao.PurgeUser(ctx, uid, removeContent)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := a.userCache.Set(ctx, domain.User{ID: uid, Deleted: true}); err != nil {
+		a.l.Warn("注销后更新用户缓存失败", zap.Int64("uid", uid), zap.Error(err))
+	}
+	return purged.PostIDs, purged.CommentIDs, nil
+}
+
+func (a *accountRepository) CollectUserData(ctx context.Context, uid int64) (domain.UserDataArchive, error) {
+	data, err := a.dao.CollectUserData(ctx, uid)
+	if err != nil {
+		return domain.UserDataArchive{}, err
+	}
+
+	archive := domain.UserDataArchive{
+		ExportedAt: time.Now().UnixMilli(),
+		User: domain.ArchiveUser{
+			ID:        data.User.ID,
+			Username:  data.User.Username,
+			CreatedAt: data.User.CreateTime,
+			RealName:  data.User.Profile.RealName,
+			Avatar:    data.User.Profile.Avatar,
+			About:     data.User.Profile.About,
+			Birthday:  data.User.Profile.Birthday,
+			Email:     data.User.Profile.Email,
+		},
+		Posts:       make([]domain.ArchivePost, 0, len(data.Posts)),
+		Comments:    make([]domain.ArchiveComment, 0, len(data.Comments)),
+		Likes:       data.Likes,
+		Collections: data.Collections,
+		Followees:   data.Followees,
+		Followers:   data.Followers,
+	}
+	if data.User.Profile.Phone != nil {
+		archive.User.Phone = *data.User.Profile.Phone
+	}
+	for _, p := range data.Posts {
+		archive.Posts = append(archive.Posts, domain.ArchivePost{
+			ID:        p.ID,
+			Title:     p.Title,
+			Content:   p.Content,
+			PlateID:   p.PlateID,
+			Tags:      p.Tags,
+			Status:    p.Status,
+			CreatedAt: p.CreatedAt.UnixMilli(),
+			UpdatedAt: p.UpdatedAt.UnixMilli(),
+		})
+	}
+	for _, c := range data.Comments {
+		archive.Comments = append(archive.Comments, domain.ArchiveComment{
+			ID:        c.Id,
+			PostID:    c.PostId,
+			Content:   c.Content,
+			CreatedAt: c.CreatedAt,
+		})
+	}
+	return archive, nil
+}
+
+func (a *accountRepository) CreateExport(ctx context.Context, uid int64) (domain.DataExport, error) {
+	export, err := a.dao.CreateExport(ctx, uid)
+	if err != nil {
+		return domain.DataExport{}, err
+	}
+	return toDomainExport(export), nil
+}
+
+func (a *accountRepository) FindLatestExport(ctx context.Context, uid int64) (domain.DataExport, error) {
+	export, err := a.dao.FindLatestExport(ctx, uid)
+	if err != nil {
+		return domain.DataExport{}, err
+	}
+	return toDomainExport(export), nil
+}
+
+func (a *accountRepository) ListExports(ctx context.Context, status domain.ExportStatus, limit int) ([]domain.DataExport, error) {
+	list, err := a.dao.ListExportsByStatus(ctx, uint8(status), limit)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainExports(list), nil
+}
+
+func (a *accountRepository) ClaimExport(ctx context.Context, id int64) (bool, error) {
+	return a.dao.ClaimExport(ctx, id)
+}
+
+func (a *accountRepository) UpdateExport(ctx context.Context, export domain.DataExport) error {
+	return a.dao.UpdateExport(ctx, dao.DataExport{
+		ID:         export.ID,
+		Status:     uint8(export.Status),
+		FilePath:   export.FilePath,
+		FinishedAt: export.FinishedAt,
+		ExpiresAt:  export.ExpiresAt,
+	})
+}
+
+func (a *accountRepository) ListExpiredExports(ctx context.Context, now int64, limit int) ([]domain.DataExport, error) {
+	list, err := a.dao.ListExpiredExports(ctx, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainExports(list), nil
+}
+
+func toDomainDeletion(d dao.AccountDeletion) domain.AccountDeletion {
+	return domain.AccountDeletion{
+		UserID:      d.UserID,
+		Status:      domain.DeletionStatus(d.Status),
+		RequestedAt: d.RequestedAt,
+		ScheduledAt: d.ScheduledAt,
+		CompletedAt: d.CompletedAt,
+	}
+}
+
+func toDomainExport(e dao.DataExport) domain.DataExport {
+	return domain.DataExport{
+		ID:         e.ID,
+		UserID:     e.UserID,
+		Status:     domain.ExportStatus(e.Status),
+		FilePath:   e.FilePath,
+		CreatedAt:  e.CreatedAt,
+		FinishedAt: e.FinishedAt,
+		ExpiresAt:  e.ExpiresAt,
+	}
+}
+
+func toDomainExports(list []dao.DataExport) []domain.DataExport {
+	res := make([]domain.DataExport, 0, len(list))
+	for _, e := range list {
+		res = append(res, toDomainExport(e))
+	}
+	return res
+}
@@ -9,11 +9,15 @@ import (
 )
 
 type ApiRepository interface {
-	CreateApi(ctx context.Context, api *domain.Api) error
+	// CreateApi 创建API，返回新API的ID
+	CreateApi(ctx context.Context, api *domain.Api) (int, error)
 	GetApiById(ctx context.Context, id int) (*domain.Api, error)
 	UpdateApi(ctx context.Context, api *domain.Api) error
 	DeleteApi(ctx context.Context, id int) error
 	ListApis(ctx context.Context, page, pageSize int) ([]*domain.Api, int, error)
+	SyncRoute(ctx context.Context, api *domain.Api) (bool, error)
+	MarkStaleRoutes(ctx context.Context, keys []string) (int64, error)
+	ListRouteApis(ctx context.Context) ([]*domain.Api, error)
 }
 
 type apiRepository struct {
@@ -29,9 +33,9 @@ func NewApiRepository(l *zap.Logger, dao dao.ApiDAO) ApiRepository {
 }
 
 // CreateApi 创建新的API
-func (a *apiRepository) CreateApi(ctx context.Context, api *domain.Api) error {
+func (a *apiRepository) CreateApi(ctx context.Context, api *domain.Api) (int, error) {
 	if api == nil {
-		return dao.ErrInvalidMenu // 添加空指针检查
+		return 0, dao.ErrInvalidMenu // 添加空指针检查
 	}
 	return a.dao.CreateApi(ctx, a.apiToDAO(api))
 }
@@ -78,11 +82,37 @@ func (a *apiRepository) ListApis(ctx context.Context, page, pageSize int) ([]*do
 	return a.apisFromDAO(apis), total, nil
 }
 
+// SyncRoute 按路由标识写入API记录
+func (a *apiRepository) SyncRoute(ctx context.Context, api *domain.Api) (bool, error) {
+	if api == nil || api.RouteKey == "" {
+		return false, dao.ErrInvalidMenu
+	}
+	return a.dao.SyncRoute(ctx, a.apiToDAO(api))
+}
+
+// MarkStaleRoutes 标记已不存在的路由
+func (a *apiRepository) MarkStaleRoutes(ctx context.Context, keys []string) (int64, error) {
+	return a.dao.MarkStaleRoutes(ctx, keys)
+}
+
+// ListRouteApis 获取有效的已同步路由
+func (a *apiRepository) ListRouteApis(ctx context.Context) ([]*domain.Api, error) {
+	apis, err := a.dao.ListRouteApis(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return a.apisFromDAO(apis), nil
+}
+
 // apiToDAO 将domain层的API对象转换为DAO层对象
 func (a *apiRepository) apiToDAO(api *domain.Api) *dao.Api {
 	if api == nil {
 		return nil
 	}
+	var routeKey *string
+	if api.RouteKey != "" {
+		routeKey = &api.RouteKey
+	}
 	return &dao.Api{
 		ID:          api.ID,
 		Name:        api.Name,
@@ -92,6 +122,8 @@ func (a *apiRepository) apiToDAO(api *domain.Api) *dao.Api {
 		Version:     api.Version,
 		Category:    api.Category,
 		IsPublic:    api.IsPublic,
+		RouteKey:    routeKey,
+		IsStale:     api.IsStale,
 		CreateTime:  api.CreateTime,
 		UpdateTime:  api.UpdateTime,
 		IsDeleted:   api.IsDeleted,
@@ -103,6 +135,10 @@ func (a *apiRepository) apiFromDAO(api *dao.Api) *domain.Api {
 	if api == nil {
 		return nil
 	}
+	var routeKey string
+	if api.RouteKey != nil {
+		routeKey = *api.RouteKey
+	}
 	return &domain.Api{
 		ID:          api.ID,
 		Name:        api.Name,
@@ -112,6 +148,8 @@ func (a *apiRepository) apiFromDAO(api *dao.Api) *domain.Api {
 		Version:     api.Version,
 		Category:    api.Category,
 		IsPublic:    api.IsPublic,
+		RouteKey:    routeKey,
+		IsStale:     api.IsStale,
 		CreateTime:  api.CreateTime,
 		UpdateTime:  api.UpdateTime,
 		IsDeleted:   api.IsDeleted,
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/repository/dao"
+	"go.uber.org/zap"
+)
+
+type AuditRepository interface {
+	Create(ctx context.Context, log domain.AuditLog) error
+	List(ctx context.Context, filter domain.AuditFilter, pagination domain.Pagination) ([]domain.AuditLog, int64, error)
+	DeleteBefore(ctx context.Context, before int64, limit int) (int64, error)
+}
+
+type auditRepository struct {
+	dao dao.AuditLogDAO
+	l   *zap.Logger
+}
+
+func NewAuditRepository(dao dao.AuditLogDAO, l *zap.Logger) AuditRepository {
+	return &auditRepository{
+		dao: dao,
+		l:   l,
+	}
+}
+
+func (a *auditRepository) Create(ctx context.Context, log domain.AuditLog) error {
+	return a.dao.Create(ctx, dao.AuditLog{
+		ActorID:    log.ActorID,
+		Action:     log.Action,
+		TargetType: log.TargetType,
+		TargetID:   log.TargetID,
+		Before:     log.Before,
+		After:      log.After,
+		IP:         log.IP,
+		RequestID:  log.RequestID,
+	})
+}
+
+func (a *auditRepository) List(ctx context.Context, filter domain.AuditFilter, pagination domain.Pagination) ([]domain.AuditLog, int64, error) {
+	logs, total, err := a.dao.List(ctx, filter, int(*pagination.Offset), int(*pagination.Size))
+	if err != nil {
+		return nil, 0, err
+	}
+	res := make([]domain.AuditLog, 0, len(logs))
+	for _, log := range logs {
+		res = append(res, toDomainAuditLog(log))
+	}
+	return res, total, nil
+}
+
+func (a *auditRepository) DeleteBefore(ctx context.Context, before int64, limit int) (int64, error) {
+	return a.dao.DeleteBefore(ctx, before, limit)
+}
+
+func toDomainAuditLog(log dao.AuditLog) domain.AuditLog {
+	return domain.AuditLog{
+		ID:         log.ID,
+		ActorID:    log.ActorID,
+		Action:     log.Action,
+		TargetType: log.TargetType,
+		TargetID:   log.TargetID,
+		Before:     log.Before,
+		After:      log.After,
+		IP:         log.IP,
+		RequestID:  log.RequestID,
+		CreatedAt:  log.CreatedAt,
+	}
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/repository/cache"
+	"github.com/GoSimplicity/LinkMe/internal/repository/dao"
+	"go.uber.org/zap"
+)
+
+type BadgeRepository interface {
+	// ListBadges 获取勋章定义，首次使用时写入内置勋章
+	ListBadges(ctx context.Context, onlyEnabled bool) ([]domain.Badge, error)
+	CreateBadge(ctx context.Context, badge domain.Badge) (int64, error)
+	UpdateBadge(ctx context.Context, badge domain.Badge) error
+	Award(ctx context.Context, uid, badgeId int64) (bool, error)
+	ListUserBadges(ctx context.Context, uid int64) ([]domain.UserBadge, error)
+	// RecordActiveDay 记录活跃日期，返回 false 表示当天已记录
+	RecordActiveDay(ctx context.Context, uid int64, day string) (bool, error)
+	ListActiveDays(ctx context.Context, uid int64) ([]string, error)
+	CountApprovedPosts(ctx context.Context, uid int64) (int64, error)
+	SumLikesReceived(ctx context.Context, uid int64) (int64, error)
+	CountLotteryParticipations(ctx context.Context, uid int64) (int64, error)
+	ListUserIDs(ctx context.Context, afterId int64, limit int) ([]int64, error)
+	BackfillActiveDays(ctx context.Context) error
+}
+
+type badgeRepository struct {
+	dao   dao.BadgeDAO
+	cache cache.BadgeCache
+	l     *zap.Logger
+}
+
+func NewBadgeRepository(dao dao.BadgeDAO, cache cache.BadgeCache, l *zap.Logger) BadgeRepository {
+	return &badgeRepository{
+		dao:   dao,
+		cache: cache,
+		l:     l,
+	}
+}
+
+func (b *badgeRepository) ListBadges(ctx context.Context, onlyEnabled bool) ([]domain.Badge, error) {
+	count, err := b.dao.CountBadges(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		defaults := make([]dao.Badge, 0, len(domain.DefaultBadges))
+		for _, badge := range domain.DefaultBadges {
+			defaults = append(defaults, toDAOBadge(badge))
+		}
+		if err := b.dao.CreateBadges(ctx, defaults); err != nil {
+			return nil, err
+		}
+	}
+
+	badges, err := b.dao.ListBadges(ctx, onlyEnabled)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.Badge, 0, len(badges))
+	for _, badge := range badges {
+		res = append(res, toDomainBadge(badge))
+	}
+	return res, nil
+}
+
+func (b *badgeRepository) CreateBadge(ctx context.Context, badge domain.Badge) (int64, error) {
+	return b.dao.CreateBadge(ctx, toDAOBadge(badge))
+}
+
+func (b *badgeRepository) UpdateBadge(ctx context.Context, badge domain.Badge) error {
+	return b.dao.UpdateBadge(ctx, toDAOBadge(badge))
+}
+
+func (b *badgeRepository) Award(ctx context.Context, uid, badgeId int64) (bool, error) {
+	return b.dao.Award(ctx, uid, badgeId)
+}
+
+func (b *badgeRepository) ListUserBadges(ctx context.Context, uid int64) ([]domain.UserBadge, error) {
+	rows, err := b.dao.ListUserBadges(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.UserBadge, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, domain.UserBadge{
+			BadgeID:     row.BadgeID,
+			Name:        row.Name,
+			Description: row.Description,
+			Icon:        row.Icon,
+			AwardedAt:   row.AwardedAt,
+		})
+	}
+	return res, nil
+}
+
+func (b *badgeRepository) RecordActiveDay(ctx context.Context, uid int64, day string) (bool, error) {
+	// 缓存只用于减少写库，不可用时直接写库
+	marked, err := b.cache.MarkActive(ctx, uid, day)
+	if err != nil {
+		b.l.Warn("标记活跃缓存失败", zap.Int64("uid", uid), zap.Error(err))
+	} else if !marked {
+		return false, nil
+	}
+
+	if err := b.dao.RecordActiveDay(ctx, uid, day); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (b *badgeRepository) ListActiveDays(ctx context.Context, uid int64) ([]string, error) {
+	return b.dao.ListActiveDays(ctx, uid)
+}
+
+func (b *badgeRepository) CountApprovedPosts(ctx context.Context, uid int64) (int64, error) {
+	return b.dao.CountApprovedPosts(ctx, uid)
+}
+
+func (b *badgeRepository) SumLikesReceived(ctx context.Context, uid int64) (int64, error) {
+	return b.dao.SumLikesReceived(ctx, uid)
+}
+
+func (b *badgeRepository) CountLotteryParticipations(ctx context.Context, uid int64) (int64, error) {
+	return b.dao.CountLotteryParticipations(ctx, uid)
+}
+
+func (b *badgeRepository) ListUserIDs(ctx context.Context, afterId int64, limit int) ([]int64, error) {
+	return b.dao.ListUserIDs(ctx, afterId, limit)
+}
+
+func (b *badgeRepository) BackfillActiveDays(ctx context.Context) error {
+	return b.dao.BackfillActiveDays(ctx)
+}
+
+func toDAOBadge(badge domain.Badge) dao.Badge {
+	return dao.Badge{
+		ID:          badge.ID,
+		Name:        badge.Name,
+		Description: badge.Description,
+		Icon:        badge.Icon,
+		Rule:        string(badge.Rule),
+		Threshold:   badge.Threshold,
+		Enabled:     badge.Enabled,
+	}
+}
+
+func toDomainBadge(badge dao.Badge) domain.Badge {
+	return domain.Badge{
+		ID:          badge.ID,
+		Name:        badge.Name,
+		Description: badge.Description,
+		Icon:        badge.Icon,
+		Rule:        domain.BadgeRule(badge.Rule),
+		Threshold:   badge.Threshold,
+		Enabled:     badge.Enabled,
+		CreatedAt:   badge.CreatedAt,
+		UpdatedAt:   badge.UpdatedAt,
+	}
+}
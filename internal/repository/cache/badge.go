@@ -0,0 +1,33 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type BadgeCache interface {
+	// MarkActive 标记用户当天已活跃，返回 false 表示当天已标记过
+	MarkActive(ctx context.Context, uid int64, day string) (bool, error)
+}
+
+type badgeCache struct {
+	client redis.Cmdable
+}
+
+func NewBadgeCache(client redis.Cmdable) BadgeCache {
+	return &badgeCache{
+		client: client,
+	}
+}
+
+func (b *badgeCache) activeKey(uid int64, day string) string {
+	return fmt.Sprintf("linkme:badge:active:%d:%s", uid, day)
+}
+
+// MarkActive 标记当天活跃，保留两天以覆盖跨时区的请求
+func (b *badgeCache) MarkActive(ctx context.Context, uid int64, day string) (bool, error) {
+	return b.client.SetNX(ctx, b.activeKey(uid, day), 1, 48*time.Hour).Result()
+}
@@ -3,13 +3,17 @@ package cache
 import (
 	"context"
 	"fmt"
+	"github.com/GoSimplicity/LinkMe/internal/domain"
 	"github.com/redis/go-redis/v9"
 	"time"
 )
 
 type EmailCache interface {
-	GetVCode(ctx context.Context, email string) (string, error)
-	StoreVCode(ctx context.Context, email, vCode string) error
+	GetVCode(ctx context.Context, email string, purpose domain.EmailPurpose) (string, error)
+	StoreVCode(ctx context.Context, email string, purpose domain.EmailPurpose, vCode string) error
+	DelVCode(ctx context.Context, email string, purpose domain.EmailPurpose) error
+	// Cooldown 限制同一邮箱同一用途的发送频率，冷却期内返回 false
+	Cooldown(ctx context.Context, email string, purpose domain.EmailPurpose, ttl time.Duration) (bool, error)
 }
 
 type emailCache struct {
@@ -22,14 +26,27 @@ func NewEmailCache(client redis.Cmdable) EmailCache {
 	}
 }
 
-func (e emailCache) GetVCode(ctx context.Context, email string) (string, error) {
-	return e.client.Get(ctx, genEmailKey(email)).Result()
+func (e emailCache) GetVCode(ctx context.Context, email string, purpose domain.EmailPurpose) (string, error) {
+	return e.client.Get(ctx, genEmailKey(email, purpose)).Result()
 }
 
-func (e emailCache) StoreVCode(ctx context.Context, email, vCode string) error {
-	return e.client.Set(ctx, genEmailKey(email), vCode, time.Duration(10)*time.Minute).Err()
+func (e emailCache) StoreVCode(ctx context.Context, email string, purpose domain.EmailPurpose, vCode string) error {
+	return e.client.Set(ctx, genEmailKey(email, purpose), vCode, time.Duration(10)*time.Minute).Err()
 }
 
-func genEmailKey(email string) string {
-	return fmt.Sprintf("linkme:email:%s", email)
+// DelVCode 删除验证码，验证通过后调用以保证验证码只能使用一次
+func (e emailCache) DelVCode(ctx context.Context, email string, purpose domain.EmailPurpose) error {
+	return e.client.Del(ctx, genEmailKey(email, purpose)).Err()
+}
+
+// Cooldown 冷却期内重复发送时返回 false
+func (e emailCache) Cooldown(ctx context.Context, email string, purpose domain.EmailPurpose, ttl time.Duration) (bool, error) {
+	return e.client.SetNX(ctx, fmt.Sprintf("linkme:email:cooldown:%s:%s", purpose, email), 1, ttl).Result()
+}
+
+func genEmailKey(email string, purpose domain.EmailPurpose) string {
+	if purpose == "" {
+		return fmt.Sprintf("linkme:email:%s", email)
+	}
+	return fmt.Sprintf("linkme:email:%s:%s", purpose, email)
 }
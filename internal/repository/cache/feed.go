@@ -0,0 +1,155 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// FeedInboxMaxSize 收件箱最多保留的帖子数
+	FeedInboxMaxSize = 1000
+	// feedInboxExpiration 收件箱过期时间，过期后视为不活跃用户，下次访问时重建
+	feedInboxExpiration = 7 * 24 * time.Hour
+	// feedInboxSentinel 占位成员，用于区分空收件箱与不存在的收件箱
+	feedInboxSentinel = "0"
+)
+
+type FeedCache interface {
+	// PushInboxes 将帖子推送到多个用户的收件箱，只写入已存在的收件箱
+	PushInboxes(ctx context.Context, uids []int64, postId uint) error
+	// AddToInbox 向用户已存在的收件箱中补充帖子
+	AddToInbox(ctx context.Context, uid int64, postIds []uint) error
+	// RemoveFromInbox 从用户收件箱中移除帖子
+	RemoveFromInbox(ctx context.Context, uid int64, postIds []uint) error
+	// GetInbox 按帖子ID倒序获取收件箱中小于 maxId 的帖子，maxId 为 0 时从最新开始；收件箱不存在时 ok 为 false
+	GetInbox(ctx context.Context, uid int64, maxId uint, limit int) (ids []uint, ok bool, err error)
+	// RebuildInbox 使用给定的帖子重建收件箱
+	RebuildInbox(ctx context.Context, uid int64, postIds []uint) error
+}
+
+type feedCache struct {
+	client     redis.Cmdable
+	pushScript *redis.Script
+}
+
+func NewFeedCache(client redis.Cmdable) FeedCache {
+	// 仅向已存在的收件箱写入，写入后裁剪到最大长度并续期
+	pushScript := redis.NewScript(`
+local maxSize = tonumber(ARGV[1])
+local ttl = tonumber(ARGV[2])
+for i = 1, #KEYS do
+	if redis.call("EXISTS", KEYS[i]) == 1 then
+		for j = 3, #ARGV do
+			redis.call("ZADD", KEYS[i], ARGV[j], ARGV[j])
+		end
+		redis.call("ZREMRANGEBYRANK", KEYS[i], 0, -(maxSize + 2))
+		redis.call("EXPIRE", KEYS[i], ttl)
+	end
+end
+return 0
+`)
+
+	return &feedCache{
+		client:     client,
+		pushScript: pushScript,
+	}
+}
+
+func (f *feedCache) inboxKey(uid int64) string {
+	return fmt.Sprintf("linkme:feed:inbox:%d", uid)
+}
+
+// PushInboxes 将帖子推送到多个用户的收件箱
+func (f *feedCache) PushInboxes(ctx context.Context, uids []int64, postId uint) error {
+	if len(uids) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(uids))
+	for _, uid := range uids {
+		keys = append(keys, f.inboxKey(uid))
+	}
+	return f.pushScript.Run(ctx, f.client, keys, FeedInboxMaxSize, int(feedInboxExpiration.Seconds()), postId).Err()
+}
+
+// AddToInbox 向用户已存在的收件箱中补充帖子
+func (f *feedCache) AddToInbox(ctx context.Context, uid int64, postIds []uint) error {
+	if len(postIds) == 0 {
+		return nil
+	}
+	args := make([]any, 0, len(postIds)+2)
+	args = append(args, FeedInboxMaxSize, int(feedInboxExpiration.Seconds()))
+	for _, id := range postIds {
+		args = append(args, id)
+	}
+	return f.pushScript.Run(ctx, f.client, []string{f.inboxKey(uid)}, args...).Err()
+}
+
+// RemoveFromInbox 从用户收件箱中移除帖子
+func (f *feedCache) RemoveFromInbox(ctx context.Context, uid int64, postIds []uint) error {
+	if len(postIds) == 0 {
+		return nil
+	}
+	members := make([]any, 0, len(postIds))
+	for _, id := range postIds {
+		members = append(members, strconv.FormatUint(uint64(id), 10))
+	}
+	return f.client.ZRem(ctx, f.inboxKey(uid), members...).Err()
+}
+
+// GetInbox 获取收件箱中的帖子ID
+func (f *feedCache) GetInbox(ctx context.Context, uid int64, maxId uint, limit int) ([]uint, bool, error) {
+	key := f.inboxKey(uid)
+	n, err := f.client.Exists(ctx, key).Result()
+	if err != nil {
+		return nil, false, err
+	}
+	if n == 0 {
+		return nil, false, nil
+	}
+
+	max := "+inf"
+	if maxId > 0 {
+		max = "(" + strconv.FormatUint(uint64(maxId), 10)
+	}
+	// 占位成员的分数为 0，下界使用 (0 将其排除
+	members, err := f.client.ZRevRangeByScore(ctx, key, &redis.ZRangeBy{
+		Max:   max,
+		Min:   "(0",
+		Count: int64(limit),
+	}).Result()
+	if err != nil {
+		return nil, true, err
+	}
+
+	ids := make([]uint, 0, len(members))
+	for _, m := range members {
+		id, err := strconv.ParseUint(m, 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, uint(id))
+	}
+	f.client.Expire(ctx, key, feedInboxExpiration)
+	return ids, true, nil
+}
+
+// RebuildInbox 使用给定的帖子重建收件箱
+func (f *feedCache) RebuildInbox(ctx context.Context, uid int64, postIds []uint) error {
+	key := f.inboxKey(uid)
+	members := make([]redis.Z, 0, len(postIds)+1)
+	members = append(members, redis.Z{Score: 0, Member: feedInboxSentinel})
+	for _, id := range postIds {
+		members = append(members, redis.Z{Score: float64(id), Member: strconv.FormatUint(uint64(id), 10)})
+	}
+
+	pipe := f.client.TxPipeline()
+	pipe.Del(ctx, key)
+	pipe.ZAdd(ctx, key, members...)
+	pipe.Expire(ctx, key, feedInboxExpiration)
+	_, err := pipe.Exec(ctx)
+	return err
+}
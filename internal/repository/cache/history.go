@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -28,6 +30,7 @@ type HistoryCache interface {
 	GetCache(ctx context.Context, pagination domain.Pagination) ([]domain.History, error)
 	DeleteOneCache(ctx context.Context, postId uint, uid int64) error
 	DeleteAllHistory(ctx context.Context, uid int64) error
+	ScanUids(ctx context.Context, cursor uint64, count int64) ([]int64, uint64, error)
 }
 
 type historyCache struct {
@@ -167,6 +170,24 @@ func (h *historyCache) DeleteAllHistory(ctx context.Context, uid int64) error {
 	})
 }
 
+// ScanUids 扫描存在阅读历史的用户，即近期活跃的用户
+func (h *historyCache) ScanUids(ctx context.Context, cursor uint64, count int64) ([]int64, uint64, error) {
+	keys, next, err := h.client.Scan(ctx, cursor, historyKeyPrefix+"*", count).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	uids := make([]int64, 0, len(keys))
+	for _, key := range keys {
+		uid, err := strconv.ParseInt(strings.TrimPrefix(key, historyKeyPrefix), 10, 64)
+		if err != nil {
+			continue
+		}
+		uids = append(uids, uid)
+	}
+	return uids, next, nil
+}
+
 // 工具函数
 
 func (h *historyCache) withLocalLock(lockKey string, fn func() error) error {
@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+type LoginGuardCache interface {
+	Get(ctx context.Context, subject domain.LoginSubject) (domain.LoginGuardState, error)
+	// IncrFailures 记录一次失败，返回窗口内的累计失败次数
+	IncrFailures(ctx context.Context, subject domain.LoginSubject, window time.Duration) (int64, error)
+	// SetNextAttempt 设置下次允许尝试的时间
+	SetNextAttempt(ctx context.Context, subject domain.LoginSubject, at int64) error
+	Lock(ctx context.Context, subject domain.LoginSubject, ttl time.Duration) error
+	// Clear 清除失败次数与锁定
+	Clear(ctx context.Context, subject domain.LoginSubject) error
+}
+
+type loginGuardCache struct {
+	client redis.Cmdable
+}
+
+func NewLoginGuardCache(client redis.Cmdable) LoginGuardCache {
+	return &loginGuardCache{
+		client: client,
+	}
+}
+
+func (l *loginGuardCache) failKey(subject domain.LoginSubject) string {
+	return fmt.Sprintf("linkme:login_guard:fail:%s:%s", subject.Kind, subject.Value)
+}
+
+func (l *loginGuardCache) lockKey(subject domain.LoginSubject) string {
+	return fmt.Sprintf("linkme:login_guard:lock:%s:%s", subject.Kind, subject.Value)
+}
+
+// Get 获取失败次数、下次允许尝试的时间与锁定截止时间
+func (l *loginGuardCache) Get(ctx context.Context, subject domain.LoginSubject) (domain.LoginGuardState, error) {
+	pipe := l.client.Pipeline()
+	failCmd := pipe.HMGet(ctx, l.failKey(subject), "failures", "next_at")
+	lockCmd := pipe.Get(ctx, l.lockKey(subject))
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return domain.LoginGuardState{}, err
+	}
+
+	var state domain.LoginGuardState
+	vals := failCmd.Val()
+	if len(vals) == 2 {
+		state.Failures = parseInt64(vals[0])
+		state.NextAttemptAt = parseInt64(vals[1])
+	}
+	if until, err := lockCmd.Int64(); err == nil {
+		state.LockedUntil = until
+	}
+	return state, nil
+}
+
+// IncrFailures 增加失败次数，首次失败时设置窗口过期时间
+func (l *loginGuardCache) IncrFailures(ctx context.Context, subject domain.LoginSubject, window time.Duration) (int64, error) {
+	key := l.failKey(subject)
+	cnt, err := l.client.HIncrBy(ctx, key, "failures", 1).Result()
+	if err != nil {
+		return 0, err
+	}
+	if cnt == 1 {
+		if err := l.client.Expire(ctx, key, window).Err(); err != nil {
+			return cnt, err
+		}
+	}
+	return cnt, nil
+}
+
+// SetNextAttempt 设置下次允许尝试的时间
+func (l *loginGuardCache) SetNextAttempt(ctx context.Context, subject domain.LoginSubject, at int64) error {
+	return l.client.HSet(ctx, l.failKey(subject), "next_at", at).Err()
+}
+
+// Lock 锁定登录对象，值为锁定截止时间
+func (l *loginGuardCache) Lock(ctx context.Context, subject domain.LoginSubject, ttl time.Duration) error {
+	return l.client.Set(ctx, l.lockKey(subject), time.Now().Add(ttl).UnixMilli(), ttl).Err()
+}
+
+// Clear 清除失败次数与锁定
+func (l *loginGuardCache) Clear(ctx context.Context, subject domain.LoginSubject) error {
+	return l.client.Del(ctx, l.failKey(subject), l.lockKey(subject)).Err()
+}
+
+func parseInt64(val interface{}) int64 {
+	s, ok := val.(string)
+	if !ok {
+		return 0
+	}
+	n, _ := strconv.ParseInt(s, 10, 64)
+	return n
+}
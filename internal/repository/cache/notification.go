@@ -0,0 +1,108 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// 通知设置缓存时间
+	notificationSettingsTTL = 30 * time.Minute
+	// 站内信最多保留条数
+	notificationInboxLimit = 500
+)
+
+type NotificationCache interface {
+	GetSettings(ctx context.Context, uid int64) (domain.NotificationSettings, bool, error)
+	SetSettings(ctx context.Context, settings domain.NotificationSettings) error
+	DelSettings(ctx context.Context, uid int64) error
+	PushInbox(ctx context.Context, n domain.Notification) error
+	ListInbox(ctx context.Context, uid int64, offset, size int64) ([]domain.Notification, error)
+}
+
+type notificationCache struct {
+	client redis.Cmdable
+}
+
+func NewNotificationCache(client redis.Cmdable) NotificationCache {
+	return &notificationCache{
+		client: client,
+	}
+}
+
+// GetSettings 获取缓存的通知设置，第二个返回值表示是否命中
+func (c *notificationCache) GetSettings(ctx context.Context, uid int64) (domain.NotificationSettings, bool, error) {
+	data, err := c.client.Get(ctx, genNotificationSettingsKey(uid)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return domain.NotificationSettings{}, false, nil
+	}
+	if err != nil {
+		return domain.NotificationSettings{}, false, err
+	}
+
+	var settings domain.NotificationSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return domain.NotificationSettings{}, false, err
+	}
+	return settings, true, nil
+}
+
+// SetSettings 缓存通知设置
+func (c *notificationCache) SetSettings(ctx context.Context, settings domain.NotificationSettings) error {
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, genNotificationSettingsKey(settings.UserID), data, notificationSettingsTTL).Err()
+}
+
+// DelSettings 删除通知设置缓存
+func (c *notificationCache) DelSettings(ctx context.Context, uid int64) error {
+	return c.client.Del(ctx, genNotificationSettingsKey(uid)).Err()
+}
+
+// PushInbox 写入站内信
+func (c *notificationCache) PushInbox(ctx context.Context, n domain.Notification) error {
+	data, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	key := genNotificationInboxKey(n.UserID)
+	pipe := c.client.TxPipeline()
+	pipe.LPush(ctx, key, data)
+	pipe.LTrim(ctx, key, 0, notificationInboxLimit-1)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// ListInbox 分页获取站内信，按时间倒序
+func (c *notificationCache) ListInbox(ctx context.Context, uid int64, offset, size int64) ([]domain.Notification, error) {
+	values, err := c.client.LRange(ctx, genNotificationInboxKey(uid), offset, offset+size-1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	notifications := make([]domain.Notification, 0, len(values))
+	for _, v := range values {
+		var n domain.Notification
+		if err := json.Unmarshal([]byte(v), &n); err != nil {
+			continue
+		}
+		notifications = append(notifications, n)
+	}
+	return notifications, nil
+}
+
+func genNotificationSettingsKey(uid int64) string {
+	return fmt.Sprintf("linkme:notification:settings:%d", uid)
+}
+
+func genNotificationInboxKey(uid int64) string {
+	return fmt.Sprintf("linkme:notification:inbox:%d", uid)
+}
@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrOAuthStateNotFound 表示授权状态不存在、已过期或已被使用
+var ErrOAuthStateNotFound = errors.New("授权状态无效或已过期")
+
+type OAuthCache interface {
+	SetState(ctx context.Context, state string, s domain.OAuthState, ttl time.Duration) error
+	// TakeState 获取并删除授权状态，保证每个状态只能使用一次
+	TakeState(ctx context.Context, state string) (domain.OAuthState, error)
+}
+
+type oauthCache struct {
+	client redis.Cmdable
+}
+
+func NewOAuthCache(client redis.Cmdable) OAuthCache {
+	return &oauthCache{
+		client: client,
+	}
+}
+
+func (o *oauthCache) key(state string) string {
+	return fmt.Sprintf("linkme:oauth:state:%s", state)
+}
+
+// SetState 保存授权状态
+func (o *oauthCache) SetState(ctx context.Context, state string, s domain.OAuthState, ttl time.Duration) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return o.client.Set(ctx, o.key(state), data, ttl).Err()
+}
+
+// TakeState 获取并删除授权状态
+func (o *oauthCache) TakeState(ctx context.Context, state string) (domain.OAuthState, error) {
+	data, err := o.client.GetDel(ctx, o.key(state)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return domain.OAuthState{}, ErrOAuthStateNotFound
+		}
+		return domain.OAuthState{}, err
+	}
+
+	var s domain.OAuthState
+	if err := json.Unmarshal(data, &s); err != nil {
+		return domain.OAuthState{}, err
+	}
+	return s, nil
+}
@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrResetTokenNotFound 表示重置令牌不存在、已过期或已被使用
+var ErrResetTokenNotFound = errors.New("重置链接无效或已过期")
+
+type PasswordResetCache interface {
+	// Cooldown 设置发送冷却，冷却期内返回 false
+	Cooldown(ctx context.Context, channel domain.ResetChannel, account string, ttl time.Duration) (bool, error)
+	// IncrAttempts 记录一次验证码校验，返回窗口内的累计次数
+	IncrAttempts(ctx context.Context, channel domain.ResetChannel, account string, window time.Duration) (int64, error)
+	ClearAttempts(ctx context.Context, channel domain.ResetChannel, account string) error
+	SetToken(ctx context.Context, tokenHash string, uid int64, ttl time.Duration) error
+	// TakeToken 获取并删除重置令牌，保证令牌只能使用一次
+	TakeToken(ctx context.Context, tokenHash string) (int64, error)
+}
+
+type passwordResetCache struct {
+	client redis.Cmdable
+}
+
+func NewPasswordResetCache(client redis.Cmdable) PasswordResetCache {
+	return &passwordResetCache{
+		client: client,
+	}
+}
+
+func (p *passwordResetCache) cooldownKey(channel domain.ResetChannel, account string) string {
+	return fmt.Sprintf("linkme:pwdreset:cooldown:%s:%s", channel, account)
+}
+
+func (p *passwordResetCache) attemptsKey(channel domain.ResetChannel, account string) string {
+	return fmt.Sprintf("linkme:pwdreset:attempts:%s:%s", channel, account)
+}
+
+func (p *passwordResetCache) tokenKey(tokenHash string) string {
+	return fmt.Sprintf("linkme:pwdreset:token:%s", tokenHash)
+}
+
+// Cooldown 限制同一账号的发送频率
+func (p *passwordResetCache) Cooldown(ctx context.Context, channel domain.ResetChannel, account string, ttl time.Duration) (bool, error) {
+	return p.client.SetNX(ctx, p.cooldownKey(channel, account), 1, ttl).Result()
+}
+
+// IncrAttempts 增加校验次数，首次校验时设置窗口过期时间
+func (p *passwordResetCache) IncrAttempts(ctx context.Context, channel domain.ResetChannel, account string, window time.Duration) (int64, error) {
+	key := p.attemptsKey(channel, account)
+	cnt, err := p.client.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if cnt == 1 {
+		if err := p.client.Expire(ctx, key, window).Err(); err != nil {
+			return cnt, err
+		}
+	}
+	return cnt, nil
+}
+
+// ClearAttempts 清除校验次数
+func (p *passwordResetCache) ClearAttempts(ctx context.Context, channel domain.ResetChannel, account string) error {
+	return p.client.Del(ctx, p.attemptsKey(channel, account)).Err()
+}
+
+// SetToken 保存重置令牌，只保存令牌的哈希
+func (p *passwordResetCache) SetToken(ctx context.Context, tokenHash string, uid int64, ttl time.Duration) error {
+	return p.client.Set(ctx, p.tokenKey(tokenHash), uid, ttl).Err()
+}
+
+// TakeToken 获取并删除重置令牌
+func (p *passwordResetCache) TakeToken(ctx context.Context, tokenHash string) (int64, error) {
+	uid, err := p.client.GetDel(ctx, p.tokenKey(tokenHash)).Int64()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, ErrResetTokenNotFound
+		}
+		return 0, err
+	}
+	return uid, nil
+}
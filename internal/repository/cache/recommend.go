@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// recommendExpiration 推荐结果过期时间，离线任务未及时更新时回退到热榜
+const recommendExpiration = 24 * time.Hour
+
+type RecommendCache interface {
+	// Set 覆盖用户的推荐结果，scores 为帖子ID到推荐得分的映射
+	Set(ctx context.Context, uid int64, scores map[uint]float64) error
+	// Get 按得分从高到低获取推荐结果，结果不存在时 ok 为 false
+	Get(ctx context.Context, uid int64, offset, limit int64) (ids []uint, ok bool, err error)
+}
+
+type recommendCache struct {
+	client redis.Cmdable
+}
+
+func NewRecommendCache(client redis.Cmdable) RecommendCache {
+	return &recommendCache{
+		client: client,
+	}
+}
+
+func (r *recommendCache) key(uid int64) string {
+	return fmt.Sprintf("linkme:recommend:user:%d", uid)
+}
+
+// Set 覆盖用户的推荐结果
+func (r *recommendCache) Set(ctx context.Context, uid int64, scores map[uint]float64) error {
+	key := r.key(uid)
+	pipe := r.client.TxPipeline()
+	pipe.Del(ctx, key)
+	if len(scores) > 0 {
+		members := make([]redis.Z, 0, len(scores))
+		for id, score := range scores {
+			members = append(members, redis.Z{Score: score, Member: strconv.FormatUint(uint64(id), 10)})
+		}
+		pipe.ZAdd(ctx, key, members...)
+		pipe.Expire(ctx, key, recommendExpiration)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Get 获取推荐结果
+func (r *recommendCache) Get(ctx context.Context, uid int64, offset, limit int64) ([]uint, bool, error) {
+	key := r.key(uid)
+	n, err := r.client.Exists(ctx, key).Result()
+	if err != nil || n == 0 {
+		return nil, false, err
+	}
+
+	members, err := r.client.ZRevRange(ctx, key, offset, offset+limit-1).Result()
+	if err != nil {
+		return nil, true, err
+	}
+	ids := make([]uint, 0, len(members))
+	for _, m := range members {
+		id, err := strconv.ParseUint(m, 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids, true, nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"github.com/GoSimplicity/LinkMe/internal/domain"
 	"github.com/redis/go-redis/v9"
+	"strconv"
 	"time"
 )
 
@@ -18,6 +19,9 @@ type RelationCache interface {
 	ClearFollowCache(ctx context.Context, followerID, followeeID int64) error
 	GenerateCacheKey(userID int64, relationType string, pagination domain.Pagination) string
 	GenerateCountCacheKey(userID int64, relationType string) string
+	GetBlockIDs(ctx context.Context, userID int64, blockType uint8) ([]int64, bool, error)
+	SetBlockIDs(ctx context.Context, userID int64, blockType uint8, ids []int64) error
+	DelBlockIDs(ctx context.Context, userID int64, blockType uint8) error
 }
 
 type relationCache struct {
@@ -75,13 +79,28 @@ func (c *relationCache) SetCountCache(ctx context.Context, key string, count int
 }
 
 func (c *relationCache) ClearFollowCache(ctx context.Context, followerID, followeeID int64) error {
-	// 批量删除相关缓存
+	// 双方的关注数与粉丝数均可能变化
 	keys := []string{
-		c.GenerateCacheKey(followerID, "followers", domain.Pagination{}),
-		c.GenerateCacheKey(followeeID, "followees", domain.Pagination{}),
 		c.GenerateCountCacheKey(followerID, "followers"),
+		c.GenerateCountCacheKey(followerID, "followees"),
+		c.GenerateCountCacheKey(followeeID, "followers"),
 		c.GenerateCountCacheKey(followeeID, "followees"),
 	}
+	// 列表缓存按分页存储，需要按前缀清理
+	for _, pattern := range []string{
+		fmt.Sprintf("relation:followers:%d:*", followerID),
+		fmt.Sprintf("relation:followees:%d:*", followerID),
+		fmt.Sprintf("relation:followers:%d:*", followeeID),
+		fmt.Sprintf("relation:followees:%d:*", followeeID),
+	} {
+		iter := c.client.Scan(ctx, 0, pattern, 100).Iterator()
+		for iter.Next(ctx) {
+			keys = append(keys, iter.Val())
+		}
+		if err := iter.Err(); err != nil {
+			return err
+		}
+	}
 
 	// 使用Pipeline进行批量删除
 	pipe := c.client.Pipeline()
@@ -105,3 +124,52 @@ func (c *relationCache) GenerateCountCacheKey(userID int64, relationType string)
 	// 生成计数缓存键
 	return fmt.Sprintf("relation:count:%s:%d", relationType, userID)
 }
+
+// GetBlockIDs 获取缓存的屏蔽或拉黑用户ID，第二个返回值表示是否命中
+func (c *relationCache) GetBlockIDs(ctx context.Context, userID int64, blockType uint8) ([]int64, bool, error) {
+	key := genBlockKey(userID, blockType)
+	members, err := c.client.SMembers(ctx, key).Result()
+	if err != nil {
+		return nil, false, err
+	}
+	if len(members) == 0 {
+		return nil, false, nil
+	}
+
+	ids := make([]int64, 0, len(members))
+	for _, m := range members {
+		id, err := strconv.ParseInt(m, 10, 64)
+		if err != nil || id == 0 {
+			// 0 为空集合占位
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids, true, nil
+}
+
+// SetBlockIDs 缓存屏蔽或拉黑用户ID，空列表写入占位值防止缓存穿透
+func (c *relationCache) SetBlockIDs(ctx context.Context, userID int64, blockType uint8, ids []int64) error {
+	key := genBlockKey(userID, blockType)
+	members := make([]interface{}, 0, len(ids)+1)
+	members = append(members, 0)
+	for _, id := range ids {
+		members = append(members, id)
+	}
+
+	pipe := c.client.TxPipeline()
+	pipe.Del(ctx, key)
+	pipe.SAdd(ctx, key, members...)
+	pipe.Expire(ctx, key, 30*time.Minute)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// DelBlockIDs 删除屏蔽或拉黑缓存
+func (c *relationCache) DelBlockIDs(ctx context.Context, userID int64, blockType uint8) error {
+	return c.client.Del(ctx, genBlockKey(userID, blockType)).Err()
+}
+
+func genBlockKey(userID int64, blockType uint8) string {
+	return fmt.Sprintf("linkme:relation:block:%d:%d", userID, blockType)
+}
@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrTwoFactorChallengeNotFound 表示两步验证挑战不存在或已过期
+var ErrTwoFactorChallengeNotFound = errors.New("两步验证已过期，请重新登录")
+
+type TwoFactorCache interface {
+	SetChallenge(ctx context.Context, token string, uid int64, ttl time.Duration) error
+	GetChallenge(ctx context.Context, token string) (int64, error)
+	// IncrChallengeAttempts 记录一次失败的验证，返回累计失败次数
+	IncrChallengeAttempts(ctx context.Context, token string) (int64, error)
+	DelChallenge(ctx context.Context, token string) error
+	// MarkCodeUsed 标记验证码所在周期已被使用，返回 false 表示重复使用
+	MarkCodeUsed(ctx context.Context, uid int64, counter int64, ttl time.Duration) (bool, error)
+}
+
+type twoFactorCache struct {
+	client redis.Cmdable
+}
+
+func NewTwoFactorCache(client redis.Cmdable) TwoFactorCache {
+	return &twoFactorCache{
+		client: client,
+	}
+}
+
+func (t *twoFactorCache) challengeKey(token string) string {
+	return fmt.Sprintf("linkme:2fa:challenge:%s", token)
+}
+
+func (t *twoFactorCache) usedKey(uid int64, counter int64) string {
+	return fmt.Sprintf("linkme:2fa:used:%d:%d", uid, counter)
+}
+
+// SetChallenge 保存登录挑战
+func (t *twoFactorCache) SetChallenge(ctx context.Context, token string, uid int64, ttl time.Duration) error {
+	key := t.challengeKey(token)
+	pipe := t.client.TxPipeline()
+	pipe.HSet(ctx, key, "uid", uid, "attempts", 0)
+	pipe.Expire(ctx, key, ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// GetChallenge 获取登录挑战对应的用户
+func (t *twoFactorCache) GetChallenge(ctx context.Context, token string) (int64, error) {
+	uid, err := t.client.HGet(ctx, t.challengeKey(token), "uid").Int64()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, ErrTwoFactorChallengeNotFound
+		}
+		return 0, err
+	}
+	return uid, nil
+}
+
+// IncrChallengeAttempts 增加失败次数
+func (t *twoFactorCache) IncrChallengeAttempts(ctx context.Context, token string) (int64, error) {
+	return t.client.HIncrBy(ctx, t.challengeKey(token), "attempts", 1).Result()
+}
+
+// DelChallenge 删除登录挑战
+func (t *twoFactorCache) DelChallenge(ctx context.Context, token string) error {
+	return t.client.Del(ctx, t.challengeKey(token)).Err()
+}
+
+// MarkCodeUsed 防止同一验证码在有效期内被重放
+func (t *twoFactorCache) MarkCodeUsed(ctx context.Context, uid int64, counter int64, ttl time.Duration) (bool, error) {
+	return t.client.SetNX(ctx, t.usedKey(uid, counter), 1, ttl).Result()
+}
@@ -12,6 +12,7 @@ type CheckRepository interface {
 	Create(ctx context.Context, check domain.Check) (int64, error)
 	UpdateStatus(ctx context.Context, check domain.Check) error
 	FindAll(ctx context.Context, pagination domain.Pagination) ([]domain.Check, error)
+	FindByPlates(ctx context.Context, plateIds []int64, pagination domain.Pagination) ([]domain.Check, error)
 	FindByID(ctx context.Context, checkID int64) (domain.Check, error)
 	FindByPostId(ctx context.Context, postID uint) (domain.Check, error)
 }
@@ -53,6 +54,15 @@ func (r *checkRepository) FindAll(ctx context.Context, pagination domain.Paginat
 	return toDomainChecks(checks), nil
 }
 
+// FindByPlates 获取指定板块内的审核列表
+func (r *checkRepository) FindByPlates(ctx context.Context, plateIds []int64, pagination domain.Pagination) ([]domain.Check, error) {
+	checks, err := r.dao.FindByPlates(ctx, plateIds, pagination)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainChecks(checks), nil
+}
+
 // FindByID 获取审核详情
 func (r *checkRepository) FindByID(ctx context.Context, checkID int64) (domain.Check, error) {
 	check, err := r.dao.FindByID(ctx, checkID)
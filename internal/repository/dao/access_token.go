@@ -0,0 +1,132 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ErrAccessTokenNotFound 表示令牌不存在
+var ErrAccessTokenNotFound = errors.New("access token not found")
+
+type AccessTokenDAO interface {
+	Create(ctx context.Context, token AccessToken) (AccessToken, error)
+	CountActive(ctx context.Context, uid int64) (int64, error)
+	FindByHash(ctx context.Context, hash string) (AccessToken, error)
+	ListByUser(ctx context.Context, uid int64) ([]AccessToken, error)
+	// Revoke 撤销用户的令牌，令牌不存在或不属于该用户时返回 ErrAccessTokenNotFound
+	Revoke(ctx context.Context, uid, id int64) error
+	// RevokeAll 撤销用户全部未撤销的令牌
+	RevokeAll(ctx context.Context, uid int64) error
+	// TouchLastUsed 更新最近使用时间，距上次记录不足 interval 时不更新
+	TouchLastUsed(ctx context.Context, id int64, interval time.Duration) error
+}
+
+type accessTokenDAO struct {
+	db *gorm.DB
+	l  *zap.Logger
+}
+
+// AccessToken 个人访问令牌
+type AccessToken struct {
+	ID         int64  `gorm:"primaryKey;autoIncrement"`
+	UserID     int64  `gorm:"column:user_id;not null;index"`
+	Name       string `gorm:"column:name;type:varchar(64);not null"`
+	TokenHash  string `gorm:"column:token_hash;type:char(64);not null;uniqueIndex"`
+	Prefix     string `gorm:"column:prefix;type:varchar(16);not null"`
+	Scopes     string `gorm:"column:scopes;type:varchar(255);not null"` // 以逗号分隔
+	ExpiresAt  int64  `gorm:"column:expires_at;type:bigint;not null"`
+	LastUsedAt int64  `gorm:"column:last_used_at;type:bigint;not null;default:0"`
+	RevokedAt  int64  `gorm:"column:revoked_at;type:bigint;not null;default:0"`
+	CreatedAt  int64  `gorm:"column:created_at;type:bigint;not null"`
+}
+
+func NewAccessTokenDAO(db *gorm.DB, l *zap.Logger) AccessTokenDAO {
+	return &accessTokenDAO{
+		db: db,
+		l:  l,
+	}
+}
+
+// Create 创建令牌
+func (a *accessTokenDAO) Create(ctx context.Context, token AccessToken) (AccessToken, error) {
+	token.CreatedAt = time.Now().UnixMilli()
+	if err := a.db.WithContext(ctx).Create(&token).Error; err != nil {
+		a.l.Error("创建访问令牌失败", zap.Int64("uid", token.UserID), zap.Error(err))
+		return AccessToken{}, err
+	}
+	return token, nil
+}
+
+// CountActive 统计用户未撤销且未过期的令牌
+func (a *accessTokenDAO) CountActive(ctx context.Context, uid int64) (int64, error) {
+	var count int64
+	if err := a.db.WithContext(ctx).Model(&AccessToken{}).
+		Where("user_id = ? AND revoked_at = 0", uid).
+		Where("expires_at = 0 OR expires_at > ?", time.Now().UnixMilli()).
+		Count(&count).Error; err != nil {
+		a.l.Error("统计访问令牌失败", zap.Int64("uid", uid), zap.Error(err))
+		return 0, err
+	}
+	return count, nil
+}
+
+// FindByHash 根据令牌哈希查找
+func (a *accessTokenDAO) FindByHash(ctx context.Context, hash string) (AccessToken, error) {
+	var token AccessToken
+	if err := a.db.WithContext(ctx).Where("token_hash = ?", hash).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return AccessToken{}, ErrAccessTokenNotFound
+		}
+		a.l.Error("查询访问令牌失败", zap.Error(err))
+		return AccessToken{}, err
+	}
+	return token, nil
+}
+
+// ListByUser 按创建时间倒序获取用户的令牌
+func (a *accessTokenDAO) ListByUser(ctx context.Context, uid int64) ([]AccessToken, error) {
+	var tokens []AccessToken
+	if err := a.db.WithContext(ctx).Where("user_id = ?", uid).Order("id DESC").Find(&tokens).Error; err != nil {
+		a.l.Error("获取访问令牌失败", zap.Int64("uid", uid), zap.Error(err))
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// Revoke 撤销令牌
+func (a *accessTokenDAO) Revoke(ctx context.Context, uid, id int64) error {
+	result := a.db.WithContext(ctx).Model(&AccessToken{}).
+		Where("id = ? AND user_id = ? AND revoked_at = 0", id, uid).
+		Update("revoked_at", time.Now().UnixMilli())
+	if result.Error != nil {
+		a.l.Error("撤销访问令牌失败", zap.Int64("id", id), zap.Error(result.Error))
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrAccessTokenNotFound
+	}
+	return nil
+}
+
+// RevokeAll 撤销用户的全部令牌
+func (a *accessTokenDAO) RevokeAll(ctx context.Context, uid int64) error {
+	if err := a.db.WithContext(ctx).Model(&AccessToken{}).
+		Where("user_id = ? AND revoked_at = 0", uid).
+		Update("revoked_at", time.Now().UnixMilli()).Error; err != nil {
+		a.l.Error("撤销全部访问令牌失败", zap.Int64("uid", uid), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// TouchLastUsed 更新最近使用时间，按间隔节流以减少写入
+func (a *accessTokenDAO) TouchLastUsed(ctx context.Context, id int64, interval time.Duration) error {
+	now := time.Now()
+	return a.db.WithContext(ctx).Model(&AccessToken{}).
+		Where("id = ? AND last_used_at < ?", id, now.Add(-interval).UnixMilli()).
+		Update("last_used_at", now.UnixMilli()).Error
+}
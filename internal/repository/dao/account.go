@@ -0,0 +1,496 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrDeletionNotFound 表示没有处于冷静期的注销申请
+	ErrDeletionNotFound = errors.New("没有待处理的注销申请")
+	// ErrExportNotFound 表示没有对应的数据导出任务
+	ErrExportNotFound = errors.New("数据导出任务不存在")
+)
+
+// deletedCommentContent 删除模式下评论保留楼层，仅清空内容
+const deletedCommentContent = "该评论已随账号注销删除"
+
+type AccountDAO interface {
+	// UpsertDeletion 创建或重新发起注销申请
+	UpsertDeletion(ctx context.Context, uid int64, scheduledAt int64) error
+	FindDeletion(ctx context.Context, uid int64) (AccountDeletion, error)
+	// CancelDeletion 撤销冷静期内的注销申请，没有待处理申请时返回 ErrDeletionNotFound
+	CancelDeletion(ctx context.Context, uid int64) error
+	ListDueDeletions(ctx context.Context, now int64, limit int) ([]AccountDeletion, error)
+	// PurgeUser 清理用户数据并完成注销申请，removeContent 为 true 时删除帖子并清空评论内容，否则仅匿名化账号
+	PurgeUser(ctx context.Context, uid int64, removeContent bool) (PurgedContent, error)
+	// CollectUserData 汇总导出所需的个人数据
+	CollectUserData(ctx context.Context, uid int64) (UserData, error)
+
+	CreateExport(ctx context.Context, uid int64) (DataExport, error)
+	FindLatestExport(ctx context.Context, uid int64) (DataExport, error)
+	ListExportsByStatus(ctx context.Context, status uint8, limit int) ([]DataExport, error)
+	// ClaimExport 将排队中的任务标记为生成中，任务已被其他实例领取时返回 false
+	ClaimExport(ctx context.Context, id int64) (bool, error)
+	UpdateExport(ctx context.Context, export DataExport) error
+	ListExpiredExports(ctx context.Context, now int64, limit int) ([]DataExport, error)
+}
+
+type accountDAO struct {
+	db *gorm.DB
+	l  *zap.Logger
+}
+
+// AccountDeletion 账号注销申请，每个用户保留一条记录
+type AccountDeletion struct {
+	ID          int64 `gorm:"primaryKey;autoIncrement"`
+	UserID      int64 `gorm:"column:user_id;not null;uniqueIndex"`
+	Status      uint8 `gorm:"column:status;not null;index:status_scheduled_at"`
+	RequestedAt int64 `gorm:"column:requested_at;type:bigint;not null"`
+	ScheduledAt int64 `gorm:"column:scheduled_at;type:bigint;not null;index:status_scheduled_at"` // 冷静期结束时间
+	CompletedAt int64 `gorm:"column:completed_at;type:bigint;not null;default:0"`
+	UpdatedAt   int64 `gorm:"column:updated_at;type:bigint;not null"`
+}
+
+// DataExport 个人数据导出任务
+type DataExport struct {
+	ID         int64  `gorm:"primaryKey;autoIncrement"`
+	UserID     int64  `gorm:"column:user_id;not null;index"`
+	Status     uint8  `gorm:"column:status;not null;index"`
+	FilePath   string `gorm:"column:file_path;type:varchar(255)"`
+	CreatedAt  int64  `gorm:"column:created_at;type:bigint;not null"`
+	FinishedAt int64  `gorm:"column:finished_at;type:bigint;not null;default:0"`
+	ExpiresAt  int64  `gorm:"column:expires_at;type:bigint;not null;default:0;index"`
+}
+
+// PurgedContent 注销时被删除的内容，用于同步清理搜索索引
+type PurgedContent struct {
+	PostIDs    []uint
+	CommentIDs []int64
+}
+
+// UserData 用户数据导出的原始记录
+type UserData struct {
+	User        User
+	Posts       []Post
+	Comments    []Comment
+	Likes       []uint
+	Collections []uint
+	Followees   []int64
+	Followers   []int64
+}
+
+func NewAccountDAO(db *gorm.DB, l *zap.Logger) AccountDAO {
+	return &accountDAO{
+		db: db,
+		l:  l,
+	}
+}
+
+// UpsertDeletion 创建注销申请，已撤销或已完成的旧申请会被覆盖
+func (a *accountDAO) UpsertDeletion(ctx context.Context, uid int64, scheduledAt int64) error {
+	now := time.Now().UnixMilli()
+	if err := a.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"status":       uint8(domain.DeletionPending),
+			"requested_at": now,
+			"scheduled_at": scheduledAt,
+			"completed_at": 0,
+			"updated_at":   now,
+		}),
+	}).Create(&AccountDeletion{
+		UserID:      uid,
+		Status:      uint8(domain.DeletionPending),
+		RequestedAt: now,
+		ScheduledAt: scheduledAt,
+		UpdatedAt:   now,
+	}).Error; err != nil {
+		a.l.Error("创建注销申请失败", zap.Int64("uid", uid), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// FindDeletion 获取用户的注销申请
+func (a *accountDAO) FindDeletion(ctx context.Context, uid int64) (AccountDeletion, error) {
+	var d AccountDeletion
+	if err := a.db.WithContext(ctx).Where("user_id = ?", uid).First(&d).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return AccountDeletion{}, ErrDeletionNotFound
+		}
+		a.l.Error("获取注销申请失败", zap.Error(err))
+		return AccountDeletion{}, err
+	}
+	return d, nil
+}
+
+// CancelDeletion 撤销注销申请
+func (a *accountDAO) CancelDeletion(ctx context.Context, uid int64) error {
+	result := a.db.WithContext(ctx).Model(&AccountDeletion{}).
+		Where("user_id = ? AND status = ?", uid, uint8(domain.DeletionPending)).
+		Updates(map[string]interface{}{
+			"status":     uint8(domain.DeletionCancelled),
+			"updated_at": time.Now().UnixMilli(),
+		})
+	if result.Error != nil {
+		a.l.Error("撤销注销申请失败", zap.Int64("uid", uid), zap.Error(result.Error))
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrDeletionNotFound
+	}
+	return nil
+}
+
+// ListDueDeletions 获取冷静期已结束的注销申请
+func (a *accountDAO) ListDueDeletions(ctx context.Context, now int64, limit int) ([]AccountDeletion, error) {
+	var list []AccountDeletion
+	if err := a.db.WithContext(ctx).
+		Where("status = ? AND scheduled_at <= ?", uint8(domain.DeletionPending), now).
+		Order("scheduled_at ASC").
+		Limit(limit).
+		Find(&list).Error; err != nil {
+		a.l.Error("获取到期注销申请失败", zap.Error(err))
+		return nil, err
+	}
+	return list, nil
+}
+
+// PurgeUser 在一个事务内清理用户数据
+func (a *accountDAO) PurgeUser(ctx context.Context, uid int64, removeContent bool) (PurgedContent, error) {
+	var purged PurgedContent
+	now := time.Now().UnixMilli()
+
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 申请可能在领取后被撤销，以事务内的状态为准
+		result := tx.Model(&AccountDeletion{}).
+			Where("user_id = ? AND status = ?", uid, uint8(domain.DeletionPending)).
+			Updates(map[string]interface{}{
+				"status":       uint8(domain.DeletionCompleted),
+				"completed_at": now,
+				"updated_at":   now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrDeletionNotFound
+		}
+
+		if removeContent {
+			if err := a.removeContent(tx, uid, &purged); err != nil {
+				return err
+			}
+		}
+		if err := a.removeInteractions(tx, uid, now); err != nil {
+			return err
+		}
+		if err := a.removeRelations(tx, uid, now); err != nil {
+			return err
+		}
+		if err := a.removeMessages(tx, uid, now); err != nil {
+			return err
+		}
+		if err := a.removeAccountData(tx, uid); err != nil {
+			return err
+		}
+
+		// 账号本身只做匿名化，保留ID以免历史内容的作者引用失效
+		if err := tx.Model(&Profile{}).Where("user_id = ?", uid).Updates(map[string]interface{}{
+			"real_name":      "",
+			"avatar":         "",
+			"about":          "",
+			"birthday":       "",
+			"email":          "",
+			"email_verified": false,
+			"verified_email": nil,
+			"phone":          nil,
+			"is_private":     false,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&User{}).Where("id = ?", uid).Updates(map[string]interface{}{
+			"username":      fmt.Sprintf("deleted_%d", uid),
+			"password_hash": "",
+			"deleted":       true,
+			"deleted_at":    now,
+			"updated_at":    now,
+		}).Error
+	})
+	if err != nil {
+		if !errors.Is(err, ErrDeletionNotFound) {
+			a.l.Error("清理注销用户数据失败", zap.Int64("uid", uid), zap.Error(err))
+		}
+		return PurgedContent{}, err
+	}
+	return purged, nil
+}
+
+// removeContent 删除帖子，评论保留楼层只清空内容
+func (a *accountDAO) removeContent(tx *gorm.DB, uid int64, purged *PurgedContent) error {
+	var postIDs []uint
+	if err := tx.Model(&Post{}).Where("uid = ?", uid).Pluck("id", &postIDs).Error; err != nil {
+		return err
+	}
+	var pubIDs []uint
+	if err := tx.Model(&PubPost{}).Where("uid = ?", uid).Pluck("id", &pubIDs).Error; err != nil {
+		return err
+	}
+	purged.PostIDs = mergeUintIDs(postIDs, pubIDs)
+
+	if err := tx.Where("uid = ?", uid).Delete(&Post{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("uid = ?", uid).Delete(&PubPost{}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Model(&Comment{}).Where("user_id = ?", uid).Pluck("id", &purged.CommentIDs).Error; err != nil {
+		return err
+	}
+	return tx.Model(&Comment{}).Where("user_id = ?", uid).
+		Update("content", deletedCommentContent).Error
+}
+
+// removeInteractions 删除点赞与收藏记录，并扣减对应帖子的计数
+func (a *accountDAO) removeInteractions(tx *gorm.DB, uid int64, now int64) error {
+	liked := tx.Model(&UserLikeBiz{}).Select("biz_id").Where("uid = ? AND status = ?", uid, StatusLiked)
+	if err := tx.Model(&Interactive{}).
+		Where("biz_id IN (?) AND like_count > 0", liked).
+		Updates(map[string]interface{}{
+			"like_count": gorm.Expr("like_count - 1"),
+			"updated_at": now,
+		}).Error; err != nil {
+		return err
+	}
+
+	collected := tx.Model(&UserCollectionBiz{}).Select("biz_id").Where("uid = ? AND status = ?", uid, StatusCollection)
+	if err := tx.Model(&Interactive{}).
+		Where("biz_id IN (?) AND collect_count > 0", collected).
+		Updates(map[string]interface{}{
+			"collect_count": gorm.Expr("collect_count - 1"),
+			"updated_at":    now,
+		}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("uid = ?", uid).Delete(&UserLikeBiz{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("uid = ?", uid).Delete(&UserCollectionBiz{}).Error
+}
+
+// removeRelations 删除关注关系与拉黑记录，并修正对方的计数
+func (a *accountDAO) removeRelations(tx *gorm.DB, uid int64, now int64) error {
+	followees := tx.Model(&Relation{}).Select("followee_id").Where("follower_id = ? AND status = ?", uid, FollowStatus)
+	if err := tx.Model(&RelationCount{}).
+		Where("user_id IN (?) AND follower_count > 0", followees).
+		Updates(map[string]interface{}{
+			"follower_count": gorm.Expr("follower_count - 1"),
+			"updated_at":     now,
+		}).Error; err != nil {
+		return err
+	}
+
+	followers := tx.Model(&Relation{}).Select("follower_id").Where("followee_id = ? AND status = ?", uid, FollowStatus)
+	if err := tx.Model(&RelationCount{}).
+		Where("user_id IN (?) AND followee_count > 0", followers).
+		Updates(map[string]interface{}{
+			"followee_count": gorm.Expr("followee_count - 1"),
+			"updated_at":     now,
+		}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("follower_id = ? OR followee_id = ?", uid, uid).Delete(&Relation{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("user_id = ?", uid).Delete(&RelationCount{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("user_id = ? OR target_id = ?", uid, uid).Delete(&UserBlock{}).Error
+}
+
+// removeMessages 清空用户发出的私信内容并删除其会话成员记录，对方保留会话但未读数按剩余消息重新计算
+func (a *accountDAO) removeMessages(tx *gorm.DB, uid int64, now int64) error {
+	if err := tx.Model(&Message{}).Where("sender_id = ?", uid).Updates(map[string]interface{}{
+		"content":    "",
+		"status":     MessageRecalled,
+		"updated_at": now,
+	}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("user_id = ?", uid).Delete(&ConversationMember{}).Error; err != nil {
+		return err
+	}
+	unread := tx.Model(&Message{}).Select("COUNT(*)").
+		Where("messages.conversation_id = conversation_members.conversation_id AND messages.receiver_id = conversation_members.user_id").
+		Where("messages.id > conversation_members.last_read_msg_id AND messages.status = ?", MessageNormal)
+	return tx.Model(&ConversationMember{}).Where("peer_id = ?", uid).Updates(map[string]interface{}{
+		"unread_count": unread,
+		"updated_at":   now,
+	}).Error
+}
+
+// removeAccountData 删除登录方式、订阅、偏好设置、积分与勋章，并停用邀请码
+func (a *accountDAO) removeAccountData(tx *gorm.DB, uid int64) error {
+	for _, model := range []interface{}{
+		&UserIdentity{},
+		&UserTwoFactor{},
+		&RecoveryCode{},
+		&NotificationPreference{},
+		&NotificationSetting{},
+		&ReputationLog{},
+		&UserReputation{},
+		&UserBadge{},
+		&UserActiveDay{},
+		&AccessToken{},
+		&SecurityEvent{},
+		&LoginDevice{},
+	} {
+		if err := tx.Where("user_id = ?", uid).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	// 邀请关系保留以便追溯，未用完的邀请码随账号停用
+	if err := tx.Model(&InviteCode{}).Where("creator_id = ?", uid).Update("disabled", true).Error; err != nil {
+		return err
+	}
+	return tx.Where("uid = ?", uid).Delete(&PlateSubscription{}).Error
+}
+
+// CollectUserData 读取用户的资料、内容与互动记录
+func (a *accountDAO) CollectUserData(ctx context.Context, uid int64) (UserData, error) {
+	var data UserData
+	db := a.db.WithContext(ctx)
+
+	if err := db.Preload("Profile").Where("id = ? AND deleted = ?", uid, false).First(&data.User).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return UserData{}, ErrUserNotFound
+		}
+		return UserData{}, err
+	}
+	if err := db.Where("uid = ?", uid).Order("id ASC").Find(&data.Posts).Error; err != nil {
+		return UserData{}, err
+	}
+	if err := db.Where("user_id = ?", uid).Order("id ASC").Find(&data.Comments).Error; err != nil {
+		return UserData{}, err
+	}
+	if err := db.Model(&UserLikeBiz{}).Where("uid = ? AND status = ?", uid, StatusLiked).
+		Order("updated_at DESC").Pluck("biz_id", &data.Likes).Error; err != nil {
+		return UserData{}, err
+	}
+	if err := db.Model(&UserCollectionBiz{}).Where("uid = ? AND status = ?", uid, StatusCollection).
+		Order("updated_at DESC").Pluck("biz_id", &data.Collections).Error; err != nil {
+		return UserData{}, err
+	}
+	if err := db.Model(&Relation{}).Where("follower_id = ? AND status = ?", uid, FollowStatus).
+		Pluck("followee_id", &data.Followees).Error; err != nil {
+		return UserData{}, err
+	}
+	if err := db.Model(&Relation{}).Where("followee_id = ? AND status = ?", uid, FollowStatus).
+		Pluck("follower_id", &data.Followers).Error; err != nil {
+		return UserData{}, err
+	}
+	return data, nil
+}
+
+// CreateExport 创建排队中的导出任务
+func (a *accountDAO) CreateExport(ctx context.Context, uid int64) (DataExport, error) {
+	export := DataExport{
+		UserID:    uid,
+		Status:    uint8(domain.ExportPending),
+		CreatedAt: time.Now().UnixMilli(),
+	}
+	if err := a.db.WithContext(ctx).Create(&export).Error; err != nil {
+		a.l.Error("创建数据导出任务失败", zap.Int64("uid", uid), zap.Error(err))
+		return DataExport{}, err
+	}
+	return export, nil
+}
+
+// FindLatestExport 获取用户最近一次导出任务
+func (a *accountDAO) FindLatestExport(ctx context.Context, uid int64) (DataExport, error) {
+	var export DataExport
+	if err := a.db.WithContext(ctx).Where("user_id = ?", uid).Order("id DESC").First(&export).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return DataExport{}, ErrExportNotFound
+		}
+		a.l.Error("获取数据导出任务失败", zap.Error(err))
+		return DataExport{}, err
+	}
+	return export, nil
+}
+
+// ListExportsByStatus 按创建顺序获取指定状态的导出任务
+func (a *accountDAO) ListExportsByStatus(ctx context.Context, status uint8, limit int) ([]DataExport, error) {
+	var list []DataExport
+	if err := a.db.WithContext(ctx).Where("status = ?", status).Order("id ASC").Limit(limit).Find(&list).Error; err != nil {
+		a.l.Error("获取数据导出任务失败", zap.Error(err))
+		return nil, err
+	}
+	return list, nil
+}
+
+// ClaimExport 领取导出任务
+func (a *accountDAO) ClaimExport(ctx context.Context, id int64) (bool, error) {
+	result := a.db.WithContext(ctx).Model(&DataExport{}).
+		Where("id = ? AND status = ?", id, uint8(domain.ExportPending)).
+		Update("status", uint8(domain.ExportRunning))
+	if result.Error != nil {
+		a.l.Error("领取数据导出任务失败", zap.Int64("id", id), zap.Error(result.Error))
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateExport 更新导出任务的状态与文件信息
+func (a *accountDAO) UpdateExport(ctx context.Context, export DataExport) error {
+	if err := a.db.WithContext(ctx).Model(&DataExport{}).Where("id = ?", export.ID).Updates(map[string]interface{}{
+		"status":      export.Status,
+		"file_path":   export.FilePath,
+		"finished_at": export.FinishedAt,
+		"expires_at":  export.ExpiresAt,
+	}).Error; err != nil {
+		a.l.Error("更新数据导出任务失败", zap.Int64("id", export.ID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// ListExpiredExports 获取文件已过期的导出任务
+func (a *accountDAO) ListExpiredExports(ctx context.Context, now int64, limit int) ([]DataExport, error) {
+	var list []DataExport
+	if err := a.db.WithContext(ctx).
+		Where("status = ? AND expires_at <= ?", uint8(domain.ExportReady), now).
+		Limit(limit).
+		Find(&list).Error; err != nil {
+		a.l.Error("获取过期数据导出任务失败", zap.Error(err))
+		return nil, err
+	}
+	return list, nil
+}
+
+// mergeUintIDs 合并两组ID并去重
+func mergeUintIDs(a, b []uint) []uint {
+	seen := make(map[uint]struct{}, len(a)+len(b))
+	ids := make([]uint, 0, len(a)+len(b))
+	for _, list := range [][]uint{a, b} {
+		for _, id := range list {
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,58 @@
+package dao
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestRemoveMessages(t *testing.T) {
+	const a, b = 1, 2
+	db := newTestDB(t, &Conversation{}, &ConversationMember{}, &Message{})
+	im := NewIMDAO(db, zap.NewNop())
+	ctx := context.Background()
+
+	conv, err := im.GetOrCreateConversation(ctx, Conversation{ID: 100, UserA: a, UserB: b})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, msg := range []Message{
+		{ID: 1, SenderID: b, ReceiverID: a},
+		{ID: 2, SenderID: a, ReceiverID: b},
+		{ID: 3, SenderID: a, ReceiverID: b},
+	} {
+		msg.ConversationID, msg.Content = conv.ID, "secret"
+		if err := im.InsertMessage(ctx, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if n, err := im.TotalUnread(ctx, b); err != nil || n != 2 {
+		t.Fatalf("peer unread before purge = %d, %v, want 2", n, err)
+	}
+
+	// A 注销后发出的私信被清空，B 的未读数随之归零
+	ad := &accountDAO{db: db, l: zap.NewNop()}
+	if err := ad.removeMessages(db, a, 1); err != nil {
+		t.Fatal(err)
+	}
+	var sent []Message
+	if err := db.Where("sender_id = ?", a).Find(&sent).Error; err != nil {
+		t.Fatal(err)
+	}
+	for _, msg := range sent {
+		if msg.Content != "" || msg.Status != MessageRecalled {
+			t.Fatalf("message %d = %q/%d, want cleared and recalled", msg.ID, msg.Content, msg.Status)
+		}
+	}
+	if _, err := im.GetMember(ctx, conv.ID, a); err == nil {
+		t.Fatal("member record of the deleted user should be removed")
+	}
+	if n, err := im.TotalUnread(ctx, b); err != nil || n != 0 {
+		t.Fatalf("peer unread = %d, %v, want 0", n, err)
+	}
+	if msg, err := im.GetMessage(ctx, 1); err != nil || msg.Content != "secret" {
+		t.Fatalf("message received by the deleted user = %q, %v, want kept", msg.Content, err)
+	}
+}
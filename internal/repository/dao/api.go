@@ -7,28 +7,37 @@ import (
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Api struct {
-	ID          int    `json:"id" gorm:"primaryKey;column:id;comment:主键ID"`
-	Name        string `json:"name" gorm:"column:name;type:varchar(50);not null;comment:API名称"`
-	Path        string `json:"path" gorm:"column:path;type:varchar(255);not null;comment:API路径"`
-	Method      int    `json:"method" gorm:"column:method;type:tinyint(1);not null;comment:HTTP请求方法(1:GET,2:POST,3:PUT,4:DELETE)"`
-	Description string `json:"description" gorm:"column:description;type:varchar(500);comment:API描述"`
-	Version     string `json:"version" gorm:"column:version;type:varchar(20);default:v1;comment:API版本"`
-	Category    int    `json:"category" gorm:"column:category;type:tinyint(1);not null;comment:API分类(1:系统,2:业务)"`
-	IsPublic    int    `json:"is_public" gorm:"column:is_public;type:tinyint(1);default:0;comment:是否公开(0:否,1:是)"`
-	CreateTime  int64  `json:"create_time" gorm:"column:create_time;autoCreateTime;comment:创建时间"`
-	UpdateTime  int64  `json:"update_time" gorm:"column:update_time;autoUpdateTime;comment:更新时间"`
-	IsDeleted   int    `json:"is_deleted" gorm:"column:is_deleted;type:tinyint(1);default:0;comment:是否删除(0:否,1:是)"`
+	ID          int     `json:"id" gorm:"primaryKey;column:id;comment:主键ID"`
+	Name        string  `json:"name" gorm:"column:name;type:varchar(50);not null;comment:API名称"`
+	Path        string  `json:"path" gorm:"column:path;type:varchar(255);not null;comment:API路径"`
+	Method      int     `json:"method" gorm:"column:method;type:tinyint(1);not null;comment:HTTP请求方法(1:GET,2:POST,3:PUT,4:DELETE)"`
+	Description string  `json:"description" gorm:"column:description;type:varchar(500);comment:API描述"`
+	Version     string  `json:"version" gorm:"column:version;type:varchar(20);default:v1;comment:API版本"`
+	Category    int     `json:"category" gorm:"column:category;type:tinyint(1);not null;comment:API分类(1:系统,2:业务)"`
+	IsPublic    int     `json:"is_public" gorm:"column:is_public;type:tinyint(1);default:0;comment:是否公开(0:否,1:是)"`
+	RouteKey    *string `json:"route_key" gorm:"column:route_key;type:varchar(191);uniqueIndex;comment:路由标识(方法 路径),手动创建的API为空"`
+	IsStale     int     `json:"is_stale" gorm:"column:is_stale;type:tinyint(1);default:0;comment:路由是否已不存在(0:否,1:是)"`
+	CreateTime  int64   `json:"create_time" gorm:"column:create_time;autoCreateTime;comment:创建时间"`
+	UpdateTime  int64   `json:"update_time" gorm:"column:update_time;autoUpdateTime;comment:更新时间"`
+	IsDeleted   int     `json:"is_deleted" gorm:"column:is_deleted;type:tinyint(1);default:0;comment:是否删除(0:否,1:是)"`
 }
 
 type ApiDAO interface {
-	CreateApi(ctx context.Context, api *Api) error
+	CreateApi(ctx context.Context, api *Api) (int, error)
 	GetApiById(ctx context.Context, id int) (*Api, error)
 	UpdateApi(ctx context.Context, api *Api) error
 	DeleteApi(ctx context.Context, id int) error
 	ListApis(ctx context.Context, page, pageSize int) ([]*Api, int, error)
+	// SyncRoute 按路由标识写入API记录，返回是否新建
+	SyncRoute(ctx context.Context, api *Api) (bool, error)
+	// MarkStaleRoutes 将不在 keys 中的已同步路由标记为失效
+	MarkStaleRoutes(ctx context.Context, keys []string) (int64, error)
+	// ListRouteApis 获取有效的已同步路由
+	ListRouteApis(ctx context.Context) ([]*Api, error)
 }
 
 type apiDAO struct {
@@ -44,15 +53,18 @@ func NewApiDAO(db *gorm.DB, l *zap.Logger) ApiDAO {
 }
 
 // CreateApi 创建新的API记录
-func (a *apiDAO) CreateApi(ctx context.Context, api *Api) error {
+func (a *apiDAO) CreateApi(ctx context.Context, api *Api) (int, error) {
 	if api == nil {
-		return gorm.ErrRecordNotFound
+		return 0, gorm.ErrRecordNotFound
 	}
 
 	api.CreateTime = time.Now().Unix()
 	api.UpdateTime = time.Now().Unix()
 
-	return a.db.WithContext(ctx).Create(api).Error
+	if err := a.db.WithContext(ctx).Create(api).Error; err != nil {
+		return 0, err
+	}
+	return api.ID, nil
 }
 
 // GetApiById 根据ID获取API记录
@@ -125,3 +137,77 @@ func (a *apiDAO) ListApis(ctx context.Context, page, pageSize int) ([]*Api, int,
 
 	return apis, int(total), nil
 }
+
+// SyncRoute 按路由标识写入API记录
+// 已同步的路由只清除失效标记，保留人工维护的名称与描述；路径和方法相同的手动记录会被认领，保持ID不变
+func (a *apiDAO) SyncRoute(ctx context.Context, api *Api) (bool, error) {
+	now := time.Now().Unix()
+	db := a.db.WithContext(ctx)
+
+	var count int64
+	if err := db.Model(&Api{}).Where("route_key = ?", *api.RouteKey).Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return false, db.Model(&Api{}).
+			Where("route_key = ? AND is_stale = 1", *api.RouteKey).
+			Updates(map[string]interface{}{
+				"is_stale":    0,
+				"update_time": now,
+			}).Error
+	}
+
+	// 手动编辑过的API路径会带有 api: 前缀
+	result := db.Model(&Api{}).
+		Where("route_key IS NULL AND method = ? AND path IN ? AND is_deleted = 0", api.Method, []string{api.Path, "api:" + api.Path}).
+		Limit(1).
+		Updates(map[string]interface{}{
+			"route_key":   *api.RouteKey,
+			"path":        api.Path,
+			"update_time": now,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return false, nil
+	}
+
+	api.CreateTime = now
+	api.UpdateTime = now
+	// 多个实例同时启动时以先写入的为准
+	result = db.Clauses(clause.OnConflict{DoNothing: true}).Create(api)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// MarkStaleRoutes 标记已不存在的路由，keys 为空时不做处理，避免误将全部路由标记为失效
+func (a *apiDAO) MarkStaleRoutes(ctx context.Context, keys []string) (int64, error) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	result := a.db.WithContext(ctx).Model(&Api{}).
+		Where("route_key IS NOT NULL AND route_key NOT IN ? AND is_stale = 0", keys).
+		Updates(map[string]interface{}{
+			"is_stale":    1,
+			"update_time": time.Now().Unix(),
+		})
+	return result.RowsAffected, result.Error
+}
+
+// ListRouteApis 获取未失效且未删除的已同步路由
+func (a *apiDAO) ListRouteApis(ctx context.Context) ([]*Api, error) {
+	var apis []*Api
+
+	if err := a.db.WithContext(ctx).
+		Where("route_key IS NOT NULL AND is_stale = 0 AND is_deleted = 0").
+		Order("id ASC").
+		Find(&apis).Error; err != nil {
+		return nil, err
+	}
+
+	return apis, nil
+}
@@ -0,0 +1,103 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type AuditLogDAO interface {
+	Create(ctx context.Context, log AuditLog) error
+	// List 按时间倒序获取审计记录，并返回符合条件的总数
+	List(ctx context.Context, filter domain.AuditFilter, offset, limit int) ([]AuditLog, int64, error)
+	// DeleteBefore 删除 before 之前的记录，每次最多删除 limit 条，返回删除数量
+	DeleteBefore(ctx context.Context, before int64, limit int) (int64, error)
+}
+
+type auditLogDAO struct {
+	db *gorm.DB
+	l  *zap.Logger
+}
+
+// AuditLog 管理员与版主操作的审计记录
+type AuditLog struct {
+	ID         int64  `gorm:"primaryKey;autoIncrement"`
+	ActorID    int64  `gorm:"column:actor_id;not null;index"`
+	Action     string `gorm:"column:action;type:varchar(64);not null;index"`
+	TargetType string `gorm:"column:target_type;type:varchar(32);not null;index:idx_audit_target"`
+	TargetID   string `gorm:"column:target_id;type:varchar(128);not null;index:idx_audit_target"`
+	Before     string `gorm:"column:before_data;type:text"`
+	After      string `gorm:"column:after_data;type:text"`
+	IP         string `gorm:"column:ip;type:varchar(64);not null"`
+	RequestID  string `gorm:"column:request_id;type:varchar(64);not null"`
+	CreatedAt  int64  `gorm:"column:created_at;type:bigint;not null;index"`
+}
+
+func NewAuditLogDAO(db *gorm.DB, l *zap.Logger) AuditLogDAO {
+	return &auditLogDAO{
+		db: db,
+		l:  l,
+	}
+}
+
+// Create 写入审计记录
+func (a *auditLogDAO) Create(ctx context.Context, log AuditLog) error {
+	log.CreatedAt = time.Now().UnixMilli()
+	if err := a.db.WithContext(ctx).Create(&log).Error; err != nil {
+		a.l.Error("写入审计记录失败", zap.String("action", log.Action), zap.Int64("actor_id", log.ActorID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// List 获取审计记录
+func (a *auditLogDAO) List(ctx context.Context, filter domain.AuditFilter, offset, limit int) ([]AuditLog, int64, error) {
+	query := a.db.WithContext(ctx).Model(&AuditLog{})
+	if filter.ActorID > 0 {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.TargetType != "" {
+		query = query.Where("target_type = ?", filter.TargetType)
+	}
+	if filter.TargetID != "" {
+		query = query.Where("target_id = ?", filter.TargetID)
+	}
+	if filter.StartTime > 0 {
+		query = query.Where("created_at >= ?", filter.StartTime)
+	}
+	if filter.EndTime > 0 {
+		query = query.Where("created_at < ?", filter.EndTime)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		a.l.Error("统计审计记录失败", zap.Error(err))
+		return nil, 0, err
+	}
+
+	var logs []AuditLog
+	if err := query.Order("id DESC").Offset(offset).Limit(limit).Find(&logs).Error; err != nil {
+		a.l.Error("获取审计记录失败", zap.Error(err))
+		return nil, 0, err
+	}
+	return logs, total, nil
+}
+
+// DeleteBefore 分批删除过期记录，避免长时间锁表
+func (a *auditLogDAO) DeleteBefore(ctx context.Context, before int64, limit int) (int64, error) {
+	result := a.db.WithContext(ctx).
+		Where("created_at < ?", before).
+		Limit(limit).
+		Delete(&AuditLog{})
+	if result.Error != nil {
+		a.l.Error("清理审计记录失败", zap.Error(result.Error))
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,271 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BadgeDAO interface {
+	CountBadges(ctx context.Context) (int64, error)
+	// CreateBadges 批量写入勋章，名称已存在的跳过
+	CreateBadges(ctx context.Context, badges []Badge) error
+	CreateBadge(ctx context.Context, badge Badge) (int64, error)
+	UpdateBadge(ctx context.Context, badge Badge) error
+	ListBadges(ctx context.Context, onlyEnabled bool) ([]Badge, error)
+	// Award 授予勋章，已获得时返回 false
+	Award(ctx context.Context, uid, badgeId int64) (bool, error)
+	ListUserBadges(ctx context.Context, uid int64) ([]UserBadgeRow, error)
+	// RecordActiveDay 记录用户某天活跃，重复记录时忽略
+	RecordActiveDay(ctx context.Context, uid int64, day string) error
+	ListActiveDays(ctx context.Context, uid int64) ([]string, error)
+	CountApprovedPosts(ctx context.Context, uid int64) (int64, error)
+	SumLikesReceived(ctx context.Context, uid int64) (int64, error)
+	CountLotteryParticipations(ctx context.Context, uid int64) (int64, error)
+	// ListUserIDs 按ID升序分批获取未注销的用户
+	ListUserIDs(ctx context.Context, afterId int64, limit int) ([]int64, error)
+	// BackfillActiveDays 根据历史发帖、评论、点赞与抽奖记录补全活跃日期
+	BackfillActiveDays(ctx context.Context) error
+}
+
+type badgeDAO struct {
+	db *gorm.DB
+	l  *zap.Logger
+}
+
+// Badge 勋章定义
+type Badge struct {
+	ID          int64  `gorm:"primaryKey;autoIncrement"`
+	Name        string `gorm:"column:name;type:varchar(64);not null;uniqueIndex"`
+	Description string `gorm:"column:description;type:varchar(255);not null;default:''"`
+	Icon        string `gorm:"column:icon;type:varchar(512);not null;default:''"`
+	Rule        string `gorm:"column:rule;type:varchar(32);not null;index"`
+	Threshold   int64  `gorm:"column:threshold;not null"`
+	Enabled     bool   `gorm:"column:enabled;not null"`
+	CreatedAt   int64  `gorm:"column:created_at;type:bigint;not null"`
+	UpdatedAt   int64  `gorm:"column:updated_at;type:bigint;not null"`
+}
+
+// UserBadge 用户获得的勋章，同一勋章只授予一次
+type UserBadge struct {
+	ID        int64 `gorm:"primaryKey;autoIncrement"`
+	UserID    int64 `gorm:"column:user_id;not null;uniqueIndex:uid_badge"`
+	BadgeID   int64 `gorm:"column:badge_id;not null;uniqueIndex:uid_badge"`
+	AwardedAt int64 `gorm:"column:awarded_at;type:bigint;not null"`
+}
+
+// UserActiveDay 用户活跃日期，用于计算连续活跃天数
+type UserActiveDay struct {
+	ID     int64  `gorm:"primaryKey;autoIncrement"`
+	UserID int64  `gorm:"column:user_id;not null;uniqueIndex:uid_day"`
+	Day    string `gorm:"column:day;type:varchar(10);not null;uniqueIndex:uid_day"`
+}
+
+// UserBadgeRow 用户勋章与勋章定义的联表结果
+type UserBadgeRow struct {
+	BadgeID     int64
+	Name        string
+	Description string
+	Icon        string
+	AwardedAt   int64
+}
+
+func NewBadgeDAO(db *gorm.DB, l *zap.Logger) BadgeDAO {
+	return &badgeDAO{
+		db: db,
+		l:  l,
+	}
+}
+
+// CountBadges 统计勋章数量
+func (b *badgeDAO) CountBadges(ctx context.Context) (int64, error) {
+	var count int64
+	if err := b.db.WithContext(ctx).Model(&Badge{}).Count(&count).Error; err != nil {
+		b.l.Error("统计勋章数量失败", zap.Error(err))
+		return 0, err
+	}
+	return count, nil
+}
+
+// CreateBadges 批量写入勋章
+func (b *badgeDAO) CreateBadges(ctx context.Context, badges []Badge) error {
+	now := time.Now().UnixMilli()
+	for i := range badges {
+		badges[i].CreatedAt = now
+		badges[i].UpdatedAt = now
+	}
+	if err := b.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&badges).Error; err != nil {
+		b.l.Error("写入勋章失败", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// CreateBadge 创建勋章
+func (b *badgeDAO) CreateBadge(ctx context.Context, badge Badge) (int64, error) {
+	now := time.Now().UnixMilli()
+	badge.CreatedAt = now
+	badge.UpdatedAt = now
+	if err := b.db.WithContext(ctx).Create(&badge).Error; err != nil {
+		b.l.Error("创建勋章失败", zap.String("name", badge.Name), zap.Error(err))
+		return 0, err
+	}
+	return badge.ID, nil
+}
+
+// UpdateBadge 更新勋章定义
+func (b *badgeDAO) UpdateBadge(ctx context.Context, badge Badge) error {
+	result := b.db.WithContext(ctx).Model(&Badge{}).Where("id = ?", badge.ID).Updates(map[string]interface{}{
+		"name":        badge.Name,
+		"description": badge.Description,
+		"icon":        badge.Icon,
+		"rule":        badge.Rule,
+		"threshold":   badge.Threshold,
+		"enabled":     badge.Enabled,
+		"updated_at":  time.Now().UnixMilli(),
+	})
+	if result.Error != nil {
+		b.l.Error("更新勋章失败", zap.Int64("id", badge.ID), zap.Error(result.Error))
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ListBadges 获取勋章定义
+func (b *badgeDAO) ListBadges(ctx context.Context, onlyEnabled bool) ([]Badge, error) {
+	var badges []Badge
+	query := b.db.WithContext(ctx).Order("id ASC")
+	if onlyEnabled {
+		query = query.Where("enabled = ?", true)
+	}
+	if err := query.Find(&badges).Error; err != nil {
+		b.l.Error("获取勋章列表失败", zap.Error(err))
+		return nil, err
+	}
+	return badges, nil
+}
+
+// Award 授予勋章
+func (b *badgeDAO) Award(ctx context.Context, uid, badgeId int64) (bool, error) {
+	result := b.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&UserBadge{
+		UserID:    uid,
+		BadgeID:   badgeId,
+		AwardedAt: time.Now().UnixMilli(),
+	})
+	if result.Error != nil {
+		b.l.Error("授予勋章失败", zap.Int64("uid", uid), zap.Int64("badgeId", badgeId), zap.Error(result.Error))
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ListUserBadges 按获得时间获取用户勋章
+func (b *badgeDAO) ListUserBadges(ctx context.Context, uid int64) ([]UserBadgeRow, error) {
+	var rows []UserBadgeRow
+	if err := b.db.WithContext(ctx).
+		Model(&UserBadge{}).
+		Select("user_badges.badge_id, badges.name, badges.description, badges.icon, user_badges.awarded_at").
+		Joins("JOIN badges ON badges.id = user_badges.badge_id").
+		Where("user_badges.user_id = ?", uid).
+		Order("user_badges.awarded_at ASC").
+		Scan(&rows).Error; err != nil {
+		b.l.Error("获取用户勋章失败", zap.Int64("uid", uid), zap.Error(err))
+		return nil, err
+	}
+	return rows, nil
+}
+
+// RecordActiveDay 记录活跃日期
+func (b *badgeDAO) RecordActiveDay(ctx context.Context, uid int64, day string) error {
+	if err := b.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&UserActiveDay{
+		UserID: uid,
+		Day:    day,
+	}).Error; err != nil {
+		b.l.Error("记录活跃日期失败", zap.Int64("uid", uid), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// ListActiveDays 按日期升序获取活跃日期
+func (b *badgeDAO) ListActiveDays(ctx context.Context, uid int64) ([]string, error) {
+	var days []string
+	if err := b.db.WithContext(ctx).Model(&UserActiveDay{}).Where("user_id = ?", uid).Order("day ASC").Pluck("day", &days).Error; err != nil {
+		b.l.Error("获取活跃日期失败", zap.Int64("uid", uid), zap.Error(err))
+		return nil, err
+	}
+	return days, nil
+}
+
+// CountApprovedPosts 统计审核通过的帖子数，同一帖子多次审核只计一次
+func (b *badgeDAO) CountApprovedPosts(ctx context.Context, uid int64) (int64, error) {
+	var count int64
+	if err := b.db.WithContext(ctx).Model(&Check{}).
+		Where("uid = ? AND biz_id = ? AND status = ?", uid, 1, domain.Approved).
+		Distinct("post_id").
+		Count(&count).Error; err != nil {
+		b.l.Error("统计审核通过帖子数失败", zap.Int64("uid", uid), zap.Error(err))
+		return 0, err
+	}
+	return count, nil
+}
+
+// SumLikesReceived 统计已发布帖子累计获得的点赞数
+func (b *badgeDAO) SumLikesReceived(ctx context.Context, uid int64) (int64, error) {
+	var sum int64
+	if err := b.db.WithContext(ctx).Model(&Interactive{}).
+		Select("COALESCE(SUM(interactives.like_count), 0)").
+		Joins("JOIN pub_posts ON pub_posts.id = interactives.biz_id").
+		Where("pub_posts.uid = ? AND pub_posts.deleted_at IS NULL", uid).
+		Scan(&sum).Error; err != nil {
+		b.l.Error("统计获赞数失败", zap.Int64("uid", uid), zap.Error(err))
+		return 0, err
+	}
+	return sum, nil
+}
+
+// CountLotteryParticipations 统计参与抽奖的次数
+func (b *badgeDAO) CountLotteryParticipations(ctx context.Context, uid int64) (int64, error) {
+	var count int64
+	if err := b.db.WithContext(ctx).Model(&Participant{}).
+		Where("user_id = ? AND lottery_id IS NOT NULL", uid).
+		Count(&count).Error; err != nil {
+		b.l.Error("统计抽奖参与次数失败", zap.Int64("uid", uid), zap.Error(err))
+		return 0, err
+	}
+	return count, nil
+}
+
+// ListUserIDs 分批获取用户ID
+func (b *badgeDAO) ListUserIDs(ctx context.Context, afterId int64, limit int) ([]int64, error) {
+	var ids []int64
+	if err := b.db.WithContext(ctx).Model(&User{}).
+		Where("id > ? AND deleted = ?", afterId, false).
+		Order("id ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error; err != nil {
+		b.l.Error("获取用户ID失败", zap.Int64("afterId", afterId), zap.Error(err))
+		return nil, err
+	}
+	return ids, nil
+}
+
+// BackfillActiveDays 补全历史活跃日期，评论与抽奖时间为秒级时间戳，点赞时间为毫秒级时间戳
+func (b *badgeDAO) BackfillActiveDays(ctx context.Context) error {
+	if err := b.db.WithContext(ctx).Exec(`INSERT IGNORE INTO user_active_days (user_id, day)
+SELECT uid, DATE_FORMAT(created_at, '%Y-%m-%d') FROM pub_posts WHERE deleted_at IS NULL
+UNION SELECT user_id, DATE_FORMAT(FROM_UNIXTIME(created_at), '%Y-%m-%d') FROM comments
+UNION SELECT uid, DATE_FORMAT(FROM_UNIXTIME(created_at / 1000), '%Y-%m-%d') FROM user_like_bizs
+UNION SELECT user_id, DATE_FORMAT(FROM_UNIXTIME(participated_at), '%Y-%m-%d') FROM participants`).Error; err != nil {
+		b.l.Error("补全活跃日期失败", zap.Error(err))
+		return err
+	}
+	return nil
+}
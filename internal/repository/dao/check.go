@@ -14,6 +14,8 @@ type CheckDAO interface {
 	Create(ctx context.Context, check Check) (int64, error)
 	UpdateStatus(ctx context.Context, check Check) error
 	FindAll(ctx context.Context, pagination domain.Pagination) ([]Check, error)
+	// FindByPlates 获取指定板块内的审核列表
+	FindByPlates(ctx context.Context, plateIds []int64, pagination domain.Pagination) ([]Check, error)
 	FindByID(ctx context.Context, checkId int64) (Check, error)
 	FindByPostId(ctx context.Context, postId uint) (Check, error)
 }
@@ -95,6 +97,7 @@ func (dao *checkDAO) FindAll(ctx context.Context, pagination domain.Pagination)
 	intOffset := int(*pagination.Offset)
 
 	result := dao.db.WithContext(ctx).
+		Order("id DESC").
 		Limit(intSize).
 		Offset(intOffset).
 		Find(&checks)
@@ -107,6 +110,25 @@ func (dao *checkDAO) FindAll(ctx context.Context, pagination domain.Pagination)
 	return checks, nil
 }
 
+// FindByPlates 获取指定板块内的审核列表
+func (dao *checkDAO) FindByPlates(ctx context.Context, plateIds []int64, pagination domain.Pagination) ([]Check, error) {
+	var checks []Check
+
+	result := dao.db.WithContext(ctx).
+		Where("plate_id IN ?", plateIds).
+		Order("id DESC").
+		Limit(int(*pagination.Size)).
+		Offset(int(*pagination.Offset)).
+		Find(&checks)
+
+	if result.Error != nil {
+		dao.l.Error("获取板块审核记录失败", zap.Int64s("plate_ids", plateIds), zap.Error(result.Error))
+		return nil, result.Error
+	}
+
+	return checks, nil
+}
+
 // FindByID 获取审核详情
 func (dao *checkDAO) FindByID(ctx context.Context, checkId int64) (Check, error) {
 	var check Check
@@ -0,0 +1,284 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	MessageNormal   uint8 = iota // 正常
+	MessageRecalled              // 已撤回
+)
+
+// ErrMessageNotFound 消息不存在或无权操作
+var ErrMessageNotFound = errors.New("message not found")
+
+// IMDAO 定义了私信相关的数据库操作
+type IMDAO interface {
+	GetOrCreateConversation(ctx context.Context, conv Conversation) (Conversation, error)
+	GetConversationsByIDs(ctx context.Context, ids []int64) ([]Conversation, error)
+	GetMember(ctx context.Context, conversationID, uid int64) (ConversationMember, error)
+	ListMembers(ctx context.Context, uid int64, offset, limit int) ([]ConversationMember, error)
+	InsertMessage(ctx context.Context, msg Message) error
+	GetMessage(ctx context.Context, id int64) (Message, error)
+	GetMessagesByIDs(ctx context.Context, ids []int64) ([]Message, error)
+	ListMessages(ctx context.Context, conversationID, beforeID int64, limit int) ([]Message, error)
+	MarkRead(ctx context.Context, conversationID, uid, msgID int64) error
+	RecallMessage(ctx context.Context, id, senderID int64) error
+	TotalUnread(ctx context.Context, uid int64) (int64, error)
+}
+
+type imDAO struct {
+	db *gorm.DB
+	l  *zap.Logger
+}
+
+// Conversation 单聊会话，UserA 始终小于 UserB，保证同一对用户只有一个会话
+type Conversation struct {
+	ID        int64 `gorm:"column:id;primaryKey;autoIncrement:false"`
+	UserA     int64 `gorm:"column:user_a;uniqueIndex:user_a_user_b"`
+	UserB     int64 `gorm:"column:user_b;uniqueIndex:user_a_user_b"`
+	LastMsgID int64 `gorm:"column:last_msg_id"`
+	CreatedAt int64 `gorm:"column:created_at"`
+	UpdatedAt int64 `gorm:"column:updated_at"`
+}
+
+// ConversationMember 会话成员，记录每个用户的未读数与已读位置
+type ConversationMember struct {
+	ID             int64 `gorm:"column:id;primaryKey;autoIncrement"`
+	ConversationID int64 `gorm:"column:conversation_id;uniqueIndex:cid_uid"`
+	UserID         int64 `gorm:"column:user_id;uniqueIndex:cid_uid;index:idx_uid_updated"`
+	PeerID         int64 `gorm:"column:peer_id"`
+	UnreadCount    int64 `gorm:"column:unread_count"`
+	LastReadMsgID  int64 `gorm:"column:last_read_msg_id"`
+	CreatedAt      int64 `gorm:"column:created_at"`
+	UpdatedAt      int64 `gorm:"column:updated_at;index:idx_uid_updated"`
+}
+
+// Message 私信消息
+type Message struct {
+	ID             int64  `gorm:"column:id;primaryKey;autoIncrement:false"`
+	ConversationID int64  `gorm:"column:conversation_id;index"`
+	SenderID       int64  `gorm:"column:sender_id"`
+	ReceiverID     int64  `gorm:"column:receiver_id"`
+	Content        string `gorm:"column:content;type:text"`
+	Status         uint8  `gorm:"column:status"`
+	CreatedAt      int64  `gorm:"column:created_at"`
+	UpdatedAt      int64  `gorm:"column:updated_at"`
+}
+
+func NewIMDAO(db *gorm.DB, l *zap.Logger) IMDAO {
+	return &imDAO{
+		db: db,
+		l:  l,
+	}
+}
+
+// GetOrCreateConversation 获取会话，不存在时创建会话及双方成员记录
+func (i *imDAO) GetOrCreateConversation(ctx context.Context, conv Conversation) (Conversation, error) {
+	now := time.Now().UnixMilli()
+	if conv.UserA > conv.UserB {
+		conv.UserA, conv.UserB = conv.UserB, conv.UserA
+	}
+	conv.CreatedAt = now
+	conv.UpdatedAt = now
+
+	var result Conversation
+	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&conv).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_a = ? AND user_b = ?", conv.UserA, conv.UserB).First(&result).Error; err != nil {
+			return err
+		}
+		members := []ConversationMember{
+			{ConversationID: result.ID, UserID: result.UserA, PeerID: result.UserB, CreatedAt: now, UpdatedAt: now},
+			{ConversationID: result.ID, UserID: result.UserB, PeerID: result.UserA, CreatedAt: now, UpdatedAt: now},
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&members).Error
+	})
+	if err != nil {
+		i.l.Error("获取或创建会话失败", zap.Int64("userA", conv.UserA), zap.Int64("userB", conv.UserB), zap.Error(err))
+		return Conversation{}, err
+	}
+	return result, nil
+}
+
+// GetConversationsByIDs 批量获取会话
+func (i *imDAO) GetConversationsByIDs(ctx context.Context, ids []int64) ([]Conversation, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var convs []Conversation
+	if err := i.db.WithContext(ctx).Where("id IN ?", ids).Find(&convs).Error; err != nil {
+		return nil, err
+	}
+	return convs, nil
+}
+
+// GetMember 获取用户在会话中的成员记录
+func (i *imDAO) GetMember(ctx context.Context, conversationID, uid int64) (ConversationMember, error) {
+	var member ConversationMember
+	if err := i.db.WithContext(ctx).
+		Where("conversation_id = ? AND user_id = ?", conversationID, uid).
+		First(&member).Error; err != nil {
+		return ConversationMember{}, err
+	}
+	return member, nil
+}
+
+// ListMembers 按最近活跃时间获取用户参与的会话
+func (i *imDAO) ListMembers(ctx context.Context, uid int64, offset, limit int) ([]ConversationMember, error) {
+	var members []ConversationMember
+	if err := i.db.WithContext(ctx).
+		Where("user_id = ?", uid).
+		Order("updated_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&members).Error; err != nil {
+		i.l.Error("获取会话列表失败", zap.Int64("uid", uid), zap.Error(err))
+		return nil, err
+	}
+	return members, nil
+}
+
+// InsertMessage 写入消息，并更新会话最后一条消息与双方的未读、已读状态
+func (i *imDAO) InsertMessage(ctx context.Context, msg Message) error {
+	now := time.Now().UnixMilli()
+	msg.CreatedAt = now
+	msg.UpdatedAt = now
+
+	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&msg).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&Conversation{}).Where("id = ?", msg.ConversationID).Updates(map[string]any{
+			"last_msg_id": msg.ID,
+			"updated_at":  now,
+		}).Error; err != nil {
+			return err
+		}
+		// 发送者回复时视为已读会话中之前的全部消息
+		if err := tx.Model(&ConversationMember{}).
+			Where("conversation_id = ? AND user_id = ?", msg.ConversationID, msg.SenderID).
+			Updates(map[string]any{
+				"last_read_msg_id": msg.ID,
+				"unread_count":     0,
+				"updated_at":       now,
+			}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&ConversationMember{}).
+			Where("conversation_id = ? AND user_id = ?", msg.ConversationID, msg.ReceiverID).
+			Updates(map[string]any{
+				"unread_count": gorm.Expr("unread_count + 1"),
+				"updated_at":   now,
+			}).Error
+	})
+	if err != nil {
+		i.l.Error("写入消息失败", zap.Int64("conversationID", msg.ConversationID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// GetMessage 根据ID获取消息
+func (i *imDAO) GetMessage(ctx context.Context, id int64) (Message, error) {
+	var msg Message
+	if err := i.db.WithContext(ctx).Where("id = ?", id).First(&msg).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Message{}, ErrMessageNotFound
+		}
+		return Message{}, err
+	}
+	return msg, nil
+}
+
+// GetMessagesByIDs 批量获取消息
+func (i *imDAO) GetMessagesByIDs(ctx context.Context, ids []int64) ([]Message, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var msgs []Message
+	if err := i.db.WithContext(ctx).Where("id IN ?", ids).Find(&msgs).Error; err != nil {
+		return nil, err
+	}
+	return msgs, nil
+}
+
+// ListMessages 获取会话历史消息，beforeID 为 0 时从最新一条开始
+func (i *imDAO) ListMessages(ctx context.Context, conversationID, beforeID int64, limit int) ([]Message, error) {
+	var msgs []Message
+	query := i.db.WithContext(ctx).Where("conversation_id = ?", conversationID)
+	if beforeID > 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+	if err := query.Order("id DESC").Limit(limit).Find(&msgs).Error; err != nil {
+		i.l.Error("获取历史消息失败", zap.Int64("conversationID", conversationID), zap.Error(err))
+		return nil, err
+	}
+	return msgs, nil
+}
+
+// MarkRead 将会话中 msgID 及之前的消息标记为已读，并重新计算未读数，已读位置只前进不后退
+func (i *imDAO) MarkRead(ctx context.Context, conversationID, uid, msgID int64) error {
+	return i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var member ConversationMember
+		if err := tx.Where("conversation_id = ? AND user_id = ?", conversationID, uid).First(&member).Error; err != nil {
+			return err
+		}
+		if msgID < member.LastReadMsgID {
+			msgID = member.LastReadMsgID
+		}
+		var unread int64
+		if err := tx.Model(&Message{}).
+			Where("conversation_id = ? AND receiver_id = ? AND id > ? AND status = ?", conversationID, uid, msgID, MessageNormal).
+			Count(&unread).Error; err != nil {
+			return err
+		}
+		return tx.Model(&ConversationMember{}).Where("id = ?", member.ID).Updates(map[string]any{
+			"last_read_msg_id": msgID,
+			"unread_count":     unread,
+		}).Error
+	})
+}
+
+// RecallMessage 撤回消息，接收方尚未读取时同步扣减未读数
+func (i *imDAO) RecallMessage(ctx context.Context, id, senderID int64) error {
+	now := time.Now().UnixMilli()
+	return i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var msg Message
+		if err := tx.Where("id = ? AND sender_id = ? AND status = ?", id, senderID, MessageNormal).First(&msg).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrMessageNotFound
+			}
+			return err
+		}
+		if err := tx.Model(&Message{}).Where("id = ?", id).Updates(map[string]any{
+			"status":     MessageRecalled,
+			"updated_at": now,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&ConversationMember{}).
+			Where("conversation_id = ? AND user_id = ? AND last_read_msg_id < ? AND unread_count > 0", msg.ConversationID, msg.ReceiverID, msg.ID).
+			Update("unread_count", gorm.Expr("unread_count - 1")).Error
+	})
+}
+
+// TotalUnread 获取用户所有会话的未读总数
+func (i *imDAO) TotalUnread(ctx context.Context, uid int64) (int64, error) {
+	var total int64
+	if err := i.db.WithContext(ctx).Model(&ConversationMember{}).
+		Where("user_id = ?", uid).
+		Select("COALESCE(SUM(unread_count), 0)").
+		Scan(&total).Error; err != nil {
+		return 0, err
+	}
+	return total, nil
+}
@@ -0,0 +1,78 @@
+package dao
+
+import (
+	"context"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// newTestDB 创建内存 SQLite 数据库并迁移指定的表
+func newTestDB(t *testing.T, models ...any) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(models...); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestMarkReadAfterReply(t *testing.T) {
+	const a, b = 1, 2
+	db := newTestDB(t, &Conversation{}, &ConversationMember{}, &Message{})
+	d := NewIMDAO(db, zap.NewNop())
+	ctx := context.Background()
+
+	conv, err := d.GetOrCreateConversation(ctx, Conversation{ID: 100, UserA: a, UserB: b})
+	if err != nil {
+		t.Fatal(err)
+	}
+	send := func(id, from, to int64) {
+		t.Helper()
+		if err := d.InsertMessage(ctx, Message{ID: id, ConversationID: conv.ID, SenderID: from, ReceiverID: to, Content: "hi"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	unread := func(uid int64) int64 {
+		t.Helper()
+		n, err := d.TotalUnread(ctx, uid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	// A 收到两条消息后未读就直接回复
+	send(1, b, a)
+	send(2, b, a)
+	if got := unread(a); got != 2 {
+		t.Fatalf("unread before reply = %d, want 2", got)
+	}
+	send(3, a, b)
+	if got := unread(a); got != 0 {
+		t.Fatalf("unread after reply = %d, want 0", got)
+	}
+	if got := unread(b); got != 1 {
+		t.Fatalf("receiver of the reply unread = %d, want 1", got)
+	}
+
+	// 再收到一条后标记已读，已读位置落后于自己发出的消息时也要重新计算
+	send(4, b, a)
+	if err := d.MarkRead(ctx, conv.ID, a, 3); err != nil {
+		t.Fatal(err)
+	}
+	if got := unread(a); got != 1 {
+		t.Fatalf("unread after marking up to own reply = %d, want 1", got)
+	}
+	if err := d.MarkRead(ctx, conv.ID, a, 4); err != nil {
+		t.Fatal(err)
+	}
+	if got := unread(a); got != 0 {
+		t.Fatalf("unread after mark read = %d, want 0", got)
+	}
+}
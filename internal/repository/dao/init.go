@@ -9,6 +9,23 @@ func InitTables(db *gorm.DB) error {
 	return db.AutoMigrate(
 		&User{},
 		&Profile{},
+		&UserIdentity{},
+		&UserTwoFactor{},
+		&RecoveryCode{},
+		&AccountDeletion{},
+		&DataExport{},
+		&ReputationLog{},
+		&UserReputation{},
+		&ReputationLevel{},
+		&Badge{},
+		&UserBadge{},
+		&UserActiveDay{},
+		&InviteCode{},
+		&Invitation{},
+		&AccessToken{},
+		&SecurityEvent{},
+		&LoginDevice{},
+		&AuditLog{},
 		&Post{},
 		&PubPost{},
 		&Menu{},
@@ -20,13 +37,20 @@ func InitTables(db *gorm.DB) error {
 		&VCodeSmsLog{},
 		&Check{},
 		&Plate{},
+		&PlateSubscription{},
 		&RecentActivity{},
 		&Comment{},
 		&Relation{},
 		&RelationCount{},
+		&UserBlock{},
 		&LotteryDraw{},
 		&SecondKillEvent{},
 		&Participant{},
 		&RankingParameter{},
+		&NotificationPreference{},
+		&NotificationSetting{},
+		&Conversation{},
+		&ConversationMember{},
+		&Message{},
 	)
 }
@@ -35,6 +35,8 @@ type InteractiveDAO interface {
 	GetCollectInfo(ctx context.Context, postId uint, uid int64) (UserCollectionBiz, error)
 	Get(ctx context.Context, postId uint) (Interactive, error)
 	GetByIds(ctx context.Context, postIds []uint) ([]Interactive, error)
+	ListLikedBizIds(ctx context.Context, uid int64, limit int) ([]uint, error)
+	ListCollectedBizIds(ctx context.Context, uid int64, limit int) ([]uint, error)
 }
 
 type interactiveDAO struct {
@@ -312,3 +314,25 @@ func (i *interactiveDAO) GetByIds(ctx context.Context, postIds []uint) ([]Intera
 	err := i.db.WithContext(ctx).Where("biz_id IN ?", postIds).Find(&inc).Error
 	return inc, err
 }
+
+// ListLikedBizIds 获取用户最近点赞的帖子ID
+func (i *interactiveDAO) ListLikedBizIds(ctx context.Context, uid int64, limit int) ([]uint, error) {
+	var ids []uint
+	err := i.db.WithContext(ctx).Model(&UserLikeBiz{}).
+		Where("uid = ? AND status = ?", uid, StatusLiked).
+		Order("updated_at DESC").
+		Limit(limit).
+		Pluck("biz_id", &ids).Error
+	return ids, err
+}
+
+// ListCollectedBizIds 获取用户最近收藏的帖子ID
+func (i *interactiveDAO) ListCollectedBizIds(ctx context.Context, uid int64, limit int) ([]uint, error) {
+	var ids []uint
+	err := i.db.WithContext(ctx).Model(&UserCollectionBiz{}).
+		Where("uid = ? AND status = ?", uid, StatusCollection).
+		Order("updated_at DESC").
+		Limit(limit).
+		Pluck("biz_id", &ids).Error
+	return ids, err
+}
@@ -0,0 +1,205 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type InviteDAO interface {
+	CreateCode(ctx context.Context, code InviteCode) (InviteCode, error)
+	GetCode(ctx context.Context, id int64) (InviteCode, error)
+	// CountActiveCodes 统计用户仍可使用的邀请码数量
+	CountActiveCodes(ctx context.Context, creatorId int64) (int64, error)
+	// ListCodes 获取邀请码，creatorId 为0时获取全部
+	ListCodes(ctx context.Context, creatorId int64, offset, limit int) ([]InviteCode, error)
+	// DisableCode 停用邀请码，creatorId 为0时不校验创建者
+	DisableCode(ctx context.Context, id, creatorId int64) error
+	// CreateUserWithInvite 在同一事务中校验并占用邀请码、创建用户与资料并记录邀请关系
+	CreateUserWithInvite(ctx context.Context, u User, code string) (int64, error)
+	// ListInvitations 获取邀请关系，inviterId 与 inviteeId 为0时不作为筛选条件
+	ListInvitations(ctx context.Context, inviterId, inviteeId int64, offset, limit int) ([]InvitationRow, error)
+}
+
+type inviteDAO struct {
+	db *gorm.DB
+	l  *zap.Logger
+}
+
+// InviteCode 邀请码
+type InviteCode struct {
+	ID        int64  `gorm:"primaryKey;autoIncrement"`
+	Code      string `gorm:"column:code;type:varchar(32);not null;uniqueIndex"`
+	CreatorID int64  `gorm:"column:creator_id;not null;index"`
+	MaxUses   int    `gorm:"column:max_uses;not null"`
+	UsedCount int    `gorm:"column:used_count;not null;default:0"`
+	ExpiresAt int64  `gorm:"column:expires_at;type:bigint;not null"`
+	Disabled  bool   `gorm:"column:disabled;not null;default:false"`
+	CreatedAt int64  `gorm:"column:created_at;type:bigint;not null"`
+}
+
+// Invitation 邀请关系，每个用户只会被邀请一次
+type Invitation struct {
+	ID        int64 `gorm:"primaryKey;autoIncrement"`
+	InviterID int64 `gorm:"column:inviter_id;not null;index"`
+	InviteeID int64 `gorm:"column:invitee_id;not null;uniqueIndex"`
+	CodeID    int64 `gorm:"column:code_id;not null;index"`
+	CreatedAt int64 `gorm:"column:created_at;type:bigint;not null"`
+}
+
+// InvitationRow 邀请关系与邀请码的联表结果
+type InvitationRow struct {
+	ID        int64
+	InviterID int64
+	InviteeID int64
+	CodeID    int64
+	Code      string
+	CreatedAt int64
+}
+
+func NewInviteDAO(db *gorm.DB, l *zap.Logger) InviteDAO {
+	return &inviteDAO{
+		db: db,
+		l:  l,
+	}
+}
+
+// CreateCode 创建邀请码
+func (i *inviteDAO) CreateCode(ctx context.Context, code InviteCode) (InviteCode, error) {
+	code.CreatedAt = time.Now().UnixMilli()
+	if err := i.db.WithContext(ctx).Create(&code).Error; err != nil {
+		i.l.Error("创建邀请码失败", zap.Int64("creatorId", code.CreatorID), zap.Error(err))
+		return InviteCode{}, err
+	}
+	return code, nil
+}
+
+// GetCode 根据ID获取邀请码
+func (i *inviteDAO) GetCode(ctx context.Context, id int64) (InviteCode, error) {
+	var code InviteCode
+	if err := i.db.WithContext(ctx).Where("id = ?", id).First(&code).Error; err != nil {
+		return InviteCode{}, err
+	}
+	return code, nil
+}
+
+// CountActiveCodes 统计未停用、未过期且仍有剩余次数的邀请码
+func (i *inviteDAO) CountActiveCodes(ctx context.Context, creatorId int64) (int64, error) {
+	var count int64
+	if err := i.db.WithContext(ctx).Model(&InviteCode{}).
+		Where("creator_id = ? AND disabled = ?", creatorId, false).
+		Where("expires_at = 0 OR expires_at > ?", time.Now().UnixMilli()).
+		Where("max_uses = 0 OR used_count < max_uses").
+		Count(&count).Error; err != nil {
+		i.l.Error("统计邀请码失败", zap.Int64("creatorId", creatorId), zap.Error(err))
+		return 0, err
+	}
+	return count, nil
+}
+
+// ListCodes 按创建时间倒序获取邀请码
+func (i *inviteDAO) ListCodes(ctx context.Context, creatorId int64, offset, limit int) ([]InviteCode, error) {
+	var codes []InviteCode
+	query := i.db.WithContext(ctx).Order("id DESC").Offset(offset).Limit(limit)
+	if creatorId > 0 {
+		query = query.Where("creator_id = ?", creatorId)
+	}
+	if err := query.Find(&codes).Error; err != nil {
+		i.l.Error("获取邀请码失败", zap.Int64("creatorId", creatorId), zap.Error(err))
+		return nil, err
+	}
+	return codes, nil
+}
+
+// DisableCode 停用邀请码
+func (i *inviteDAO) DisableCode(ctx context.Context, id, creatorId int64) error {
+	query := i.db.WithContext(ctx).Model(&InviteCode{}).Where("id = ?", id)
+	if creatorId > 0 {
+		query = query.Where("creator_id = ?", creatorId)
+	}
+	result := query.Update("disabled", true)
+	if result.Error != nil {
+		i.l.Error("停用邀请码失败", zap.Int64("id", id), zap.Error(result.Error))
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// CreateUserWithInvite 使用邀请码注册用户，邀请码行加锁以保证使用次数不会超限
+func (i *inviteDAO) CreateUserWithInvite(ctx context.Context, u User, code string) (int64, error) {
+	now := time.Now().UnixMilli()
+	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ic InviteCode
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("code = ?", code).First(&ic).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrInviteCodeInvalid
+			}
+			return err
+		}
+		if err := (domain.InviteCode{
+			MaxUses:   ic.MaxUses,
+			UsedCount: ic.UsedCount,
+			ExpiresAt: ic.ExpiresAt,
+			Disabled:  ic.Disabled,
+		}).Usable(now); err != nil {
+			return err
+		}
+
+		if err := createUserWithProfile(tx, &u, now); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&InviteCode{}).Where("id = ?", ic.ID).
+			Update("used_count", gorm.Expr("used_count + 1")).Error; err != nil {
+			return err
+		}
+		return tx.Create(&Invitation{
+			InviterID: ic.CreatorID,
+			InviteeID: u.ID,
+			CodeID:    ic.ID,
+			CreatedAt: now,
+		}).Error
+	})
+	if err != nil {
+		if !errors.Is(err, ErrDuplicateUsername) && !isInviteCodeErr(err) {
+			i.l.Error("邀请注册用户失败", zap.Error(err))
+		}
+		return 0, err
+	}
+	return u.ID, nil
+}
+
+// ListInvitations 按时间倒序获取邀请关系
+func (i *inviteDAO) ListInvitations(ctx context.Context, inviterId, inviteeId int64, offset, limit int) ([]InvitationRow, error) {
+	var rows []InvitationRow
+	query := i.db.WithContext(ctx).
+		Model(&Invitation{}).
+		Select("invitations.id, invitations.inviter_id, invitations.invitee_id, invitations.code_id, invite_codes.code, invitations.created_at").
+		Joins("LEFT JOIN invite_codes ON invite_codes.id = invitations.code_id")
+	if inviterId > 0 {
+		query = query.Where("invitations.inviter_id = ?", inviterId)
+	}
+	if inviteeId > 0 {
+		query = query.Where("invitations.invitee_id = ?", inviteeId)
+	}
+	if err := query.Order("invitations.id DESC").Offset(offset).Limit(limit).Scan(&rows).Error; err != nil {
+		i.l.Error("获取邀请关系失败", zap.Error(err))
+		return nil, err
+	}
+	return rows, nil
+}
+
+// isInviteCodeErr 判断是否为邀请码不可用导致的错误
+func isInviteCodeErr(err error) bool {
+	return errors.Is(err, domain.ErrInviteCodeInvalid) ||
+		errors.Is(err, domain.ErrInviteCodeExpired) ||
+		errors.Is(err, domain.ErrInviteCodeExhausted)
+}
@@ -31,7 +31,7 @@ type Menu struct {
 }
 
 type MenuDAO interface {
-	CreateMenu(ctx context.Context, menu *Menu) error
+	CreateMenu(ctx context.Context, menu *Menu) (int, error)
 	GetMenuById(ctx context.Context, id int) (*Menu, error)
 	UpdateMenu(ctx context.Context, menu *Menu) error
 	DeleteMenu(ctx context.Context, id int) error
@@ -52,21 +52,21 @@ func NewMenuDAO(db *gorm.DB, l *zap.Logger) MenuDAO {
 }
 
 // CreateMenu 创建菜单
-func (m *menuDAO) CreateMenu(ctx context.Context, menu *Menu) error {
+func (m *menuDAO) CreateMenu(ctx context.Context, menu *Menu) (int, error) {
 	if menu == nil {
-		return ErrInvalidMenu
+		return 0, ErrInvalidMenu
 	}
 
 	// 检查必填字段
 	if menu.Name == "" {
-		return errors.New("菜单名称不能为空")
+		return 0, errors.New("菜单名称不能为空")
 	}
 
 	if menu.Path == "" {
-		return errors.New("菜单路径不能为空")
+		return 0, errors.New("菜单路径不能为空")
 	}
 
-	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// 检查父菜单是否存在
 		if menu.ParentID != 0 {
 			var count int64
@@ -93,6 +93,10 @@ func (m *menuDAO) CreateMenu(ctx context.Context, menu *Menu) error {
 
 		return tx.Create(menu).Error
 	})
+	if err != nil {
+		return 0, err
+	}
+	return menu.ID, nil
 }
 
 // GetMenuById 根据ID获取菜单
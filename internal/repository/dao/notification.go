@@ -0,0 +1,114 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NotificationDAO 定义了通知偏好相关的数据库操作
+type NotificationDAO interface {
+	ListPreferences(ctx context.Context, uid int64) ([]NotificationPreference, error)
+	UpsertPreferences(ctx context.Context, uid int64, prefs []NotificationPreference) error
+	GetSetting(ctx context.Context, uid int64) (NotificationSetting, error)
+	UpsertSetting(ctx context.Context, setting NotificationSetting) error
+}
+
+type notificationDAO struct {
+	db *gorm.DB
+	l  *zap.Logger
+}
+
+// NotificationPreference 用户在某一通知类型、某一渠道上的开关
+type NotificationPreference struct {
+	ID        int64  `gorm:"column:id;primaryKey;autoIncrement"`
+	UserID    int64  `gorm:"column:user_id;uniqueIndex:uid_type_channel"`
+	Type      string `gorm:"column:type;type:varchar(32);uniqueIndex:uid_type_channel"`
+	Channel   string `gorm:"column:channel;type:varchar(16);uniqueIndex:uid_type_channel"`
+	Enabled   bool   `gorm:"column:enabled"`
+	CreatedAt int64  `gorm:"column:created_at"`
+	UpdatedAt int64  `gorm:"column:updated_at"`
+}
+
+// NotificationSetting 用户的通用通知设置(免打扰时段)
+type NotificationSetting struct {
+	ID           int64  `gorm:"column:id;primaryKey;autoIncrement"`
+	UserID       int64  `gorm:"column:user_id;unique"`
+	QuietEnabled bool   `gorm:"column:quiet_enabled"`
+	QuietStart   string `gorm:"column:quiet_start;type:varchar(5)"`
+	QuietEnd     string `gorm:"column:quiet_end;type:varchar(5)"`
+	Timezone     string `gorm:"column:timezone;type:varchar(64)"`
+	CreatedAt    int64  `gorm:"column:created_at"`
+	UpdatedAt    int64  `gorm:"column:updated_at"`
+}
+
+func NewNotificationDAO(db *gorm.DB, l *zap.Logger) NotificationDAO {
+	return &notificationDAO{
+		db: db,
+		l:  l,
+	}
+}
+
+// ListPreferences 获取用户已保存的通知偏好
+func (n *notificationDAO) ListPreferences(ctx context.Context, uid int64) ([]NotificationPreference, error) {
+	var prefs []NotificationPreference
+	if err := n.db.WithContext(ctx).Where("user_id = ?", uid).Find(&prefs).Error; err != nil {
+		n.l.Error("获取通知偏好失败", zap.Int64("uid", uid), zap.Error(err))
+		return nil, err
+	}
+	return prefs, nil
+}
+
+// UpsertPreferences 批量保存通知偏好
+func (n *notificationDAO) UpsertPreferences(ctx context.Context, uid int64, prefs []NotificationPreference) error {
+	if len(prefs) == 0 {
+		return nil
+	}
+	now := time.Now().UnixMilli()
+	for i := range prefs {
+		prefs[i].UserID = uid
+		prefs[i].CreatedAt = now
+		prefs[i].UpdatedAt = now
+	}
+	if err := n.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "type"}, {Name: "channel"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "updated_at"}),
+	}).Create(&prefs).Error; err != nil {
+		n.l.Error("保存通知偏好失败", zap.Int64("uid", uid), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// GetSetting 获取用户的免打扰设置，不存在时返回零值
+func (n *notificationDAO) GetSetting(ctx context.Context, uid int64) (NotificationSetting, error) {
+	var setting NotificationSetting
+	err := n.db.WithContext(ctx).Where("user_id = ?", uid).First(&setting).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return NotificationSetting{UserID: uid}, nil
+	}
+	if err != nil {
+		n.l.Error("获取通知设置失败", zap.Int64("uid", uid), zap.Error(err))
+		return NotificationSetting{}, err
+	}
+	return setting, nil
+}
+
+// UpsertSetting 保存用户的免打扰设置
+func (n *notificationDAO) UpsertSetting(ctx context.Context, setting NotificationSetting) error {
+	now := time.Now().UnixMilli()
+	setting.CreatedAt = now
+	setting.UpdatedAt = now
+	if err := n.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"quiet_enabled", "quiet_start", "quiet_end", "timezone", "updated_at"}),
+	}).Create(&setting).Error; err != nil {
+		n.l.Error("保存通知设置失败", zap.Int64("uid", setting.UserID), zap.Error(err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrIdentityNotFound 表示第三方账号未绑定
+	ErrIdentityNotFound = errors.New("第三方账号未绑定")
+	// ErrIdentityExists 表示第三方账号已被绑定
+	ErrIdentityExists = errors.New("第三方账号已被绑定")
+)
+
+type OAuthDAO interface {
+	FindIdentity(ctx context.Context, provider, subject string) (UserIdentity, error)
+	ListIdentities(ctx context.Context, uid int64) ([]UserIdentity, error)
+	CreateIdentity(ctx context.Context, identity UserIdentity) error
+	DeleteIdentity(ctx context.Context, uid int64, provider string) error
+	// CreateUserWithIdentity 首次第三方登录时创建用户、资料并绑定第三方账号
+	CreateUserWithIdentity(ctx context.Context, u User, identity UserIdentity) (int64, error)
+}
+
+type oauthDAO struct {
+	db *gorm.DB
+	l  *zap.Logger
+}
+
+// UserIdentity 用户绑定的第三方账号
+type UserIdentity struct {
+	ID         int64  `gorm:"primaryKey;autoIncrement"`
+	UserID     int64  `gorm:"column:user_id;not null;uniqueIndex:user_id_provider"`                                // 绑定的用户
+	Provider   string `gorm:"type:varchar(32);not null;uniqueIndex:provider_subject;uniqueIndex:user_id_provider"` // 登录方式
+	Subject    string `gorm:"type:varchar(191);not null;uniqueIndex:provider_subject"`                             // 第三方账号唯一标识
+	Username   string `gorm:"type:varchar(100)"`                                                                   // 第三方用户名
+	Email      string `gorm:"type:varchar(100)"`                                                                   // 第三方邮箱
+	Registered bool   `gorm:"not null;default:false"`                                                              // 是否通过该账号首次登录注册
+	CreatedAt  int64  `gorm:"column:created_at;type:bigint;not null"`                                              // 绑定时间
+}
+
+func NewOAuthDAO(db *gorm.DB, l *zap.Logger) OAuthDAO {
+	return &oauthDAO{
+		db: db,
+		l:  l,
+	}
+}
+
+// FindIdentity 根据第三方账号查找绑定记录
+func (o *oauthDAO) FindIdentity(ctx context.Context, provider, subject string) (UserIdentity, error) {
+	var identity UserIdentity
+	err := o.db.WithContext(ctx).Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return UserIdentity{}, ErrIdentityNotFound
+		}
+		o.l.Error("查询第三方账号失败", zap.Error(err))
+		return UserIdentity{}, err
+	}
+	return identity, nil
+}
+
+// ListIdentities 获取用户绑定的全部第三方账号
+func (o *oauthDAO) ListIdentities(ctx context.Context, uid int64) ([]UserIdentity, error) {
+	var identities []UserIdentity
+	if err := o.db.WithContext(ctx).Where("user_id = ?", uid).Order("id ASC").Find(&identities).Error; err != nil {
+		o.l.Error("获取第三方账号列表失败", zap.Error(err))
+		return nil, err
+	}
+	return identities, nil
+}
+
+// CreateIdentity 绑定第三方账号，同一用户的同一登录方式只能绑定一个账号
+func (o *oauthDAO) CreateIdentity(ctx context.Context, identity UserIdentity) error {
+	identity.CreatedAt = time.Now().UnixMilli()
+	if err := o.db.WithContext(ctx).Create(&identity).Error; err != nil {
+		if isDuplicateErr(err) {
+			return ErrIdentityExists
+		}
+		o.l.Error("绑定第三方账号失败", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// DeleteIdentity 解绑第三方账号，解绑的是注册时使用的账号时将注册标记转移到剩余的第三方账号
+func (o *oauthDAO) DeleteIdentity(ctx context.Context, uid int64, provider string) error {
+	err := o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var identity UserIdentity
+		if err := tx.Where("user_id = ? AND provider = ?", uid, provider).First(&identity).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrIdentityNotFound
+			}
+			return err
+		}
+		if err := tx.Delete(&identity).Error; err != nil {
+			return err
+		}
+		if !identity.Registered {
+			return nil
+		}
+		return tx.Model(&UserIdentity{}).
+			Where("user_id = ?", uid).
+			Order("id ASC").
+			Limit(1).
+			Update("registered", true).Error
+	})
+	if err != nil && !errors.Is(err, ErrIdentityNotFound) {
+		o.l.Error("解绑第三方账号失败", zap.Error(err))
+	}
+	return err
+}
+
+// CreateUserWithIdentity 在同一事务中创建用户、资料与第三方账号绑定
+func (o *oauthDAO) CreateUserWithIdentity(ctx context.Context, u User, identity UserIdentity) (int64, error) {
+	now := time.Now().UnixMilli()
+	err := o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := createUserWithProfile(tx, &u, now); err != nil {
+			return err
+		}
+
+		identity.UserID = u.ID
+		identity.Registered = true
+		identity.CreatedAt = now
+		if err := tx.Create(&identity).Error; err != nil {
+			if isDuplicateErr(err) {
+				return ErrIdentityExists
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, ErrDuplicateUsername) && !errors.Is(err, ErrIdentityExists) {
+			o.l.Error("第三方登录注册用户失败", zap.Error(err))
+		}
+		return 0, err
+	}
+	return u.ID, nil
+}
+
+// isDuplicateErr 判断是否为唯一索引冲突
+func isDuplicateErr(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == ErrCodeDuplicateUsernameNumber
+}
@@ -24,11 +24,11 @@ type PermissionDAO interface {
 type permissionDAO struct {
 	db       *gorm.DB
 	l        *zap.Logger
-	enforcer *casbin.Enforcer
+	enforcer *casbin.SyncedEnforcer
 	apiDao   ApiDAO
 }
 
-func NewPermissionDAO(db *gorm.DB, l *zap.Logger, enforcer *casbin.Enforcer, apiDao ApiDAO) PermissionDAO {
+func NewPermissionDAO(db *gorm.DB, l *zap.Logger, enforcer *casbin.SyncedEnforcer, apiDao ApiDAO) PermissionDAO {
 	return &permissionDAO{
 		db:       db,
 		l:        l,
@@ -2,17 +2,25 @@ package dao
 
 import (
 	"context"
+	"errors"
 	"github.com/GoSimplicity/LinkMe/internal/domain"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"time"
 )
 
 type PlateDAO interface {
-	CreatePlate(ctx context.Context, plate domain.Plate) error
+	CreatePlate(ctx context.Context, plate domain.Plate) (int64, error)
 	ListPlate(ctx context.Context, pagination domain.Pagination) ([]Plate, error)
 	UpdatePlate(ctx context.Context, plate domain.Plate) error
 	DeletePlate(ctx context.Context, plateId int64, uid int64) error
+	Subscribe(ctx context.Context, plateId int64, uid int64) error
+	Unsubscribe(ctx context.Context, plateId int64, uid int64) error
+	ListSubscribedPlates(ctx context.Context, uid int64, pagination domain.Pagination) ([]Plate, error)
+	ListSubscribedPlateIDs(ctx context.Context, uid int64) ([]int64, error)
+	ExistsPlate(ctx context.Context, plateId int64) (bool, error)
+	GetPlate(ctx context.Context, plateId int64) (Plate, error)
 }
 
 type plateDAO struct {
@@ -21,15 +29,24 @@ type plateDAO struct {
 }
 
 type Plate struct {
-	ID          int64  `gorm:"primaryKey;autoIncrement"`      // 板块ID
-	Name        string `gorm:"size:255;not null;uniqueIndex"` // 板块名称
-	Description string `gorm:"type:text"`                     // 板块描述
-	CreateTime  int64  `gorm:"column:created_at;type:bigint"` // 创建时间
-	UpdatedTime int64  `gorm:"column:updated_at;type:bigint"` // 更新时间
-	DeletedTime int64  `gorm:"column:deleted_at;type:bigint"` // 删除时间
-	Deleted     bool   `gorm:"column:deleted;default:false"`  // 是否删除
-	Uid         int64  `gorm:"index"`                         // 板主id
-	Posts       []Post `gorm:"foreignKey:PlateID"`            // 帖子关系
+	ID              int64  `gorm:"primaryKey;autoIncrement"`          // 板块ID
+	Name            string `gorm:"size:255;not null;uniqueIndex"`     // 板块名称
+	Description     string `gorm:"type:text"`                         // 板块描述
+	CreateTime      int64  `gorm:"column:created_at;type:bigint"`     // 创建时间
+	UpdatedTime     int64  `gorm:"column:updated_at;type:bigint"`     // 更新时间
+	DeletedTime     int64  `gorm:"column:deleted_at;type:bigint"`     // 删除时间
+	Deleted         bool   `gorm:"column:deleted;default:false"`      // 是否删除
+	Uid             int64  `gorm:"index"`                             // 板主id
+	Posts           []Post `gorm:"foreignKey:PlateID"`                // 帖子关系
+	SubscriberCount int64  `gorm:"column:subscriber_count;default:0"` // 订阅人数
+}
+
+// PlateSubscription 用户订阅的板块
+type PlateSubscription struct {
+	ID        int64 `gorm:"primaryKey;autoIncrement"`                  // 主键ID
+	PlateID   int64 `gorm:"column:plate_id;uniqueIndex:plate_id_uid"`  // 板块ID
+	Uid       int64 `gorm:"column:uid;uniqueIndex:plate_id_uid;index"` // 订阅者ID
+	CreatedAt int64 `gorm:"column:created_at;type:bigint"`             // 订阅时间
 }
 
 func NewPlateDAO(l *zap.Logger, db *gorm.DB) PlateDAO {
@@ -39,7 +56,7 @@ func NewPlateDAO(l *zap.Logger, db *gorm.DB) PlateDAO {
 	}
 }
 
-func (p *plateDAO) CreatePlate(ctx context.Context, plate domain.Plate) error {
+func (p *plateDAO) CreatePlate(ctx context.Context, plate domain.Plate) (int64, error) {
 	now := time.Now().UnixMilli()
 	newPlate := &Plate{
 		Name:        plate.Name,
@@ -51,10 +68,10 @@ func (p *plateDAO) CreatePlate(ctx context.Context, plate domain.Plate) error {
 
 	if er := p.db.WithContext(ctx).Create(newPlate).Error; er != nil {
 		p.l.Error("创建板块失败", zap.String("板块名称", plate.Name), zap.Error(er))
-		return er
+		return 0, er
 	}
 
-	return nil
+	return newPlate.ID, nil
 }
 
 func (p *plateDAO) ListPlate(ctx context.Context, pagination domain.Pagination) ([]Plate, error) {
@@ -111,3 +128,86 @@ func (p *plateDAO) DeletePlate(ctx context.Context, plateId int64, uid int64) er
 
 	return nil
 }
+
+// Subscribe 订阅板块，重复订阅不会重复计数
+func (p *plateDAO) Subscribe(ctx context.Context, plateId int64, uid int64) error {
+	return p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&PlateSubscription{
+			PlateID:   plateId,
+			Uid:       uid,
+			CreatedAt: time.Now().UnixMilli(),
+		})
+		if res.Error != nil {
+			p.l.Error("订阅板块失败", zap.Int64("plate_id", plateId), zap.Error(res.Error))
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+		return tx.Model(&Plate{}).Where("id = ?", plateId).
+			Update("subscriber_count", gorm.Expr("subscriber_count + 1")).Error
+	})
+}
+
+// Unsubscribe 取消订阅板块
+func (p *plateDAO) Unsubscribe(ctx context.Context, plateId int64, uid int64) error {
+	return p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("plate_id = ? AND uid = ?", plateId, uid).Delete(&PlateSubscription{})
+		if res.Error != nil {
+			p.l.Error("取消订阅板块失败", zap.Int64("plate_id", plateId), zap.Error(res.Error))
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+		return tx.Model(&Plate{}).Where("id = ? AND subscriber_count > 0", plateId).
+			Update("subscriber_count", gorm.Expr("subscriber_count - 1")).Error
+	})
+}
+
+// ListSubscribedPlates 按订阅时间倒序获取用户订阅的板块
+func (p *plateDAO) ListSubscribedPlates(ctx context.Context, uid int64, pagination domain.Pagination) ([]Plate, error) {
+	var plates []Plate
+	err := p.db.WithContext(ctx).
+		Joins("JOIN plate_subscriptions ON plate_subscriptions.plate_id = plates.id").
+		Where("plate_subscriptions.uid = ? AND plates.deleted = ?", uid, false).
+		Order("plate_subscriptions.created_at DESC").
+		Limit(int(*pagination.Size)).
+		Offset(int(*pagination.Offset)).
+		Find(&plates).Error
+	if err != nil {
+		p.l.Error("获取订阅板块列表失败", zap.Int64("uid", uid), zap.Error(err))
+		return nil, err
+	}
+	return plates, nil
+}
+
+// ListSubscribedPlateIDs 获取用户订阅的全部板块ID
+func (p *plateDAO) ListSubscribedPlateIDs(ctx context.Context, uid int64) ([]int64, error) {
+	var ids []int64
+	err := p.db.WithContext(ctx).Model(&PlateSubscription{}).
+		Where("uid = ?", uid).
+		Pluck("plate_id", &ids).Error
+	return ids, err
+}
+
+// ExistsPlate 判断板块是否存在且未删除
+func (p *plateDAO) ExistsPlate(ctx context.Context, plateId int64) (bool, error) {
+	var plate Plate
+	err := p.db.WithContext(ctx).Select("id").
+		Where("id = ? AND deleted = ?", plateId, false).
+		First(&plate).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// GetPlate 获取未删除的板块
+func (p *plateDAO) GetPlate(ctx context.Context, plateId int64) (Plate, error) {
+	var plate Plate
+	err := p.db.WithContext(ctx).
+		Where("id = ? AND deleted = ?", plateId, false).
+		First(&plate).Error
+	return plate, err
+}
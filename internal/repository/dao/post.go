@@ -30,6 +30,12 @@ type PostDAO interface {
 	GetPost(ctx context.Context, postId uint) (Post, error)
 	GetPostsCount(ctx context.Context) (int64, error)
 	GetPostsByPlate(ctx context.Context, plateId int64, pagination domain.Pagination) ([]Post, error)
+	GetPubByIds(ctx context.Context, postIds []uint) ([]PubPost, error)
+	ListPubIdsByAuthors(ctx context.Context, uids []int64, maxId uint, limit int) ([]uint, error)
+	ListPubSince(ctx context.Context, since time.Time, limit int) ([]PubPost, error)
+	ListPubByPlates(ctx context.Context, plateIds []int64, maxId uint, limit int) ([]PubPost, error)
+	ListPubPlateIdsByAuthor(ctx context.Context, uid int64) ([]int64, error)
+	ListActiveAuthorsByPlates(ctx context.Context, plateIds []int64, since time.Time, limit int) ([]UserCount, error)
 }
 
 type postDAO struct {
@@ -369,3 +375,100 @@ func (p *postDAO) GetPostsByPlate(ctx context.Context, plateId int64, pagination
 
 	return posts, nil
 }
+
+// GetPubByIds 批量获取已发布的帖子
+func (p *postDAO) GetPubByIds(ctx context.Context, postIds []uint) ([]PubPost, error) {
+	if len(postIds) == 0 {
+		return nil, nil
+	}
+
+	var posts []PubPost
+	if err := p.db.WithContext(ctx).Where("id IN ?", postIds).Find(&posts).Error; err != nil {
+		p.l.Error("批量获取已发布帖子失败", zap.Error(err))
+		return nil, err
+	}
+	return posts, nil
+}
+
+// ListPubIdsByAuthors 按ID倒序获取指定作者已发布帖子的ID，maxId 为 0 时从最新开始
+func (p *postDAO) ListPubIdsByAuthors(ctx context.Context, uids []int64, maxId uint, limit int) ([]uint, error) {
+	if len(uids) == 0 {
+		return nil, nil
+	}
+
+	var ids []uint
+	query := p.db.WithContext(ctx).Model(&PubPost{}).Where("uid IN ?", uids)
+	if maxId > 0 {
+		query = query.Where("id < ?", maxId)
+	}
+	if err := query.Order("id DESC").Limit(limit).Pluck("id", &ids).Error; err != nil {
+		p.l.Error("获取作者已发布帖子失败", zap.Error(err))
+		return nil, err
+	}
+	return ids, nil
+}
+
+// ListPubSince 获取指定时间之后发布的帖子，按ID倒序
+func (p *postDAO) ListPubSince(ctx context.Context, since time.Time, limit int) ([]PubPost, error) {
+	var posts []PubPost
+	if err := p.db.WithContext(ctx).
+		Where("created_at >= ?", since).
+		Order("id DESC").
+		Limit(limit).
+		Find(&posts).Error; err != nil {
+		p.l.Error("获取近期已发布帖子失败", zap.Error(err))
+		return nil, err
+	}
+	return posts, nil
+}
+
+// ListPubByPlates 按ID倒序获取指定板块中已发布的帖子，maxId 为 0 时从最新开始
+func (p *postDAO) ListPubByPlates(ctx context.Context, plateIds []int64, maxId uint, limit int) ([]PubPost, error) {
+	if len(plateIds) == 0 {
+		return nil, nil
+	}
+
+	var posts []PubPost
+	query := p.db.WithContext(ctx).Where("plate_id IN ?", plateIds)
+	if maxId > 0 {
+		query = query.Where("id < ?", maxId)
+	}
+	if err := query.Order("id DESC").Limit(limit).Find(&posts).Error; err != nil {
+		p.l.Error("获取板块已发布帖子失败", zap.Error(err))
+		return nil, err
+	}
+	return posts, nil
+}
+
+// ListPubPlateIdsByAuthor 获取作者发布过帖子的板块ID
+func (p *postDAO) ListPubPlateIdsByAuthor(ctx context.Context, uid int64) ([]int64, error) {
+	var ids []int64
+	if err := p.db.WithContext(ctx).Model(&PubPost{}).
+		Where("uid = ? AND plate_id > 0", uid).
+		Distinct().
+		Pluck("plate_id", &ids).Error; err != nil {
+		p.l.Error("获取作者发帖板块失败", zap.Error(err), zap.Int64("uid", uid))
+		return nil, err
+	}
+	return ids, nil
+}
+
+// ListActiveAuthorsByPlates 获取指定时间之后在这些板块中发帖的作者，按发帖数倒序
+func (p *postDAO) ListActiveAuthorsByPlates(ctx context.Context, plateIds []int64, since time.Time, limit int) ([]UserCount, error) {
+	if len(plateIds) == 0 {
+		return nil, nil
+	}
+
+	var result []UserCount
+	if err := p.db.WithContext(ctx).Model(&PubPost{}).
+		Select("uid AS user_id, COUNT(*) AS cnt").
+		Where("plate_id IN ? AND created_at >= ?", plateIds, since).
+		Group("uid").
+		Order("cnt DESC").
+		Limit(limit).
+		Scan(&result).Error; err != nil {
+		p.l.Error("获取板块活跃作者失败", zap.Error(err))
+		return nil, err
+	}
+	return result, nil
+}
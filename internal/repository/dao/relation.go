@@ -2,6 +2,7 @@ package dao
 
 import (
 	"context"
+	"errors"
 	"github.com/GoSimplicity/LinkMe/internal/domain"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -10,11 +11,15 @@ import (
 )
 
 const (
-	FollowStatus uint8 = iota // 关注
-	ShieldStatus              // 屏蔽
-	BlockStatus               // 拉黑
+	FollowStatus  uint8 = iota // 关注
+	ShieldStatus               // 屏蔽
+	BlockStatus                // 拉黑
+	PendingStatus              // 待私密账号审核的关注请求
 )
 
+// ErrFollowRequestNotFound 表示关注请求不存在或已处理
+var ErrFollowRequestNotFound = errors.New("关注请求不存在")
+
 // RelationDAO 定义了与用户关系相关的接口
 type RelationDAO interface {
 	ListFollowerRelations(ctx context.Context, followerID int64, pagination domain.Pagination) ([]Relation, error)
@@ -23,6 +28,23 @@ type RelationDAO interface {
 	CancelFollowUser(ctx context.Context, followerID, followeeID int64) error
 	UpdateStatus(ctx context.Context, followerID, followeeID int64, status bool) error
 	FollowCount(ctx context.Context, userID int64) (RelationCount, error)
+	AddBlock(ctx context.Context, userID, targetID int64, blockType uint8) error
+	RemoveBlock(ctx context.Context, userID, targetID int64, blockType uint8) error
+	ListBlocks(ctx context.Context, userID int64, blockType uint8, pagination domain.Pagination) ([]UserBlock, error)
+	ListBlockTargetIDs(ctx context.Context, userID int64, blockType uint8) ([]int64, error)
+	ListFollowerIDs(ctx context.Context, userID int64) ([]int64, error)
+	ListFolloweeIDs(ctx context.Context, userID int64) ([]int64, error)
+	ListFolloweeIDsByMinFollowers(ctx context.Context, userID, minFollowers int64) ([]int64, error)
+	FilterFollowers(ctx context.Context, userID int64, ids []int64) ([]int64, error)
+	FilterFollowees(ctx context.Context, userID int64, ids []int64) ([]int64, error)
+	ListMutualIDs(ctx context.Context, userID int64, pagination domain.Pagination) ([]int64, error)
+	ListCommonFollowees(ctx context.Context, viewerID, targetID int64, limit int) ([]int64, int64, error)
+	ListSecondDegree(ctx context.Context, userID int64, limit int) ([]UserCount, error)
+	RequestFollow(ctx context.Context, followerID, followeeID int64) error
+	ApproveFollowRequest(ctx context.Context, followerID, followeeID int64) error
+	RejectFollowRequest(ctx context.Context, followerID, followeeID int64) error
+	ApproveAllFollowRequests(ctx context.Context, followeeID int64) ([]int64, error)
+	ListFollowRequests(ctx context.Context, followeeID int64, pagination domain.Pagination) ([]Relation, error)
 }
 
 type relationDAO struct {
@@ -51,6 +73,21 @@ type RelationCount struct {
 	UpdatedAt     int64 `gorm:"column:updated_at"`                  // 更新时间
 }
 
+// UserBlock 存储用户的屏蔽(ShieldStatus)与拉黑(BlockStatus)列表
+type UserBlock struct {
+	ID        int64 `gorm:"column:id;primaryKey;autoIncrement"`                        // 主键ID
+	UserID    int64 `gorm:"column:user_id;uniqueIndex:user_id_target_id_type"`         // 操作者ID
+	TargetID  int64 `gorm:"column:target_id;uniqueIndex:user_id_target_id_type;index"` // 被屏蔽或拉黑的用户ID
+	Type      uint8 `gorm:"column:type;uniqueIndex:user_id_target_id_type"`            // ShieldStatus 或 BlockStatus
+	CreatedAt int64 `gorm:"column:created_at"`                                         // 创建时间
+}
+
+// UserCount 用户及其对应的计数，用于聚合查询结果
+type UserCount struct {
+	UserID int64 `gorm:"column:user_id"`
+	Count  int64 `gorm:"column:cnt"`
+}
+
 // NewRelationDAO 创建RelationDAO实例
 func NewRelationDAO(db *gorm.DB, l *zap.Logger) RelationDAO {
 	return &relationDAO{
@@ -130,28 +167,123 @@ func (r *relationDAO) FollowUser(ctx context.Context, followerID, followeeID int
 	return nil
 }
 
-// CancelFollowUser 取消关注用户，并更新相关计数器
-func (r *relationDAO) CancelFollowUser(ctx context.Context, followerID, followeeID int64) error {
+// RequestFollow 向私密账号发起关注请求，已关注或已有请求时忽略
+func (r *relationDAO) RequestFollow(ctx context.Context, followerID, followeeID int64) error {
+	now := r.getCurrentTime()
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&Relation{
+		FollowerID: followerID,
+		FolloweeID: followeeID,
+		Status:     PendingStatus,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}).Error; err != nil {
+		r.l.Error("failed to request follow", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// ApproveFollowRequest 通过关注请求，并更新相关计数器
+func (r *relationDAO) ApproveFollowRequest(ctx context.Context, followerID, followeeID int64) error {
 	now := r.getCurrentTime()
 
 	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// 删除Relation记录
-		if err := tx.Where("follower_id = ? AND followee_id = ? AND status = ?", followerID, followeeID, FollowStatus).
-			Delete(&Relation{}).Error; err != nil {
-			return err
-		}
+		return r.approveRequest(tx, followerID, followeeID, now)
+	})
+	if err != nil && !errors.Is(err, ErrFollowRequestNotFound) {
+		r.l.Error("failed to approve follow request", zap.Error(err))
+	}
+	return err
+}
 
-		// 更新关注者的关注数
-		if err := r.updateRelationCount(tx, followerID, "followee_count", -1, now); err != nil {
-			return err
-		}
+// RejectFollowRequest 拒绝关注请求
+func (r *relationDAO) RejectFollowRequest(ctx context.Context, followerID, followeeID int64) error {
+	res := r.db.WithContext(ctx).
+		Where("follower_id = ? AND followee_id = ? AND status = ?", followerID, followeeID, PendingStatus).
+		Delete(&Relation{})
+	if res.Error != nil {
+		r.l.Error("failed to reject follow request", zap.Error(res.Error))
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrFollowRequestNotFound
+	}
+	return nil
+}
 
-		// 更新被关注者的粉丝数
-		if err := r.updateRelationCount(tx, followeeID, "follower_count", -1, now); err != nil {
+// ApproveAllFollowRequests 通过全部待处理的关注请求，返回请求者ID
+func (r *relationDAO) ApproveAllFollowRequests(ctx context.Context, followeeID int64) ([]int64, error) {
+	now := r.getCurrentTime()
+
+	var approved []int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []int64
+		if err := tx.Model(&Relation{}).
+			Where("followee_id = ? AND status = ?", followeeID, PendingStatus).
+			Pluck("follower_id", &ids).Error; err != nil {
 			return err
 		}
+		for _, id := range ids {
+			if err := r.approveRequest(tx, id, followeeID, now); err != nil {
+				if errors.Is(err, ErrFollowRequestNotFound) {
+					continue
+				}
+				return err
+			}
+			approved = append(approved, id)
+		}
 		return nil
 	})
+	if err != nil {
+		r.l.Error("failed to approve all follow requests", zap.Error(err))
+		return nil, err
+	}
+	return approved, nil
+}
+
+// ListFollowRequests 分页获取待处理的关注请求，按请求时间倒序
+func (r *relationDAO) ListFollowRequests(ctx context.Context, followeeID int64, pagination domain.Pagination) ([]Relation, error) {
+	var relations []Relation
+	if err := r.db.WithContext(ctx).
+		Where("followee_id = ? AND status = ?", followeeID, PendingStatus).
+		Order("created_at DESC").
+		Offset(int(*pagination.Offset)).
+		Limit(int(*pagination.Size)).
+		Find(&relations).Error; err != nil {
+		r.l.Error("failed to list follow requests", zap.Error(err))
+		return nil, err
+	}
+	return relations, nil
+}
+
+// approveRequest 将关注请求转为关注关系，仅在请求确实存在时更新计数器
+func (r *relationDAO) approveRequest(tx *gorm.DB, followerID, followeeID int64, now int64) error {
+	res := tx.Model(&Relation{}).
+		Where("follower_id = ? AND followee_id = ? AND status = ?", followerID, followeeID, PendingStatus).
+		Updates(map[string]any{
+			"status":     FollowStatus,
+			"updated_at": now,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrFollowRequestNotFound
+	}
+	if err := r.updateRelationCount(tx, followerID, "followee_count", 1, now); err != nil {
+		return err
+	}
+	return r.updateRelationCount(tx, followeeID, "follower_count", 1, now)
+}
+
+// CancelFollowUser 取消关注用户，并更新相关计数器
+func (r *relationDAO) CancelFollowUser(ctx context.Context, followerID, followeeID int64) error {
+	now := r.getCurrentTime()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 删除Relation记录并更新双方计数器
+		return r.removeFollow(tx, followerID, followeeID, now)
+	})
 	if err != nil {
 		r.l.Error("failed to cancel follow user", zap.Error(err))
 		return err
@@ -193,8 +325,230 @@ func (r *relationDAO) updateRelationCount(tx *gorm.DB, userID int64, field strin
 			"updated_at": timestamp,
 		}),
 	}).Create(&RelationCount{
-		UserID:    userID,
-		CreatedAt: timestamp,
-		UpdatedAt: timestamp,
+		UserID:        userID,
+		FollowerCount: initialCount(field, "follower_count", delta),
+		FolloweeCount: initialCount(field, "followee_count", delta),
+		CreatedAt:     timestamp,
+		UpdatedAt:     timestamp,
 	}).Error
 }
+
+// initialCount 计数记录首次创建时的初始值，避免首次关注时计数丢失
+func initialCount(field, target string, delta int64) int64 {
+	if field != target || delta < 0 {
+		return 0
+	}
+	return delta
+}
+
+// ListFollowerIDs 获取关注了 userID 的全部用户ID
+func (r *relationDAO) ListFollowerIDs(ctx context.Context, userID int64) ([]int64, error) {
+	var ids []int64
+	if err := r.db.WithContext(ctx).Model(&Relation{}).
+		Where("followee_id = ? AND status = ?", userID, FollowStatus).
+		Pluck("follower_id", &ids).Error; err != nil {
+		r.l.Error("failed to list follower ids", zap.Error(err))
+		return nil, err
+	}
+	return ids, nil
+}
+
+// ListFolloweeIDs 获取 userID 关注的全部用户ID
+func (r *relationDAO) ListFolloweeIDs(ctx context.Context, userID int64) ([]int64, error) {
+	var ids []int64
+	if err := r.db.WithContext(ctx).Model(&Relation{}).
+		Where("follower_id = ? AND status = ?", userID, FollowStatus).
+		Pluck("followee_id", &ids).Error; err != nil {
+		r.l.Error("failed to list followee ids", zap.Error(err))
+		return nil, err
+	}
+	return ids, nil
+}
+
+// ListFolloweeIDsByMinFollowers 获取 userID 关注的用户中粉丝数不少于 minFollowers 的用户ID
+func (r *relationDAO) ListFolloweeIDsByMinFollowers(ctx context.Context, userID, minFollowers int64) ([]int64, error) {
+	var ids []int64
+	if err := r.db.WithContext(ctx).Model(&Relation{}).
+		Joins("JOIN relation_counts ON relation_counts.user_id = relations.followee_id").
+		Where("relations.follower_id = ? AND relations.status = ? AND relation_counts.follower_count >= ?", userID, FollowStatus, minFollowers).
+		Pluck("relations.followee_id", &ids).Error; err != nil {
+		r.l.Error("failed to list followee ids by min followers", zap.Error(err))
+		return nil, err
+	}
+	return ids, nil
+}
+
+// FilterFollowers 返回 ids 中关注了 userID 的用户
+func (r *relationDAO) FilterFollowers(ctx context.Context, userID int64, ids []int64) ([]int64, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var result []int64
+	if err := r.db.WithContext(ctx).Model(&Relation{}).
+		Where("followee_id = ? AND follower_id IN ? AND status = ?", userID, ids, FollowStatus).
+		Pluck("follower_id", &result).Error; err != nil {
+		r.l.Error("failed to filter followers", zap.Error(err))
+		return nil, err
+	}
+	return result, nil
+}
+
+// FilterFollowees 返回 ids 中被 userID 关注的用户
+func (r *relationDAO) FilterFollowees(ctx context.Context, userID int64, ids []int64) ([]int64, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var result []int64
+	if err := r.db.WithContext(ctx).Model(&Relation{}).
+		Where("follower_id = ? AND followee_id IN ? AND status = ?", userID, ids, FollowStatus).
+		Pluck("followee_id", &result).Error; err != nil {
+		r.l.Error("failed to filter followees", zap.Error(err))
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListMutualIDs 分页获取与 userID 互相关注的用户ID，按关注时间倒序
+func (r *relationDAO) ListMutualIDs(ctx context.Context, userID int64, pagination domain.Pagination) ([]int64, error) {
+	var ids []int64
+	if err := r.db.WithContext(ctx).Table("relations AS r1").
+		Joins("JOIN relations AS r2 ON r2.follower_id = r1.followee_id AND r2.followee_id = r1.follower_id AND r2.status = ?", FollowStatus).
+		Where("r1.follower_id = ? AND r1.status = ?", userID, FollowStatus).
+		Order("r1.created_at DESC").
+		Offset(int(*pagination.Offset)).
+		Limit(int(*pagination.Size)).
+		Pluck("r1.followee_id", &ids).Error; err != nil {
+		r.l.Error("failed to list mutual ids", zap.Error(err))
+		return nil, err
+	}
+	return ids, nil
+}
+
+// ListCommonFollowees 获取 viewerID 关注的用户中同样关注了 targetID 的用户，返回最多 limit 个ID及总数
+func (r *relationDAO) ListCommonFollowees(ctx context.Context, viewerID, targetID int64, limit int) ([]int64, int64, error) {
+	query := func() *gorm.DB {
+		return r.db.WithContext(ctx).Table("relations AS r1").
+			Joins("JOIN relations AS r2 ON r2.follower_id = r1.followee_id AND r2.followee_id = ? AND r2.status = ?", targetID, FollowStatus).
+			Where("r1.follower_id = ? AND r1.status = ?", viewerID, FollowStatus)
+	}
+
+	var total int64
+	if err := query().Count(&total).Error; err != nil {
+		r.l.Error("failed to count common followees", zap.Error(err))
+		return nil, 0, err
+	}
+	if total == 0 {
+		return nil, 0, nil
+	}
+
+	var ids []int64
+	if err := query().Order("r2.created_at DESC").Limit(limit).Pluck("r1.followee_id", &ids).Error; err != nil {
+		r.l.Error("failed to list common followees", zap.Error(err))
+		return nil, 0, err
+	}
+	return ids, total, nil
+}
+
+// ListSecondDegree 获取 userID 关注的用户所关注的用户，按共同关注人数倒序
+func (r *relationDAO) ListSecondDegree(ctx context.Context, userID int64, limit int) ([]UserCount, error) {
+	var result []UserCount
+	if err := r.db.WithContext(ctx).Table("relations AS r1").
+		Select("r2.followee_id AS user_id, COUNT(*) AS cnt").
+		Joins("JOIN relations AS r2 ON r2.follower_id = r1.followee_id AND r2.status = ?", FollowStatus).
+		Where("r1.follower_id = ? AND r1.status = ? AND r2.followee_id <> ?", userID, FollowStatus, userID).
+		Group("r2.followee_id").
+		Order("cnt DESC").
+		Limit(limit).
+		Scan(&result).Error; err != nil {
+		r.l.Error("failed to list second degree relations", zap.Error(err))
+		return nil, err
+	}
+	return result, nil
+}
+
+// AddBlock 屏蔽或拉黑用户，拉黑时同时解除双方的关注关系
+func (r *relationDAO) AddBlock(ctx context.Context, userID, targetID int64, blockType uint8) error {
+	now := r.getCurrentTime()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&UserBlock{
+			UserID:    userID,
+			TargetID:  targetID,
+			Type:      blockType,
+			CreatedAt: now,
+		}).Error; err != nil {
+			return err
+		}
+		if blockType != BlockStatus {
+			return nil
+		}
+		if err := r.removeFollow(tx, userID, targetID, now); err != nil {
+			return err
+		}
+		return r.removeFollow(tx, targetID, userID, now)
+	})
+	if err != nil {
+		r.l.Error("failed to add block", zap.Int64("userID", userID), zap.Int64("targetID", targetID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// RemoveBlock 取消屏蔽或拉黑
+func (r *relationDAO) RemoveBlock(ctx context.Context, userID, targetID int64, blockType uint8) error {
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND target_id = ? AND type = ?", userID, targetID, blockType).
+		Delete(&UserBlock{}).Error; err != nil {
+		r.l.Error("failed to remove block", zap.Int64("userID", userID), zap.Int64("targetID", targetID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// ListBlocks 分页获取屏蔽或拉黑列表
+func (r *relationDAO) ListBlocks(ctx context.Context, userID int64, blockType uint8, pagination domain.Pagination) ([]UserBlock, error) {
+	var blocks []UserBlock
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND type = ?", userID, blockType).
+		Order("id DESC").
+		Offset(int(*pagination.Offset)).
+		Limit(int(*pagination.Size)).
+		Find(&blocks).Error; err != nil {
+		r.l.Error("failed to list blocks", zap.Int64("userID", userID), zap.Error(err))
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// ListBlockTargetIDs 获取全部被屏蔽或拉黑的用户ID
+func (r *relationDAO) ListBlockTargetIDs(ctx context.Context, userID int64, blockType uint8) ([]int64, error) {
+	var ids []int64
+	if err := r.db.WithContext(ctx).Model(&UserBlock{}).
+		Where("user_id = ? AND type = ?", userID, blockType).
+		Pluck("target_id", &ids).Error; err != nil {
+		r.l.Error("failed to list block target ids", zap.Int64("userID", userID), zap.Error(err))
+		return nil, err
+	}
+	return ids, nil
+}
+
+// removeFollow 删除关注关系及未处理的关注请求，仅在确实存在关注记录时更新计数器
+func (r *relationDAO) removeFollow(tx *gorm.DB, followerID, followeeID int64, now int64) error {
+	if err := tx.Where("follower_id = ? AND followee_id = ? AND status = ?", followerID, followeeID, PendingStatus).
+		Delete(&Relation{}).Error; err != nil {
+		return err
+	}
+
+	res := tx.Where("follower_id = ? AND followee_id = ? AND status = ?", followerID, followeeID, FollowStatus).
+		Delete(&Relation{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil
+	}
+	if err := r.updateRelationCount(tx, followerID, "followee_count", -1, now); err != nil {
+		return err
+	}
+	return r.updateRelationCount(tx, followeeID, "follower_count", -1, now)
+}
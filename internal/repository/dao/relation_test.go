@@ -0,0 +1,44 @@
+package dao
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestFollowCountsFirstFollow(t *testing.T) {
+	const a, b, c = 1, 2, 3
+	db := newTestDB(t, &Relation{}, &RelationCount{})
+	d := NewRelationDAO(db, zap.NewNop())
+	ctx := context.Background()
+
+	// 双方都还没有计数记录时，首次关注也要计入
+	if err := d.FollowUser(ctx, a, b); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.FollowUser(ctx, c, b); err != nil {
+		t.Fatal(err)
+	}
+	count := func(uid int64) RelationCount {
+		t.Helper()
+		rc, err := d.FollowCount(ctx, uid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rc
+	}
+	if rc := count(a); rc.FolloweeCount != 1 || rc.FollowerCount != 0 {
+		t.Fatalf("follower counts = %d/%d, want followee 1 follower 0", rc.FolloweeCount, rc.FollowerCount)
+	}
+	if rc := count(b); rc.FollowerCount != 2 || rc.FolloweeCount != 0 {
+		t.Fatalf("followee counts = %d/%d, want follower 2 followee 0", rc.FollowerCount, rc.FolloweeCount)
+	}
+
+	if err := d.CancelFollowUser(ctx, a, b); err != nil {
+		t.Fatal(err)
+	}
+	if rc := count(b); rc.FollowerCount != 1 {
+		t.Fatalf("follower count after unfollow = %d, want 1", rc.FollowerCount)
+	}
+}
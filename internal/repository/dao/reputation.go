@@ -0,0 +1,150 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReputationDAO interface {
+	// AddPoints 记录一条积分流水并累加总积分，同一来源重复记录时返回 false
+	AddPoints(ctx context.Context, log ReputationLog) (bool, error)
+	GetPoints(ctx context.Context, uid int64) (int64, error)
+	ListLogs(ctx context.Context, uid int64, offset, limit int) ([]ReputationLog, error)
+	ListLevels(ctx context.Context) ([]ReputationLevel, error)
+	// ReplaceLevels 使用新的等级配置整体替换旧配置
+	ReplaceLevels(ctx context.Context, levels []ReputationLevel) error
+}
+
+type reputationDAO struct {
+	db *gorm.DB
+	l  *zap.Logger
+}
+
+// ReputationLog 积分流水，同一用户、来源、业务与触发者只记录一次
+type ReputationLog struct {
+	ID        int64  `gorm:"primaryKey;autoIncrement"`
+	UserID    int64  `gorm:"column:user_id;not null;uniqueIndex:uid_event_biz_actor;index:uid_created_at"`
+	Event     string `gorm:"column:event;type:varchar(32);not null;uniqueIndex:uid_event_biz_actor"`
+	BizID     int64  `gorm:"column:biz_id;not null;uniqueIndex:uid_event_biz_actor"`
+	ActorID   int64  `gorm:"column:actor_id;not null;default:0;uniqueIndex:uid_event_biz_actor"`
+	Points    int64  `gorm:"column:points;not null"`
+	CreatedAt int64  `gorm:"column:created_at;type:bigint;not null;index:uid_created_at"`
+}
+
+// UserReputation 用户总积分
+type UserReputation struct {
+	ID        int64 `gorm:"primaryKey;autoIncrement"`
+	UserID    int64 `gorm:"column:user_id;not null;uniqueIndex"`
+	Points    int64 `gorm:"column:points;not null;default:0"`
+	UpdatedAt int64 `gorm:"column:updated_at;type:bigint;not null"`
+}
+
+// ReputationLevel 管理员配置的等级门槛与权限
+type ReputationLevel struct {
+	ID               int64  `gorm:"primaryKey;autoIncrement"`
+	Level            int    `gorm:"column:level;not null;uniqueIndex"`
+	Name             string `gorm:"column:name;type:varchar(32);not null"`
+	MinPoints        int64  `gorm:"column:min_points;not null"`
+	CanPostLinks     bool   `gorm:"column:can_post_links;not null;default:false"`
+	CanCreateLottery bool   `gorm:"column:can_create_lottery;not null;default:false"`
+}
+
+func NewReputationDAO(db *gorm.DB, l *zap.Logger) ReputationDAO {
+	return &reputationDAO{
+		db: db,
+		l:  l,
+	}
+}
+
+// AddPoints 记录积分流水
+func (r *reputationDAO) AddPoints(ctx context.Context, log ReputationLog) (bool, error) {
+	now := time.Now().UnixMilli()
+	log.CreatedAt = now
+	added := false
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&log)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		added = true
+
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"points":     gorm.Expr("points + ?", log.Points),
+				"updated_at": now,
+			}),
+		}).Create(&UserReputation{
+			UserID:    log.UserID,
+			Points:    log.Points,
+			UpdatedAt: now,
+		}).Error
+	})
+	if err != nil {
+		r.l.Error("记录积分流水失败", zap.Int64("uid", log.UserID), zap.String("event", log.Event), zap.Error(err))
+		return false, err
+	}
+	return added, nil
+}
+
+// GetPoints 获取用户总积分，没有记录时为0
+func (r *reputationDAO) GetPoints(ctx context.Context, uid int64) (int64, error) {
+	var rep UserReputation
+	if err := r.db.WithContext(ctx).Where("user_id = ?", uid).First(&rep).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil
+		}
+		r.l.Error("获取用户积分失败", zap.Int64("uid", uid), zap.Error(err))
+		return 0, err
+	}
+	return rep.Points, nil
+}
+
+// ListLogs 按时间倒序获取积分流水
+func (r *reputationDAO) ListLogs(ctx context.Context, uid int64, offset, limit int) ([]ReputationLog, error) {
+	var logs []ReputationLog
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", uid).
+		Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&logs).Error; err != nil {
+		r.l.Error("获取积分流水失败", zap.Int64("uid", uid), zap.Error(err))
+		return nil, err
+	}
+	return logs, nil
+}
+
+// ListLevels 按门槛升序获取等级配置
+func (r *reputationDAO) ListLevels(ctx context.Context) ([]ReputationLevel, error) {
+	var levels []ReputationLevel
+	if err := r.db.WithContext(ctx).Order("min_points ASC").Find(&levels).Error; err != nil {
+		r.l.Error("获取等级配置失败", zap.Error(err))
+		return nil, err
+	}
+	return levels, nil
+}
+
+// ReplaceLevels 替换等级配置
+func (r *reputationDAO) ReplaceLevels(ctx context.Context, levels []ReputationLevel) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&ReputationLevel{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&levels).Error
+	})
+	if err != nil {
+		r.l.Error("保存等级配置失败", zap.Error(err))
+		return err
+	}
+	return nil
+}
@@ -28,7 +28,7 @@ type Role struct {
 }
 
 type RoleDAO interface {
-	CreateRole(ctx context.Context, role *Role, menuIds []int, apiIds []int) error
+	CreateRole(ctx context.Context, role *Role, menuIds []int, apiIds []int) (int, error)
 	GetRoleById(ctx context.Context, id int) (*Role, error)
 	UpdateRole(ctx context.Context, role *Role) error
 	DeleteRole(ctx context.Context, id int) error
@@ -40,11 +40,11 @@ type RoleDAO interface {
 type roleDAO struct {
 	db            *gorm.DB
 	l             *zap.Logger
-	enforcer      *casbin.Enforcer
+	enforcer      *casbin.SyncedEnforcer
 	permissionDao PermissionDAO
 }
 
-func NewRoleDAO(db *gorm.DB, l *zap.Logger, enforcer *casbin.Enforcer, permissionDao PermissionDAO) RoleDAO {
+func NewRoleDAO(db *gorm.DB, l *zap.Logger, enforcer *casbin.SyncedEnforcer, permissionDao PermissionDAO) RoleDAO {
 	return &roleDAO{
 		db:            db,
 		l:             l,
@@ -54,13 +54,13 @@ func NewRoleDAO(db *gorm.DB, l *zap.Logger, enforcer *casbin.Enforcer, permissio
 }
 
 // CreateRole 创建角色
-func (r *roleDAO) CreateRole(ctx context.Context, role *Role, menuIds []int, apiIds []int) error {
+func (r *roleDAO) CreateRole(ctx context.Context, role *Role, menuIds []int, apiIds []int) (int, error) {
 	if role == nil {
-		return errors.New("角色对象不能为空")
+		return 0, errors.New("角色对象不能为空")
 	}
 
 	if role.Name == "" {
-		return errors.New("角色名称不能为空")
+		return 0, errors.New("角色名称不能为空")
 	}
 
 	var roleId int
@@ -93,17 +93,17 @@ func (r *roleDAO) CreateRole(ctx context.Context, role *Role, menuIds []int, api
 	})
 
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	// 分配权限
 	if len(menuIds) > 0 || len(apiIds) > 0 {
 		if err := r.permissionDao.AssignRole(ctx, roleId, menuIds, apiIds); err != nil {
-			return fmt.Errorf("分配权限失败: %v", err)
+			return roleId, fmt.Errorf("分配权限失败: %v", err)
 		}
 	}
 
-	return nil
+	return roleId, nil
 }
 
 // GetRoleById 根据ID获取角色
@@ -0,0 +1,118 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SecurityEventDAO interface {
+	CreateEvent(ctx context.Context, event SecurityEvent) error
+	// ListEvents 按时间倒序获取安全事件，uid 为0时不按用户过滤，eventType 为空时不按类型过滤
+	ListEvents(ctx context.Context, uid int64, eventType string, offset, limit int) ([]SecurityEvent, error)
+	// TouchDevice 记录用户的登录设备，返回是否为首次出现的设备
+	TouchDevice(ctx context.Context, device LoginDevice) (bool, error)
+	CountDevices(ctx context.Context, uid int64) (int64, error)
+}
+
+type securityEventDAO struct {
+	db *gorm.DB
+	l  *zap.Logger
+}
+
+// SecurityEvent 安全事件
+type SecurityEvent struct {
+	ID           int64  `gorm:"primaryKey;autoIncrement"`
+	UserID       int64  `gorm:"column:user_id;not null;index"`
+	Type         string `gorm:"column:type;type:varchar(32);not null;index"`
+	SubjectKind  string `gorm:"column:subject_kind;type:varchar(16);not null"`
+	SubjectValue string `gorm:"column:subject_value;type:varchar(128);not null"`
+	IP           string `gorm:"column:ip;type:varchar(64);not null"`
+	UserAgent    string `gorm:"column:user_agent;type:varchar(255);not null"`
+	Detail       string `gorm:"column:detail;type:varchar(255);not null"`
+	CreatedAt    int64  `gorm:"column:created_at;type:bigint;not null;index"`
+}
+
+// LoginDevice 用户登录过的设备，用于识别新设备登录
+type LoginDevice struct {
+	ID          int64  `gorm:"primaryKey;autoIncrement"`
+	UserID      int64  `gorm:"column:user_id;not null;uniqueIndex:uniq_uid_device"`
+	Fingerprint string `gorm:"column:fingerprint;type:char(64);not null;uniqueIndex:uniq_uid_device"`
+	UserAgent   string `gorm:"column:user_agent;type:varchar(255);not null"`
+	LastIP      string `gorm:"column:last_ip;type:varchar(64);not null"`
+	LastLoginAt int64  `gorm:"column:last_login_at;type:bigint;not null"`
+	CreatedAt   int64  `gorm:"column:created_at;type:bigint;not null"`
+}
+
+func NewSecurityEventDAO(db *gorm.DB, l *zap.Logger) SecurityEventDAO {
+	return &securityEventDAO{
+		db: db,
+		l:  l,
+	}
+}
+
+// CreateEvent 记录安全事件
+func (s *securityEventDAO) CreateEvent(ctx context.Context, event SecurityEvent) error {
+	event.CreatedAt = time.Now().UnixMilli()
+	if err := s.db.WithContext(ctx).Create(&event).Error; err != nil {
+		s.l.Error("记录安全事件失败", zap.Int64("uid", event.UserID), zap.String("type", event.Type), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// ListEvents 获取安全事件
+func (s *securityEventDAO) ListEvents(ctx context.Context, uid int64, eventType string, offset, limit int) ([]SecurityEvent, error) {
+	var events []SecurityEvent
+	query := s.db.WithContext(ctx).Model(&SecurityEvent{})
+	if uid > 0 {
+		query = query.Where("user_id = ?", uid)
+	}
+	if eventType != "" {
+		query = query.Where("type = ?", eventType)
+	}
+	if err := query.Order("id DESC").Offset(offset).Limit(limit).Find(&events).Error; err != nil {
+		s.l.Error("获取安全事件失败", zap.Int64("uid", uid), zap.Error(err))
+		return nil, err
+	}
+	return events, nil
+}
+
+// TouchDevice 新设备直接插入，已有设备更新最近登录信息
+func (s *securityEventDAO) TouchDevice(ctx context.Context, device LoginDevice) (bool, error) {
+	now := time.Now().UnixMilli()
+	device.LastLoginAt = now
+	device.CreatedAt = now
+	result := s.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&device)
+	if result.Error != nil {
+		s.l.Error("记录登录设备失败", zap.Int64("uid", device.UserID), zap.Error(result.Error))
+		return false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return true, nil
+	}
+
+	if err := s.db.WithContext(ctx).Model(&LoginDevice{}).
+		Where("user_id = ? AND fingerprint = ?", device.UserID, device.Fingerprint).
+		Updates(map[string]interface{}{
+			"last_ip":       device.LastIP,
+			"last_login_at": now,
+		}).Error; err != nil {
+		s.l.Error("更新登录设备失败", zap.Int64("uid", device.UserID), zap.Error(err))
+		return false, err
+	}
+	return false, nil
+}
+
+// CountDevices 统计用户登录过的设备数量
+func (s *securityEventDAO) CountDevices(ctx context.Context, uid int64) (int64, error) {
+	var count int64
+	if err := s.db.WithContext(ctx).Model(&LoginDevice{}).Where("user_id = ?", uid).Count(&count).Error; err != nil {
+		s.l.Error("统计登录设备失败", zap.Int64("uid", uid), zap.Error(err))
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,180 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrTwoFactorNotFound 表示用户未绑定两步验证
+var ErrTwoFactorNotFound = errors.New("未绑定两步验证")
+
+type TwoFactorDAO interface {
+	FindByUserID(ctx context.Context, uid int64) (UserTwoFactor, error)
+	// SaveSecret 保存新生成的密钥，覆盖未启用的旧密钥
+	SaveSecret(ctx context.Context, uid int64, secret string) error
+	// Enable 启用两步验证并写入恢复码
+	Enable(ctx context.Context, uid int64, codeHashes []string) error
+	Disable(ctx context.Context, uid int64) error
+	ReplaceRecoveryCodes(ctx context.Context, uid int64, codeHashes []string) error
+	// UseRecoveryCode 核销恢复码，恢复码不存在或已使用时返回 false
+	UseRecoveryCode(ctx context.Context, uid int64, codeHash string) (bool, error)
+	CountRecoveryCodes(ctx context.Context, uid int64) (int64, error)
+}
+
+type twoFactorDAO struct {
+	db *gorm.DB
+	l  *zap.Logger
+}
+
+// UserTwoFactor 用户两步验证配置
+type UserTwoFactor struct {
+	ID        int64  `gorm:"primaryKey;autoIncrement"`
+	UserID    int64  `gorm:"column:user_id;not null;uniqueIndex"`
+	Secret    string `gorm:"type:varchar(64);not null"` // Base32 编码的 TOTP 密钥
+	Enabled   bool   `gorm:"not null;default:false"`    // 验证首个验证码后启用
+	CreatedAt int64  `gorm:"column:created_at;type:bigint;not null"`
+	UpdatedAt int64  `gorm:"column:updated_at;type:bigint;not null"`
+}
+
+// RecoveryCode 两步验证恢复码，仅保存哈希
+type RecoveryCode struct {
+	ID        int64  `gorm:"primaryKey;autoIncrement"`
+	UserID    int64  `gorm:"column:user_id;not null;index:user_id_code"`
+	CodeHash  string `gorm:"type:char(64);not null;index:user_id_code"`
+	UsedAt    int64  `gorm:"column:used_at;type:bigint;not null;default:0"` // 0 表示未使用
+	CreatedAt int64  `gorm:"column:created_at;type:bigint;not null"`
+}
+
+func NewTwoFactorDAO(db *gorm.DB, l *zap.Logger) TwoFactorDAO {
+	return &twoFactorDAO{
+		db: db,
+		l:  l,
+	}
+}
+
+// FindByUserID 获取用户的两步验证配置
+func (t *twoFactorDAO) FindByUserID(ctx context.Context, uid int64) (UserTwoFactor, error) {
+	var tf UserTwoFactor
+	if err := t.db.WithContext(ctx).Where("user_id = ?", uid).First(&tf).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return UserTwoFactor{}, ErrTwoFactorNotFound
+		}
+		t.l.Error("获取两步验证配置失败", zap.Error(err))
+		return UserTwoFactor{}, err
+	}
+	return tf, nil
+}
+
+// SaveSecret 保存待启用的密钥
+func (t *twoFactorDAO) SaveSecret(ctx context.Context, uid int64, secret string) error {
+	now := time.Now().UnixMilli()
+	tf := UserTwoFactor{
+		UserID:    uid,
+		Secret:    secret,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := t.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"secret", "enabled", "updated_at"}),
+	}).Create(&tf).Error; err != nil {
+		t.l.Error("保存两步验证密钥失败", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Enable 启用两步验证，同时替换恢复码
+func (t *twoFactorDAO) Enable(ctx context.Context, uid int64, codeHashes []string) error {
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&UserTwoFactor{}).
+			Where("user_id = ? AND enabled = ?", uid, false).
+			Updates(map[string]any{"enabled": true, "updated_at": time.Now().UnixMilli()})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrTwoFactorNotFound
+		}
+		return replaceRecoveryCodes(tx, uid, codeHashes)
+	})
+	if err != nil && !errors.Is(err, ErrTwoFactorNotFound) {
+		t.l.Error("启用两步验证失败", zap.Error(err))
+	}
+	return err
+}
+
+// Disable 关闭两步验证并删除恢复码
+func (t *twoFactorDAO) Disable(ctx context.Context, uid int64) error {
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", uid).Delete(&UserTwoFactor{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", uid).Delete(&RecoveryCode{}).Error
+	})
+	if err != nil {
+		t.l.Error("关闭两步验证失败", zap.Error(err))
+	}
+	return err
+}
+
+// ReplaceRecoveryCodes 重新生成恢复码，旧恢复码全部作废
+func (t *twoFactorDAO) ReplaceRecoveryCodes(ctx context.Context, uid int64, codeHashes []string) error {
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return replaceRecoveryCodes(tx, uid, codeHashes)
+	})
+	if err != nil {
+		t.l.Error("重新生成恢复码失败", zap.Error(err))
+	}
+	return err
+}
+
+// UseRecoveryCode 核销恢复码
+func (t *twoFactorDAO) UseRecoveryCode(ctx context.Context, uid int64, codeHash string) (bool, error) {
+	res := t.db.WithContext(ctx).Model(&RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at = ?", uid, codeHash, 0).
+		Limit(1).
+		Update("used_at", time.Now().UnixMilli())
+	if res.Error != nil {
+		t.l.Error("核销恢复码失败", zap.Error(res.Error))
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// CountRecoveryCodes 统计剩余可用的恢复码
+func (t *twoFactorDAO) CountRecoveryCodes(ctx context.Context, uid int64) (int64, error) {
+	var count int64
+	if err := t.db.WithContext(ctx).Model(&RecoveryCode{}).
+		Where("user_id = ? AND used_at = ?", uid, 0).
+		Count(&count).Error; err != nil {
+		t.l.Error("统计恢复码失败", zap.Error(err))
+		return 0, err
+	}
+	return count, nil
+}
+
+func replaceRecoveryCodes(tx *gorm.DB, uid int64, codeHashes []string) error {
+	if err := tx.Where("user_id = ?", uid).Delete(&RecoveryCode{}).Error; err != nil {
+		return err
+	}
+	if len(codeHashes) == 0 {
+		return nil
+	}
+
+	now := time.Now().UnixMilli()
+	codes := make([]RecoveryCode, len(codeHashes))
+	for i, hash := range codeHashes {
+		codes[i] = RecoveryCode{
+			UserID:    uid,
+			CodeHash:  hash,
+			CreatedAt: now,
+		}
+	}
+	return tx.Create(&codes).Error
+}
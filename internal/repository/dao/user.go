@@ -8,7 +8,6 @@ import (
 
 	"github.com/GoSimplicity/LinkMe/internal/domain"
 	"github.com/casbin/casbin/v2"
-	"github.com/go-sql-driver/mysql"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
@@ -20,6 +19,8 @@ var (
 	ErrDuplicateUsername = errors.New("用户名已存在")
 	// ErrUserNotFound 表示用户未找到错误
 	ErrUserNotFound = errors.New("用户不存在")
+	// ErrEmailExists 表示邮箱已被其他账号验证
+	ErrEmailExists = errors.New("该邮箱已被注册")
 )
 
 type UserDAO interface {
@@ -27,18 +28,23 @@ type UserDAO interface {
 	FindByID(ctx context.Context, id int64) (User, error)
 	FindByUsername(ctx context.Context, username string) (User, error)
 	FindByPhone(ctx context.Context, phone string) (User, error)
+	// FindByVerifiedEmail 通过已验证的邮箱查找用户
+	FindByVerifiedEmail(ctx context.Context, email string) (User, error)
 	UpdatePasswordByUsername(ctx context.Context, username string, newPassword string) error
-	DeleteUser(ctx context.Context, username string, uid int64) error
 	UpdateProfile(ctx context.Context, profile domain.Profile) error
 	GetProfileByUserID(ctx context.Context, userId int64) (domain.Profile, error)
 	ListUser(ctx context.Context, pagination domain.Pagination) ([]domain.UserWithProfile, error)
 	UpdateProfileAdmin(ctx context.Context, profile domain.Profile) error
+	UpdatePrivacy(ctx context.Context, uid int64, isPrivate bool) error
+	ListPrivateUserIDs(ctx context.Context, uids []int64) ([]int64, error)
+	// UpdateVerifiedEmail 设置已验证的邮箱，邮箱已被其他账号验证时返回 ErrEmailExists
+	UpdateVerifiedEmail(ctx context.Context, uid int64, email string) error
 }
 
 type userDAO struct {
 	db *gorm.DB
 	l  *zap.Logger
-	ce *casbin.Enforcer
+	ce *casbin.SyncedEnforcer
 }
 
 // User 用户模型
@@ -56,17 +62,20 @@ type User struct {
 
 // Profile 用户资料信息模型
 type Profile struct {
-	ID       int64   `gorm:"primaryKey;autoIncrement"`
-	UserID   int64   `gorm:"not null;index"`
-	RealName string  `gorm:"size:50"`
-	Avatar   string  `gorm:"type:text"`
-	About    string  `gorm:"type:text"`
-	Birthday string  `gorm:"column:birthday;type:varchar(10)"`
-	Email    string  `gorm:"type:varchar(100)"`
-	Phone    *string `gorm:"type:varchar(15);uniqueIndex"`
+	ID            int64   `gorm:"primaryKey;autoIncrement"`
+	UserID        int64   `gorm:"not null;index"`
+	RealName      string  `gorm:"size:50"`
+	Avatar        string  `gorm:"type:text"`
+	About         string  `gorm:"type:text"`
+	Birthday      string  `gorm:"column:birthday;type:varchar(10)"`
+	Email         string  `gorm:"type:varchar(100);index"`
+	EmailVerified bool    `gorm:"column:email_verified;default:false;not null"`        // 邮箱是否已验证
+	VerifiedEmail *string `gorm:"column:verified_email;type:varchar(100);uniqueIndex"` // 已验证的邮箱，未验证时为 NULL，唯一索引保证同一邮箱只能被一个账号验证
+	Phone         *string `gorm:"type:varchar(15);uniqueIndex"`
+	IsPrivate     bool    `gorm:"column:is_private;default:false;not null"` // 私密账号，关注需经本人同意
 }
 
-func NewUserDAO(db *gorm.DB, l *zap.Logger, ce *casbin.Enforcer) UserDAO {
+func NewUserDAO(db *gorm.DB, l *zap.Logger, ce *casbin.SyncedEnforcer) UserDAO {
 	return &userDAO{
 		db: db,
 		l:  l,
@@ -80,42 +89,40 @@ func (ud *userDAO) currentTime() int64 {
 
 // CreateUser 创建用户
 func (ud *userDAO) CreateUser(ctx context.Context, u User) error {
-	now := ud.currentTime()
+	err := ud.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return createUserWithProfile(tx, &u, ud.currentTime())
+	})
+	if err != nil {
+		if errors.Is(err, ErrDuplicateUsername) {
+			ud.l.Error("用户名重复错误", zap.String("username", u.Username), zap.Error(err))
+		} else {
+			ud.l.Error("创建用户失败", zap.Error(err))
+		}
+	}
+
+	return err
+}
+
+// createUserWithProfile 在调用方的事务中创建用户与资料，供普通注册、邀请注册与第三方登录注册共用，
+// 资料仅使用 u.Profile 中的昵称与头像
+func createUserWithProfile(tx *gorm.DB, u *User, now int64) error {
 	u.CreateTime = now
 	u.UpdatedTime = now
-
 	if u.Roles == "" {
 		u.Roles = "[]"
 	}
 
-	profile := Profile{
-		UserID:   u.ID,
-		RealName: "",
-		Avatar:   "",
-		About:    "",
-		Birthday: "",
-	}
-
-	err := ud.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := tx.Create(&u).Error; err != nil {
-			var mysqlErr *mysql.MySQLError
-			if errors.As(err, &mysqlErr) && mysqlErr.Number == ErrCodeDuplicateUsernameNumber {
-				ud.l.Error("用户名重复错误", zap.String("username", u.Username), zap.Error(err))
-				return ErrDuplicateUsername
-			}
-			ud.l.Error("创建用户失败", zap.Error(err))
-			return err
+	if err := tx.Omit("Profile").Create(u).Error; err != nil {
+		if isDuplicateErr(err) {
+			return ErrDuplicateUsername
 		}
-
-		if err := tx.Create(&profile).Error; err != nil {
-			ud.l.Error("创建用户资料失败", zap.Error(err))
-			return err
-		}
-
-		return nil
-	})
-
-	return err
+		return err
+	}
+	return tx.Create(&Profile{
+		UserID:   u.ID,
+		RealName: u.Profile.RealName,
+		Avatar:   u.Profile.Avatar,
+	}).Error
 }
 
 func (ud *userDAO) FindByID(ctx context.Context, id int64) (User, error) {
@@ -157,6 +164,19 @@ func (ud *userDAO) FindByPhone(ctx context.Context, phone string) (User, error)
 	return ud.FindByID(ctx, profile.UserID)
 }
 
+func (ud *userDAO) FindByVerifiedEmail(ctx context.Context, email string) (User, error) {
+	var profile Profile
+	err := ud.db.WithContext(ctx).Where("email = ? AND email_verified = ?", email, true).First(&profile).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return User{}, ErrUserNotFound
+		}
+		return User{}, err
+	}
+
+	return ud.FindByID(ctx, profile.UserID)
+}
+
 func (ud *userDAO) UpdatePasswordByUsername(ctx context.Context, username string, newPassword string) error {
 	result := ud.db.WithContext(ctx).Model(&User{}).
 		Where("username = ? AND deleted = ?", username, false).
@@ -174,27 +194,6 @@ func (ud *userDAO) UpdatePasswordByUsername(ctx context.Context, username string
 	return nil
 }
 
-func (ud *userDAO) DeleteUser(ctx context.Context, username string, uid int64) error {
-	err := ud.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		result := tx.Model(&User{}).
-			Where("username = ? AND deleted = ? AND id = ?", username, false, uid).
-			Update("deleted", true)
-
-		if result.Error != nil {
-			ud.l.Error("标记用户删除失败", zap.String("username", username), zap.Error(result.Error))
-			return result.Error
-		}
-
-		if result.RowsAffected == 0 {
-			return ErrUserNotFound
-		}
-
-		return nil
-	})
-
-	return err
-}
-
 func (ud *userDAO) UpdateProfile(ctx context.Context, profile domain.Profile) error {
 	updates := domain.Profile{
 		RealName: profile.RealName,
@@ -292,3 +291,67 @@ func (ud *userDAO) UpdateProfileAdmin(ctx context.Context, profile domain.Profil
 
 	return nil
 }
+
+// UpdatePrivacy 设置账号是否私密
+func (ud *userDAO) UpdatePrivacy(ctx context.Context, uid int64, isPrivate bool) error {
+	result := ud.db.WithContext(ctx).Model(&Profile{}).
+		Where("user_id = ?", uid).
+		Update("is_private", isPrivate)
+	if result.Error != nil {
+		ud.l.Error("更新账号隐私设置失败", zap.Int64("uid", uid), zap.Error(result.Error))
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// ListPrivateUserIDs 返回 uids 中的私密账号
+func (ud *userDAO) ListPrivateUserIDs(ctx context.Context, uids []int64) ([]int64, error) {
+	if len(uids) == 0 {
+		return nil, nil
+	}
+	var ids []int64
+	if err := ud.db.WithContext(ctx).Model(&Profile{}).
+		Where("user_id IN ? AND is_private = ?", uids, true).
+		Pluck("user_id", &ids).Error; err != nil {
+		ud.l.Error("获取私密账号失败", zap.Error(err))
+		return nil, err
+	}
+	return ids, nil
+}
+
+// UpdateVerifiedEmail 更新邮箱并标记为已验证
+func (ud *userDAO) UpdateVerifiedEmail(ctx context.Context, uid int64, email string) error {
+	err := ud.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&Profile{}).
+			Where("email = ? AND email_verified = ? AND user_id <> ?", email, true, uid).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrEmailExists
+		}
+
+		// 并发验证同一邮箱时由 verified_email 唯一索引兜底
+		result := tx.Model(&Profile{}).
+			Where("user_id = ?", uid).
+			Updates(map[string]any{"email": email, "email_verified": true, "verified_email": email})
+		if result.Error != nil {
+			if isDuplicateErr(result.Error) {
+				return ErrEmailExists
+			}
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrUserNotFound
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, ErrEmailExists) && !errors.Is(err, ErrUserNotFound) {
+		ud.l.Error("更新邮箱失败", zap.Int64("uid", uid), zap.Error(err))
+	}
+	return err
+}
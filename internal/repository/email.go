@@ -3,37 +3,54 @@ package repository
 import (
 	"context"
 	"fmt"
+	"github.com/GoSimplicity/LinkMe/internal/domain"
 	"github.com/GoSimplicity/LinkMe/internal/repository/cache"
 	qqEmail "github.com/GoSimplicity/LinkMe/pkg/email"
 	"github.com/GoSimplicity/LinkMe/utils"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
+	"time"
 )
 
 type EmailRepository interface {
-	SendCode(ctx context.Context, email string) error
-	CheckCode(ctx context.Context, email, vCode string) (bool, error)
+	SendCode(ctx context.Context, email string, purpose domain.EmailPurpose) error
+	// CheckCode 校验验证码，通过后验证码立即失效
+	CheckCode(ctx context.Context, email string, purpose domain.EmailPurpose, vCode string) (bool, error)
+	// Cooldown 限制验证码发送频率，冷却期内返回 false
+	Cooldown(ctx context.Context, email string, purpose domain.EmailPurpose, ttl time.Duration) (bool, error)
+	Notify(ctx context.Context, uid int64, t domain.NotificationType, email string, subject string, body string) error
 }
 
 // emailRepository 实现了 EmailRepository 接口
 type emailRepository struct {
-	cache cache.EmailCache
-	l     *zap.Logger
+	cache  cache.EmailCache
+	l      *zap.Logger
+	notify NotificationRepository
 }
 
 // NewEmailRepository 创建并返回一个新的 smsRepository 实例
-func NewEmailRepository(cache cache.EmailCache, l *zap.Logger) EmailRepository {
+func NewEmailRepository(cache cache.EmailCache, l *zap.Logger, notify NotificationRepository) EmailRepository {
 	return &emailRepository{
-		cache: cache,
-		l:     l,
+		cache:  cache,
+		l:      l,
+		notify: notify,
 	}
 }
 
-func (e emailRepository) SendCode(ctx context.Context, email string) error {
-	e.l.Info("[emailRepository.SendCode]", zap.String("email", email))
+// emailSubjects 不同用途验证码邮件的标题
+var emailSubjects = map[domain.EmailPurpose]string{
+	"":                        "【LinkMe】密码重置",
+	domain.EmailPurposeVerify: "【LinkMe】邮箱验证",
+	domain.EmailPurposeLogin:  "【LinkMe】登录验证码",
+	domain.EmailPurposeChange: "【LinkMe】更换邮箱验证",
+	domain.EmailPurposeReset:  "【LinkMe】密码重置",
+}
+
+func (e emailRepository) SendCode(ctx context.Context, email string, purpose domain.EmailPurpose) error {
+	e.l.Info("[emailRepository.SendCode]", zap.String("email", email), zap.String("purpose", string(purpose)))
 	vCode := utils.GenRandomCode(6)
 	e.l.Info("[emailRepository.SendCode]", zap.String("vCode", vCode))
-	if err := e.cache.StoreVCode(ctx, email, vCode); err != nil {
+	if err := e.cache.StoreVCode(ctx, email, purpose, vCode); err != nil {
 		e.l.Error("[emailRepository.SendCode] StoreVCode失败", zap.Error(err))
 		return err
 	}
@@ -42,10 +59,39 @@ func (e emailRepository) SendCode(ctx context.Context, email string) error {
 		e.l.Info("[emailRepository.SendCode] 邮件验证码走模拟通道", zap.String("email", email), zap.String("vCode", vCode))
 		return nil
 	}
-	return qqEmail.SendEmail(email, "【LinkMe】密码重置", body)
+	return qqEmail.SendEmail(email, emailSubjects[purpose], body)
+}
+
+func (e emailRepository) CheckCode(ctx context.Context, email string, purpose domain.EmailPurpose, vCode string) (bool, error) {
+	storedCode, err := e.cache.GetVCode(ctx, email, purpose)
+	if err != nil || storedCode != vCode {
+		return false, err
+	}
+	if err := e.cache.DelVCode(ctx, email, purpose); err != nil {
+		e.l.Warn("[emailRepository.CheckCode] DelVCode失败", zap.Error(err))
+	}
+	return true, nil
+}
+
+func (e emailRepository) Cooldown(ctx context.Context, email string, purpose domain.EmailPurpose, ttl time.Duration) (bool, error) {
+	return e.cache.Cooldown(ctx, email, purpose, ttl)
 }
 
-func (e emailRepository) CheckCode(ctx context.Context, email, vCode string) (bool, error) {
-	storedCode, err := e.cache.GetVCode(ctx, email)
-	return storedCode == vCode, err
+// Notify 发送邮件通知，投递前校验用户的通知偏好与免打扰时段
+func (e emailRepository) Notify(ctx context.Context, uid int64, t domain.NotificationType, email string, subject string, body string) error {
+	if email == "" {
+		return nil
+	}
+	ok, err := e.notify.Allow(ctx, uid, t, domain.ChannelEmail)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotificationSuppressed
+	}
+	if viper.GetString("email.provider") != "qq" {
+		e.l.Info("[emailRepository.Notify] 邮件通知走模拟通道", zap.Int64("uid", uid), zap.String("email", email), zap.String("subject", subject))
+		return nil
+	}
+	return qqEmail.SendEmail(email, subject, body)
 }
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/GoSimplicity/LinkMe/internal/repository/cache"
+	"github.com/GoSimplicity/LinkMe/internal/repository/dao"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+const (
+	// 粉丝数达到该值的作者不再推送，由粉丝读取时拉取
+	defaultBigVThreshold = 5000
+	// 单次推送的收件箱数量
+	feedPushBatchSize = 500
+	// 关注新用户时回填的帖子数
+	feedBackfillSize = 100
+)
+
+// FeedRepository 关注流存储，采用推拉结合的方式：普通作者发布时推送到粉丝收件箱，大V作者由粉丝读取时拉取
+type FeedRepository interface {
+	// PushPost 将新发布的帖子推送到作者及其粉丝的收件箱，大V作者只推送到自己的收件箱
+	PushPost(ctx context.Context, postId uint, authorId int64) error
+	// InboxPostIds 读取收件箱中小于 maxId 的帖子ID，收件箱不存在时从关注的作者重建
+	InboxPostIds(ctx context.Context, uid int64, maxId uint, limit int) ([]uint, error)
+	// PullPostIds 拉取关注的大V作者小于 maxId 的帖子ID
+	PullPostIds(ctx context.Context, uid int64, maxId uint, limit int) ([]uint, error)
+	// Backfill 关注新用户后将其近期帖子回填到收件箱
+	Backfill(ctx context.Context, uid, followeeId int64) error
+	// RemoveAuthor 取消关注后从收件箱中移除该作者的帖子
+	RemoveAuthor(ctx context.Context, uid, followeeId int64) error
+}
+
+type feedRepository struct {
+	cache       cache.FeedCache
+	postDAO     dao.PostDAO
+	relationDAO dao.RelationDAO
+	l           *zap.Logger
+}
+
+func NewFeedRepository(cache cache.FeedCache, postDAO dao.PostDAO, relationDAO dao.RelationDAO, l *zap.Logger) FeedRepository {
+	return &feedRepository{
+		cache:       cache,
+		postDAO:     postDAO,
+		relationDAO: relationDAO,
+		l:           l,
+	}
+}
+
+// bigVThreshold 获取大V粉丝数阈值
+func (f *feedRepository) bigVThreshold() int64 {
+	if threshold := viper.GetInt64("feed.big_v_threshold"); threshold > 0 {
+		return threshold
+	}
+	return defaultBigVThreshold
+}
+
+// PushPost 推送帖子到收件箱
+func (f *feedRepository) PushPost(ctx context.Context, postId uint, authorId int64) error {
+	// 作者自己的收件箱总是推送
+	if err := f.cache.PushInboxes(ctx, []int64{authorId}, postId); err != nil {
+		return err
+	}
+
+	count, err := f.relationDAO.FollowCount(ctx, authorId)
+	if err == nil && count.FollowerCount >= f.bigVThreshold() {
+		return nil
+	}
+
+	followers, err := f.relationDAO.ListFollowerIDs(ctx, authorId)
+	if err != nil {
+		return err
+	}
+	for start := 0; start < len(followers); start += feedPushBatchSize {
+		end := min(start+feedPushBatchSize, len(followers))
+		if err := f.cache.PushInboxes(ctx, followers[start:end], postId); err != nil {
+			f.l.Error("推送帖子到收件箱失败", zap.Uint("post_id", postId), zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
+// InboxPostIds 读取收件箱
+func (f *feedRepository) InboxPostIds(ctx context.Context, uid int64, maxId uint, limit int) ([]uint, error) {
+	ids, ok, err := f.cache.GetInbox(ctx, uid, maxId, limit)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return ids, nil
+	}
+
+	// 收件箱不存在(新用户或长期未活跃)，从关注的作者及自己的帖子重建
+	authors, err := f.relationDAO.ListFolloweeIDs(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	authors = append(authors, uid)
+	recent, err := f.postDAO.ListPubIdsByAuthors(ctx, authors, 0, cache.FeedInboxMaxSize)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.cache.RebuildInbox(ctx, uid, recent); err != nil {
+		f.l.Warn("重建收件箱失败", zap.Int64("uid", uid), zap.Error(err))
+	}
+
+	ids = make([]uint, 0, limit)
+	for _, id := range recent {
+		if maxId > 0 && id >= maxId {
+			continue
+		}
+		ids = append(ids, id)
+		if len(ids) >= limit {
+			break
+		}
+	}
+	return ids, nil
+}
+
+// PullPostIds 拉取大V作者的帖子
+func (f *feedRepository) PullPostIds(ctx context.Context, uid int64, maxId uint, limit int) ([]uint, error) {
+	authors, err := f.relationDAO.ListFolloweeIDsByMinFollowers(ctx, uid, f.bigVThreshold())
+	if err != nil {
+		return nil, err
+	}
+	return f.postDAO.ListPubIdsByAuthors(ctx, authors, maxId, limit)
+}
+
+// Backfill 回填新关注用户的帖子
+func (f *feedRepository) Backfill(ctx context.Context, uid, followeeId int64) error {
+	ids, err := f.postDAO.ListPubIdsByAuthors(ctx, []int64{followeeId}, 0, feedBackfillSize)
+	if err != nil {
+		return err
+	}
+	return f.cache.AddToInbox(ctx, uid, ids)
+}
+
+// RemoveAuthor 移除取消关注用户的帖子
+func (f *feedRepository) RemoveAuthor(ctx context.Context, uid, followeeId int64) error {
+	ids, err := f.postDAO.ListPubIdsByAuthors(ctx, []int64{followeeId}, 0, cache.FeedInboxMaxSize)
+	if err != nil {
+		return err
+	}
+	return f.cache.RemoveFromInbox(ctx, uid, ids)
+}
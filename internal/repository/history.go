@@ -13,6 +13,8 @@ type HistoryRepository interface {
 	SetHistory(ctx context.Context, post domain.Post) error
 	DeleteOneHistory(ctx context.Context, postId uint, uid int64) error
 	DeleteAllHistory(ctx context.Context, uid int64) error
+	// ScanActiveUsers 分批扫描近期有阅读记录的用户，cursor 为 0 时从头开始，返回的 cursor 为 0 表示扫描结束
+	ScanActiveUsers(ctx context.Context, cursor uint64, count int64) ([]int64, uint64, error)
 }
 
 type historyRepository struct {
@@ -53,6 +55,11 @@ func (h *historyRepository) DeleteAllHistory(ctx context.Context, uid int64) err
 	return h.cache.DeleteAllHistory(ctx, uid)
 }
 
+// ScanActiveUsers 扫描近期活跃的用户
+func (h *historyRepository) ScanActiveUsers(ctx context.Context, cursor uint64, count int64) ([]int64, uint64, error) {
+	return h.cache.ScanUids(ctx, cursor, count)
+}
+
 // createContentSummary 创建内容摘要,限制为28个汉字
 func createContentSummary(content string) string {
 	const limit = 28
@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/repository/dao"
+	"go.uber.org/zap"
+)
+
+// ErrMessageNotFound 消息不存在或无权操作
+var ErrMessageNotFound = dao.ErrMessageNotFound
+
+// IMRepository 私信存储
+type IMRepository interface {
+	GetOrCreateConversation(ctx context.Context, id, uid, peerID int64) (int64, error)
+	// GetConversation 获取用户视角下的会话，非会话成员返回错误
+	GetConversation(ctx context.Context, conversationID, uid int64) (domain.Conversation, int64, error)
+	ListConversations(ctx context.Context, uid int64, pagination domain.Pagination) ([]domain.Conversation, error)
+	SendMessage(ctx context.Context, msg domain.Message) error
+	GetMessage(ctx context.Context, id int64) (domain.Message, error)
+	ListMessages(ctx context.Context, conversationID, beforeID int64, limit int) ([]domain.Message, error)
+	MarkRead(ctx context.Context, conversationID, uid, msgID int64) error
+	RecallMessage(ctx context.Context, id, senderID int64) error
+	TotalUnread(ctx context.Context, uid int64) (int64, error)
+}
+
+type imRepository struct {
+	dao dao.IMDAO
+	l   *zap.Logger
+}
+
+func NewIMRepository(dao dao.IMDAO, l *zap.Logger) IMRepository {
+	return &imRepository{
+		dao: dao,
+		l:   l,
+	}
+}
+
+// GetOrCreateConversation 获取两个用户之间的会话ID，不存在时使用传入的ID创建
+func (i *imRepository) GetOrCreateConversation(ctx context.Context, id, uid, peerID int64) (int64, error) {
+	conv, err := i.dao.GetOrCreateConversation(ctx, dao.Conversation{
+		ID:    id,
+		UserA: uid,
+		UserB: peerID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return conv.ID, nil
+}
+
+// GetConversation 获取会话，同时返回对方的已读位置用于已读回执
+func (i *imRepository) GetConversation(ctx context.Context, conversationID, uid int64) (domain.Conversation, int64, error) {
+	member, err := i.dao.GetMember(ctx, conversationID, uid)
+	if err != nil {
+		return domain.Conversation{}, 0, err
+	}
+	peer, err := i.dao.GetMember(ctx, conversationID, member.PeerID)
+	if err != nil {
+		return domain.Conversation{}, 0, err
+	}
+	return toDomainConversation(member), peer.LastReadMsgID, nil
+}
+
+// ListConversations 获取会话列表，并附带每个会话的最后一条消息
+func (i *imRepository) ListConversations(ctx context.Context, uid int64, pagination domain.Pagination) ([]domain.Conversation, error) {
+	members, err := i.dao.ListMembers(ctx, uid, int(*pagination.Offset), int(*pagination.Size))
+	if err != nil {
+		return nil, err
+	}
+	if len(members) == 0 {
+		return []domain.Conversation{}, nil
+	}
+
+	cids := make([]int64, 0, len(members))
+	for _, m := range members {
+		cids = append(cids, m.ConversationID)
+	}
+	convs, err := i.dao.GetConversationsByIDs(ctx, cids)
+	if err != nil {
+		return nil, err
+	}
+	lastIDs := make([]int64, 0, len(convs))
+	for _, c := range convs {
+		if c.LastMsgID > 0 {
+			lastIDs = append(lastIDs, c.LastMsgID)
+		}
+	}
+	msgs, err := i.dao.GetMessagesByIDs(ctx, lastIDs)
+	if err != nil {
+		return nil, err
+	}
+	lastMsgs := make(map[int64]domain.Message, len(msgs))
+	for _, m := range msgs {
+		lastMsgs[m.ConversationID] = toDomainMessage(m)
+	}
+
+	conversations := make([]domain.Conversation, 0, len(members))
+	for _, m := range members {
+		conv := toDomainConversation(m)
+		if msg, ok := lastMsgs[m.ConversationID]; ok {
+			conv.LastMessage = &msg
+		}
+		conversations = append(conversations, conv)
+	}
+	return conversations, nil
+}
+
+// SendMessage 发送消息
+func (i *imRepository) SendMessage(ctx context.Context, msg domain.Message) error {
+	return i.dao.InsertMessage(ctx, dao.Message{
+		ID:             msg.ID,
+		ConversationID: msg.ConversationID,
+		SenderID:       msg.SenderID,
+		ReceiverID:     msg.ReceiverID,
+		Content:        msg.Content,
+		Status:         dao.MessageNormal,
+	})
+}
+
+// GetMessage 获取消息
+func (i *imRepository) GetMessage(ctx context.Context, id int64) (domain.Message, error) {
+	msg, err := i.dao.GetMessage(ctx, id)
+	if err != nil {
+		return domain.Message{}, err
+	}
+	return toDomainMessage(msg), nil
+}
+
+// ListMessages 获取会话历史消息
+func (i *imRepository) ListMessages(ctx context.Context, conversationID, beforeID int64, limit int) ([]domain.Message, error) {
+	msgs, err := i.dao.ListMessages(ctx, conversationID, beforeID, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.Message, 0, len(msgs))
+	for _, m := range msgs {
+		result = append(result, toDomainMessage(m))
+	}
+	return result, nil
+}
+
+// MarkRead 标记已读
+func (i *imRepository) MarkRead(ctx context.Context, conversationID, uid, msgID int64) error {
+	return i.dao.MarkRead(ctx, conversationID, uid, msgID)
+}
+
+// RecallMessage 撤回消息
+func (i *imRepository) RecallMessage(ctx context.Context, id, senderID int64) error {
+	return i.dao.RecallMessage(ctx, id, senderID)
+}
+
+// TotalUnread 获取未读总数
+func (i *imRepository) TotalUnread(ctx context.Context, uid int64) (int64, error) {
+	return i.dao.TotalUnread(ctx, uid)
+}
+
+// toDomainConversation 将dao层对象转为领域层对象
+func toDomainConversation(m dao.ConversationMember) domain.Conversation {
+	return domain.Conversation{
+		ID:          m.ConversationID,
+		PeerID:      m.PeerID,
+		UnreadCount: m.UnreadCount,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+// toDomainMessage 将dao层对象转为领域层对象，撤回的消息不返回内容
+func toDomainMessage(m dao.Message) domain.Message {
+	msg := domain.Message{
+		ID:             m.ID,
+		ConversationID: m.ConversationID,
+		SenderID:       m.SenderID,
+		ReceiverID:     m.ReceiverID,
+		Content:        m.Content,
+		Recalled:       m.Status == dao.MessageRecalled,
+		CreatedAt:      m.CreatedAt,
+	}
+	if msg.Recalled {
+		msg.Content = ""
+	}
+	return msg
+}
@@ -22,6 +22,10 @@ type InteractiveRepository interface {
 	Liked(ctx context.Context, postId uint, uid int64) (bool, error)
 	Collected(ctx context.Context, postId uint, uid int64) (bool, error)
 	GetById(ctx context.Context, postIds []uint) ([]domain.Interactive, error)
+	// ListLikedPostIds 获取用户最近点赞的帖子
+	ListLikedPostIds(ctx context.Context, uid int64, limit int) ([]uint, error)
+	// ListCollectedPostIds 获取用户最近收藏的帖子
+	ListCollectedPostIds(ctx context.Context, uid int64, limit int) ([]uint, error)
 }
 
 type InteractiveRepositoryImpl struct {
@@ -111,6 +115,16 @@ func (i *InteractiveRepositoryImpl) GetById(ctx context.Context, postIds []uint)
 	return result, nil
 }
 
+// ListLikedPostIds 获取用户最近点赞的帖子
+func (i *InteractiveRepositoryImpl) ListLikedPostIds(ctx context.Context, uid int64, limit int) ([]uint, error) {
+	return i.dao.ListLikedBizIds(ctx, uid, limit)
+}
+
+// ListCollectedPostIds 获取用户最近收藏的帖子
+func (i *InteractiveRepositoryImpl) ListCollectedPostIds(ctx context.Context, uid int64, limit int) ([]uint, error) {
+	return i.dao.ListCollectedBizIds(ctx, uid, limit)
+}
+
 // checkExistence 检查是否存在
 func (i *InteractiveRepositoryImpl) checkExistence(err error, logMsg string) (bool, error) {
 	switch {
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/repository/dao"
+	"go.uber.org/zap"
+)
+
+type InviteRepository interface {
+	CreateCode(ctx context.Context, code domain.InviteCode) (domain.InviteCode, error)
+	GetCode(ctx context.Context, id int64) (domain.InviteCode, error)
+	CountActiveCodes(ctx context.Context, creatorId int64) (int64, error)
+	ListCodes(ctx context.Context, creatorId int64, pagination domain.Pagination) ([]domain.InviteCode, error)
+	DisableCode(ctx context.Context, id, creatorId int64) error
+	// RegisterWithInvite 使用邀请码创建用户，返回新用户ID
+	RegisterWithInvite(ctx context.Context, u domain.User, code string) (int64, error)
+	ListInvitations(ctx context.Context, inviterId, inviteeId int64, pagination domain.Pagination) ([]domain.Invitation, error)
+}
+
+type inviteRepository struct {
+	dao dao.InviteDAO
+	l   *zap.Logger
+}
+
+func NewInviteRepository(dao dao.InviteDAO, l *zap.Logger) InviteRepository {
+	return &inviteRepository{
+		dao: dao,
+		l:   l,
+	}
+}
+
+func (i *inviteRepository) CreateCode(ctx context.Context, code domain.InviteCode) (domain.InviteCode, error) {
+	created, err := i.dao.CreateCode(ctx, dao.InviteCode{
+		Code:      code.Code,
+		CreatorID: code.CreatorID,
+		MaxUses:   code.MaxUses,
+		ExpiresAt: code.ExpiresAt,
+	})
+	if err != nil {
+		return domain.InviteCode{}, err
+	}
+	return toDomainInviteCode(created), nil
+}
+
+func (i *inviteRepository) GetCode(ctx context.Context, id int64) (domain.InviteCode, error) {
+	code, err := i.dao.GetCode(ctx, id)
+	if err != nil {
+		return domain.InviteCode{}, err
+	}
+	return toDomainInviteCode(code), nil
+}
+
+func (i *inviteRepository) CountActiveCodes(ctx context.Context, creatorId int64) (int64, error) {
+	return i.dao.CountActiveCodes(ctx, creatorId)
+}
+
+func (i *inviteRepository) ListCodes(ctx context.Context, creatorId int64, pagination domain.Pagination) ([]domain.InviteCode, error) {
+	codes, err := i.dao.ListCodes(ctx, creatorId, int(*pagination.Offset), int(*pagination.Size))
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.InviteCode, 0, len(codes))
+	for _, c := range codes {
+		res = append(res, toDomainInviteCode(c))
+	}
+	return res, nil
+}
+
+func (i *inviteRepository) DisableCode(ctx context.Context, id, creatorId int64) error {
+	return i.dao.DisableCode(ctx, id, creatorId)
+}
+
+func (i *inviteRepository) RegisterWithInvite(ctx context.Context, u domain.User, code string) (int64, error) {
+	return i.dao.CreateUserWithInvite(ctx, fromDomainUser(u), code)
+}
+
+func (i *inviteRepository) ListInvitations(ctx context.Context, inviterId, inviteeId int64, pagination domain.Pagination) ([]domain.Invitation, error) {
+	rows, err := i.dao.ListInvitations(ctx, inviterId, inviteeId, int(*pagination.Offset), int(*pagination.Size))
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.Invitation, 0, len(rows))
+	for _, r := range rows {
+		res = append(res, domain.Invitation{
+			ID:        r.ID,
+			InviterID: r.InviterID,
+			InviteeID: r.InviteeID,
+			CodeID:    r.CodeID,
+			Code:      r.Code,
+			CreatedAt: r.CreatedAt,
+		})
+	}
+	return res, nil
+}
+
+func toDomainInviteCode(c dao.InviteCode) domain.InviteCode {
+	return domain.InviteCode{
+		ID:        c.ID,
+		Code:      c.Code,
+		CreatorID: c.CreatorID,
+		MaxUses:   c.MaxUses,
+		UsedCount: c.UsedCount,
+		ExpiresAt: c.ExpiresAt,
+		Disabled:  c.Disabled,
+		CreatedAt: c.CreatedAt,
+	}
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/repository/cache"
+	"github.com/GoSimplicity/LinkMe/internal/repository/dao"
+	"go.uber.org/zap"
+)
+
+type LoginGuardRepository interface {
+	GetState(ctx context.Context, subject domain.LoginSubject) (domain.LoginGuardState, error)
+	IncrFailures(ctx context.Context, subject domain.LoginSubject, window time.Duration) (int64, error)
+	SetNextAttempt(ctx context.Context, subject domain.LoginSubject, at int64) error
+	Lock(ctx context.Context, subject domain.LoginSubject, ttl time.Duration) error
+	Clear(ctx context.Context, subject domain.LoginSubject) error
+	CreateEvent(ctx context.Context, event domain.SecurityEvent) error
+	ListEvents(ctx context.Context, uid int64, eventType domain.SecurityEventType, pagination domain.Pagination) ([]domain.SecurityEvent, error)
+	// TouchDevice 记录登录设备，返回是否为首次出现的设备
+	TouchDevice(ctx context.Context, uid int64, fingerprint, userAgent, ip string) (bool, error)
+	CountDevices(ctx context.Context, uid int64) (int64, error)
+}
+
+type loginGuardRepository struct {
+	dao   dao.SecurityEventDAO
+	cache cache.LoginGuardCache
+	l     *zap.Logger
+}
+
+func NewLoginGuardRepository(dao dao.SecurityEventDAO, cache cache.LoginGuardCache, l *zap.Logger) LoginGuardRepository {
+	return &loginGuardRepository{
+		dao:   dao,
+		cache: cache,
+		l:     l,
+	}
+}
+
+func (g *loginGuardRepository) GetState(ctx context.Context, subject domain.LoginSubject) (domain.LoginGuardState, error) {
+	return g.cache.Get(ctx, subject)
+}
+
+func (g *loginGuardRepository) IncrFailures(ctx context.Context, subject domain.LoginSubject, window time.Duration) (int64, error) {
+	return g.cache.IncrFailures(ctx, subject, window)
+}
+
+func (g *loginGuardRepository) SetNextAttempt(ctx context.Context, subject domain.LoginSubject, at int64) error {
+	return g.cache.SetNextAttempt(ctx, subject, at)
+}
+
+func (g *loginGuardRepository) Lock(ctx context.Context, subject domain.LoginSubject, ttl time.Duration) error {
+	return g.cache.Lock(ctx, subject, ttl)
+}
+
+func (g *loginGuardRepository) Clear(ctx context.Context, subject domain.LoginSubject) error {
+	return g.cache.Clear(ctx, subject)
+}
+
+func (g *loginGuardRepository) CreateEvent(ctx context.Context, event domain.SecurityEvent) error {
+	return g.dao.CreateEvent(ctx, dao.SecurityEvent{
+		UserID:       event.UserID,
+		Type:         string(event.Type),
+		SubjectKind:  string(event.Subject.Kind),
+		SubjectValue: event.Subject.Value,
+		IP:           event.IP,
+		UserAgent:    event.UserAgent,
+		Detail:       event.Detail,
+	})
+}
+
+func (g *loginGuardRepository) ListEvents(ctx context.Context, uid int64, eventType domain.SecurityEventType, pagination domain.Pagination) ([]domain.SecurityEvent, error) {
+	events, err := g.dao.ListEvents(ctx, uid, string(eventType), int(*pagination.Offset), int(*pagination.Size))
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.SecurityEvent, 0, len(events))
+	for _, e := range events {
+		res = append(res, toDomainSecurityEvent(e))
+	}
+	return res, nil
+}
+
+func (g *loginGuardRepository) TouchDevice(ctx context.Context, uid int64, fingerprint, userAgent, ip string) (bool, error) {
+	return g.dao.TouchDevice(ctx, dao.LoginDevice{
+		UserID:      uid,
+		Fingerprint: fingerprint,
+		UserAgent:   userAgent,
+		LastIP:      ip,
+	})
+}
+
+func (g *loginGuardRepository) CountDevices(ctx context.Context, uid int64) (int64, error) {
+	return g.dao.CountDevices(ctx, uid)
+}
+
+func toDomainSecurityEvent(e dao.SecurityEvent) domain.SecurityEvent {
+	return domain.SecurityEvent{
+		ID:     e.ID,
+		UserID: e.UserID,
+		Type:   domain.SecurityEventType(e.Type),
+		Subject: domain.LoginSubject{
+			Kind:  domain.LoginSubjectKind(e.SubjectKind),
+			Value: e.SubjectValue,
+		},
+		IP:        e.IP,
+		UserAgent: e.UserAgent,
+		Detail:    e.Detail,
+		CreatedAt: e.CreatedAt,
+	}
+}
@@ -9,7 +9,8 @@ import (
 )
 
 type MenuRepository interface {
-	CreateMenu(ctx context.Context, menu *domain.Menu) error
+	// CreateMenu 创建菜单，返回新菜单的ID
+	CreateMenu(ctx context.Context, menu *domain.Menu) (int, error)
 	GetMenuById(ctx context.Context, id int) (*domain.Menu, error)
 	UpdateMenu(ctx context.Context, menu *domain.Menu) error
 	DeleteMenu(ctx context.Context, id int) error
@@ -30,7 +31,7 @@ func NewMenuRepository(l *zap.Logger, dao dao.MenuDAO) MenuRepository {
 }
 
 // CreateMenu 创建新的菜单
-func (m *menuRepository) CreateMenu(ctx context.Context, menu *domain.Menu) error {
+func (m *menuRepository) CreateMenu(ctx context.Context, menu *domain.Menu) (int, error) {
 	return m.dao.CreateMenu(ctx, m.menuToDAO(menu))
 }
 
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/repository/cache"
+	"github.com/GoSimplicity/LinkMe/internal/repository/dao"
+	"go.uber.org/zap"
+)
+
+// ErrNotificationSuppressed 表示通知因用户偏好或免打扰时段未被投递
+var ErrNotificationSuppressed = errors.New("notification suppressed by user preference")
+
+// NotificationRepository 通知偏好与站内信存储
+type NotificationRepository interface {
+	GetSettings(ctx context.Context, uid int64) (domain.NotificationSettings, error)
+	UpdatePreferences(ctx context.Context, uid int64, prefs []domain.NotificationPreference) error
+	UpdateQuietHours(ctx context.Context, uid int64, q domain.QuietHours) error
+	// Allow 所有外发渠道在投递前都需要调用该方法
+	Allow(ctx context.Context, uid int64, t domain.NotificationType, c domain.NotificationChannel) (bool, error)
+	PushInApp(ctx context.Context, n domain.Notification) error
+	ListInApp(ctx context.Context, uid int64, pagination domain.Pagination) ([]domain.Notification, error)
+}
+
+type notificationRepository struct {
+	dao   dao.NotificationDAO
+	cache cache.NotificationCache
+	l     *zap.Logger
+}
+
+func NewNotificationRepository(dao dao.NotificationDAO, cache cache.NotificationCache, l *zap.Logger) NotificationRepository {
+	return &notificationRepository{
+		dao:   dao,
+		cache: cache,
+		l:     l,
+	}
+}
+
+// GetSettings 获取用户通知设置，优先读取缓存
+func (n *notificationRepository) GetSettings(ctx context.Context, uid int64) (domain.NotificationSettings, error) {
+	if settings, ok, err := n.cache.GetSettings(ctx, uid); err == nil && ok {
+		return settings, nil
+	}
+
+	prefs, err := n.dao.ListPreferences(ctx, uid)
+	if err != nil {
+		return domain.NotificationSettings{}, err
+	}
+	setting, err := n.dao.GetSetting(ctx, uid)
+	if err != nil {
+		return domain.NotificationSettings{}, err
+	}
+
+	settings := toDomainNotificationSettings(uid, prefs, setting)
+	if err := n.cache.SetSettings(ctx, settings); err != nil {
+		n.l.Warn("缓存通知设置失败", zap.Int64("uid", uid), zap.Error(err))
+	}
+	return settings, nil
+}
+
+// UpdatePreferences 更新通知偏好
+func (n *notificationRepository) UpdatePreferences(ctx context.Context, uid int64, prefs []domain.NotificationPreference) error {
+	items := make([]dao.NotificationPreference, 0, len(prefs))
+	for _, p := range prefs {
+		items = append(items, dao.NotificationPreference{
+			Type:    string(p.Type),
+			Channel: string(p.Channel),
+			Enabled: p.Enabled,
+		})
+	}
+	if err := n.dao.UpsertPreferences(ctx, uid, items); err != nil {
+		return err
+	}
+	return n.cache.DelSettings(ctx, uid)
+}
+
+// UpdateQuietHours 更新免打扰时段
+func (n *notificationRepository) UpdateQuietHours(ctx context.Context, uid int64, q domain.QuietHours) error {
+	if err := n.dao.UpsertSetting(ctx, dao.NotificationSetting{
+		UserID:       uid,
+		QuietEnabled: q.Enabled,
+		QuietStart:   q.Start,
+		QuietEnd:     q.End,
+		Timezone:     q.Timezone,
+	}); err != nil {
+		return err
+	}
+	return n.cache.DelSettings(ctx, uid)
+}
+
+// Allow 判断通知是否可以通过指定渠道投递给用户
+func (n *notificationRepository) Allow(ctx context.Context, uid int64, t domain.NotificationType, c domain.NotificationChannel) (bool, error) {
+	if t == domain.NotificationSecurity {
+		return true, nil
+	}
+	settings, err := n.GetSettings(ctx, uid)
+	if err != nil {
+		return false, err
+	}
+	return settings.Allow(t, c, time.Now()), nil
+}
+
+// PushInApp 投递站内信
+func (n *notificationRepository) PushInApp(ctx context.Context, notification domain.Notification) error {
+	ok, err := n.Allow(ctx, notification.UserID, notification.Type, domain.ChannelInApp)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotificationSuppressed
+	}
+	if notification.CreatedAt == 0 {
+		notification.CreatedAt = time.Now().UnixMilli()
+	}
+	return n.cache.PushInbox(ctx, notification)
+}
+
+// ListInApp 获取站内信列表
+func (n *notificationRepository) ListInApp(ctx context.Context, uid int64, pagination domain.Pagination) ([]domain.Notification, error) {
+	return n.cache.ListInbox(ctx, uid, *pagination.Offset, *pagination.Size)
+}
+
+// toDomainNotificationSettings 将dao层对象转为领域层对象
+func toDomainNotificationSettings(uid int64, prefs []dao.NotificationPreference, setting dao.NotificationSetting) domain.NotificationSettings {
+	settings := domain.NotificationSettings{
+		UserID:      uid,
+		Preferences: make([]domain.NotificationPreference, 0, len(prefs)),
+		QuietHours: domain.QuietHours{
+			Enabled:  setting.QuietEnabled,
+			Start:    setting.QuietStart,
+			End:      setting.QuietEnd,
+			Timezone: setting.Timezone,
+		},
+	}
+	for _, p := range prefs {
+		settings.Preferences = append(settings.Preferences, domain.NotificationPreference{
+			Type:    domain.NotificationType(p.Type),
+			Channel: domain.NotificationChannel(p.Channel),
+			Enabled: p.Enabled,
+		})
+	}
+	return settings
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/repository/cache"
+	"github.com/GoSimplicity/LinkMe/internal/repository/dao"
+	"go.uber.org/zap"
+)
+
+type OAuthRepository interface {
+	SaveState(ctx context.Context, state string, s domain.OAuthState, ttl time.Duration) error
+	TakeState(ctx context.Context, state string) (domain.OAuthState, error)
+	FindIdentity(ctx context.Context, provider, subject string) (domain.UserIdentity, error)
+	ListIdentities(ctx context.Context, uid int64) ([]domain.UserIdentity, error)
+	LinkIdentity(ctx context.Context, identity domain.UserIdentity) error
+	UnlinkIdentity(ctx context.Context, uid int64, provider string) error
+	// RegisterWithIdentity 创建用户并绑定第三方账号，返回新用户ID
+	RegisterWithIdentity(ctx context.Context, u domain.User, identity domain.UserIdentity) (int64, error)
+}
+
+type oauthRepository struct {
+	dao   dao.OAuthDAO
+	cache cache.OAuthCache
+	l     *zap.Logger
+}
+
+func NewOAuthRepository(dao dao.OAuthDAO, cache cache.OAuthCache, l *zap.Logger) OAuthRepository {
+	return &oauthRepository{
+		dao:   dao,
+		cache: cache,
+		l:     l,
+	}
+}
+
+func (o *oauthRepository) SaveState(ctx context.Context, state string, s domain.OAuthState, ttl time.Duration) error {
+	return o.cache.SetState(ctx, state, s, ttl)
+}
+
+func (o *oauthRepository) TakeState(ctx context.Context, state string) (domain.OAuthState, error) {
+	return o.cache.TakeState(ctx, state)
+}
+
+func (o *oauthRepository) FindIdentity(ctx context.Context, provider, subject string) (domain.UserIdentity, error) {
+	identity, err := o.dao.FindIdentity(ctx, provider, subject)
+	if err != nil {
+		return domain.UserIdentity{}, err
+	}
+	return toDomainIdentity(identity), nil
+}
+
+func (o *oauthRepository) ListIdentities(ctx context.Context, uid int64) ([]domain.UserIdentity, error) {
+	identities, err := o.dao.ListIdentities(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.UserIdentity, len(identities))
+	for i, identity := range identities {
+		result[i] = toDomainIdentity(identity)
+	}
+	return result, nil
+}
+
+func (o *oauthRepository) LinkIdentity(ctx context.Context, identity domain.UserIdentity) error {
+	return o.dao.CreateIdentity(ctx, fromDomainIdentity(identity))
+}
+
+func (o *oauthRepository) UnlinkIdentity(ctx context.Context, uid int64, provider string) error {
+	return o.dao.DeleteIdentity(ctx, uid, provider)
+}
+
+func (o *oauthRepository) RegisterWithIdentity(ctx context.Context, u domain.User, identity domain.UserIdentity) (int64, error) {
+	du := fromDomainUser(u)
+	du.Profile = dao.Profile{
+		RealName: u.Profile.RealName,
+		Avatar:   u.Profile.Avatar,
+	}
+	return o.dao.CreateUserWithIdentity(ctx, du, fromDomainIdentity(identity))
+}
+
+func toDomainIdentity(identity dao.UserIdentity) domain.UserIdentity {
+	return domain.UserIdentity{
+		Provider:   identity.Provider,
+		Subject:    identity.Subject,
+		UserID:     identity.UserID,
+		Username:   identity.Username,
+		Email:      identity.Email,
+		Registered: identity.Registered,
+		CreatedAt:  identity.CreatedAt,
+	}
+}
+
+func fromDomainIdentity(identity domain.UserIdentity) dao.UserIdentity {
+	return dao.UserIdentity{
+		UserID:   identity.UserID,
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		Username: identity.Username,
+		Email:    identity.Email,
+	}
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/repository/cache"
+	"go.uber.org/zap"
+)
+
+type PasswordResetRepository interface {
+	Cooldown(ctx context.Context, channel domain.ResetChannel, account string, ttl time.Duration) (bool, error)
+	IncrAttempts(ctx context.Context, channel domain.ResetChannel, account string, window time.Duration) (int64, error)
+	ClearAttempts(ctx context.Context, channel domain.ResetChannel, account string) error
+	SaveToken(ctx context.Context, tokenHash string, uid int64, ttl time.Duration) error
+	TakeToken(ctx context.Context, tokenHash string) (int64, error)
+}
+
+type passwordResetRepository struct {
+	cache cache.PasswordResetCache
+	l     *zap.Logger
+}
+
+func NewPasswordResetRepository(cache cache.PasswordResetCache, l *zap.Logger) PasswordResetRepository {
+	return &passwordResetRepository{
+		cache: cache,
+		l:     l,
+	}
+}
+
+func (p *passwordResetRepository) Cooldown(ctx context.Context, channel domain.ResetChannel, account string, ttl time.Duration) (bool, error) {
+	return p.cache.Cooldown(ctx, channel, account, ttl)
+}
+
+func (p *passwordResetRepository) IncrAttempts(ctx context.Context, channel domain.ResetChannel, account string, window time.Duration) (int64, error) {
+	return p.cache.IncrAttempts(ctx, channel, account, window)
+}
+
+func (p *passwordResetRepository) ClearAttempts(ctx context.Context, channel domain.ResetChannel, account string) error {
+	return p.cache.ClearAttempts(ctx, channel, account)
+}
+
+func (p *passwordResetRepository) SaveToken(ctx context.Context, tokenHash string, uid int64, ttl time.Duration) error {
+	return p.cache.SetToken(ctx, tokenHash, uid, ttl)
+}
+
+func (p *passwordResetRepository) TakeToken(ctx context.Context, tokenHash string) (int64, error) {
+	return p.cache.TakeToken(ctx, tokenHash)
+}
@@ -9,10 +9,17 @@ import (
 )
 
 type PlateRepository interface {
-	CreatePlate(ctx context.Context, plate domain.Plate) error
+	// CreatePlate 创建板块，返回新板块的ID
+	CreatePlate(ctx context.Context, plate domain.Plate) (int64, error)
 	ListPlate(ctx context.Context, pagination domain.Pagination) ([]domain.Plate, error)
 	UpdatePlate(ctx context.Context, plate domain.Plate) error
 	DeletePlate(ctx context.Context, plateId int64, uid int64) error
+	Subscribe(ctx context.Context, plateId int64, uid int64) error
+	Unsubscribe(ctx context.Context, plateId int64, uid int64) error
+	ListSubscribedPlates(ctx context.Context, uid int64, pagination domain.Pagination) ([]domain.Plate, error)
+	ListSubscribedPlateIDs(ctx context.Context, uid int64) ([]int64, error)
+	ExistsPlate(ctx context.Context, plateId int64) (bool, error)
+	GetPlate(ctx context.Context, plateId int64) (domain.Plate, error)
 }
 
 type plateRepository struct {
@@ -27,7 +34,7 @@ func NewPlateRepository(l *zap.Logger, dao dao.PlateDAO) PlateRepository {
 	}
 }
 
-func (p *plateRepository) CreatePlate(ctx context.Context, plate domain.Plate) error {
+func (p *plateRepository) CreatePlate(ctx context.Context, plate domain.Plate) (int64, error) {
 	return p.dao.CreatePlate(ctx, plate)
 }
 
@@ -47,19 +54,52 @@ func (p *plateRepository) DeletePlate(ctx context.Context, plateId int64, uid in
 	return p.dao.DeletePlate(ctx, plateId, uid)
 }
 
+func (p *plateRepository) Subscribe(ctx context.Context, plateId int64, uid int64) error {
+	return p.dao.Subscribe(ctx, plateId, uid)
+}
+
+func (p *plateRepository) Unsubscribe(ctx context.Context, plateId int64, uid int64) error {
+	return p.dao.Unsubscribe(ctx, plateId, uid)
+}
+
+func (p *plateRepository) ListSubscribedPlates(ctx context.Context, uid int64, pagination domain.Pagination) ([]domain.Plate, error) {
+	plates, err := p.dao.ListSubscribedPlates(ctx, uid, pagination)
+	if err != nil {
+		return nil, err
+	}
+	return fromDomainSlicePlate(plates), nil
+}
+
+func (p *plateRepository) ListSubscribedPlateIDs(ctx context.Context, uid int64) ([]int64, error) {
+	return p.dao.ListSubscribedPlateIDs(ctx, uid)
+}
+
+func (p *plateRepository) ExistsPlate(ctx context.Context, plateId int64) (bool, error) {
+	return p.dao.ExistsPlate(ctx, plateId)
+}
+
+func (p *plateRepository) GetPlate(ctx context.Context, plateId int64) (domain.Plate, error) {
+	plate, err := p.dao.GetPlate(ctx, plateId)
+	if err != nil {
+		return domain.Plate{}, err
+	}
+	return fromDomainSlicePlate([]dao.Plate{plate})[0], nil
+}
+
 // 将dao层对象转为领域层对象
 func fromDomainSlicePlate(post []dao.Plate) []domain.Plate {
 	domainPlate := make([]domain.Plate, len(post))
 	for i, repoPlate := range post {
 		domainPlate[i] = domain.Plate{
-			ID:          repoPlate.ID,
-			Name:        repoPlate.Name,
-			Uid:         repoPlate.Uid,
-			Description: repoPlate.Description,
-			CreatedAt:   repoPlate.CreateTime,
-			UpdatedAt:   repoPlate.UpdatedTime,
-			DeletedAt:   repoPlate.DeletedTime,
-			Deleted:     repoPlate.Deleted,
+			ID:              repoPlate.ID,
+			Name:            repoPlate.Name,
+			Uid:             repoPlate.Uid,
+			Description:     repoPlate.Description,
+			CreatedAt:       repoPlate.CreateTime,
+			UpdatedAt:       repoPlate.UpdatedTime,
+			DeletedAt:       repoPlate.DeletedTime,
+			Deleted:         repoPlate.Deleted,
+			SubscriberCount: repoPlate.SubscriberCount,
 		}
 	}
 	return domainPlate
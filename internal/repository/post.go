@@ -32,6 +32,12 @@ type PostRepository interface {
 	ListAllPosts(ctx context.Context, pagination domain.Pagination) ([]domain.Post, error)
 	GetPostsCount(ctx context.Context) (int64, error)
 	GetPostsByPlate(ctx context.Context, plateId int64, pagination domain.Pagination) ([]domain.Post, error)
+	GetPublishPostsByIds(ctx context.Context, postIds []uint) ([]domain.Post, error)
+	ListRecentPublishPosts(ctx context.Context, since time.Time, limit int) ([]domain.Post, error)
+	ListPublishPostsByPlates(ctx context.Context, plateIds []int64, maxId uint, limit int) ([]domain.Post, error)
+	ListPublishPlateIdsByAuthor(ctx context.Context, uid int64) ([]int64, error)
+	// ActiveAuthorsByPlates 获取指定时间之后在这些板块中发帖的作者及其发帖数
+	ActiveAuthorsByPlates(ctx context.Context, plateIds []int64, since time.Time, limit int) (map[int64]int64, error)
 }
 
 type postRepository struct {
@@ -328,3 +334,59 @@ func (p *postRepository) GetPostsByPlate(ctx context.Context, plateId int64, pag
 
 	return change.FromDomainSlicePost(posts), nil
 }
+
+// GetPublishPostsByIds 批量获取已发布的帖子，按传入的ID顺序返回，已撤回或删除的帖子会被忽略
+func (p *postRepository) GetPublishPostsByIds(ctx context.Context, postIds []uint) ([]domain.Post, error) {
+	pub, err := p.dao.GetPubByIds(ctx, postIds)
+	if err != nil {
+		return nil, fmt.Errorf("批量获取已发布帖子失败: %w", err)
+	}
+
+	posts := make(map[uint]domain.Post, len(pub))
+	for _, post := range change.FromDomainSlicePubPostList(pub) {
+		posts[post.ID] = post
+	}
+	result := make([]domain.Post, 0, len(posts))
+	for _, id := range postIds {
+		if post, ok := posts[id]; ok {
+			result = append(result, post)
+		}
+	}
+	return result, nil
+}
+
+// ListRecentPublishPosts 获取近期发布的帖子
+func (p *postRepository) ListRecentPublishPosts(ctx context.Context, since time.Time, limit int) ([]domain.Post, error) {
+	pub, err := p.dao.ListPubSince(ctx, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("获取近期已发布帖子失败: %w", err)
+	}
+	return change.FromDomainSlicePubPostList(pub), nil
+}
+
+// ListPublishPostsByPlates 按ID倒序获取多个板块中已发布的帖子
+func (p *postRepository) ListPublishPostsByPlates(ctx context.Context, plateIds []int64, maxId uint, limit int) ([]domain.Post, error) {
+	pub, err := p.dao.ListPubByPlates(ctx, plateIds, maxId, limit)
+	if err != nil {
+		return nil, fmt.Errorf("获取板块已发布帖子失败: %w", err)
+	}
+	return change.FromDomainSlicePubPostList(pub), nil
+}
+
+// ListPublishPlateIdsByAuthor 获取作者发布过帖子的板块
+func (p *postRepository) ListPublishPlateIdsByAuthor(ctx context.Context, uid int64) ([]int64, error) {
+	return p.dao.ListPubPlateIdsByAuthor(ctx, uid)
+}
+
+// ActiveAuthorsByPlates 获取板块中的活跃作者
+func (p *postRepository) ActiveAuthorsByPlates(ctx context.Context, plateIds []int64, since time.Time, limit int) (map[int64]int64, error) {
+	counts, err := p.dao.ListActiveAuthorsByPlates(ctx, plateIds, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("获取板块活跃作者失败: %w", err)
+	}
+	result := make(map[int64]int64, len(counts))
+	for _, c := range counts {
+		result[c.UserID] = c.Count
+	}
+	return result, nil
+}
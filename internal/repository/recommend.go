@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/GoSimplicity/LinkMe/internal/repository/cache"
+	"go.uber.org/zap"
+)
+
+// RecommendRepository 个性化推荐结果存储
+type RecommendRepository interface {
+	// SaveRecommendations 保存离线计算的推荐结果
+	SaveRecommendations(ctx context.Context, uid int64, scores map[uint]float64) error
+	// GetRecommendations 获取推荐结果，尚未计算或已过期时 ok 为 false
+	GetRecommendations(ctx context.Context, uid int64, offset, limit int64) (ids []uint, ok bool, err error)
+}
+
+type recommendRepository struct {
+	cache cache.RecommendCache
+	l     *zap.Logger
+}
+
+func NewRecommendRepository(cache cache.RecommendCache, l *zap.Logger) RecommendRepository {
+	return &recommendRepository{
+		cache: cache,
+		l:     l,
+	}
+}
+
+// SaveRecommendations 保存推荐结果
+func (r *recommendRepository) SaveRecommendations(ctx context.Context, uid int64, scores map[uint]float64) error {
+	return r.cache.Set(ctx, uid, scores)
+}
+
+// GetRecommendations 获取推荐结果
+func (r *recommendRepository) GetRecommendations(ctx context.Context, uid int64, offset, limit int64) ([]uint, bool, error) {
+	return r.cache.Get(ctx, uid, offset, limit)
+}
@@ -17,6 +17,29 @@ type RelationRepository interface {
 	CancelFollowUser(ctx context.Context, followerID, followeeID int64) error
 	GetFolloweeCount(ctx context.Context, userID int64) (int64, error)
 	GetFollowerCount(ctx context.Context, userID int64) (int64, error)
+	AddBlock(ctx context.Context, userID, targetID int64, blockType uint8) error
+	RemoveBlock(ctx context.Context, userID, targetID int64, blockType uint8) error
+	ListBlocks(ctx context.Context, userID int64, blockType uint8, pagination domain.Pagination) ([]domain.UserBlock, error)
+	// IsBlocked 判断 userID 是否拉黑了 targetID
+	IsBlocked(ctx context.Context, userID, targetID int64) (bool, error)
+	// HiddenUserIDs 获取 userID 屏蔽及拉黑的全部用户，用于过滤列表与信息流
+	HiddenUserIDs(ctx context.Context, userID int64) (map[int64]struct{}, error)
+	// FilterFollowers 返回 ids 中关注了 userID 的用户
+	FilterFollowers(ctx context.Context, userID int64, ids []int64) (map[int64]struct{}, error)
+	// FilterFollowees 返回 ids 中被 userID 关注的用户
+	FilterFollowees(ctx context.Context, userID int64, ids []int64) (map[int64]struct{}, error)
+	ListMutualIDs(ctx context.Context, userID int64, pagination domain.Pagination) ([]int64, error)
+	// CommonFollowees 获取 viewerID 关注的用户中同样关注了 targetID 的用户
+	CommonFollowees(ctx context.Context, viewerID, targetID int64, limit int) ([]int64, int64, error)
+	// SecondDegree 获取二度关系用户及共同关注人数
+	SecondDegree(ctx context.Context, userID int64, limit int) (map[int64]int64, error)
+	ListFolloweeIDs(ctx context.Context, userID int64) ([]int64, error)
+	RequestFollow(ctx context.Context, followerID, followeeID int64) error
+	ApproveFollowRequest(ctx context.Context, followerID, followeeID int64) error
+	RejectFollowRequest(ctx context.Context, followerID, followeeID int64) error
+	// ApproveAllFollowRequests 通过全部待处理的关注请求，返回请求者ID
+	ApproveAllFollowRequests(ctx context.Context, followeeID int64) ([]int64, error)
+	ListFollowRequests(ctx context.Context, followeeID int64, pagination domain.Pagination) ([]domain.FollowRequest, error)
 }
 
 type relationRepository struct {
@@ -133,6 +156,196 @@ func (r *relationRepository) GetFollowerCount(ctx context.Context, userID int64)
 	return count.FollowerCount, nil
 }
 
+// AddBlock 屏蔽或拉黑用户
+func (r *relationRepository) AddBlock(ctx context.Context, userID, targetID int64, blockType uint8) error {
+	if err := r.dao.AddBlock(ctx, userID, targetID, blockType); err != nil {
+		return err
+	}
+	if blockType == domain.BlockTypeBlock {
+		// 拉黑会解除双方关注关系
+		r.cache.ClearFollowCache(ctx, userID, targetID)
+		r.cache.ClearFollowCache(ctx, targetID, userID)
+	}
+	return r.cache.DelBlockIDs(ctx, userID, blockType)
+}
+
+// RemoveBlock 取消屏蔽或拉黑
+func (r *relationRepository) RemoveBlock(ctx context.Context, userID, targetID int64, blockType uint8) error {
+	if err := r.dao.RemoveBlock(ctx, userID, targetID, blockType); err != nil {
+		return err
+	}
+	return r.cache.DelBlockIDs(ctx, userID, blockType)
+}
+
+// ListBlocks 分页获取屏蔽或拉黑列表
+func (r *relationRepository) ListBlocks(ctx context.Context, userID int64, blockType uint8, pagination domain.Pagination) ([]domain.UserBlock, error) {
+	blocks, err := r.dao.ListBlocks(ctx, userID, blockType, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.UserBlock, len(blocks))
+	for i, b := range blocks {
+		result[i] = domain.UserBlock{
+			UserID:    b.UserID,
+			TargetID:  b.TargetID,
+			Type:      b.Type,
+			CreatedAt: b.CreatedAt,
+		}
+	}
+	return result, nil
+}
+
+// IsBlocked 判断 userID 是否拉黑了 targetID
+func (r *relationRepository) IsBlocked(ctx context.Context, userID, targetID int64) (bool, error) {
+	ids, err := r.blockIDs(ctx, userID, domain.BlockTypeBlock)
+	if err != nil {
+		return false, err
+	}
+	for _, id := range ids {
+		if id == targetID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// HiddenUserIDs 获取 userID 屏蔽及拉黑的全部用户
+func (r *relationRepository) HiddenUserIDs(ctx context.Context, userID int64) (map[int64]struct{}, error) {
+	hidden := make(map[int64]struct{})
+	if userID <= 0 {
+		return hidden, nil
+	}
+	for _, blockType := range []uint8{domain.BlockTypeMute, domain.BlockTypeBlock} {
+		ids, err := r.blockIDs(ctx, userID, blockType)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			hidden[id] = struct{}{}
+		}
+	}
+	return hidden, nil
+}
+
+// FilterFollowers 返回 ids 中关注了 userID 的用户
+func (r *relationRepository) FilterFollowers(ctx context.Context, userID int64, ids []int64) (map[int64]struct{}, error) {
+	result, err := r.dao.FilterFollowers(ctx, userID, ids)
+	if err != nil {
+		return nil, err
+	}
+	return toIDSet(result), nil
+}
+
+// FilterFollowees 返回 ids 中被 userID 关注的用户
+func (r *relationRepository) FilterFollowees(ctx context.Context, userID int64, ids []int64) (map[int64]struct{}, error) {
+	result, err := r.dao.FilterFollowees(ctx, userID, ids)
+	if err != nil {
+		return nil, err
+	}
+	return toIDSet(result), nil
+}
+
+// ListMutualIDs 分页获取互相关注的用户
+func (r *relationRepository) ListMutualIDs(ctx context.Context, userID int64, pagination domain.Pagination) ([]int64, error) {
+	return r.dao.ListMutualIDs(ctx, userID, pagination)
+}
+
+// CommonFollowees 获取共同关注
+func (r *relationRepository) CommonFollowees(ctx context.Context, viewerID, targetID int64, limit int) ([]int64, int64, error) {
+	return r.dao.ListCommonFollowees(ctx, viewerID, targetID, limit)
+}
+
+// SecondDegree 获取二度关系用户
+func (r *relationRepository) SecondDegree(ctx context.Context, userID int64, limit int) (map[int64]int64, error) {
+	counts, err := r.dao.ListSecondDegree(ctx, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[int64]int64, len(counts))
+	for _, c := range counts {
+		result[c.UserID] = c.Count
+	}
+	return result, nil
+}
+
+// ListFolloweeIDs 获取关注的全部用户ID
+func (r *relationRepository) ListFolloweeIDs(ctx context.Context, userID int64) ([]int64, error) {
+	return r.dao.ListFolloweeIDs(ctx, userID)
+}
+
+// RequestFollow 向私密账号发起关注请求
+func (r *relationRepository) RequestFollow(ctx context.Context, followerID, followeeID int64) error {
+	return r.dao.RequestFollow(ctx, followerID, followeeID)
+}
+
+// ApproveFollowRequest 通过关注请求
+func (r *relationRepository) ApproveFollowRequest(ctx context.Context, followerID, followeeID int64) error {
+	if err := r.dao.ApproveFollowRequest(ctx, followerID, followeeID); err != nil {
+		return err
+	}
+	r.cache.ClearFollowCache(ctx, followerID, followeeID)
+	return nil
+}
+
+// RejectFollowRequest 拒绝关注请求
+func (r *relationRepository) RejectFollowRequest(ctx context.Context, followerID, followeeID int64) error {
+	return r.dao.RejectFollowRequest(ctx, followerID, followeeID)
+}
+
+// ApproveAllFollowRequests 通过全部关注请求
+func (r *relationRepository) ApproveAllFollowRequests(ctx context.Context, followeeID int64) ([]int64, error) {
+	ids, err := r.dao.ApproveAllFollowRequests(ctx, followeeID)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		r.cache.ClearFollowCache(ctx, id, followeeID)
+	}
+	return ids, nil
+}
+
+// ListFollowRequests 分页获取待处理的关注请求
+func (r *relationRepository) ListFollowRequests(ctx context.Context, followeeID int64, pagination domain.Pagination) ([]domain.FollowRequest, error) {
+	relations, err := r.dao.ListFollowRequests(ctx, followeeID, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.FollowRequest, len(relations))
+	for i, rel := range relations {
+		result[i] = domain.FollowRequest{
+			FollowerID: rel.FollowerID,
+			CreatedAt:  rel.CreatedAt,
+		}
+	}
+	return result, nil
+}
+
+func toIDSet(ids []int64) map[int64]struct{} {
+	set := make(map[int64]struct{}, len(ids))
+	for _, id := range ids {
+		set[id] = struct{}{}
+	}
+	return set
+}
+
+// blockIDs 获取屏蔽或拉黑的用户ID，优先读取缓存
+func (r *relationRepository) blockIDs(ctx context.Context, userID int64, blockType uint8) ([]int64, error) {
+	if ids, ok, err := r.cache.GetBlockIDs(ctx, userID, blockType); err == nil && ok {
+		return ids, nil
+	}
+
+	ids, err := r.dao.ListBlockTargetIDs(ctx, userID, blockType)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.cache.SetBlockIDs(ctx, userID, blockType, ids); err != nil {
+		r.logger.Warn("缓存屏蔽列表失败", zap.Int64("userID", userID), zap.Error(err))
+	}
+	return ids, nil
+}
+
 func (r *relationRepository) toDomainRelation(relation dao.Relation) domain.Relation {
 	return domain.Relation{
 		FolloweeId: relation.FolloweeID,
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/repository/dao"
+	"go.uber.org/zap"
+)
+
+type ReputationRepository interface {
+	AddPoints(ctx context.Context, log domain.ReputationLog) (bool, error)
+	GetPoints(ctx context.Context, uid int64) (int64, error)
+	ListLogs(ctx context.Context, uid int64, pagination domain.Pagination) ([]domain.ReputationLog, error)
+	// ListLevels 获取等级配置，未配置时返回默认等级
+	ListLevels(ctx context.Context) ([]domain.ReputationLevel, error)
+	SaveLevels(ctx context.Context, levels []domain.ReputationLevel) error
+}
+
+type reputationRepository struct {
+	dao dao.ReputationDAO
+	l   *zap.Logger
+}
+
+func NewReputationRepository(dao dao.ReputationDAO, l *zap.Logger) ReputationRepository {
+	return &reputationRepository{
+		dao: dao,
+		l:   l,
+	}
+}
+
+func (r *reputationRepository) AddPoints(ctx context.Context, log domain.ReputationLog) (bool, error) {
+	return r.dao.AddPoints(ctx, dao.ReputationLog{
+		UserID:  log.UserID,
+		Event:   string(log.Event),
+		BizID:   log.BizID,
+		ActorID: log.ActorID,
+		Points:  log.Points,
+	})
+}
+
+func (r *reputationRepository) GetPoints(ctx context.Context, uid int64) (int64, error) {
+	return r.dao.GetPoints(ctx, uid)
+}
+
+func (r *reputationRepository) ListLogs(ctx context.Context, uid int64, pagination domain.Pagination) ([]domain.ReputationLog, error) {
+	logs, err := r.dao.ListLogs(ctx, uid, int(*pagination.Offset), int(*pagination.Size))
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.ReputationLog, 0, len(logs))
+	for _, l := range logs {
+		res = append(res, domain.ReputationLog{
+			ID:        l.ID,
+			UserID:    l.UserID,
+			Event:     domain.ReputationEvent(l.Event),
+			BizID:     l.BizID,
+			ActorID:   l.ActorID,
+			Points:    l.Points,
+			CreatedAt: l.CreatedAt,
+		})
+	}
+	return res, nil
+}
+
+func (r *reputationRepository) ListLevels(ctx context.Context) ([]domain.ReputationLevel, error) {
+	levels, err := r.dao.ListLevels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(levels) == 0 {
+		return domain.DefaultReputationLevels, nil
+	}
+	res := make([]domain.ReputationLevel, 0, len(levels))
+	for _, l := range levels {
+		res = append(res, domain.ReputationLevel{
+			Level:            l.Level,
+			Name:             l.Name,
+			MinPoints:        l.MinPoints,
+			CanPostLinks:     l.CanPostLinks,
+			CanCreateLottery: l.CanCreateLottery,
+		})
+	}
+	return res, nil
+}
+
+func (r *reputationRepository) SaveLevels(ctx context.Context, levels []domain.ReputationLevel) error {
+	list := make([]dao.ReputationLevel, 0, len(levels))
+	for _, l := range levels {
+		list = append(list, dao.ReputationLevel{
+			Level:            l.Level,
+			Name:             l.Name,
+			MinPoints:        l.MinPoints,
+			CanPostLinks:     l.CanPostLinks,
+			CanCreateLottery: l.CanCreateLottery,
+		})
+	}
+	return r.dao.ReplaceLevels(ctx, list)
+}
@@ -9,7 +9,8 @@ import (
 )
 
 type RoleRepository interface {
-	CreateRole(ctx context.Context, role *domain.Role, menuIds []int, apiIds []int) error
+	// CreateRole 创建角色，返回新角色的ID
+	CreateRole(ctx context.Context, role *domain.Role, menuIds []int, apiIds []int) (int, error)
 	GetRoleById(ctx context.Context, id int) (*domain.Role, error)
 	UpdateRole(ctx context.Context, role *domain.Role) error
 	DeleteRole(ctx context.Context, id int) error
@@ -31,7 +32,7 @@ func NewRoleRepository(l *zap.Logger, dao dao.RoleDAO) RoleRepository {
 }
 
 // CreateRole 创建角色
-func (r *roleRepository) CreateRole(ctx context.Context, role *domain.Role, menuIds []int, apiIds []int) error {
+func (r *roleRepository) CreateRole(ctx context.Context, role *domain.Role, menuIds []int, apiIds []int) (int, error) {
 	return r.dao.CreateRole(ctx, r.roleToDAO(role), menuIds, apiIds)
 }
 
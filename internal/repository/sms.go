@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/GoSimplicity/LinkMe/internal/domain"
 	"github.com/GoSimplicity/LinkMe/internal/repository/cache"
 	"github.com/GoSimplicity/LinkMe/internal/repository/dao"
 	"github.com/GoSimplicity/LinkMe/pkg/sms"
@@ -30,6 +31,7 @@ type SmsRepository interface {
 	IncrCnt(ctx context.Context, number string) error
 	ReleaseLock(ctx context.Context, number string) error
 	SendCode(ctx context.Context, number string) error
+	Notify(ctx context.Context, uid int64, t domain.NotificationType, number string, content string) error
 }
 
 // smsRepository 实现了 SmsRepository 接口
@@ -38,15 +40,17 @@ type smsRepository struct {
 	cache  cache.SMSCache
 	l      *zap.Logger
 	client *sms.TencentSms //Todo 完成多个sms的集成
+	notify NotificationRepository
 }
 
 // NewSmsRepository 创建并返回一个新的 smsRepository 实例
-func NewSmsRepository(dao dao.SmsDAO, cache cache.SMSCache, l *zap.Logger, client *sms.TencentSms) SmsRepository {
+func NewSmsRepository(dao dao.SmsDAO, cache cache.SMSCache, l *zap.Logger, client *sms.TencentSms, notify NotificationRepository) SmsRepository {
 	return &smsRepository{
 		dao:    dao,
 		cache:  cache,
 		l:      l,
 		client: client,
+		notify: notify,
 	}
 }
 
@@ -153,3 +157,23 @@ func (s *smsRepository) SendCode(ctx context.Context, number string) error {
 	}
 	return s.AddUserOperationLog(ctx, log)
 }
+
+// Notify 发送短信通知，投递前校验用户的通知偏好与免打扰时段
+func (s *smsRepository) Notify(ctx context.Context, uid int64, t domain.NotificationType, number string, content string) error {
+	if number == "" {
+		return nil
+	}
+	ok, err := s.notify.Allow(ctx, uid, t, domain.ChannelSMS)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotificationSuppressed
+	}
+	if viper.GetString("sms.provider") == "tencent" && s.client != nil {
+		_, _, err = s.client.Send(ctx, []string{content}, number)
+		return err
+	}
+	s.l.Info("短信通知走模拟通道", zap.Int64("uid", uid), zap.String("number", number), zap.String("content", content))
+	return nil
+}
@@ -31,7 +31,8 @@ func TestSendCode(t *testing.T) {
 	d := dao.NewSmsDAO(ioc.InitDB(), logger)
 	c := cache.NewSMSCache(ioc.InitRedis())
 	client := ioc.InitSms()
-	repo := repository.NewSmsRepository(d, c, logger, client)
+	notify := repository.NewNotificationRepository(dao.NewNotificationDAO(ioc.InitDB(), logger), cache.NewNotificationCache(ioc.InitRedis()), logger)
+	repo := repository.NewSmsRepository(d, c, logger, client, notify)
 	if er := repo.SendCode(context.Background(), "xxx"); er != nil {
 		fmt.Println(er)
 		return
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/repository/cache"
+	"github.com/GoSimplicity/LinkMe/internal/repository/dao"
+	"go.uber.org/zap"
+)
+
+type TwoFactorRepository interface {
+	Find(ctx context.Context, uid int64) (domain.TwoFactor, error)
+	SaveSecret(ctx context.Context, uid int64, secret string) error
+	Enable(ctx context.Context, uid int64, codeHashes []string) error
+	Disable(ctx context.Context, uid int64) error
+	ReplaceRecoveryCodes(ctx context.Context, uid int64, codeHashes []string) error
+	UseRecoveryCode(ctx context.Context, uid int64, codeHash string) (bool, error)
+	CountRecoveryCodes(ctx context.Context, uid int64) (int64, error)
+	SaveChallenge(ctx context.Context, token string, uid int64, ttl time.Duration) error
+	GetChallenge(ctx context.Context, token string) (int64, error)
+	IncrChallengeAttempts(ctx context.Context, token string) (int64, error)
+	DelChallenge(ctx context.Context, token string) error
+	MarkCodeUsed(ctx context.Context, uid int64, counter int64, ttl time.Duration) (bool, error)
+}
+
+type twoFactorRepository struct {
+	dao   dao.TwoFactorDAO
+	cache cache.TwoFactorCache
+	l     *zap.Logger
+}
+
+func NewTwoFactorRepository(dao dao.TwoFactorDAO, cache cache.TwoFactorCache, l *zap.Logger) TwoFactorRepository {
+	return &twoFactorRepository{
+		dao:   dao,
+		cache: cache,
+		l:     l,
+	}
+}
+
+func (t *twoFactorRepository) Find(ctx context.Context, uid int64) (domain.TwoFactor, error) {
+	tf, err := t.dao.FindByUserID(ctx, uid)
+	if err != nil {
+		return domain.TwoFactor{}, err
+	}
+	return domain.TwoFactor{
+		UserID:  tf.UserID,
+		Secret:  tf.Secret,
+		Enabled: tf.Enabled,
+	}, nil
+}
+
+func (t *twoFactorRepository) SaveSecret(ctx context.Context, uid int64, secret string) error {
+	return t.dao.SaveSecret(ctx, uid, secret)
+}
+
+func (t *twoFactorRepository) Enable(ctx context.Context, uid int64, codeHashes []string) error {
+	return t.dao.Enable(ctx, uid, codeHashes)
+}
+
+func (t *twoFactorRepository) Disable(ctx context.Context, uid int64) error {
+	return t.dao.Disable(ctx, uid)
+}
+
+func (t *twoFactorRepository) ReplaceRecoveryCodes(ctx context.Context, uid int64, codeHashes []string) error {
+	return t.dao.ReplaceRecoveryCodes(ctx, uid, codeHashes)
+}
+
+func (t *twoFactorRepository) UseRecoveryCode(ctx context.Context, uid int64, codeHash string) (bool, error) {
+	return t.dao.UseRecoveryCode(ctx, uid, codeHash)
+}
+
+func (t *twoFactorRepository) CountRecoveryCodes(ctx context.Context, uid int64) (int64, error) {
+	return t.dao.CountRecoveryCodes(ctx, uid)
+}
+
+func (t *twoFactorRepository) SaveChallenge(ctx context.Context, token string, uid int64, ttl time.Duration) error {
+	return t.cache.SetChallenge(ctx, token, uid, ttl)
+}
+
+func (t *twoFactorRepository) GetChallenge(ctx context.Context, token string) (int64, error) {
+	return t.cache.GetChallenge(ctx, token)
+}
+
+func (t *twoFactorRepository) IncrChallengeAttempts(ctx context.Context, token string) (int64, error) {
+	return t.cache.IncrChallengeAttempts(ctx, token)
+}
+
+func (t *twoFactorRepository) DelChallenge(ctx context.Context, token string) error {
+	return t.cache.DelChallenge(ctx, token)
+}
+
+func (t *twoFactorRepository) MarkCodeUsed(ctx context.Context, uid int64, counter int64, ttl time.Duration) (bool, error) {
+	return t.cache.MarkCodeUsed(ctx, uid, counter, ttl)
+}
@@ -13,19 +13,25 @@ import (
 var (
 	// ErrDuplicateUsername 表示用户名重复错误
 	ErrDuplicateUsername = dao.ErrDuplicateUsername
+	// ErrEmailExists 表示邮箱已被其他账号验证
+	ErrEmailExists = dao.ErrEmailExists
 )
 
 type UserRepository interface {
 	CreateUser(ctx context.Context, u domain.User) error
 	FindByID(ctx context.Context, id int64) (domain.User, error)
 	FindByPhone(ctx context.Context, phone string) (domain.User, error)
+	FindByEmail(ctx context.Context, email string) (domain.User, error)
 	FindByUsername(ctx context.Context, username string) (domain.User, error)
 	ChangePassword(ctx context.Context, username string, newPassword string) error
-	DeleteUser(ctx context.Context, username string, uid int64) error
 	UpdateProfile(ctx context.Context, profile domain.Profile) error
 	GetProfile(ctx context.Context, UserID int64) (domain.Profile, error)
 	ListUser(ctx context.Context, pagination domain.Pagination) ([]domain.UserWithProfile, error)
 	UpdateProfileAdmin(ctx context.Context, profile domain.Profile) error
+	UpdatePrivacy(ctx context.Context, uid int64, isPrivate bool) error
+	// PrivateUserIDs 返回 uids 中的私密账号
+	PrivateUserIDs(ctx context.Context, uids []int64) (map[int64]struct{}, error)
+	UpdateVerifiedEmail(ctx context.Context, uid int64, email string) error
 }
 
 type userRepository struct {
@@ -110,9 +116,9 @@ func (ur *userRepository) FindByPhone(ctx context.Context, phone string) (domain
 	return toDomainUser(u), nil
 }
 
-// FindByUsername 通过用户名查询用户
-func (ur *userRepository) FindByUsername(ctx context.Context, username string) (domain.User, error) {
-	u, err := ur.dao.FindByUsername(ctx, username)
+// FindByEmail 通过已验证的邮箱查询用户
+func (ur *userRepository) FindByEmail(ctx context.Context, email string) (domain.User, error) {
+	u, err := ur.dao.FindByVerifiedEmail(ctx, email)
 	if err != nil {
 		return domain.User{}, err
 	}
@@ -120,26 +126,14 @@ func (ur *userRepository) FindByUsername(ctx context.Context, username string) (
 	return toDomainUser(u), nil
 }
 
-// DeleteUser 删除用户
-func (ur *userRepository) DeleteUser(ctx context.Context, username string, uid int64) error {
-	err := ur.dao.DeleteUser(ctx, username, uid)
+// FindByUsername 通过用户名查询用户
+func (ur *userRepository) FindByUsername(ctx context.Context, username string) (domain.User, error) {
+	u, err := ur.dao.FindByUsername(ctx, username)
 	if err != nil {
-		ur.l.Error("删除用户失败", zap.Error(err))
-		return err
+		return domain.User{}, err
 	}
 
-	// 异步删除缓存
-	go func() {
-		ctx := context.Background()
-		du, err := ur.cache.Get(ctx, uid)
-		if err == nil {
-			if err := ur.cache.Set(ctx, domain.User{ID: du.ID, Deleted: true}); err != nil {
-				ur.l.Error("删除用户后更新缓存失败", zap.Error(err))
-			}
-		}
-	}()
-
-	return nil
+	return toDomainUser(u), nil
 }
 
 // UpdateProfile 更新用户资料
@@ -191,6 +185,58 @@ func (ur *userRepository) UpdateProfileAdmin(ctx context.Context, profile domain
 	return ur.dao.UpdateProfileAdmin(ctx, profile)
 }
 
+// UpdatePrivacy 设置账号是否私密
+func (ur *userRepository) UpdatePrivacy(ctx context.Context, uid int64, isPrivate bool) error {
+	if err := ur.dao.UpdatePrivacy(ctx, uid, isPrivate); err != nil {
+		return err
+	}
+
+	// 异步更新缓存
+	go func() {
+		ctx := context.Background()
+		du, err := ur.cache.Get(ctx, uid)
+		if err == nil {
+			du.Profile.IsPrivate = isPrivate
+			if err := ur.cache.Set(ctx, du); err != nil {
+				ur.l.Error("更新隐私设置后更新缓存失败", zap.Error(err))
+			}
+		}
+	}()
+
+	return nil
+}
+
+// PrivateUserIDs 返回 uids 中的私密账号
+func (ur *userRepository) PrivateUserIDs(ctx context.Context, uids []int64) (map[int64]struct{}, error) {
+	ids, err := ur.dao.ListPrivateUserIDs(ctx, uids)
+	if err != nil {
+		return nil, err
+	}
+	return toIDSet(ids), nil
+}
+
+// UpdateVerifiedEmail 更新已验证的邮箱
+func (ur *userRepository) UpdateVerifiedEmail(ctx context.Context, uid int64, email string) error {
+	if err := ur.dao.UpdateVerifiedEmail(ctx, uid, email); err != nil {
+		return err
+	}
+
+	// 异步更新缓存
+	go func() {
+		ctx := context.Background()
+		du, err := ur.cache.Get(ctx, uid)
+		if err == nil {
+			du.Profile.Email = email
+			du.Profile.EmailVerified = true
+			if err := ur.cache.Set(ctx, du); err != nil {
+				ur.l.Error("更新邮箱后更新缓存失败", zap.Error(err))
+			}
+		}
+	}()
+
+	return nil
+}
+
 // fromDomainUser 将领域层对象转为dao层对象
 func fromDomainUser(u domain.User) dao.User {
 	return dao.User{
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrAccessTokenLimit 表示有效令牌数量已达上限
+	ErrAccessTokenLimit = errors.New("有效的访问令牌数量已达上限，请先撤销不再使用的令牌")
+	// ErrModerateNotAllowed 表示账号既没有全站审核权限也不是版主，不能创建审核令牌
+	ErrModerateNotAllowed = errors.New("当前账号没有审核权限，不能授予 moderate 权限")
+	// ErrAccessTokenNotFound 表示令牌不存在或已撤销
+	ErrAccessTokenNotFound = errors.New("访问令牌不存在或已撤销")
+)
+
+const (
+	// maxAccessTokens 每个用户同时有效的令牌数量上限
+	maxAccessTokens = 20
+	// accessTokenTouchInterval 最近使用时间的更新间隔
+	accessTokenTouchInterval = time.Minute
+)
+
+type AccessTokenService interface {
+	// Create 创建令牌，返回仅展示一次的令牌明文，ttl 为0时永不过期
+	Create(ctx context.Context, uid int64, name string, scopes []string, ttl time.Duration) (string, domain.AccessToken, error)
+	List(ctx context.Context, uid int64) ([]domain.AccessToken, error)
+	Revoke(ctx context.Context, uid, id int64) error
+	// RevokeAll 撤销用户的全部令牌，用于修改密码、重置密码与申请注销
+	RevokeAll(ctx context.Context, uid int64) error
+	// Verify 校验令牌明文并记录使用时间
+	Verify(ctx context.Context, token string) (domain.AccessToken, error)
+}
+
+type accessTokenService struct {
+	repo         repository.AccessTokenRepository
+	moderatorSvc ModeratorService
+	l            *zap.Logger
+}
+
+func NewAccessTokenService(repo repository.AccessTokenRepository, moderatorSvc ModeratorService, l *zap.Logger) AccessTokenService {
+	return &accessTokenService{
+		repo:         repo,
+		moderatorSvc: moderatorSvc,
+		l:            l,
+	}
+}
+
+// Create 创建令牌
+func (a *accessTokenService) Create(ctx context.Context, uid int64, name string, scopes []string, ttl time.Duration) (string, domain.AccessToken, error) {
+	scopes, err := domain.NormalizeScopes(scopes)
+	if err != nil {
+		return "", domain.AccessToken{}, err
+	}
+	if containsScope(scopes, domain.ScopeModerate) && !a.canModerate(ctx, uid) {
+		return "", domain.AccessToken{}, ErrModerateNotAllowed
+	}
+
+	count, err := a.repo.CountActive(ctx, uid)
+	if err != nil {
+		return "", domain.AccessToken{}, err
+	}
+	if count >= maxAccessTokens {
+		return "", domain.AccessToken{}, ErrAccessTokenLimit
+	}
+
+	secret, err := randomHex(20)
+	if err != nil {
+		return "", domain.AccessToken{}, err
+	}
+	plain := domain.AccessTokenPrefix + secret
+	var expiresAt int64
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl).UnixMilli()
+	}
+
+	token, err := a.repo.Create(ctx, domain.AccessToken{
+		UserID:    uid,
+		Name:      strings.TrimSpace(name),
+		Prefix:    plain[:len(domain.AccessTokenPrefix)+6],
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	}, hashAccessToken(plain))
+	if err != nil {
+		return "", domain.AccessToken{}, err
+	}
+	a.l.Info("创建访问令牌", zap.Int64("uid", uid), zap.Int64("tokenId", token.ID), zap.Strings("scopes", scopes))
+	return plain, token, nil
+}
+
+// List 获取用户的全部令牌
+func (a *accessTokenService) List(ctx context.Context, uid int64) ([]domain.AccessToken, error) {
+	return a.repo.ListByUser(ctx, uid)
+}
+
+// Revoke 撤销令牌，立即生效
+func (a *accessTokenService) Revoke(ctx context.Context, uid, id int64) error {
+	if err := a.repo.Revoke(ctx, uid, id); err != nil {
+		if errors.Is(err, repository.ErrAccessTokenNotFound) {
+			return ErrAccessTokenNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// RevokeAll 撤销用户的全部令牌
+func (a *accessTokenService) RevokeAll(ctx context.Context, uid int64) error {
+	return a.repo.RevokeAll(ctx, uid)
+}
+
+// Verify 校验令牌
+func (a *accessTokenService) Verify(ctx context.Context, plain string) (domain.AccessToken, error) {
+	if !strings.HasPrefix(plain, domain.AccessTokenPrefix) {
+		return domain.AccessToken{}, domain.ErrAccessTokenInvalid
+	}
+	token, err := a.repo.FindByHash(ctx, hashAccessToken(plain))
+	if err != nil {
+		if errors.Is(err, repository.ErrAccessTokenNotFound) {
+			return domain.AccessToken{}, domain.ErrAccessTokenInvalid
+		}
+		return domain.AccessToken{}, err
+	}
+	if !token.Active(time.Now().UnixMilli()) {
+		return domain.AccessToken{}, domain.ErrAccessTokenInvalid
+	}
+
+	// 使用时间仅用于展示，更新失败不影响请求
+	if err := a.repo.TouchLastUsed(ctx, token.ID, accessTokenTouchInterval); err != nil {
+		a.l.Warn("更新令牌使用时间失败", zap.Int64("tokenId", token.ID), zap.Error(err))
+	}
+	return token, nil
+}
+
+// canModerate 判断账号是否拥有全站审核权限或担任任一板块的版主，
+// 版主的令牌在审核接口中仍只能处理所管理板块的内容
+func (a *accessTokenService) canModerate(ctx context.Context, uid int64) bool {
+	ok, err := a.moderatorSvc.CanModerateAll(ctx, uid)
+	if err != nil {
+		a.l.Warn("校验审核权限失败", zap.Int64("uid", uid), zap.Error(err))
+		return false
+	}
+	if ok {
+		return true
+	}
+	plates, err := a.moderatorSvc.ModeratedPlates(ctx, uid)
+	if err != nil {
+		a.l.Warn("获取版主板块失败", zap.Int64("uid", uid), zap.Error(err))
+		return false
+	}
+	return len(plates) > 0
+}
+
+func containsScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// hashAccessToken 计算令牌明文的哈希，令牌本身为高熵随机串，无需加盐
+func hashAccessToken(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/repository"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrDeletionPending 表示已有处于冷静期的注销申请
+	ErrDeletionPending = errors.New("账号已在注销冷静期内")
+	// ErrNoPendingDeletion 表示没有可撤销的注销申请
+	ErrNoPendingDeletion = errors.New("没有待处理的注销申请")
+	// ErrExportInProgress 表示已有正在生成的导出任务
+	ErrExportInProgress = errors.New("数据导出正在生成中，请稍后查看")
+	// ErrExportNotReady 表示没有可下载的导出文件
+	ErrExportNotReady = errors.New("没有可下载的导出文件")
+)
+
+const (
+	defaultDeletionGrace = 7 * 24 * time.Hour
+	defaultExportTTL     = 24 * time.Hour
+	defaultExportDir     = "data/exports"
+	// 定时任务单次处理的数量上限
+	accountJobBatch = 50
+)
+
+type AccountService interface {
+	// RequestDeletion 校验密码后发起注销申请，冷静期结束后才会清理数据
+	RequestDeletion(ctx context.Context, uid int64, username, password string) (domain.AccountDeletion, error)
+	// DeletionStatus 获取注销申请，没有申请时返回零值
+	DeletionStatus(ctx context.Context, uid int64) (domain.AccountDeletion, error)
+	CancelDeletion(ctx context.Context, uid int64) error
+	// PurgeDueAccounts 清理冷静期已结束的账号，由定时任务调用
+	PurgeDueAccounts(ctx context.Context) error
+
+	// RequestExport 创建个人数据导出任务，文件由定时任务异步生成
+	RequestExport(ctx context.Context, uid int64) (domain.DataExport, error)
+	ExportStatus(ctx context.Context, uid int64) (domain.DataExport, error)
+	// ExportFile 返回可下载的导出文件路径
+	ExportFile(ctx context.Context, uid int64) (string, error)
+	// ProcessDataExports 生成排队中的导出文件并清理过期文件，由定时任务调用
+	ProcessDataExports(ctx context.Context) error
+}
+
+type accountService struct {
+	repo        repository.AccountRepository
+	userRepo    repository.UserRepository
+	historyRepo repository.HistoryRepository
+	searchRepo  repository.SearchRepository
+	l           *zap.Logger
+}
+
+func NewAccountService(repo repository.AccountRepository, userRepo repository.UserRepository, historyRepo repository.HistoryRepository, searchRepo repository.SearchRepository, l *zap.Logger) AccountService {
+	return &accountService{
+		repo:        repo,
+		userRepo:    userRepo,
+		historyRepo: historyRepo,
+		searchRepo:  searchRepo,
+		l:           l,
+	}
+}
+
+// RequestDeletion 发起注销申请
+func (a *accountService) RequestDeletion(ctx context.Context, uid int64, username, password string) (domain.AccountDeletion, error) {
+	u, err := a.userRepo.FindByUsername(ctx, username)
+	if err != nil || u.ID != uid {
+		return domain.AccountDeletion{}, ErrInvalidUserOrPassword
+	}
+	if err := u.VerifyPassword(password); err != nil {
+		return domain.AccountDeletion{}, ErrInvalidUserOrPassword
+	}
+
+	d, err := a.repo.FindDeletion(ctx, uid)
+	if err == nil && d.Pending() {
+		return domain.AccountDeletion{}, ErrDeletionPending
+	}
+	if err != nil && !errors.Is(err, repository.ErrDeletionNotFound) {
+		return domain.AccountDeletion{}, err
+	}
+
+	scheduledAt := time.Now().Add(durationOr("account.deletion_grace", defaultDeletionGrace)).UnixMilli()
+	if err := a.repo.RequestDeletion(ctx, uid, scheduledAt); err != nil {
+		return domain.AccountDeletion{}, err
+	}
+	return a.repo.FindDeletion(ctx, uid)
+}
+
+// DeletionStatus 获取注销申请状态
+func (a *accountService) DeletionStatus(ctx context.Context, uid int64) (domain.AccountDeletion, error) {
+	d, err := a.repo.FindDeletion(ctx, uid)
+	if errors.Is(err, repository.ErrDeletionNotFound) {
+		return domain.AccountDeletion{UserID: uid}, nil
+	}
+	return d, err
+}
+
+// CancelDeletion 撤销冷静期内的注销申请
+func (a *accountService) CancelDeletion(ctx context.Context, uid int64) error {
+	if err := a.repo.CancelDeletion(ctx, uid); err != nil {
+		if errors.Is(err, repository.ErrDeletionNotFound) {
+			return ErrNoPendingDeletion
+		}
+		return err
+	}
+	return nil
+}
+
+// PurgeDueAccounts 清理到期账号，单个账号失败不影响其他账号
+func (a *accountService) PurgeDueAccounts(ctx context.Context) error {
+	due, err := a.repo.ListDueDeletions(ctx, time.Now().UnixMilli(), accountJobBatch)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, d := range due {
+		if err := a.purge(ctx, d.UserID); err != nil {
+			failed++
+			a.l.Error("清理注销账号失败", zap.Int64("uid", d.UserID), zap.Error(err))
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d 个账号清理失败", failed)
+	}
+	return nil
+}
+
+func (a *accountService) purge(ctx context.Context, uid int64) error {
+	removeContent := viper.GetString("account.deletion_mode") == "delete"
+	postIDs, commentIDs, err := a.repo.PurgeUser(ctx, uid, removeContent)
+	if err != nil {
+		// 领取后被撤销的申请直接跳过
+		if errors.Is(err, repository.ErrDeletionNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	// 数据库已清理完成，以下均为派生数据，失败只记录日志
+	if err := a.historyRepo.DeleteAllHistory(ctx, uid); err != nil {
+		a.l.Warn("清理注销用户的浏览历史失败", zap.Int64("uid", uid), zap.Error(err))
+	}
+	if err := a.searchRepo.DeleteUserIndex(ctx, uid); err != nil {
+		a.l.Warn("删除注销用户的搜索索引失败", zap.Int64("uid", uid), zap.Error(err))
+	}
+	for _, id := range postIDs {
+		if err := a.searchRepo.DeletePostIndex(ctx, id); err != nil {
+			a.l.Warn("删除注销用户的帖子索引失败", zap.Uint("post_id", id), zap.Error(err))
+		}
+	}
+	for _, id := range commentIDs {
+		if err := a.searchRepo.DeleteCommentIndex(ctx, uint(id)); err != nil {
+			a.l.Warn("删除注销用户的评论索引失败", zap.Int64("comment_id", id), zap.Error(err))
+		}
+	}
+
+	if export, err := a.repo.FindLatestExport(ctx, uid); err == nil && export.Status == domain.ExportReady {
+		a.expireExport(ctx, export)
+	}
+
+	a.l.Info("注销账号清理完成", zap.Int64("uid", uid), zap.Bool("remove_content", removeContent))
+	return nil
+}
+
+// RequestExport 创建导出任务
+func (a *accountService) RequestExport(ctx context.Context, uid int64) (domain.DataExport, error) {
+	latest, err := a.repo.FindLatestExport(ctx, uid)
+	if err != nil && !errors.Is(err, repository.ErrExportNotFound) {
+		return domain.DataExport{}, err
+	}
+	if err == nil && (latest.Status == domain.ExportPending || latest.Status == domain.ExportRunning) {
+		return domain.DataExport{}, ErrExportInProgress
+	}
+	return a.repo.CreateExport(ctx, uid)
+}
+
+// ExportStatus 获取最近一次导出任务
+func (a *accountService) ExportStatus(ctx context.Context, uid int64) (domain.DataExport, error) {
+	export, err := a.repo.FindLatestExport(ctx, uid)
+	if errors.Is(err, repository.ErrExportNotFound) {
+		return domain.DataExport{}, ErrExportNotReady
+	}
+	return export, err
+}
+
+// ExportFile 获取可下载的导出文件
+func (a *accountService) ExportFile(ctx context.Context, uid int64) (string, error) {
+	export, err := a.repo.FindLatestExport(ctx, uid)
+	if err != nil {
+		if errors.Is(err, repository.ErrExportNotFound) {
+			return "", ErrExportNotReady
+		}
+		return "", err
+	}
+	if export.Status != domain.ExportReady || export.ExpiresAt <= time.Now().UnixMilli() {
+		return "", ErrExportNotReady
+	}
+	return export.FilePath, nil
+}
+
+// ProcessDataExports 处理排队中的导出任务
+func (a *accountService) ProcessDataExports(ctx context.Context) error {
+	a.cleanExpiredExports(ctx)
+
+	pending, err := a.repo.ListExports(ctx, domain.ExportPending, accountJobBatch)
+	if err != nil {
+		return err
+	}
+
+	for _, export := range pending {
+		ok, err := a.repo.ClaimExport(ctx, export.ID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		now := time.Now()
+		path, err := a.writeExport(ctx, export)
+		if err != nil {
+			a.l.Error("生成数据导出文件失败", zap.Int64("id", export.ID), zap.Int64("uid", export.UserID), zap.Error(err))
+			export.Status = domain.ExportFailed
+		} else {
+			export.Status = domain.ExportReady
+			export.FilePath = path
+			export.ExpiresAt = now.Add(durationOr("account.export_ttl", defaultExportTTL)).UnixMilli()
+		}
+		export.FinishedAt = now.UnixMilli()
+		if err := a.repo.UpdateExport(ctx, export); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeExport 汇总用户数据并写入 JSON 文件
+func (a *accountService) writeExport(ctx context.Context, export domain.DataExport) (string, error) {
+	archive, err := a.repo.CollectUserData(ctx, export.UserID)
+	if err != nil {
+		return "", err
+	}
+
+	size := int64(1000)
+	offset := int64(0)
+	history, err := a.historyRepo.GetHistory(ctx, domain.Pagination{Uid: export.UserID, Size: &size, Offset: &offset})
+	if err != nil {
+		a.l.Warn("导出时获取浏览历史失败", zap.Int64("uid", export.UserID), zap.Error(err))
+	}
+	archive.History = history
+
+	data, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	dir := viper.GetString("account.export_dir")
+	if dir == "" {
+		dir = defaultExportDir
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("linkme-export-%d-%d.json", export.UserID, export.ID))
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// cleanExpiredExports 删除过期的导出文件
+func (a *accountService) cleanExpiredExports(ctx context.Context) {
+	expired, err := a.repo.ListExpiredExports(ctx, time.Now().UnixMilli(), accountJobBatch)
+	if err != nil {
+		a.l.Warn("获取过期导出任务失败", zap.Error(err))
+		return
+	}
+	for _, export := range expired {
+		a.expireExport(ctx, export)
+	}
+}
+
+func (a *accountService) expireExport(ctx context.Context, export domain.DataExport) {
+	if err := os.Remove(export.FilePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		a.l.Warn("删除导出文件失败", zap.String("path", export.FilePath), zap.Error(err))
+		return
+	}
+	export.Status = domain.ExportExpired
+	export.FilePath = ""
+	if err := a.repo.UpdateExport(ctx, export); err != nil {
+		a.l.Warn("更新导出任务状态失败", zap.Int64("id", export.ID), zap.Error(err))
+	}
+}
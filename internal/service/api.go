@@ -3,29 +3,45 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/GoSimplicity/LinkMe/internal/domain"
 	"github.com/GoSimplicity/LinkMe/internal/repository"
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/util"
 	"go.uber.org/zap"
 )
 
+// apiNameMaxLen API名称的最大长度，与表结构一致
+const apiNameMaxLen = 50
+
 type ApiService interface {
 	CreateApi(ctx context.Context, api *domain.Api) error
 	GetApiById(ctx context.Context, id int) (*domain.Api, error)
 	UpdateApi(ctx context.Context, api *domain.Api) error
 	DeleteApi(ctx context.Context, id int) error
 	ListApis(ctx context.Context, page, pageSize int) ([]*domain.Api, int, error)
+	// SyncRoutes 将服务注册的路由写入API注册表，并标记已不存在的路由
+	SyncRoutes(ctx context.Context, routes []domain.Route) (domain.RouteSyncResult, error)
+	// ListUncoveredApis 获取没有配置任何权限策略的非公开路由
+	ListUncoveredApis(ctx context.Context) ([]*domain.Api, error)
 }
 
 type apiService struct {
-	l    *zap.Logger
-	repo repository.ApiRepository
+	l        *zap.Logger
+	repo     repository.ApiRepository
+	ce       *casbin.SyncedEnforcer
+	auditSvc AuditService
 }
 
-func NewApiService(l *zap.Logger, repo repository.ApiRepository) ApiService {
+func NewApiService(l *zap.Logger, repo repository.ApiRepository, ce *casbin.SyncedEnforcer, auditSvc AuditService) ApiService {
 	return &apiService{
-		l:    l,
-		repo: repo,
+		l:        l,
+		repo:     repo,
+		ce:       ce,
+		auditSvc: auditSvc,
 	}
 }
 
@@ -36,7 +52,18 @@ func (a *apiService) CreateApi(ctx context.Context, api *domain.Api) error {
 		return errors.New("api不能为空")
 	}
 
-	return a.repo.CreateApi(ctx, api)
+	id, err := a.repo.CreateApi(ctx, api)
+	if err != nil {
+		return err
+	}
+	api.ID = id
+	a.auditSvc.Record(ctx, domain.AuditEntry{
+		Action:     domain.AuditApiCreate,
+		TargetType: domain.AuditTargetApi,
+		TargetID:   fmt.Sprint(id),
+		After:      api,
+	})
+	return nil
 }
 
 // GetApiById 根据ID获取API
@@ -56,7 +83,18 @@ func (a *apiService) UpdateApi(ctx context.Context, api *domain.Api) error {
 		return errors.New("api不能为空")
 	}
 
-	return a.repo.UpdateApi(ctx, api)
+	before, _ := a.repo.GetApiById(ctx, api.ID)
+	if err := a.repo.UpdateApi(ctx, api); err != nil {
+		return err
+	}
+	a.auditSvc.Record(ctx, domain.AuditEntry{
+		Action:     domain.AuditApiUpdate,
+		TargetType: domain.AuditTargetApi,
+		TargetID:   fmt.Sprint(api.ID),
+		Before:     before,
+		After:      api,
+	})
+	return nil
 }
 
 // DeleteApi 删除指定ID的API
@@ -66,7 +104,17 @@ func (a *apiService) DeleteApi(ctx context.Context, id int) error {
 		return errors.New("api id无效")
 	}
 
-	return a.repo.DeleteApi(ctx, id)
+	before, _ := a.repo.GetApiById(ctx, id)
+	if err := a.repo.DeleteApi(ctx, id); err != nil {
+		return err
+	}
+	a.auditSvc.Record(ctx, domain.AuditEntry{
+		Action:     domain.AuditApiDelete,
+		TargetType: domain.AuditTargetApi,
+		TargetID:   fmt.Sprint(id),
+		Before:     before,
+	})
+	return nil
 }
 
 // ListApis 分页获取API列表
@@ -78,3 +126,92 @@ func (a *apiService) ListApis(ctx context.Context, page, pageSize int) ([]*domai
 
 	return a.repo.ListApis(ctx, page, pageSize)
 }
+
+// SyncRoutes 同步路由，只处理 /api/ 下的路由
+func (a *apiService) SyncRoutes(ctx context.Context, routes []domain.Route) (domain.RouteSyncResult, error) {
+	var result domain.RouteSyncResult
+	keys := make([]string, 0, len(routes))
+	seen := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		if !route.Syncable() || seen[route.Key()] {
+			continue
+		}
+		seen[route.Key()] = true
+		method, _ := route.MethodCode()
+
+		created, err := a.repo.SyncRoute(ctx, &domain.Api{
+			Name:        truncateApiName(route.Key()),
+			Path:        route.Path,
+			Method:      method,
+			Description: "由路由自动同步",
+			Version:     "v1",
+			Category:    2,
+			RouteKey:    route.Key(),
+		})
+		if err != nil {
+			return result, fmt.Errorf("同步路由 %s 失败: %w", route.Key(), err)
+		}
+		if created {
+			result.Created++
+		}
+		keys = append(keys, route.Key())
+	}
+	result.Total = len(keys)
+
+	stale, err := a.repo.MarkStaleRoutes(ctx, keys)
+	if err != nil {
+		return result, fmt.Errorf("标记失效路由失败: %w", err)
+	}
+	result.Stale = stale
+	return result, nil
+}
+
+// ListUncoveredApis 获取没有配置任何权限策略的非公开路由
+func (a *apiService) ListUncoveredApis(ctx context.Context) ([]*domain.Api, error) {
+	apis, err := a.repo.ListRouteApis(ctx)
+	if err != nil {
+		return nil, err
+	}
+	policies, err := a.ce.GetPolicy()
+	if err != nil {
+		return nil, err
+	}
+	return uncoveredApis(apis, policies), nil
+}
+
+// uncoveredApis 筛选没有匹配策略的非公开API，策略对象可以是 api:<id>，也可以是按 keyMatch2 匹配的路径
+func uncoveredApis(apis []*domain.Api, policies [][]string) []*domain.Api {
+	res := make([]*domain.Api, 0)
+	for _, api := range apis {
+		if api.IsPublic == 1 {
+			continue
+		}
+		method := domain.ApiMethodName(api.Method)
+		obj := fmt.Sprintf("api:%d", api.ID)
+		// 手动编辑过的API路径会带有 api: 前缀
+		path := strings.TrimPrefix(api.Path, "api:")
+
+		covered := false
+		for _, p := range policies {
+			if len(p) < 3 || p[2] != method {
+				continue
+			}
+			if p[1] == obj || util.KeyMatch2(path, p[1]) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			res = append(res, api)
+		}
+	}
+	return res
+}
+
+// truncateApiName 按字符截断API名称
+func truncateApiName(name string) string {
+	if utf8.RuneCountInString(name) <= apiNameMaxLen {
+		return name
+	}
+	return string([]rune(name)[:apiNameMaxLen])
+}
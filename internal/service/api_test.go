@@ -0,0 +1,43 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+)
+
+func TestUncoveredApis(t *testing.T) {
+	apis := []*domain.Api{
+		{ID: 1, Path: "/api/posts/list", Method: 2},
+		{ID: 2, Path: "/api/posts/detail/:postId", Method: 1},
+		{ID: 3, Path: "/api/plate/create", Method: 2},
+		{ID: 4, Path: "/api/users/login", Method: 2, IsPublic: 1},
+		{ID: 5, Path: "api:/api/checks/approve", Method: 2},
+		{ID: 6, Path: "/api/plate/delete/:plateId", Method: 4},
+	}
+	policies := [][]string{
+		{"admin", "api:1", "POST"},
+		{"admin", "/api/posts/*", "GET"},
+		{"admin", "/api/checks/approve", "POST"},
+		{"admin", "api:6", "GET"}, // 方法不一致
+	}
+
+	got := uncoveredApis(apis, policies)
+	var ids []int
+	for _, api := range got {
+		ids = append(ids, api.ID)
+	}
+	if len(ids) != 2 || ids[0] != 3 || ids[1] != 6 {
+		t.Fatalf("uncoveredApis() = %v, want [3 6]", ids)
+	}
+}
+
+func TestTruncateApiName(t *testing.T) {
+	if got := truncateApiName("GET /api/a"); got != "GET /api/a" {
+		t.Errorf("short name changed: %q", got)
+	}
+	long := "POST /api/very/long/path/that/goes/beyond/fifty/characters/for/sure"
+	if got := truncateApiName(long); len([]rune(got)) != apiNameMaxLen {
+		t.Errorf("truncateApiName() len = %d, want %d", len([]rune(got)), apiNameMaxLen)
+	}
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/repository"
+	"go.uber.org/zap"
+)
+
+const (
+	// auditPurgeBatch 每批删除的过期审计记录数量
+	auditPurgeBatch = 1000
+	// maxAuditPageSize 单页最多返回的审计记录数量
+	maxAuditPageSize = 100
+)
+
+// ErrInvalidAuditQuery 表示审计记录的分页或时间范围参数无效
+var ErrInvalidAuditQuery = errors.New("无效的分页参数或时间范围")
+
+type AuditService interface {
+	// Record 记录审计事件，写入失败只记录日志，不影响业务操作
+	Record(ctx context.Context, entry domain.AuditEntry)
+	List(ctx context.Context, filter domain.AuditFilter, pagination domain.Pagination) ([]domain.AuditLog, int64, error)
+	// PurgeExpired 删除超过保留期限的审计记录，保留期限由 audit.retention 配置
+	PurgeExpired(ctx context.Context) error
+}
+
+type auditService struct {
+	repo repository.AuditRepository
+	l    *zap.Logger
+}
+
+func NewAuditService(repo repository.AuditRepository, l *zap.Logger) AuditService {
+	return &auditService{
+		repo: repo,
+		l:    l,
+	}
+}
+
+// Record 记录审计事件，请求信息从上下文中读取
+func (a *auditService) Record(ctx context.Context, entry domain.AuditEntry) {
+	meta := domain.AuditMetaFromContext(ctx)
+	actor := entry.ActorID
+	if actor == 0 {
+		actor = meta.ActorID
+	}
+
+	if err := a.repo.Create(ctx, domain.AuditLog{
+		ActorID:    actor,
+		Action:     entry.Action,
+		TargetType: entry.TargetType,
+		TargetID:   entry.TargetID,
+		Before:     a.snapshot(entry.Before),
+		After:      a.snapshot(entry.After),
+		IP:         meta.IP,
+		RequestID:  meta.RequestID,
+	}); err != nil {
+		a.l.Error("记录审计事件失败",
+			zap.String("action", entry.Action),
+			zap.String("target_type", entry.TargetType),
+			zap.String("target_id", entry.TargetID),
+			zap.Int64("actor_id", actor),
+			zap.Error(err))
+	}
+}
+
+// List 分页查询审计记录
+func (a *auditService) List(ctx context.Context, filter domain.AuditFilter, pagination domain.Pagination) ([]domain.AuditLog, int64, error) {
+	if pagination.Page <= 0 || pagination.Size == nil || *pagination.Size <= 0 || *pagination.Size > maxAuditPageSize {
+		return nil, 0, ErrInvalidAuditQuery
+	}
+	if filter.StartTime > 0 && filter.EndTime > 0 && filter.StartTime >= filter.EndTime {
+		return nil, 0, ErrInvalidAuditQuery
+	}
+	offset := int64(pagination.Page-1) * *pagination.Size
+	pagination.Offset = &offset
+	return a.repo.List(ctx, filter, pagination)
+}
+
+// PurgeExpired 分批删除过期记录
+func (a *auditService) PurgeExpired(ctx context.Context) error {
+	before := time.Now().Add(-durationOr("audit.retention", 180*24*time.Hour)).UnixMilli()
+	var total int64
+	for {
+		n, err := a.repo.DeleteBefore(ctx, before, auditPurgeBatch)
+		if err != nil {
+			return err
+		}
+		total += n
+		if n < auditPurgeBatch {
+			break
+		}
+	}
+	if total > 0 {
+		a.l.Info("清理过期审计记录", zap.Int64("count", total))
+	}
+	return nil
+}
+
+// snapshot 将快照序列化为 JSON，nil 时返回空字符串
+func (a *auditService) snapshot(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		a.l.Warn("序列化审计快照失败", zap.Error(err))
+		return ""
+	}
+	return string(data)
+}
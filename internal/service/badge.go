@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/repository"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ErrBadgeNotFound 表示勋章不存在
+var ErrBadgeNotFound = errors.New("勋章不存在")
+
+// backfillBatchSize 回填时每批处理的用户数
+const backfillBatchSize = 200
+
+type BadgeService interface {
+	ListBadges(ctx context.Context, includeDisabled bool) ([]domain.Badge, error)
+	CreateBadge(ctx context.Context, badge domain.Badge) (int64, error)
+	UpdateBadge(ctx context.Context, badge domain.Badge) error
+	GetUserBadges(ctx context.Context, uid int64) ([]domain.UserBadge, error)
+	// Evaluate 按当前统计数据评估用户的勋章，未指定条件时评估全部条件，已获得的勋章不会重复授予
+	Evaluate(ctx context.Context, uid int64, rules ...domain.BadgeRule) error
+	// EvaluatePostAuthor 评估帖子作者的勋章
+	EvaluatePostAuthor(ctx context.Context, postId uint, rules ...domain.BadgeRule) error
+	// RecordActivity 记录用户当天活跃，并评估连续活跃勋章
+	RecordActivity(ctx context.Context, uid int64) error
+	// Backfill 根据历史数据为全部用户评估勋章
+	Backfill(ctx context.Context) error
+}
+
+type badgeService struct {
+	repo     repository.BadgeRepository
+	postRepo repository.PostRepository
+	auditSvc AuditService
+	l        *zap.Logger
+}
+
+func NewBadgeService(repo repository.BadgeRepository, postRepo repository.PostRepository, auditSvc AuditService, l *zap.Logger) BadgeService {
+	return &badgeService{
+		repo:     repo,
+		postRepo: postRepo,
+		auditSvc: auditSvc,
+		l:        l,
+	}
+}
+
+// ListBadges 获取勋章定义
+func (b *badgeService) ListBadges(ctx context.Context, includeDisabled bool) ([]domain.Badge, error) {
+	return b.repo.ListBadges(ctx, !includeDisabled)
+}
+
+// CreateBadge 创建勋章
+func (b *badgeService) CreateBadge(ctx context.Context, badge domain.Badge) (int64, error) {
+	if err := badge.Validate(); err != nil {
+		return 0, err
+	}
+	id, err := b.repo.CreateBadge(ctx, badge)
+	if err != nil {
+		return 0, err
+	}
+	badge.ID = id
+	b.auditSvc.Record(ctx, domain.AuditEntry{
+		Action:     domain.AuditBadgeCreate,
+		TargetType: domain.AuditTargetBadge,
+		TargetID:   fmt.Sprint(id),
+		After:      badge,
+	})
+	return id, nil
+}
+
+// UpdateBadge 更新勋章
+func (b *badgeService) UpdateBadge(ctx context.Context, badge domain.Badge) error {
+	if err := badge.Validate(); err != nil {
+		return err
+	}
+	before := b.findBadge(ctx, badge.ID)
+	if err := b.repo.UpdateBadge(ctx, badge); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrBadgeNotFound
+		}
+		return err
+	}
+	b.auditSvc.Record(ctx, domain.AuditEntry{
+		Action:     domain.AuditBadgeUpdate,
+		TargetType: domain.AuditTargetBadge,
+		TargetID:   fmt.Sprint(badge.ID),
+		Before:     before,
+		After:      badge,
+	})
+	return nil
+}
+
+// findBadge 获取勋章定义作为审计快照，勋章数量很少，直接从列表中查找
+func (b *badgeService) findBadge(ctx context.Context, id int64) *domain.Badge {
+	badges, err := b.repo.ListBadges(ctx, false)
+	if err != nil {
+		return nil
+	}
+	for i := range badges {
+		if badges[i].ID == id {
+			return &badges[i]
+		}
+	}
+	return nil
+}
+
+// GetUserBadges 获取用户已获得的勋章
+func (b *badgeService) GetUserBadges(ctx context.Context, uid int64) ([]domain.UserBadge, error) {
+	return b.repo.ListUserBadges(ctx, uid)
+}
+
+// Evaluate 评估并授予勋章
+func (b *badgeService) Evaluate(ctx context.Context, uid int64, rules ...domain.BadgeRule) error {
+	if uid <= 0 {
+		return nil
+	}
+	badges, err := b.repo.ListBadges(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	// 同一条件的统计值只查询一次
+	stats := make(map[domain.BadgeRule]int64)
+	for _, badge := range badges {
+		if len(rules) > 0 && !containsRule(rules, badge.Rule) {
+			continue
+		}
+		value, ok := stats[badge.Rule]
+		if !ok {
+			if value, err = b.stat(ctx, uid, badge.Rule); err != nil {
+				return err
+			}
+			stats[badge.Rule] = value
+		}
+		if !badge.Reached(value) {
+			continue
+		}
+		awarded, err := b.repo.Award(ctx, uid, badge.ID)
+		if err != nil {
+			return err
+		}
+		if awarded {
+			b.l.Info("授予勋章", zap.Int64("uid", uid), zap.String("badge", badge.Name))
+		}
+	}
+	return nil
+}
+
+// EvaluatePostAuthor 评估帖子作者的勋章
+func (b *badgeService) EvaluatePostAuthor(ctx context.Context, postId uint, rules ...domain.BadgeRule) error {
+	post, err := b.postRepo.GetPublishPostById(ctx, postId)
+	if err != nil {
+		return err
+	}
+	return b.Evaluate(ctx, post.Uid, rules...)
+}
+
+// RecordActivity 记录活跃日期，每天只在首次活跃时评估
+func (b *badgeService) RecordActivity(ctx context.Context, uid int64) error {
+	if uid <= 0 {
+		return nil
+	}
+	recorded, err := b.repo.RecordActiveDay(ctx, uid, time.Now().Format(time.DateOnly))
+	if err != nil || !recorded {
+		return err
+	}
+	return b.Evaluate(ctx, uid, domain.BadgeRuleActiveStreak)
+}
+
+// Backfill 补全历史活跃日期后分批评估所有用户，单个用户失败不影响其他用户
+func (b *badgeService) Backfill(ctx context.Context) error {
+	if err := b.repo.BackfillActiveDays(ctx); err != nil {
+		return err
+	}
+
+	var lastId, evaluated int64
+	for {
+		ids, err := b.repo.ListUserIDs(ctx, lastId, backfillBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if err := b.Evaluate(ctx, id); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				b.l.Warn("回填用户勋章失败", zap.Int64("uid", id), zap.Error(err))
+				continue
+			}
+			evaluated++
+		}
+		if len(ids) < backfillBatchSize {
+			break
+		}
+		lastId = ids[len(ids)-1]
+	}
+
+	b.l.Info("勋章回填完成", zap.Int64("users", evaluated))
+	return nil
+}
+
+// stat 获取用户在指定条件下的统计值
+func (b *badgeService) stat(ctx context.Context, uid int64, rule domain.BadgeRule) (int64, error) {
+	switch rule {
+	case domain.BadgeRuleApprovedPosts:
+		return b.repo.CountApprovedPosts(ctx, uid)
+	case domain.BadgeRuleLikesReceived:
+		return b.repo.SumLikesReceived(ctx, uid)
+	case domain.BadgeRuleLotteryParticipations:
+		return b.repo.CountLotteryParticipations(ctx, uid)
+	case domain.BadgeRuleActiveStreak:
+		days, err := b.repo.ListActiveDays(ctx, uid)
+		if err != nil {
+			return 0, err
+		}
+		return domain.LongestStreak(days), nil
+	}
+	return 0, nil
+}
+
+func containsRule(rules []domain.BadgeRule, rule domain.BadgeRule) bool {
+	for _, r := range rules {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
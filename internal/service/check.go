@@ -17,8 +17,9 @@ import (
 type CheckService interface {
 	ApproveCheck(ctx context.Context, checkID int64, remark string, uid int64) error
 	RejectCheck(ctx context.Context, checkID int64, remark string, uid int64) error
+	// ListChecks 获取审核列表，版主只能看到其负责板块的审核记录
 	ListChecks(ctx context.Context, pagination domain.Pagination) ([]domain.Check, error)
-	CheckDetail(ctx context.Context, checkID int64) (domain.Check, error)
+	CheckDetail(ctx context.Context, checkID int64, uid int64) (domain.Check, error)
 }
 
 type checkService struct {
@@ -28,9 +29,16 @@ type checkService struct {
 	searchRepo      repository.SearchRepository
 	l               *zap.Logger
 	commentProducer comment.Producer
+	reputationSvc   ReputationService
+	badgeSvc        BadgeService
+	moderatorSvc    ModeratorService
+	postRepo        repository.PostRepository
+	commentRepo     repository.CommentRepository
+	auditSvc        AuditService
+	notifySvc       NotificationService
 }
 
-func NewCheckService(repo repository.CheckRepository, searchRepo repository.SearchRepository, l *zap.Logger, ActivityRepo repository.ActivityRepository, publishProducer publish.Producer, commentProducer comment.Producer) CheckService {
+func NewCheckService(repo repository.CheckRepository, searchRepo repository.SearchRepository, l *zap.Logger, ActivityRepo repository.ActivityRepository, publishProducer publish.Producer, commentProducer comment.Producer, reputationSvc ReputationService, badgeSvc BadgeService, moderatorSvc ModeratorService, postRepo repository.PostRepository, commentRepo repository.CommentRepository, auditSvc AuditService, notifySvc NotificationService) CheckService {
 	return &checkService{
 		repo:            repo,
 		ActivityRepo:    ActivityRepo,
@@ -38,6 +46,13 @@ func NewCheckService(repo repository.CheckRepository, searchRepo repository.Sear
 		l:               l,
 		postProducer:    publishProducer,
 		commentProducer: commentProducer,
+		reputationSvc:   reputationSvc,
+		badgeSvc:        badgeSvc,
+		moderatorSvc:    moderatorSvc,
+		postRepo:        postRepo,
+		commentRepo:     commentRepo,
+		auditSvc:        auditSvc,
+		notifySvc:       notifySvc,
 	}
 }
 
@@ -49,6 +64,9 @@ func (s *checkService) ApproveCheck(ctx context.Context, checkID int64, remark s
 		s.l.Error("获取审核详情失败", zap.Error(err))
 		return fmt.Errorf("获取审核详情失败: %w", err)
 	}
+	if err := s.authorize(ctx, check, uid); err != nil {
+		return err
+	}
 
 	// 检查是否已审核
 	if check.Status != domain.UnderReview {
@@ -68,6 +86,19 @@ func (s *checkService) ApproveCheck(ctx context.Context, checkID int64, remark s
 			zap.Error(err))
 		return fmt.Errorf("更新审核状态失败: %w", err)
 	}
+	s.recordAudit(ctx, domain.AuditCheckApprove, check, domain.Approved, remark, uid)
+	go s.notifyResult(context.Background(), check, domain.Approved, remark)
+
+	// 帖子按帖子ID计分，重复审核不会重复加分；评论按审核记录计分
+	switch check.BizId {
+	case 1:
+		s.recordReputation(ctx, check.Uid, domain.ReputationPostApproved, int64(check.PostID))
+		if err := s.badgeSvc.Evaluate(ctx, check.Uid, domain.BadgeRuleApprovedPosts); err != nil {
+			s.l.Warn("评估发帖勋章失败", zap.Int64("uid", check.Uid), zap.Error(err))
+		}
+	case 2:
+		s.recordReputation(ctx, check.Uid, domain.ReputationComment, checkID)
+	}
 
 	go func() {
 		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
@@ -126,6 +157,9 @@ func (s *checkService) RejectCheck(ctx context.Context, checkID int64, remark st
 		s.l.Error("获取审核详情失败", zap.Error(err))
 		return fmt.Errorf("获取审核详情失败: %w", err)
 	}
+	if err := s.authorize(ctx, check, uid); err != nil {
+		return err
+	}
 
 	// 检查状态
 	if check.Status != domain.UnderReview {
@@ -144,6 +178,10 @@ func (s *checkService) RejectCheck(ctx context.Context, checkID int64, remark st
 			zap.Error(err))
 		return fmt.Errorf("更新审核状态失败: %w", err)
 	}
+	s.recordAudit(ctx, domain.AuditCheckReject, check, domain.UnApproved, remark, uid)
+	go s.notifyResult(context.Background(), check, domain.UnApproved, remark)
+
+	s.recordReputation(ctx, check.Uid, domain.ReputationViolation, checkID)
 
 	go func() {
 		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
@@ -178,12 +216,51 @@ func (s *checkService) RejectCheck(ctx context.Context, checkID int64, remark st
 	return nil
 }
 
+// notifyResult 向提交者发送审核结果提醒
+func (s *checkService) notifyResult(ctx context.Context, check domain.Check, status uint8, remark string) {
+	kind := "帖子"
+	if check.BizId == 2 {
+		kind = "评论"
+	}
+	title := fmt.Sprintf("你的%s已通过审核", kind)
+	if status == domain.UnApproved {
+		title = fmt.Sprintf("你的%s未通过审核", kind)
+	}
+	content := check.Title
+	if remark != "" {
+		content = fmt.Sprintf("%s 审核备注：%s", check.Title, remark)
+	}
+	if err := s.notifySvc.Notify(ctx, domain.Notification{
+		UserID:  check.Uid,
+		Type:    domain.NotificationModeration,
+		Title:   title,
+		Content: content,
+	}); err != nil {
+		s.l.Warn("发送审核结果提醒失败", zap.Int64("uid", check.Uid), zap.Error(err))
+	}
+}
+
 // ListChecks 获取审核列表
 func (s *checkService) ListChecks(ctx context.Context, pagination domain.Pagination) ([]domain.Check, error) {
 	// 计算偏移量
 	offset := int64(pagination.Page-1) * *pagination.Size
 	pagination.Offset = &offset
 
+	all, err := s.moderatorSvc.CanModerateAll(ctx, pagination.Uid)
+	if err != nil {
+		return nil, err
+	}
+	if !all {
+		plates, err := s.moderatorSvc.ModeratedPlates(ctx, pagination.Uid)
+		if err != nil {
+			return nil, err
+		}
+		if len(plates) == 0 {
+			return nil, ErrModerateForbidden
+		}
+		return s.repo.FindByPlates(ctx, plates, pagination)
+	}
+
 	checks, err := s.repo.FindAll(ctx, pagination)
 	if err != nil {
 		s.l.Error("获取审核列表失败", zap.Error(err))
@@ -194,16 +271,61 @@ func (s *checkService) ListChecks(ctx context.Context, pagination domain.Paginat
 }
 
 // CheckDetail 获取审核详情
-func (s *checkService) CheckDetail(ctx context.Context, checkID int64) (domain.Check, error) {
+func (s *checkService) CheckDetail(ctx context.Context, checkID int64, uid int64) (domain.Check, error) {
 	check, err := s.repo.FindByID(ctx, checkID)
 	if err != nil {
 		s.l.Error("获取审核详情失败", zap.Error(err))
 		return domain.Check{}, err
 	}
+	if err := s.authorize(ctx, check, uid); err != nil {
+		return domain.Check{}, err
+	}
 
 	return check, nil
 }
 
+// authorize 校验用户能否审核该记录，全站审核员或内容所在板块的版主可以操作
+func (s *checkService) authorize(ctx context.Context, check domain.Check, uid int64) error {
+	plateId, err := s.checkPlate(ctx, check)
+	if err != nil {
+		s.l.Warn("获取审核内容所在板块失败", zap.Int64("check_id", check.ID), zap.Error(err))
+	}
+	ok, err := s.moderatorSvc.CanModerate(ctx, uid, plateId)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrModerateForbidden
+	}
+	return nil
+}
+
+// checkPlate 获取审核内容所在的板块，早期的审核记录未保存板块时按内容回查
+func (s *checkService) checkPlate(ctx context.Context, check domain.Check) (int64, error) {
+	if check.PlateID > 0 {
+		return check.PlateID, nil
+	}
+	switch check.BizId {
+	case 1:
+		post, err := s.postRepo.GetPostById(ctx, check.PostID, check.Uid)
+		if err != nil {
+			return 0, err
+		}
+		return post.PlateID, nil
+	case 2:
+		comment, err := s.commentRepo.FindCommentByCommentId(ctx, int64(check.PostID))
+		if err != nil {
+			return 0, err
+		}
+		post, err := s.postRepo.GetPublishPostById(ctx, uint(comment.PostId))
+		if err != nil {
+			return 0, err
+		}
+		return post.PlateID, nil
+	}
+	return 0, nil
+}
+
 // recordActivity 记录活动
 func (s *checkService) recordActivity(uid int64, desc string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -222,3 +344,25 @@ func (s *checkService) recordActivity(uid int64, desc string) error {
 
 	return nil
 }
+
+// recordAudit 记录审核操作，快照为审核前后的审核记录
+func (s *checkService) recordAudit(ctx context.Context, action string, before domain.Check, status uint8, remark string, uid int64) {
+	after := before
+	after.Status = status
+	after.Remark = remark
+	s.auditSvc.Record(ctx, domain.AuditEntry{
+		ActorID:    uid,
+		Action:     action,
+		TargetType: domain.AuditTargetCheck,
+		TargetID:   strconv.FormatInt(before.ID, 10),
+		Before:     before,
+		After:      after,
+	})
+}
+
+// recordReputation 记录审核结果对应的积分，失败只记录日志
+func (s *checkService) recordReputation(ctx context.Context, uid int64, event domain.ReputationEvent, bizId int64) {
+	if err := s.reputationSvc.Record(ctx, uid, event, bizId, 0); err != nil {
+		s.l.Warn("记录审核积分失败", zap.Int64("uid", uid), zap.String("event", string(event)), zap.Error(err))
+	}
+}
@@ -4,32 +4,49 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strconv"
 	"time"
 
 	"github.com/GoSimplicity/LinkMe/internal/domain"
 	"github.com/GoSimplicity/LinkMe/internal/domain/events/check"
 	"github.com/GoSimplicity/LinkMe/internal/repository"
 	"github.com/GoSimplicity/LinkMe/pkg/general"
+	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
 
 type commentService struct {
 	repo          repository.CommentRepository
 	checkProducer check.Producer
+	postRepo      repository.PostRepository
+	relationRepo  repository.RelationRepository
+	userRepo      repository.UserRepository
+	notifySvc     NotificationService
+	moderatorSvc  ModeratorService
+	auditSvc      AuditService
+	l             *zap.Logger
 }
 
 type CommentService interface {
 	CreateComment(ctx context.Context, comment domain.Comment) error
-	DeleteComment(ctx context.Context, commentId int64) error
-	ListComments(ctx context.Context, postId, minID, limit int64) ([]domain.Comment, error)
-	GetMoreCommentsReply(ctx context.Context, rootId, maxId, limit int64) ([]domain.Comment, error)
-	GetTopCommentsReply(ctx context.Context, postId int64) (domain.Comment, error)
+	// DeleteComment 删除评论，仅评论作者或评论所在板块的版主可以删除
+	DeleteComment(ctx context.Context, commentId, uid int64) error
+	ListComments(ctx context.Context, postId, minID, limit, uid int64) ([]domain.Comment, error)
+	GetMoreCommentsReply(ctx context.Context, rootId, maxId, limit, uid int64) ([]domain.Comment, error)
+	GetTopCommentsReply(ctx context.Context, postId, uid int64) (domain.Comment, error)
 }
 
-func NewCommentService(repo repository.CommentRepository, c check.Producer) CommentService {
+func NewCommentService(repo repository.CommentRepository, c check.Producer, postRepo repository.PostRepository, relationRepo repository.RelationRepository, userRepo repository.UserRepository, notifySvc NotificationService, moderatorSvc ModeratorService, auditSvc AuditService, l *zap.Logger) CommentService {
 	return &commentService{
 		repo:          repo,
 		checkProducer: c,
+		postRepo:      postRepo,
+		relationRepo:  relationRepo,
+		userRepo:      userRepo,
+		notifySvc:     notifySvc,
+		moderatorSvc:  moderatorSvc,
+		auditSvc:      auditSvc,
+		l:             l,
 	}
 }
 
@@ -40,6 +57,12 @@ func (c *commentService) CreateComment(ctx context.Context, comment domain.Comme
 		return fmt.Errorf("评论内容不能为空")
 	}
 
+	// 帖子作者或被回复者拉黑了评论者时不允许评论
+	post, err := c.checkCommentBlocked(ctx, comment)
+	if err != nil {
+		return err
+	}
+
 	// 创建评论
 	commentId, err := c.repo.CreateComment(ctx, comment)
 	if err != nil || commentId == 0 {
@@ -57,6 +80,7 @@ func (c *commentService) CreateComment(ctx context.Context, comment domain.Comme
 				PostId:  uint(commentId),
 				Content: comment.Content,
 				Uid:     comment.UserId,
+				PlateID: post.PlateID,
 			}
 
 			if err := c.checkProducer.ProduceCheckEvent(event); err != nil {
@@ -67,26 +91,165 @@ func (c *commentService) CreateComment(ctx context.Context, comment domain.Comme
 		return nil
 	})()
 
+	// 异步发送评论与 @ 提醒
+	go c.notifyPostAuthor(context.Background(), post, comment)
+	go c.notifyMentions(context.Background(), comment)
+
 	return nil
 }
 
+// checkCommentBlocked 校验帖子作者与被回复的评论作者是否拉黑了评论者，返回评论所在的帖子
+func (c *commentService) checkCommentBlocked(ctx context.Context, comment domain.Comment) (domain.Post, error) {
+	owners := make([]int64, 0, 2)
+	post, err := c.visiblePost(ctx, comment.PostId, comment.UserId)
+	if err != nil {
+		return domain.Post{}, err
+	}
+	owners = append(owners, post.Uid)
+	if comment.ParentComment != nil {
+		parent, err := c.repo.FindCommentByCommentId(ctx, comment.ParentComment.Id)
+		if err != nil {
+			return domain.Post{}, err
+		}
+		owners = append(owners, parent.UserId)
+	}
+
+	for _, owner := range owners {
+		if owner == comment.UserId {
+			continue
+		}
+		blocked, err := c.relationRepo.IsBlocked(ctx, owner, comment.UserId)
+		if err != nil {
+			return domain.Post{}, err
+		}
+		if blocked {
+			return domain.Post{}, ErrUserBlocked
+		}
+	}
+	return post, nil
+}
+
+// notifyPostAuthor 向帖子作者发送评论提醒，跳过自己评论及作者屏蔽了评论者的情况
+func (c *commentService) notifyPostAuthor(ctx context.Context, post domain.Post, comment domain.Comment) {
+	if post.Uid == comment.UserId {
+		return
+	}
+	hidden, err := c.relationRepo.HiddenUserIDs(ctx, post.Uid)
+	if err != nil {
+		c.l.Warn("获取屏蔽列表失败", zap.Int64("uid", post.Uid), zap.Error(err))
+		return
+	}
+	if _, ok := hidden[comment.UserId]; ok {
+		return
+	}
+	if err := c.notifySvc.Notify(ctx, domain.Notification{
+		UserID:  post.Uid,
+		Type:    domain.NotificationComment,
+		Title:   "有人评论了你的帖子",
+		Content: comment.Content,
+	}); err != nil {
+		c.l.Warn("发送评论提醒失败", zap.Int64("uid", post.Uid), zap.Error(err))
+	}
+}
+
+// notifyMentions 向评论中 @ 的用户发送提醒，跳过拉黑或屏蔽了评论者的用户
+func (c *commentService) notifyMentions(ctx context.Context, comment domain.Comment) {
+	for _, username := range comment.Mentions() {
+		u, err := c.userRepo.FindByUsername(ctx, username)
+		if err != nil || u.ID == comment.UserId {
+			continue
+		}
+		hidden, err := c.relationRepo.HiddenUserIDs(ctx, u.ID)
+		if err != nil {
+			c.l.Warn("获取屏蔽列表失败", zap.Int64("uid", u.ID), zap.Error(err))
+			continue
+		}
+		if _, ok := hidden[comment.UserId]; ok {
+			continue
+		}
+		if err := c.notifySvc.Notify(ctx, domain.Notification{
+			UserID:  u.ID,
+			Type:    domain.NotificationMention,
+			Title:   "有人在评论中提到了你",
+			Content: comment.Content,
+		}); err != nil {
+			c.l.Warn("发送提及提醒失败", zap.Int64("uid", u.ID), zap.Error(err))
+		}
+	}
+}
+
 // DeleteComment 删除评论的实现
-func (c *commentService) DeleteComment(ctx context.Context, commentId int64) error {
-	return c.repo.DeleteComment(ctx, commentId)
+func (c *commentService) DeleteComment(ctx context.Context, commentId, uid int64) error {
+	comment, err := c.repo.FindCommentByCommentId(ctx, commentId)
+	if err != nil {
+		return err
+	}
+	if comment.UserId != uid {
+		// 帖子已下架时取不到板块，只有全站审核员可以删除
+		var plateId int64
+		if post, err := c.postRepo.GetPublishPostById(ctx, uint(comment.PostId)); err == nil {
+			plateId = post.PlateID
+		}
+		ok, err := c.moderatorSvc.CanModerate(ctx, uid, plateId)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrModerateForbidden
+		}
+	}
+	if err := c.repo.DeleteComment(ctx, commentId); err != nil {
+		return err
+	}
+	// 作者删除自己的评论不属于管理操作，只记录版主删除
+	if comment.UserId != uid {
+		c.auditSvc.Record(ctx, domain.AuditEntry{
+			ActorID:    uid,
+			Action:     domain.AuditCommentDelete,
+			TargetType: domain.AuditTargetComment,
+			TargetID:   strconv.FormatInt(commentId, 10),
+			Before:     comment,
+		})
+	}
+	return nil
 }
 
 // GetMoreCommentsReply 获取更多评论回复的实现
-func (c *commentService) GetMoreCommentsReply(ctx context.Context, rootId, maxId, limit int64) ([]domain.Comment, error) {
-	return c.repo.GetMoreCommentsReply(ctx, rootId, maxId, limit)
+func (c *commentService) GetMoreCommentsReply(ctx context.Context, rootId, maxId, limit, uid int64) ([]domain.Comment, error) {
+	root, err := c.repo.FindCommentByCommentId(ctx, rootId)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.visiblePost(ctx, root.PostId, uid); err != nil {
+		return nil, err
+	}
+	hidden, err := c.relationRepo.HiddenUserIDs(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := c.repo.GetMoreCommentsReply(ctx, rootId, maxId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return filterHiddenComments(comments, hidden), nil
 }
 
-// ListComments 列出评论的实现
-func (c *commentService) ListComments(ctx context.Context, postId, minID, limit int64) ([]domain.Comment, error) {
+// ListComments 列出评论的实现，过滤当前用户屏蔽或拉黑的用户的评论
+func (c *commentService) ListComments(ctx context.Context, postId, minID, limit, uid int64) ([]domain.Comment, error) {
+	if _, err := c.visiblePost(ctx, postId, uid); err != nil {
+		return nil, err
+	}
+	hidden, err := c.relationRepo.HiddenUserIDs(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
 	// 获取评论列表
 	comments, err := c.repo.ListComments(ctx, postId, minID, limit)
 	if err != nil {
 		return nil, fmt.Errorf("获取评论列表失败: %w", err)
 	}
+	comments = filterHiddenComments(comments, hidden)
 
 	// 初始化返回的评论列表
 	domainComments := make([]domain.Comment, 0, len(comments))
@@ -105,7 +268,7 @@ func (c *commentService) ListComments(ctx context.Context, postId, minID, limit
 				return fmt.Errorf("获取子评论失败: %w", err)
 			}
 
-			domainComments[i].Children = subComments
+			domainComments[i].Children = filterHiddenComments(subComments, hidden)
 			return nil
 		})
 	}
@@ -117,6 +280,40 @@ func (c *commentService) ListComments(ctx context.Context, postId, minID, limit
 	return domainComments, nil
 }
 
-func (c *commentService) GetTopCommentsReply(ctx context.Context, postId int64) (domain.Comment, error) {
+func (c *commentService) GetTopCommentsReply(ctx context.Context, postId, uid int64) (domain.Comment, error) {
+	if _, err := c.visiblePost(ctx, postId, uid); err != nil {
+		return domain.Comment{}, err
+	}
 	return c.repo.GetTopCommentsReply(ctx, postId)
 }
+
+// visiblePost 获取评论所在的帖子，私密账号的帖子仅对本人及关注者可见
+func (c *commentService) visiblePost(ctx context.Context, postId, uid int64) (domain.Post, error) {
+	post, err := c.postRepo.GetPublishPostById(ctx, uint(postId))
+	if err != nil {
+		return domain.Post{}, fmt.Errorf("获取帖子失败: %w", err)
+	}
+	ok, err := canViewUser(ctx, c.userRepo, c.relationRepo, uid, post.Uid)
+	if err != nil {
+		return domain.Post{}, err
+	}
+	if !ok {
+		return domain.Post{}, ErrPrivateAccount
+	}
+	return post, nil
+}
+
+// filterHiddenComments 过滤被屏蔽用户的评论
+func filterHiddenComments(comments []domain.Comment, hidden map[int64]struct{}) []domain.Comment {
+	if len(hidden) == 0 {
+		return comments
+	}
+	result := make([]domain.Comment, 0, len(comments))
+	for _, comment := range comments {
+		if _, ok := hidden[comment.UserId]; ok {
+			continue
+		}
+		result = append(result, comment)
+	}
+	return result
+}
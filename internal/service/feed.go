@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/repository"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultTimelineLimit = 20
+	maxTimelineLimit     = 100
+)
+
+type FeedService interface {
+	// Timeline 获取关注流，按帖子ID倒序，cursor 为上一页返回的游标，首页传 0
+	Timeline(ctx context.Context, uid int64, cursor uint, limit int) (domain.Timeline, error)
+}
+
+type feedService struct {
+	repo         repository.FeedRepository
+	postRepo     repository.PostRepository
+	relationRepo repository.RelationRepository
+	l            *zap.Logger
+}
+
+func NewFeedService(repo repository.FeedRepository, postRepo repository.PostRepository, relationRepo repository.RelationRepository, l *zap.Logger) FeedService {
+	return &feedService{
+		repo:         repo,
+		postRepo:     postRepo,
+		relationRepo: relationRepo,
+		l:            l,
+	}
+}
+
+// Timeline 合并收件箱中推送的帖子与拉取的大V帖子
+func (f *feedService) Timeline(ctx context.Context, uid int64, cursor uint, limit int) (domain.Timeline, error) {
+	if limit <= 0 {
+		limit = defaultTimelineLimit
+	}
+	if limit > maxTimelineLimit {
+		limit = maxTimelineLimit
+	}
+
+	pushed, err := f.repo.InboxPostIds(ctx, uid, cursor, limit)
+	if err != nil {
+		return domain.Timeline{}, err
+	}
+	pulled, err := f.repo.PullPostIds(ctx, uid, cursor, limit)
+	if err != nil {
+		return domain.Timeline{}, err
+	}
+
+	ids := mergePostIds(pushed, pulled, limit)
+	timeline := domain.Timeline{Posts: []domain.Post{}}
+	if len(ids) == 0 {
+		return timeline, nil
+	}
+	// 游标基于合并后的结果计算，不受后续过滤影响
+	if len(ids) == limit {
+		timeline.Cursor = ids[len(ids)-1]
+	}
+
+	posts, err := f.postRepo.GetPublishPostsByIds(ctx, ids)
+	if err != nil {
+		return domain.Timeline{}, err
+	}
+	hidden, err := f.relationRepo.HiddenUserIDs(ctx, uid)
+	if err != nil {
+		return domain.Timeline{}, err
+	}
+	for _, post := range posts {
+		if _, ok := hidden[post.Uid]; ok {
+			continue
+		}
+		timeline.Posts = append(timeline.Posts, post)
+	}
+	return timeline, nil
+}
+
+// mergePostIds 合并两个按ID倒序排列的帖子ID列表，去重后最多返回 limit 个
+func mergePostIds(a, b []uint, limit int) []uint {
+	result := make([]uint, 0, limit)
+	i, j := 0, 0
+	for len(result) < limit && (i < len(a) || j < len(b)) {
+		var next uint
+		switch {
+		case j >= len(b) || (i < len(a) && a[i] >= b[j]):
+			next = a[i]
+			i++
+		default:
+			next = b[j]
+			j++
+		}
+		if len(result) > 0 && result[len(result)-1] == next {
+			continue
+		}
+		result = append(result, next)
+	}
+	return result
+}
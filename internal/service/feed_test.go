@@ -0,0 +1,28 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergePostIds(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  []uint
+		limit int
+		want  []uint
+	}{
+		{"empty", nil, nil, 10, []uint{}},
+		{"only pushed", []uint{9, 5, 1}, nil, 10, []uint{9, 5, 1}},
+		{"interleave", []uint{9, 5, 1}, []uint{8, 6, 2}, 10, []uint{9, 8, 6, 5, 2, 1}},
+		{"dedup", []uint{9, 5}, []uint{9, 7, 5}, 10, []uint{9, 7, 5}},
+		{"limit", []uint{9, 5, 1}, []uint{8, 6, 2}, 4, []uint{9, 8, 6, 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mergePostIds(tt.a, tt.b, tt.limit); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergePostIds() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,5 +4,205 @@ package service
  * @Author: Bamboo
  * @Author: 13664854532@163.com
  * @Date: 2024/9/9 18:27
- * @Desc:
+ * @Desc: 一对一私信
  */
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/repository"
+	"github.com/GoSimplicity/LinkMe/internal/repository/dao"
+	"github.com/GoSimplicity/LinkMe/utils/contentfilter"
+	sf "github.com/bwmarrin/snowflake"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+const (
+	// 单条消息最大长度
+	maxMessageLength = 2000
+	// 单次拉取历史消息的最大条数
+	maxMessageLimit = 100
+	// 默认撤回时限
+	defaultRecallWindow = 2 * time.Minute
+)
+
+var (
+	ErrInvalidReceiver     = errors.New("无效的接收者")
+	ErrEmptyMessage        = errors.New("消息内容不能为空")
+	ErrMessageTooLong      = errors.New("消息内容过长")
+	ErrNotConversationUser = errors.New("不是该会话的成员")
+	ErrRecallWindowExpired = errors.New("消息已超过可撤回时间")
+	ErrMessageNotFound     = repository.ErrMessageNotFound
+)
+
+type IMService interface {
+	SendMessage(ctx context.Context, senderID, receiverID int64, content string) (domain.Message, error)
+	ListConversations(ctx context.Context, uid int64, pagination domain.Pagination) ([]domain.Conversation, error)
+	ListMessages(ctx context.Context, uid, conversationID, beforeID int64, limit int) ([]domain.Message, error)
+	MarkRead(ctx context.Context, uid, conversationID, msgID int64) error
+	RecallMessage(ctx context.Context, uid, msgID int64) error
+	UnreadCount(ctx context.Context, uid int64) (int64, error)
+}
+
+type imService struct {
+	repo         repository.IMRepository
+	relationRepo repository.RelationRepository
+	userRepo     repository.UserRepository
+	node         *sf.Node
+	l            *zap.Logger
+}
+
+func NewIMService(repo repository.IMRepository, relationRepo repository.RelationRepository, userRepo repository.UserRepository, node *sf.Node, l *zap.Logger) IMService {
+	return &imService{
+		repo:         repo,
+		relationRepo: relationRepo,
+		userRepo:     userRepo,
+		node:         node,
+		l:            l,
+	}
+}
+
+// SendMessage 发送私信，内容经过敏感词过滤
+func (s *imService) SendMessage(ctx context.Context, senderID, receiverID int64, content string) (domain.Message, error) {
+	if receiverID <= 0 || receiverID == senderID {
+		return domain.Message{}, ErrInvalidReceiver
+	}
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return domain.Message{}, ErrEmptyMessage
+	}
+	if utf8.RuneCountInString(content) > maxMessageLength {
+		return domain.Message{}, ErrMessageTooLong
+	}
+	// 接收者不存在或已注销时不创建会话
+	if _, err := s.userRepo.FindByID(ctx, receiverID); err != nil {
+		if errors.Is(err, dao.ErrUserNotFound) {
+			return domain.Message{}, ErrInvalidReceiver
+		}
+		return domain.Message{}, err
+	}
+	// 任意一方拉黑对方后不能再发送私信
+	blocked, err := eitherBlocked(ctx, s.relationRepo, senderID, receiverID)
+	if err != nil {
+		return domain.Message{}, err
+	}
+	if blocked {
+		return domain.Message{}, ErrUserBlocked
+	}
+
+	cid, err := s.repo.GetOrCreateConversation(ctx, s.node.Generate().Int64(), senderID, receiverID)
+	if err != nil {
+		return domain.Message{}, err
+	}
+
+	msg := domain.Message{
+		ID:             s.node.Generate().Int64(),
+		ConversationID: cid,
+		SenderID:       senderID,
+		ReceiverID:     receiverID,
+		Content:        contentfilter.SensitiveFilterFun(content),
+		CreatedAt:      time.Now().UnixMilli(),
+	}
+	if err := s.repo.SendMessage(ctx, msg); err != nil {
+		return domain.Message{}, err
+	}
+
+	return msg, nil
+}
+
+// ListConversations 获取会话列表
+func (s *imService) ListConversations(ctx context.Context, uid int64, pagination domain.Pagination) ([]domain.Conversation, error) {
+	if pagination.Page <= 0 || pagination.Size == nil || *pagination.Size <= 0 {
+		return nil, errors.New("无效的分页参数")
+	}
+	offset := int64(pagination.Page-1) * *pagination.Size
+	pagination.Offset = &offset
+
+	return s.repo.ListConversations(ctx, uid, pagination)
+}
+
+// ListMessages 获取会话历史消息，按消息ID倒序，beforeID 作为翻页游标
+func (s *imService) ListMessages(ctx context.Context, uid, conversationID, beforeID int64, limit int) ([]domain.Message, error) {
+	_, peerReadID, err := s.repo.GetConversation(ctx, conversationID, uid)
+	if err != nil {
+		return nil, ErrNotConversationUser
+	}
+	if limit <= 0 || limit > maxMessageLimit {
+		limit = maxMessageLimit
+	}
+
+	msgs, err := s.repo.ListMessages(ctx, conversationID, beforeID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// 已读回执：自己发出且 ID 不大于对方已读位置的消息视为已读
+	for i := range msgs {
+		if msgs[i].SenderID == uid {
+			msgs[i].Read = msgs[i].ID <= peerReadID
+		} else {
+			msgs[i].Read = true
+		}
+	}
+	return msgs, nil
+}
+
+// MarkRead 标记会话已读，msgID 为 0 时标记到最新一条
+func (s *imService) MarkRead(ctx context.Context, uid, conversationID, msgID int64) error {
+	if _, _, err := s.repo.GetConversation(ctx, conversationID, uid); err != nil {
+		return ErrNotConversationUser
+	}
+	if msgID <= 0 {
+		latest, err := s.repo.ListMessages(ctx, conversationID, 0, 1)
+		if err != nil {
+			return err
+		}
+		if len(latest) == 0 {
+			return nil
+		}
+		msgID = latest[0].ID
+	} else {
+		// 只能标记本会话中的消息，避免用其他会话的消息ID推进已读位置
+		msg, err := s.repo.GetMessage(ctx, msgID)
+		if err != nil {
+			return err
+		}
+		if msg.ConversationID != conversationID {
+			return ErrMessageNotFound
+		}
+	}
+
+	return s.repo.MarkRead(ctx, conversationID, uid, msgID)
+}
+
+// RecallMessage 撤回自己发送的消息，需在撤回时限内
+func (s *imService) RecallMessage(ctx context.Context, uid, msgID int64) error {
+	msg, err := s.repo.GetMessage(ctx, msgID)
+	if err != nil {
+		return err
+	}
+	if msg.SenderID != uid || msg.Recalled {
+		return ErrMessageNotFound
+	}
+
+	window := viper.GetDuration("im.recall_window")
+	if window <= 0 {
+		window = defaultRecallWindow
+	}
+	if time.Since(time.UnixMilli(msg.CreatedAt)) > window {
+		return ErrRecallWindowExpired
+	}
+
+	return s.repo.RecallMessage(ctx, msgID, uid)
+}
+
+// UnreadCount 获取未读消息总数
+func (s *imService) UnreadCount(ctx context.Context, uid int64) (int64, error) {
+	return s.repo.TotalUnread(ctx, uid)
+}
@@ -22,14 +22,24 @@ type InteractiveService interface {
 }
 
 type interactiveService struct {
-	repo repository.InteractiveRepository
-	l    *zap.Logger
+	repo          repository.InteractiveRepository
+	reputationSvc ReputationService
+	badgeSvc      BadgeService
+	postRepo      repository.PostRepository
+	relationRepo  repository.RelationRepository
+	notifySvc     NotificationService
+	l             *zap.Logger
 }
 
-func NewInteractiveService(repo repository.InteractiveRepository, l *zap.Logger) InteractiveService {
+func NewInteractiveService(repo repository.InteractiveRepository, reputationSvc ReputationService, badgeSvc BadgeService, postRepo repository.PostRepository, relationRepo repository.RelationRepository, notifySvc NotificationService, l *zap.Logger) InteractiveService {
 	return &interactiveService{
-		repo: repo,
-		l:    l,
+		repo:          repo,
+		reputationSvc: reputationSvc,
+		badgeSvc:      badgeSvc,
+		postRepo:      postRepo,
+		relationRepo:  relationRepo,
+		notifySvc:     notifySvc,
+		l:             l,
 	}
 }
 
@@ -49,7 +59,43 @@ func (i *interactiveService) Like(ctx context.Context, postId uint, uid int64) e
 		return i.repo.DecrLike(ctx, postId, uid)
 	}
 
-	return i.repo.IncrLike(ctx, postId, uid)
+	if err := i.repo.IncrLike(ctx, postId, uid); err != nil {
+		return err
+	}
+
+	// 积分只是附加奖励，失败不影响点赞
+	if err := i.reputationSvc.RecordPostReceived(ctx, domain.ReputationLikeReceived, postId, uid); err != nil {
+		i.l.Warn("记录点赞积分失败", zap.Uint("postId", postId), zap.Error(err))
+	}
+	if err := i.badgeSvc.EvaluatePostAuthor(ctx, postId, domain.BadgeRuleLikesReceived); err != nil {
+		i.l.Warn("评估获赞勋章失败", zap.Uint("postId", postId), zap.Error(err))
+	}
+	go i.notifyLike(context.Background(), postId, uid)
+	return nil
+}
+
+// notifyLike 向帖子作者发送点赞提醒，跳过自己点赞及作者屏蔽了点赞者的情况
+func (i *interactiveService) notifyLike(ctx context.Context, postId uint, uid int64) {
+	post, err := i.postRepo.GetPublishPostById(ctx, postId)
+	if err != nil || post.Uid == uid {
+		return
+	}
+	hidden, err := i.relationRepo.HiddenUserIDs(ctx, post.Uid)
+	if err != nil {
+		i.l.Warn("获取屏蔽列表失败", zap.Int64("uid", post.Uid), zap.Error(err))
+		return
+	}
+	if _, ok := hidden[uid]; ok {
+		return
+	}
+	if err := i.notifySvc.Notify(ctx, domain.Notification{
+		UserID:  post.Uid,
+		Type:    domain.NotificationLike,
+		Title:   "有人赞了你的帖子",
+		Content: post.Title,
+	}); err != nil {
+		i.l.Warn("发送点赞提醒失败", zap.Int64("uid", post.Uid), zap.Error(err))
+	}
 }
 
 // CancelLike 处理取消点赞逻辑
@@ -76,7 +122,14 @@ func (i *interactiveService) Collect(ctx context.Context, postId uint, uid int64
 		return errors.New("已收藏")
 	}
 
-	return i.repo.IncrCollectionItem(ctx, postId, uid)
+	if err := i.repo.IncrCollectionItem(ctx, postId, uid); err != nil {
+		return err
+	}
+
+	if err := i.reputationSvc.RecordPostReceived(ctx, domain.ReputationCollectReceived, postId, uid); err != nil {
+		i.l.Warn("记录收藏积分失败", zap.Uint("postId", postId), zap.Error(err))
+	}
+	return nil
 }
 
 // CancelCollect 处理取消收藏逻辑
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/repository"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrRegistrationClosed 表示当前已关闭注册
+	ErrRegistrationClosed = errors.New("当前已关闭注册")
+	// ErrInviteRequired 表示当前仅支持邀请码注册
+	ErrInviteRequired = errors.New("当前仅支持邀请注册，请填写邀请码")
+	// ErrInviteNotAllowed 表示当前等级不能生成邀请码
+	ErrInviteNotAllowed = errors.New("当前等级暂不能生成邀请码")
+	// ErrInviteLimitReached 表示可用邀请码数量已达上限
+	ErrInviteLimitReached = errors.New("可用的邀请码数量已达上限")
+	// ErrInviteCodeNotFound 表示邀请码不存在或不属于当前用户
+	ErrInviteCodeNotFound = errors.New("邀请码不存在")
+)
+
+type InviteService interface {
+	// Mode 当前注册模式
+	Mode() domain.RegistrationMode
+	// CreateCode 用户生成邀请码，需达到配置的等级，次数与有效期不超过配置上限
+	CreateCode(ctx context.Context, uid int64, maxUses int, ttl time.Duration) (domain.InviteCode, error)
+	// CreateAdminCode 管理员生成邀请码，maxUses 与 ttl 为0时不限制
+	CreateAdminCode(ctx context.Context, uid int64, maxUses int, ttl time.Duration) (domain.InviteCode, error)
+	// ListCodes 获取邀请码，creatorId 为0时获取全部
+	ListCodes(ctx context.Context, creatorId int64, pagination domain.Pagination) ([]domain.InviteCode, error)
+	// DisableCode 停用邀请码，creatorId 为0时不校验创建者
+	DisableCode(ctx context.Context, id, creatorId int64) error
+	// ListInvitations 获取邀请关系，inviterId 与 inviteeId 为0时不作为筛选条件
+	ListInvitations(ctx context.Context, inviterId, inviteeId int64, pagination domain.Pagination) ([]domain.Invitation, error)
+}
+
+type inviteService struct {
+	repo          repository.InviteRepository
+	reputationSvc ReputationService
+	auditSvc      AuditService
+	l             *zap.Logger
+}
+
+func NewInviteService(repo repository.InviteRepository, reputationSvc ReputationService, auditSvc AuditService, l *zap.Logger) InviteService {
+	return &inviteService{
+		repo:          repo,
+		reputationSvc: reputationSvc,
+		auditSvc:      auditSvc,
+		l:             l,
+	}
+}
+
+// Mode 获取注册模式
+func (i *inviteService) Mode() domain.RegistrationMode {
+	return registrationMode()
+}
+
+// CreateCode 用户生成邀请码
+func (i *inviteService) CreateCode(ctx context.Context, uid int64, maxUses int, ttl time.Duration) (domain.InviteCode, error) {
+	rep, err := i.reputationSvc.GetReputation(ctx, uid)
+	if err != nil {
+		return domain.InviteCode{}, err
+	}
+	if rep.Level.Level < viper.GetInt("registration.invite_min_level") {
+		return domain.InviteCode{}, ErrInviteNotAllowed
+	}
+
+	active, err := i.repo.CountActiveCodes(ctx, uid)
+	if err != nil {
+		return domain.InviteCode{}, err
+	}
+	if active >= viper.GetInt64("registration.invite_max_active") {
+		return domain.InviteCode{}, ErrInviteLimitReached
+	}
+
+	limitUses := viper.GetInt("registration.invite_max_uses")
+	if maxUses <= 0 || maxUses > limitUses {
+		maxUses = limitUses
+	}
+	limitTTL := viper.GetDuration("registration.invite_ttl")
+	if ttl <= 0 || ttl > limitTTL {
+		ttl = limitTTL
+	}
+	return i.create(ctx, uid, maxUses, ttl)
+}
+
+// CreateAdminCode 管理员生成邀请码
+func (i *inviteService) CreateAdminCode(ctx context.Context, uid int64, maxUses int, ttl time.Duration) (domain.InviteCode, error) {
+	if maxUses < 0 {
+		maxUses = 0
+	}
+	code, err := i.create(ctx, uid, maxUses, ttl)
+	if err != nil {
+		return domain.InviteCode{}, err
+	}
+	i.auditSvc.Record(ctx, domain.AuditEntry{
+		Action:     domain.AuditInviteCreate,
+		TargetType: domain.AuditTargetInviteCode,
+		TargetID:   fmt.Sprint(code.ID),
+		After:      code,
+	})
+	return code, nil
+}
+
+// ListCodes 获取邀请码
+func (i *inviteService) ListCodes(ctx context.Context, creatorId int64, pagination domain.Pagination) ([]domain.InviteCode, error) {
+	if pagination.Page <= 0 || pagination.Size == nil || *pagination.Size <= 0 {
+		return nil, errors.New("无效的分页参数")
+	}
+	offset := int64(pagination.Page-1) * *pagination.Size
+	pagination.Offset = &offset
+	return i.repo.ListCodes(ctx, creatorId, pagination)
+}
+
+// DisableCode 停用邀请码，已注册的用户不受影响
+func (i *inviteService) DisableCode(ctx context.Context, id, creatorId int64) error {
+	var before *domain.InviteCode
+	if creatorId == 0 {
+		if code, err := i.repo.GetCode(ctx, id); err == nil {
+			before = &code
+		}
+	}
+	if err := i.repo.DisableCode(ctx, id, creatorId); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInviteCodeNotFound
+		}
+		return err
+	}
+	// 仅记录管理员停用，用户停用自己的邀请码不属于管理操作
+	if creatorId == 0 {
+		var after *domain.InviteCode
+		if before != nil {
+			disabled := *before
+			disabled.Disabled = true
+			after = &disabled
+		}
+		i.auditSvc.Record(ctx, domain.AuditEntry{
+			Action:     domain.AuditInviteDisable,
+			TargetType: domain.AuditTargetInviteCode,
+			TargetID:   fmt.Sprint(id),
+			Before:     before,
+			After:      after,
+		})
+	}
+	return nil
+}
+
+// ListInvitations 获取邀请关系
+func (i *inviteService) ListInvitations(ctx context.Context, inviterId, inviteeId int64, pagination domain.Pagination) ([]domain.Invitation, error) {
+	if pagination.Page <= 0 || pagination.Size == nil || *pagination.Size <= 0 {
+		return nil, errors.New("无效的分页参数")
+	}
+	offset := int64(pagination.Page-1) * *pagination.Size
+	pagination.Offset = &offset
+	return i.repo.ListInvitations(ctx, inviterId, inviteeId, pagination)
+}
+
+// create 生成随机邀请码
+func (i *inviteService) create(ctx context.Context, uid int64, maxUses int, ttl time.Duration) (domain.InviteCode, error) {
+	code, err := randomHex(5)
+	if err != nil {
+		return domain.InviteCode{}, err
+	}
+	var expiresAt int64
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl).UnixMilli()
+	}
+
+	created, err := i.repo.CreateCode(ctx, domain.InviteCode{
+		Code:      strings.ToUpper(code),
+		CreatorID: uid,
+		MaxUses:   maxUses,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return domain.InviteCode{}, err
+	}
+	i.l.Info("生成邀请码", zap.Int64("uid", uid), zap.Int64("codeId", created.ID), zap.Int("maxUses", maxUses))
+	return created, nil
+}
+
+// registrationMode 读取注册模式配置
+func registrationMode() domain.RegistrationMode {
+	return domain.ParseRegistrationMode(viper.GetString("registration.mode"))
+}
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/repository"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrLoginLocked 表示连续登录失败次数过多，账号或IP被临时锁定
+	ErrLoginLocked = errors.New("登录失败次数过多，已被临时锁定，请稍后再试")
+	// ErrLoginTooFrequent 表示距上次失败的等待时间未到
+	ErrLoginTooFrequent = errors.New("登录尝试过于频繁，请稍后再试")
+	// ErrInvalidLoginSubject 表示解锁对象无效
+	ErrInvalidLoginSubject = errors.New("无效的解锁对象")
+)
+
+const (
+	defaultLoginFreeAttempts   = 3
+	defaultLoginMaxAttempts    = 10
+	defaultLoginIPFreeAttempts = 20
+	defaultLoginIPMaxAttempts  = 100
+	defaultLoginBaseDelay      = time.Second
+	defaultLoginMaxDelay       = 30 * time.Second
+	defaultLoginLockDuration   = 15 * time.Minute
+	defaultLoginFailureWindow  = 15 * time.Minute
+	// maxUserAgentLength 安全事件与登录设备中保存的 User-Agent 最大长度
+	maxUserAgentLength = 255
+)
+
+type LoginGuardService interface {
+	// Check 登录前检查账号与IP是否被锁定或仍需等待
+	Check(ctx context.Context, attempt domain.LoginAttempt) error
+	// RecordFailure 记录一次失败，按策略设置等待时间，达到阈值时锁定并记录安全事件
+	RecordFailure(ctx context.Context, attempt domain.LoginAttempt)
+	// RecordSuccess 清除账号的失败记录，识别新设备登录
+	RecordSuccess(ctx context.Context, uid int64, attempt domain.LoginAttempt)
+	// ReportTokenReuse 记录刷新令牌被重复使用，会话已由调用方下线
+	ReportTokenReuse(ctx context.Context, uid int64, ip, userAgent string)
+	// Unlock 解除锁定并清除失败次数，operatorId 为执行解锁的管理员
+	Unlock(ctx context.Context, operatorId int64, subject domain.LoginSubject) error
+	// ListEvents 获取安全事件，uid 为0时获取全部
+	ListEvents(ctx context.Context, uid int64, eventType domain.SecurityEventType, pagination domain.Pagination) ([]domain.SecurityEvent, error)
+}
+
+type loginGuardService struct {
+	repo      repository.LoginGuardRepository
+	userRepo  repository.UserRepository
+	notifySvc NotificationService
+	auditSvc  AuditService
+	l         *zap.Logger
+}
+
+func NewLoginGuardService(repo repository.LoginGuardRepository, userRepo repository.UserRepository, notifySvc NotificationService, auditSvc AuditService, l *zap.Logger) LoginGuardService {
+	return &loginGuardService{
+		repo:      repo,
+		userRepo:  userRepo,
+		notifySvc: notifySvc,
+		auditSvc:  auditSvc,
+		l:         l,
+	}
+}
+
+// Check 检查登录对象的锁定与等待状态
+func (g *loginGuardService) Check(ctx context.Context, attempt domain.LoginAttempt) error {
+	now := time.Now().UnixMilli()
+	for _, subject := range attempt.Subjects() {
+		state, err := g.repo.GetState(ctx, subject)
+		if err != nil {
+			return err
+		}
+		if state.LockedUntil > now {
+			return ErrLoginLocked
+		}
+		if state.NextAttemptAt > now {
+			return ErrLoginTooFrequent
+		}
+	}
+	return nil
+}
+
+// RecordFailure 记录登录失败，计数失败不影响登录结果
+func (g *loginGuardService) RecordFailure(ctx context.Context, attempt domain.LoginAttempt) {
+	window := durationOr("login_guard.failure_window", defaultLoginFailureWindow)
+	for _, subject := range attempt.Subjects() {
+		failures, err := g.repo.IncrFailures(ctx, subject, window)
+		if err != nil {
+			g.l.Warn("记录登录失败次数失败", zap.String("kind", string(subject.Kind)), zap.Error(err))
+			continue
+		}
+
+		policy := loginGuardPolicy(subject.Kind)
+		if policy.ShouldLock(failures) {
+			if err := g.repo.Lock(ctx, subject, policy.LockDuration); err != nil {
+				g.l.Warn("锁定登录对象失败", zap.String("kind", string(subject.Kind)), zap.Error(err))
+				continue
+			}
+			g.onLocked(ctx, subject, attempt, failures, policy.LockDuration)
+			continue
+		}
+		if delay := policy.Delay(failures); delay > 0 {
+			if err := g.repo.SetNextAttempt(ctx, subject, time.Now().Add(delay).UnixMilli()); err != nil {
+				g.l.Warn("设置登录等待时间失败", zap.String("kind", string(subject.Kind)), zap.Error(err))
+			}
+		}
+	}
+}
+
+// RecordSuccess 登录成功，IP 的失败记录保留，避免攻击者用自己的账号清除
+func (g *loginGuardService) RecordSuccess(ctx context.Context, uid int64, attempt domain.LoginAttempt) {
+	if attempt.Account.Value != "" {
+		if err := g.repo.Clear(ctx, attempt.Account); err != nil {
+			g.l.Warn("清除登录失败次数失败", zap.Int64("uid", uid), zap.Error(err))
+		}
+	}
+	if attempt.UserAgent == "" {
+		return
+	}
+
+	userAgent := truncateUserAgent(attempt.UserAgent)
+	sum := sha256.Sum256([]byte(attempt.UserAgent))
+	isNew, err := g.repo.TouchDevice(ctx, uid, hex.EncodeToString(sum[:]), userAgent, attempt.IP)
+	if err != nil || !isNew {
+		return
+	}
+	// 首次登录的设备不视为异常
+	count, err := g.repo.CountDevices(ctx, uid)
+	if err != nil || count <= 1 {
+		return
+	}
+
+	if err := g.repo.CreateEvent(ctx, domain.SecurityEvent{
+		UserID:    uid,
+		Type:      domain.SecurityEventNewDevice,
+		Subject:   attempt.Account,
+		IP:        attempt.IP,
+		UserAgent: userAgent,
+	}); err != nil {
+		return
+	}
+	g.notify(ctx, uid, "新设备登录提醒", fmt.Sprintf("你的账号于 %s 在新设备上登录(IP: %s)。如非本人操作，请立即修改密码并在会话管理中下线该设备。",
+		time.Now().Format("2006-01-02 15:04"), attempt.IP))
+}
+
+// ReportTokenReuse 记录刷新令牌被重复使用并提醒用户
+func (g *loginGuardService) ReportTokenReuse(ctx context.Context, uid int64, ip, userAgent string) {
+	if err := g.repo.CreateEvent(ctx, domain.SecurityEvent{
+		UserID:    uid,
+		Type:      domain.SecurityEventTokenReused,
+		IP:        ip,
+		UserAgent: truncateUserAgent(userAgent),
+		Detail:    "已轮换的刷新令牌被再次使用，对应会话已下线",
+	}); err != nil {
+		return
+	}
+	g.notify(ctx, uid, "登录凭证异常提醒", fmt.Sprintf("检测到你的登录凭证在 %s 被重复使用(IP: %s)，可能已经泄露，相关设备已被强制下线。如非本人操作，请立即修改密码。",
+		time.Now().Format("2006-01-02 15:04"), ip))
+}
+
+// Unlock 解除锁定
+func (g *loginGuardService) Unlock(ctx context.Context, operatorId int64, subject domain.LoginSubject) error {
+	if !subject.Kind.Valid() || subject.Value == "" {
+		return ErrInvalidLoginSubject
+	}
+	before, _ := g.repo.GetState(ctx, subject)
+	if err := g.repo.Clear(ctx, subject); err != nil {
+		return err
+	}
+	g.auditSvc.Record(ctx, domain.AuditEntry{
+		ActorID:    operatorId,
+		Action:     domain.AuditLoginUnlock,
+		TargetType: domain.AuditTargetLoginSubject,
+		TargetID:   string(subject.Kind) + ":" + subject.Value,
+		Before:     before,
+	})
+
+	if err := g.repo.CreateEvent(ctx, domain.SecurityEvent{
+		UserID:  g.findUserID(ctx, subject),
+		Type:    domain.SecurityEventUnlocked,
+		Subject: subject,
+		Detail:  fmt.Sprintf("管理员 %d 解除锁定", operatorId),
+	}); err != nil {
+		g.l.Warn("记录解锁事件失败", zap.Error(err))
+	}
+	return nil
+}
+
+// ListEvents 获取安全事件
+func (g *loginGuardService) ListEvents(ctx context.Context, uid int64, eventType domain.SecurityEventType, pagination domain.Pagination) ([]domain.SecurityEvent, error) {
+	if pagination.Page <= 0 || pagination.Size == nil || *pagination.Size <= 0 {
+		return nil, errors.New("无效的分页参数")
+	}
+	offset := int64(pagination.Page-1) * *pagination.Size
+	pagination.Offset = &offset
+	return g.repo.ListEvents(ctx, uid, eventType, pagination)
+}
+
+// onLocked 记录锁定事件，能关联到账号时提醒用户
+func (g *loginGuardService) onLocked(ctx context.Context, subject domain.LoginSubject, attempt domain.LoginAttempt, failures int64, lock time.Duration) {
+	uid := g.findUserID(ctx, subject)
+	if err := g.repo.CreateEvent(ctx, domain.SecurityEvent{
+		UserID:    uid,
+		Type:      domain.SecurityEventLoginLocked,
+		Subject:   subject,
+		IP:        attempt.IP,
+		UserAgent: truncateUserAgent(attempt.UserAgent),
+		Detail:    fmt.Sprintf("连续失败 %d 次，锁定 %s", failures, lock),
+	}); err != nil {
+		return
+	}
+	if uid > 0 {
+		g.notify(ctx, uid, "账号登录异常提醒", fmt.Sprintf("你的账号在短时间内多次登录失败(IP: %s)，已被临时锁定 %d 分钟。如非本人操作，建议尽快修改密码并开启两步验证。",
+			attempt.IP, int64(lock.Minutes())))
+	}
+}
+
+// notify 发送安全提醒，可通过 login_guard.notify 关闭
+func (g *loginGuardService) notify(ctx context.Context, uid int64, title, content string) {
+	if !viper.GetBool("login_guard.notify") {
+		return
+	}
+	if err := g.notifySvc.Notify(ctx, domain.Notification{
+		UserID:  uid,
+		Type:    domain.NotificationSecurity,
+		Title:   title,
+		Content: content,
+	}); err != nil {
+		g.l.Warn("发送安全提醒失败", zap.Int64("uid", uid), zap.Error(err))
+	}
+}
+
+// findUserID 查找登录对象对应的用户，IP 或账号不存在时返回0
+func (g *loginGuardService) findUserID(ctx context.Context, subject domain.LoginSubject) int64 {
+	var (
+		u   domain.User
+		err error
+	)
+	switch subject.Kind {
+	case domain.LoginSubjectUsername:
+		u, err = g.userRepo.FindByUsername(ctx, subject.Value)
+	case domain.LoginSubjectEmail:
+		u, err = g.userRepo.FindByEmail(ctx, subject.Value)
+	case domain.LoginSubjectPhone:
+		u, err = g.userRepo.FindByPhone(ctx, subject.Value)
+	default:
+		return 0
+	}
+	if err != nil {
+		return 0
+	}
+	return u.ID
+}
+
+// loginGuardPolicy 读取登录对象的延迟与锁定策略，IP 可能为多人共用，阈值更宽松
+func loginGuardPolicy(kind domain.LoginSubjectKind) domain.LoginGuardPolicy {
+	policy := domain.LoginGuardPolicy{
+		FreeAttempts: int64Or("login_guard.free_attempts", defaultLoginFreeAttempts),
+		BaseDelay:    durationOr("login_guard.base_delay", defaultLoginBaseDelay),
+		MaxDelay:     durationOr("login_guard.max_delay", defaultLoginMaxDelay),
+		MaxAttempts:  int64Or("login_guard.max_attempts", defaultLoginMaxAttempts),
+		LockDuration: durationOr("login_guard.lock_duration", defaultLoginLockDuration),
+	}
+	if kind == domain.LoginSubjectIP {
+		policy.FreeAttempts = int64Or("login_guard.ip_free_attempts", defaultLoginIPFreeAttempts)
+		policy.MaxAttempts = int64Or("login_guard.ip_max_attempts", defaultLoginIPMaxAttempts)
+	}
+	return policy
+}
+
+func int64Or(key string, def int64) int64 {
+	if n := viper.GetInt64(key); n > 0 {
+		return n
+	}
+	return def
+}
+
+// truncateUserAgent 按字符截断 User-Agent，避免截断多字节字符产生非法 UTF-8
+func truncateUserAgent(ua string) string {
+	if utf8.RuneCountInString(ua) <= maxUserAgentLength {
+		return ua
+	}
+	return string([]rune(ua)[:maxUserAgentLength])
+}
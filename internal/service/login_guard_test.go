@@ -0,0 +1,22 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncateUserAgent(t *testing.T) {
+	if got := truncateUserAgent("curl/8.0"); got != "curl/8.0" {
+		t.Errorf("short user agent changed: %q", got)
+	}
+	// 多字节字符不能被截断成非法 UTF-8
+	long := strings.Repeat("a", maxUserAgentLength-1) + strings.Repeat("设备", 10)
+	got := truncateUserAgent(long)
+	if !utf8.ValidString(got) {
+		t.Fatalf("truncated user agent is not valid UTF-8: %q", got)
+	}
+	if n := utf8.RuneCountInString(got); n != maxUserAgentLength {
+		t.Fatalf("truncated user agent has %d runes, want %d", n, maxUserAgentLength)
+	}
+}
@@ -31,8 +31,9 @@ type LotteryDrawService interface {
 }
 
 type lotteryDrawService struct {
-	repo repository.LotteryDrawRepository
-	l    *zap.Logger
+	repo     repository.LotteryDrawRepository
+	badgeSvc BadgeService
+	l        *zap.Logger
 
 	// 并发控制
 	lotterySem    *semaphore.Weighted
@@ -54,10 +55,11 @@ const (
 	maxSecondKillConcurrency = 1000
 )
 
-func NewLotteryDrawService(repo repository.LotteryDrawRepository, l *zap.Logger) LotteryDrawService {
+func NewLotteryDrawService(repo repository.LotteryDrawRepository, badgeSvc BadgeService, l *zap.Logger) LotteryDrawService {
 	ctx, cancel := context.WithCancel(context.Background())
 	service := &lotteryDrawService{
 		repo:          repo,
+		badgeSvc:      badgeSvc,
 		l:             l,
 		lotterySem:    semaphore.NewWeighted(maxLotteryConcurrency),
 		secondKillSem: semaphore.NewWeighted(maxSecondKillConcurrency),
@@ -223,7 +225,15 @@ func (s *lotteryDrawService) ParticipateLotteryDraw(ctx context.Context, id int,
 	}
 	defer s.lotterySem.Release(1)
 
-	return s.processLotteryParticipation(ctx, id, userID)
+	if err := s.processLotteryParticipation(ctx, id, userID); err != nil {
+		return err
+	}
+
+	// 勋章评估在活动锁之外进行，失败不影响参与结果
+	if err := s.badgeSvc.Evaluate(ctx, userID, domain.BadgeRuleLotteryParticipations); err != nil {
+		s.l.Warn("评估抽奖勋章失败", zap.Int64("userID", userID), zap.Error(err))
+	}
+	return nil
 }
 
 // ParticipateSecondKill 允许用户参与秒杀活动
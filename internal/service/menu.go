@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/GoSimplicity/LinkMe/internal/domain"
 	"github.com/GoSimplicity/LinkMe/internal/repository"
@@ -19,14 +20,16 @@ type MenuService interface {
 }
 
 type menuService struct {
-	l    *zap.Logger
-	repo repository.MenuRepository
+	l        *zap.Logger
+	repo     repository.MenuRepository
+	auditSvc AuditService
 }
 
-func NewMenuService(l *zap.Logger, repo repository.MenuRepository) MenuService {
+func NewMenuService(l *zap.Logger, repo repository.MenuRepository, auditSvc AuditService) MenuService {
 	return &menuService{
-		l:    l,
-		repo: repo,
+		l:        l,
+		repo:     repo,
+		auditSvc: auditSvc,
 	}
 }
 
@@ -57,7 +60,18 @@ func (m *menuService) CreateMenu(ctx context.Context, menu *domain.Menu) error {
 		return errors.New("菜单不能为空")
 	}
 
-	return m.repo.CreateMenu(ctx, menu)
+	id, err := m.repo.CreateMenu(ctx, menu)
+	if err != nil {
+		return err
+	}
+	menu.ID = id
+	m.auditSvc.Record(ctx, domain.AuditEntry{
+		Action:     domain.AuditMenuCreate,
+		TargetType: domain.AuditTargetMenu,
+		TargetID:   fmt.Sprint(id),
+		After:      menu,
+	})
+	return nil
 }
 
 // GetMenuById 根据ID获取菜单
@@ -77,7 +91,18 @@ func (m *menuService) UpdateMenu(ctx context.Context, menu *domain.Menu) error {
 		return errors.New("菜单不能为空")
 	}
 
-	return m.repo.UpdateMenu(ctx, menu)
+	before, _ := m.repo.GetMenuById(ctx, menu.ID)
+	if err := m.repo.UpdateMenu(ctx, menu); err != nil {
+		return err
+	}
+	m.auditSvc.Record(ctx, domain.AuditEntry{
+		Action:     domain.AuditMenuUpdate,
+		TargetType: domain.AuditTargetMenu,
+		TargetID:   fmt.Sprint(menu.ID),
+		Before:     before,
+		After:      menu,
+	})
+	return nil
 }
 
 // DeleteMenu 删除指定ID的菜单
@@ -87,7 +112,17 @@ func (m *menuService) DeleteMenu(ctx context.Context, id int) error {
 		return errors.New("菜单ID无效")
 	}
 
-	return m.repo.DeleteMenu(ctx, id)
+	before, _ := m.repo.GetMenuById(ctx, id)
+	if err := m.repo.DeleteMenu(ctx, id); err != nil {
+		return err
+	}
+	m.auditSvc.Record(ctx, domain.AuditEntry{
+		Action:     domain.AuditMenuDelete,
+		TargetType: domain.AuditTargetMenu,
+		TargetID:   fmt.Sprint(id),
+		Before:     before,
+	})
+	return nil
 }
 
 // GetMenuTree 获取菜单树形结构
@@ -42,11 +42,12 @@ func (m *MockUserService) EXPECT() *MockUserServiceMockRecorder {
 }
 
 // ChangePassword mocks base method.
-func (m *MockUserService) ChangePassword(ctx context.Context, username, password, newPassword, confirmPassword string) error {
+func (m *MockUserService) ChangePassword(ctx context.Context, username, password, newPassword, confirmPassword string) (int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ChangePassword", ctx, username, password, newPassword, confirmPassword)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // ChangePassword indicates an expected call of ChangePassword.
@@ -55,20 +56,6 @@ func (mr *MockUserServiceMockRecorder) ChangePassword(ctx, username, password, n
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockUserService)(nil).ChangePassword), ctx, username, password, newPassword, confirmPassword)
 }
 
-// DeleteUser mocks base method.
-func (m *MockUserService) DeleteUser(ctx context.Context, username, password string, uid int64) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", ctx, username, password, uid)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockUserServiceMockRecorder) DeleteUser(ctx, username, password, uid any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserService)(nil).DeleteUser), ctx, username, password, uid)
-}
-
 // GetProfileByUserID mocks base method.
 func (m *MockUserService) GetProfileByUserID(ctx context.Context, UserID int64) (domain.Profile, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/repository"
+	"github.com/casbin/casbin/v2"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrModeratorExists 表示用户已是该板块的版主
+	ErrModeratorExists = errors.New("该用户已是板块版主")
+	// ErrModeratorNotFound 表示用户不是该板块的版主
+	ErrModeratorNotFound = errors.New("该用户不是板块版主")
+	// ErrModerateForbidden 表示没有审核目标内容所在板块的权限
+	ErrModerateForbidden = errors.New("没有审核该板块内容的权限")
+)
+
+type ModeratorService interface {
+	Appoint(ctx context.Context, plateId, uid int64) error
+	Remove(ctx context.Context, plateId, uid int64) error
+	// RemovePlate 移除板块的全部版主，用于删除板块
+	RemovePlate(ctx context.Context, plateId int64) error
+	ListModerators(ctx context.Context, plateId int64) ([]domain.PlateModerator, error)
+	// ModeratedPlates 获取用户担任版主的板块
+	ModeratedPlates(ctx context.Context, uid int64) ([]int64, error)
+	// CanModerateAll 判断用户是否拥有全站审核权限
+	CanModerateAll(ctx context.Context, uid int64) (bool, error)
+	// CanModerate 判断用户能否审核指定板块的内容，全站审核员对所有板块生效
+	CanModerate(ctx context.Context, uid, plateId int64) (bool, error)
+}
+
+type moderatorService struct {
+	ce        *casbin.SyncedEnforcer
+	plateRepo repository.PlateRepository
+	userRepo  repository.UserRepository
+	auditSvc  AuditService
+	l         *zap.Logger
+}
+
+func NewModeratorService(ce *casbin.SyncedEnforcer, plateRepo repository.PlateRepository, userRepo repository.UserRepository, auditSvc AuditService, l *zap.Logger) ModeratorService {
+	return &moderatorService{
+		ce:        ce,
+		plateRepo: plateRepo,
+		userRepo:  userRepo,
+		auditSvc:  auditSvc,
+		l:         l,
+	}
+}
+
+// Appoint 任命板块版主
+func (m *moderatorService) Appoint(ctx context.Context, plateId, uid int64) error {
+	exists, err := m.plateRepo.ExistsPlate(ctx, plateId)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrPlateNotFound
+	}
+	if _, err := m.userRepo.FindByID(ctx, uid); err != nil {
+		return err
+	}
+
+	added, err := m.ce.AddNamedGroupingPolicy("g2", strconv.FormatInt(uid, 10), domain.PlateModeratorRole, domain.PlateDomain(plateId))
+	if err != nil {
+		m.l.Error("任命板块版主失败", zap.Int64("plateId", plateId), zap.Int64("uid", uid), zap.Error(err))
+		return err
+	}
+	if !added {
+		return ErrModeratorExists
+	}
+	m.l.Info("任命板块版主", zap.Int64("plateId", plateId), zap.Int64("uid", uid))
+	m.auditSvc.Record(ctx, domain.AuditEntry{
+		Action:     domain.AuditModeratorAppoint,
+		TargetType: domain.AuditTargetPlateModerator,
+		TargetID:   moderatorTarget(plateId, uid),
+		After:      domain.PlateModerator{PlateID: plateId, Uid: uid},
+	})
+	return nil
+}
+
+// Remove 移除板块版主
+func (m *moderatorService) Remove(ctx context.Context, plateId, uid int64) error {
+	removed, err := m.ce.RemoveNamedGroupingPolicy("g2", strconv.FormatInt(uid, 10), domain.PlateModeratorRole, domain.PlateDomain(plateId))
+	if err != nil {
+		m.l.Error("移除板块版主失败", zap.Int64("plateId", plateId), zap.Int64("uid", uid), zap.Error(err))
+		return err
+	}
+	if !removed {
+		return ErrModeratorNotFound
+	}
+	m.l.Info("移除板块版主", zap.Int64("plateId", plateId), zap.Int64("uid", uid))
+	m.auditSvc.Record(ctx, domain.AuditEntry{
+		Action:     domain.AuditModeratorRemove,
+		TargetType: domain.AuditTargetPlateModerator,
+		TargetID:   moderatorTarget(plateId, uid),
+		Before:     domain.PlateModerator{PlateID: plateId, Uid: uid},
+	})
+	return nil
+}
+
+// RemovePlate 移除板块的全部版主
+func (m *moderatorService) RemovePlate(ctx context.Context, plateId int64) error {
+	if _, err := m.ce.RemoveFilteredNamedGroupingPolicy("g2", 1, domain.PlateModeratorRole, domain.PlateDomain(plateId)); err != nil {
+		m.l.Error("移除板块全部版主失败", zap.Int64("plateId", plateId), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// ListModerators 获取板块的版主
+func (m *moderatorService) ListModerators(ctx context.Context, plateId int64) ([]domain.PlateModerator, error) {
+	rules, err := m.ce.GetFilteredNamedGroupingPolicy("g2", 1, domain.PlateModeratorRole, domain.PlateDomain(plateId))
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.PlateModerator, 0, len(rules))
+	for _, rule := range rules {
+		uid, err := strconv.ParseInt(rule[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		res = append(res, domain.PlateModerator{PlateID: plateId, Uid: uid})
+	}
+	return res, nil
+}
+
+// ModeratedPlates 获取用户担任版主的板块
+func (m *moderatorService) ModeratedPlates(ctx context.Context, uid int64) ([]int64, error) {
+	rules, err := m.ce.GetFilteredNamedGroupingPolicy("g2", 0, strconv.FormatInt(uid, 10), domain.PlateModeratorRole)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]int64, 0, len(rules))
+	for _, rule := range rules {
+		if plateId, ok := domain.ParsePlateDomain(rule[2]); ok {
+			res = append(res, plateId)
+		}
+	}
+	return res, nil
+}
+
+// moderatePath 用于判断账号是否拥有全站审核权限的接口
+const moderatePath = "/api/checks/approve"
+
+// CanModerateAll 判断用户是否拥有审核接口的全局权限
+func (m *moderatorService) CanModerateAll(ctx context.Context, uid int64) (bool, error) {
+	return m.ce.Enforce(strconv.FormatInt(uid, 10), moderatePath, "POST")
+}
+
+// CanModerate 先校验全局权限，再按板块域校验版主权限
+func (m *moderatorService) CanModerate(ctx context.Context, uid, plateId int64) (bool, error) {
+	ok, err := m.CanModerateAll(ctx, uid)
+	if err != nil || ok {
+		return ok, err
+	}
+	if plateId <= 0 {
+		return false, nil
+	}
+	return m.ce.Enforce(casbin.NewEnforceContext("2"), strconv.FormatInt(uid, 10), domain.PlateDomain(plateId), domain.ActModerate)
+}
+
+// moderatorTarget 版主审计对象ID，格式为 板块ID:用户ID
+func moderatorTarget(plateId, uid int64) string {
+	return strconv.FormatInt(plateId, 10) + ":" + strconv.FormatInt(uid, 10)
+}
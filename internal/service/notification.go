@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/repository"
+	"go.uber.org/zap"
+)
+
+// NotificationService 通知偏好设置与通知投递
+type NotificationService interface {
+	GetSettings(ctx context.Context, uid int64) (domain.NotificationSettings, error)
+	UpdatePreferences(ctx context.Context, uid int64, prefs []domain.NotificationPreference) error
+	UpdateQuietHours(ctx context.Context, uid int64, q domain.QuietHours) error
+	ListNotifications(ctx context.Context, uid int64, pagination domain.Pagination) ([]domain.Notification, error)
+	Notify(ctx context.Context, n domain.Notification) error
+}
+
+// NotificationSender 外发渠道，新增渠道(如推送)时实现该接口并在 senders 中注册，
+// 实现方需在投递前通过 NotificationRepository.Allow 校验用户偏好
+type NotificationSender interface {
+	Channel() domain.NotificationChannel
+	Send(ctx context.Context, n domain.Notification) error
+}
+
+type notificationService struct {
+	repo    repository.NotificationRepository
+	senders []NotificationSender
+	l       *zap.Logger
+}
+
+func NewNotificationService(repo repository.NotificationRepository, userRepo repository.UserRepository, smsRepo repository.SmsRepository, emailRepo repository.EmailRepository, l *zap.Logger) NotificationService {
+	return &notificationService{
+		repo: repo,
+		senders: []NotificationSender{
+			&inAppSender{repo: repo},
+			&emailSender{userRepo: userRepo, emailRepo: emailRepo},
+			&smsSender{userRepo: userRepo, smsRepo: smsRepo},
+		},
+		l: l,
+	}
+}
+
+// GetSettings 获取通知设置，未设置的项以默认值补全
+func (n *notificationService) GetSettings(ctx context.Context, uid int64) (domain.NotificationSettings, error) {
+	settings, err := n.repo.GetSettings(ctx, uid)
+	if err != nil {
+		return domain.NotificationSettings{}, err
+	}
+	return settings.Expand(), nil
+}
+
+// UpdatePreferences 更新通知偏好
+func (n *notificationService) UpdatePreferences(ctx context.Context, uid int64, prefs []domain.NotificationPreference) error {
+	for _, p := range prefs {
+		if !p.Type.Valid() {
+			return domain.ErrInvalidNotificationType
+		}
+		if !p.Channel.Valid() {
+			return domain.ErrInvalidNotificationChannel
+		}
+	}
+	return n.repo.UpdatePreferences(ctx, uid, prefs)
+}
+
+// UpdateQuietHours 更新免打扰时段
+func (n *notificationService) UpdateQuietHours(ctx context.Context, uid int64, q domain.QuietHours) error {
+	if err := q.Validate(); err != nil {
+		return err
+	}
+	return n.repo.UpdateQuietHours(ctx, uid, q)
+}
+
+// ListNotifications 获取站内信列表
+func (n *notificationService) ListNotifications(ctx context.Context, uid int64, pagination domain.Pagination) ([]domain.Notification, error) {
+	if pagination.Page <= 0 || pagination.Size == nil || *pagination.Size <= 0 {
+		return nil, errors.New("无效的分页参数")
+	}
+	offset := int64(pagination.Page-1) * *pagination.Size
+	pagination.Offset = &offset
+
+	return n.repo.ListInApp(ctx, uid, pagination)
+}
+
+// Notify 将通知投递到用户允许的所有渠道，单个渠道失败不影响其他渠道
+func (n *notificationService) Notify(ctx context.Context, notification domain.Notification) error {
+	if notification.UserID <= 0 {
+		return errors.New("无效的用户ID")
+	}
+	if notification.CreatedAt == 0 {
+		notification.CreatedAt = time.Now().UnixMilli()
+	}
+
+	var errs []error
+	for _, sender := range n.senders {
+		err := sender.Send(ctx, notification)
+		if err == nil || errors.Is(err, repository.ErrNotificationSuppressed) {
+			continue
+		}
+		n.l.Error("通知投递失败",
+			zap.Int64("uid", notification.UserID),
+			zap.String("type", string(notification.Type)),
+			zap.String("channel", string(sender.Channel())),
+			zap.Error(err))
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// inAppSender 站内信
+type inAppSender struct {
+	repo repository.NotificationRepository
+}
+
+func (s *inAppSender) Channel() domain.NotificationChannel {
+	return domain.ChannelInApp
+}
+
+func (s *inAppSender) Send(ctx context.Context, n domain.Notification) error {
+	return s.repo.PushInApp(ctx, n)
+}
+
+// emailSender 邮件通知，收件地址取自用户资料
+type emailSender struct {
+	userRepo  repository.UserRepository
+	emailRepo repository.EmailRepository
+}
+
+func (s *emailSender) Channel() domain.NotificationChannel {
+	return domain.ChannelEmail
+}
+
+func (s *emailSender) Send(ctx context.Context, n domain.Notification) error {
+	profile, err := s.userRepo.GetProfile(ctx, n.UserID)
+	if err != nil {
+		return err
+	}
+	return s.emailRepo.Notify(ctx, n.UserID, n.Type, profile.Email, "【LinkMe】"+n.Title, n.Content)
+}
+
+// smsSender 短信通知，手机号取自用户资料
+type smsSender struct {
+	userRepo repository.UserRepository
+	smsRepo  repository.SmsRepository
+}
+
+func (s *smsSender) Channel() domain.NotificationChannel {
+	return domain.ChannelSMS
+}
+
+func (s *smsSender) Send(ctx context.Context, n domain.Notification) error {
+	profile, err := s.userRepo.GetProfile(ctx, n.UserID)
+	if err != nil {
+		return err
+	}
+	if profile.Phone == nil {
+		return nil
+	}
+	return s.smsRepo.Notify(ctx, n.UserID, n.Type, *profile.Phone, n.Content)
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/repository"
+	"github.com/GoSimplicity/LinkMe/internal/repository/cache"
+	"github.com/GoSimplicity/LinkMe/internal/repository/dao"
+	"github.com/GoSimplicity/LinkMe/pkg/oauth"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrInvalidOAuthState 表示授权状态无效、已过期或与登录方式不匹配
+	ErrInvalidOAuthState = errors.New("授权状态无效或已过期")
+	// ErrIdentityLinked 表示第三方账号已绑定其他用户
+	ErrIdentityLinked = errors.New("该第三方账号已绑定其他用户")
+	// ErrLastLoginMethod 表示解绑后用户将无法登录
+	ErrLastLoginMethod = errors.New("该第三方账号是唯一的登录方式，无法解绑")
+	// ErrOAuthRegistrationClosed 表示非开放注册模式下不能通过第三方登录创建账号
+	ErrOAuthRegistrationClosed = errors.New("当前不开放第三方账号直接注册，请先注册账号后再绑定")
+)
+
+const (
+	defaultOAuthStateTTL = 10 * time.Minute
+	// 自动注册时用户名冲突的最大重试次数
+	oauthRegisterRetries = 5
+)
+
+type OAuthService interface {
+	// Providers 已启用的第三方登录方式
+	Providers() []string
+	// AuthURL 生成第三方授权地址，uid 大于 0 时为已登录用户绑定第三方账号
+	AuthURL(ctx context.Context, provider string, uid int64) (string, error)
+	// Callback 处理授权回调，已绑定时登录，未绑定时自动注册或为发起绑定的用户绑定
+	Callback(ctx context.Context, provider, code, state string) (domain.OAuthResult, error)
+	ListIdentities(ctx context.Context, uid int64) ([]domain.UserIdentity, error)
+	Unlink(ctx context.Context, uid int64, provider string) error
+}
+
+type oauthService struct {
+	repo      repository.OAuthRepository
+	userRepo  repository.UserRepository
+	providers oauth.Registry
+	l         *zap.Logger
+}
+
+func NewOAuthService(repo repository.OAuthRepository, userRepo repository.UserRepository, providers oauth.Registry, l *zap.Logger) OAuthService {
+	return &oauthService{
+		repo:      repo,
+		userRepo:  userRepo,
+		providers: providers,
+		l:         l,
+	}
+}
+
+func (o *oauthService) Providers() []string {
+	names := o.providers.Names()
+	sort.Strings(names)
+	return names
+}
+
+// AuthURL 生成授权地址并保存一次性的授权状态
+func (o *oauthService) AuthURL(ctx context.Context, provider string, uid int64) (string, error) {
+	p, err := o.providers.Get(provider)
+	if err != nil {
+		return "", err
+	}
+
+	state, err := randomHex(16)
+	if err != nil {
+		return "", err
+	}
+	ttl := viper.GetDuration("oauth.state_ttl")
+	if ttl <= 0 {
+		ttl = defaultOAuthStateTTL
+	}
+	if err := o.repo.SaveState(ctx, state, domain.OAuthState{Provider: provider, Uid: uid}, ttl); err != nil {
+		return "", err
+	}
+	return p.AuthCodeURL(state), nil
+}
+
+// Callback 处理授权回调
+func (o *oauthService) Callback(ctx context.Context, provider, code, state string) (domain.OAuthResult, error) {
+	p, err := o.providers.Get(provider)
+	if err != nil {
+		return domain.OAuthResult{}, err
+	}
+	if code == "" || state == "" {
+		return domain.OAuthResult{}, ErrInvalidOAuthState
+	}
+	s, err := o.repo.TakeState(ctx, state)
+	if err != nil {
+		if errors.Is(err, cache.ErrOAuthStateNotFound) {
+			return domain.OAuthResult{}, ErrInvalidOAuthState
+		}
+		return domain.OAuthResult{}, err
+	}
+	if s.Provider != provider {
+		return domain.OAuthResult{}, ErrInvalidOAuthState
+	}
+
+	identity, err := p.Exchange(ctx, code)
+	if err != nil {
+		o.l.Warn("第三方授权失败", zap.String("provider", provider), zap.Error(err))
+		return domain.OAuthResult{}, oauth.ErrExchangeFailed
+	}
+
+	existing, err := o.repo.FindIdentity(ctx, provider, identity.Subject)
+	switch {
+	case err == nil:
+		if s.Uid > 0 {
+			if existing.UserID != s.Uid {
+				return domain.OAuthResult{}, ErrIdentityLinked
+			}
+			return domain.OAuthResult{Uid: s.Uid, Linked: true}, nil
+		}
+		// 已注销的用户不允许登录
+		if _, err := o.userRepo.FindByID(ctx, existing.UserID); err != nil {
+			return domain.OAuthResult{}, ErrInvalidUserOrPassword
+		}
+		return domain.OAuthResult{Uid: existing.UserID}, nil
+	case !errors.Is(err, dao.ErrIdentityNotFound):
+		return domain.OAuthResult{}, err
+	}
+
+	if s.Uid > 0 {
+		if err := o.repo.LinkIdentity(ctx, toUserIdentity(identity, s.Uid)); err != nil {
+			if errors.Is(err, dao.ErrIdentityExists) {
+				return domain.OAuthResult{}, ErrIdentityLinked
+			}
+			return domain.OAuthResult{}, err
+		}
+		return domain.OAuthResult{Uid: s.Uid, Linked: true}, nil
+	}
+
+	uid, err := o.register(ctx, identity)
+	if err != nil {
+		return domain.OAuthResult{}, err
+	}
+	return domain.OAuthResult{Uid: uid, Registered: true}, nil
+}
+
+// register 首次第三方登录时自动注册，仅开放注册模式可用，密码随机生成，用户名冲突时追加随机后缀
+func (o *oauthService) register(ctx context.Context, identity oauth.Identity) (int64, error) {
+	// 邀请与关闭注册模式下，第三方登录只能用于已有账号
+	if registrationMode() != domain.RegistrationOpen {
+		return 0, ErrOAuthRegistrationClosed
+	}
+
+	password, err := randomHex(24)
+	if err != nil {
+		return 0, err
+	}
+	u := domain.User{
+		Password: password,
+		Profile: domain.Profile{
+			RealName: identity.Name,
+			Avatar:   identity.Avatar,
+		},
+	}
+	if err := u.HashPassword(); err != nil {
+		return 0, err
+	}
+
+	for attempt := 0; attempt < oauthRegisterRetries; attempt++ {
+		suffix := ""
+		if attempt > 0 {
+			if suffix, err = randomHex(3); err != nil {
+				return 0, err
+			}
+		}
+		u.Username = oauthUsername(identity, suffix)
+		uid, err := o.repo.RegisterWithIdentity(ctx, u, toUserIdentity(identity, 0))
+		if errors.Is(err, dao.ErrDuplicateUsername) {
+			continue
+		}
+		return uid, err
+	}
+	return 0, dao.ErrDuplicateUsername
+}
+
+func (o *oauthService) ListIdentities(ctx context.Context, uid int64) ([]domain.UserIdentity, error) {
+	return o.repo.ListIdentities(ctx, uid)
+}
+
+// Unlink 解绑第三方账号，通过第三方登录注册的用户不能解绑最后一个第三方账号
+func (o *oauthService) Unlink(ctx context.Context, uid int64, provider string) error {
+	identities, err := o.repo.ListIdentities(ctx, uid)
+	if err != nil {
+		return err
+	}
+	if len(identities) == 1 && identities[0].Provider == provider && identities[0].Registered {
+		return ErrLastLoginMethod
+	}
+	return o.repo.UnlinkIdentity(ctx, uid, provider)
+}
+
+func toUserIdentity(identity oauth.Identity, uid int64) domain.UserIdentity {
+	return domain.UserIdentity{
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		UserID:   uid,
+		Username: identity.Username,
+		Email:    identity.Email,
+	}
+}
+
+// oauthUsername 根据第三方用户名生成符合规则(至少6位字母数字)的用户名
+func oauthUsername(identity oauth.Identity, suffix string) string {
+	var b strings.Builder
+	for _, r := range identity.Provider + identity.Username {
+		if r < 128 && (r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	name := b.String()
+	if len(name) > 40 {
+		name = name[:40]
+	}
+	name += suffix
+	for len(name) < 6 {
+		name += "0"
+	}
+	return name
+}
+
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/pkg/oauth"
+)
+
+func TestOAuthUsername(t *testing.T) {
+	u := domain.User{}
+	cases := []struct {
+		identity oauth.Identity
+		suffix   string
+		want     string
+	}{
+		{oauth.Identity{Provider: "github", Username: "octo-cat"}, "", "githuboctocat"},
+		{oauth.Identity{Provider: "oidc", Username: "张三"}, "", "oidc00"},
+		{oauth.Identity{Provider: "oidc", Username: "alice"}, "a1b2c3", "oidcalicea1b2c3"},
+	}
+	for _, c := range cases {
+		got := oauthUsername(c.identity, c.suffix)
+		if got != c.want {
+			t.Errorf("oauthUsername(%+v, %q) = %q, want %q", c.identity, c.suffix, got, c.want)
+		}
+		u.Username = got
+		if err := u.ValidateUsername(); err != nil {
+			t.Errorf("username %q should be valid: %v", got, err)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/domain/events/email"
+	"github.com/GoSimplicity/LinkMe/internal/domain/events/sms"
+	"github.com/GoSimplicity/LinkMe/internal/repository"
+	"github.com/GoSimplicity/LinkMe/utils"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrInvalidResetChannel 表示不支持的找回渠道或账号格式错误
+	ErrInvalidResetChannel = errors.New("无效的找回方式或账号")
+	// ErrResetTooFrequent 表示验证码发送过于频繁
+	ErrResetTooFrequent = errors.New("验证码发送过于频繁，请稍后再试")
+	// ErrInvalidResetCode 表示验证码错误或已过期
+	ErrInvalidResetCode = errors.New("验证码错误或已过期")
+	// ErrResetAttemptsExceeded 表示验证码校验失败次数过多
+	ErrResetAttemptsExceeded = errors.New("验证次数过多，请稍后重新获取验证码")
+	// ErrInvalidResetToken 表示重置令牌无效、已过期或已被使用
+	ErrInvalidResetToken = errors.New("重置链接无效或已过期")
+	// ErrInvalidPasswordFormat 表示新密码格式不符合要求
+	ErrInvalidPasswordFormat = errors.New("密码需要至少8位的字母数字符号组合")
+)
+
+const (
+	defaultResetTokenTTL     = 15 * time.Minute
+	defaultResetCooldown     = time.Minute
+	defaultResetMaxAttempts  = 5
+	defaultResetAttemptsSpan = 15 * time.Minute
+)
+
+type PasswordResetService interface {
+	// RequestReset 向账号绑定的邮箱或手机号发送验证码，账号不存在时同样返回成功以避免账号探测
+	RequestReset(ctx context.Context, channel domain.ResetChannel, account string) error
+	// VerifyCode 校验验证码，通过后返回一次性的重置令牌
+	VerifyCode(ctx context.Context, channel domain.ResetChannel, account, code string) (string, error)
+	// ResetPassword 使用重置令牌设置新密码，返回被重置的用户ID
+	ResetPassword(ctx context.Context, token, newPassword string) (int64, error)
+}
+
+type passwordResetService struct {
+	repo          repository.PasswordResetRepository
+	userRepo      repository.UserRepository
+	smsRepo       repository.SmsRepository
+	emailRepo     repository.EmailRepository
+	smsProducer   sms.Producer
+	emailProducer email.Producer
+	l             *zap.Logger
+}
+
+func NewPasswordResetService(repo repository.PasswordResetRepository, userRepo repository.UserRepository, smsRepo repository.SmsRepository, emailRepo repository.EmailRepository, smsProducer sms.Producer, emailProducer email.Producer, l *zap.Logger) PasswordResetService {
+	return &passwordResetService{
+		repo:          repo,
+		userRepo:      userRepo,
+		smsRepo:       smsRepo,
+		emailRepo:     emailRepo,
+		smsProducer:   smsProducer,
+		emailProducer: emailProducer,
+		l:             l,
+	}
+}
+
+// RequestReset 发送找回密码验证码
+func (p *passwordResetService) RequestReset(ctx context.Context, channel domain.ResetChannel, account string) error {
+	account, err := normalizeResetAccount(channel, account)
+	if err != nil {
+		return err
+	}
+
+	ok, err := p.repo.Cooldown(ctx, channel, account, durationOr("password_reset.cooldown", defaultResetCooldown))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrResetTooFrequent
+	}
+
+	if _, err := p.findUser(ctx, channel, account); err != nil {
+		p.l.Info("找回密码的账号不存在", zap.String("channel", string(channel)), zap.Error(err))
+		return nil
+	}
+
+	if channel == domain.ResetChannelEmail {
+		return p.emailProducer.ProduceEmail(ctx, email.EmailEvent{Email: account, Purpose: domain.EmailPurposeReset})
+	}
+	return p.smsProducer.ProduceSMSCode(ctx, sms.SMSCodeEvent{Number: account})
+}
+
+// VerifyCode 校验验证码并签发重置令牌
+func (p *passwordResetService) VerifyCode(ctx context.Context, channel domain.ResetChannel, account, code string) (string, error) {
+	account, err := normalizeResetAccount(channel, account)
+	if err != nil {
+		return "", err
+	}
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return "", ErrInvalidResetCode
+	}
+
+	maxAttempts := viper.GetInt64("password_reset.max_attempts")
+	if maxAttempts <= 0 {
+		maxAttempts = defaultResetMaxAttempts
+	}
+	attempts, err := p.repo.IncrAttempts(ctx, channel, account, durationOr("password_reset.attempt_window", defaultResetAttemptsSpan))
+	if err != nil {
+		return "", err
+	}
+	if attempts > maxAttempts {
+		return "", ErrResetAttemptsExceeded
+	}
+
+	var ok bool
+	if channel == domain.ResetChannelEmail {
+		ok, err = p.emailRepo.CheckCode(ctx, account, domain.EmailPurposeReset, code)
+	} else {
+		ok, err = p.smsRepo.CheckCode(ctx, smsTemplateID(), account, code)
+	}
+	if err != nil || !ok {
+		return "", ErrInvalidResetCode
+	}
+
+	u, err := p.findUser(ctx, channel, account)
+	if err != nil {
+		return "", ErrInvalidResetCode
+	}
+
+	if err := p.repo.ClearAttempts(ctx, channel, account); err != nil {
+		p.l.Warn("清除找回密码校验次数失败", zap.Error(err))
+	}
+
+	token, err := randomHex(32)
+	if err != nil {
+		return "", err
+	}
+	if err := p.repo.SaveToken(ctx, hashResetToken(token), u.ID, durationOr("password_reset.token_ttl", defaultResetTokenTTL)); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// ResetPassword 重置密码
+func (p *passwordResetService) ResetPassword(ctx context.Context, token, newPassword string) (int64, error) {
+	// 先校验密码格式，避免格式错误时令牌被消耗
+	u := domain.User{Password: newPassword}
+	if err := u.ValidatePassword(); err != nil {
+		return 0, ErrInvalidPasswordFormat
+	}
+	if err := u.HashPassword(); err != nil {
+		return 0, err
+	}
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return 0, ErrInvalidResetToken
+	}
+	uid, err := p.repo.TakeToken(ctx, hashResetToken(token))
+	if err != nil {
+		return 0, ErrInvalidResetToken
+	}
+
+	du, err := p.userRepo.FindByID(ctx, uid)
+	if err != nil {
+		return 0, err
+	}
+	if err := p.userRepo.ChangePassword(ctx, du.Username, u.Password); err != nil {
+		return 0, err
+	}
+	return uid, nil
+}
+
+func (p *passwordResetService) findUser(ctx context.Context, channel domain.ResetChannel, account string) (domain.User, error) {
+	if channel == domain.ResetChannelEmail {
+		return p.userRepo.FindByEmail(ctx, account)
+	}
+	return p.userRepo.FindByPhone(ctx, account)
+}
+
+// normalizeResetAccount 校验找回渠道并统一账号格式
+func normalizeResetAccount(channel domain.ResetChannel, account string) (string, error) {
+	account = strings.TrimSpace(account)
+	switch channel {
+	case domain.ResetChannelEmail:
+		account = utils.NormalizeEmail(account)
+		if utils.IsValidEmail(account) {
+			return account, nil
+		}
+	case domain.ResetChannelSMS:
+		if utils.IsValidNumber(account) {
+			return account, nil
+		}
+	}
+	return "", ErrInvalidResetChannel
+}
+
+// hashResetToken 缓存中只保存令牌哈希，避免缓存泄露后令牌可被直接使用
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// durationOr 读取时长配置，未配置或非法时使用默认值
+func durationOr(key string, def time.Duration) time.Duration {
+	if d := viper.GetDuration(key); d > 0 {
+		return d
+	}
+	return def
+}
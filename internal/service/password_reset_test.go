@@ -0,0 +1,29 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+)
+
+func TestNormalizeResetAccount(t *testing.T) {
+	cases := []struct {
+		channel domain.ResetChannel
+		account string
+		want    string
+		err     error
+	}{
+		{domain.ResetChannelEmail, " Alice@Example.COM ", "alice@example.com", nil},
+		{domain.ResetChannelEmail, "not-an-email", "", ErrInvalidResetChannel},
+		{domain.ResetChannelSMS, "13800138000", "13800138000", nil},
+		{domain.ResetChannelSMS, "12345", "", ErrInvalidResetChannel},
+		{"wechat", "alice@example.com", "", ErrInvalidResetChannel},
+	}
+	for _, c := range cases {
+		got, err := normalizeResetAccount(c.channel, c.account)
+		if got != c.want || !errors.Is(err, c.err) {
+			t.Errorf("normalizeResetAccount(%q, %q) = (%q, %v), want (%q, %v)", c.channel, c.account, got, err, c.want, c.err)
+		}
+	}
+}
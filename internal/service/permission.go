@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/GoSimplicity/LinkMe/internal/domain"
 	"github.com/GoSimplicity/LinkMe/internal/repository"
 	"go.uber.org/zap"
 )
@@ -14,14 +16,16 @@ type PermissionService interface {
 }
 
 type permissionService struct {
-	l    *zap.Logger
-	repo repository.PermissionRepository
+	l        *zap.Logger
+	repo     repository.PermissionRepository
+	auditSvc AuditService
 }
 
-func NewPermissionService(l *zap.Logger, repo repository.PermissionRepository) PermissionService {
+func NewPermissionService(l *zap.Logger, repo repository.PermissionRepository, auditSvc AuditService) PermissionService {
 	return &permissionService{
-		l:    l,
-		repo: repo,
+		l:        l,
+		repo:     repo,
+		auditSvc: auditSvc,
 	}
 }
 
@@ -46,7 +50,19 @@ func (p *permissionService) AssignRole(ctx context.Context, roleId int, menuIds
 	}
 
 	// 分配新权限
-	return p.repo.AssignRole(ctx, roleId, menuIds, apiIds)
+	if err := p.repo.AssignRole(ctx, roleId, menuIds, apiIds); err != nil {
+		return err
+	}
+	p.auditSvc.Record(ctx, domain.AuditEntry{
+		Action:     domain.AuditPermissionRole,
+		TargetType: domain.AuditTargetRole,
+		TargetID:   fmt.Sprint(roleId),
+		After: map[string]interface{}{
+			"menuIds": menuIds,
+			"apiIds":  apiIds,
+		},
+	})
+	return nil
 }
 
 // AssignRoleToUser 为用户分配角色和权限
@@ -64,7 +80,20 @@ func (p *permissionService) AssignRoleToUser(ctx context.Context, userId int, ro
 	}
 
 	// 分配新角色和权限
-	return p.repo.AssignRoleToUser(ctx, userId, roleIds, menuIds, apiIds)
+	if err := p.repo.AssignRoleToUser(ctx, userId, roleIds, menuIds, apiIds); err != nil {
+		return err
+	}
+	p.auditSvc.Record(ctx, domain.AuditEntry{
+		Action:     domain.AuditPermissionUser,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   fmt.Sprint(userId),
+		After: map[string]interface{}{
+			"roleIds": roleIds,
+			"menuIds": menuIds,
+			"apiIds":  apiIds,
+		},
+	})
+	return nil
 }
 
 // AssignRoleToUsers 为多个用户批量分配角色和权限
@@ -82,5 +111,21 @@ func (p *permissionService) AssignRoleToUsers(ctx context.Context, userIds []int
 	}
 
 	// 批量分配新角色和权限
-	return p.repo.AssignRoleToUsers(ctx, userIds, roleIds, menuIds, apiIds)
+	if err := p.repo.AssignRoleToUsers(ctx, userIds, roleIds, menuIds, apiIds); err != nil {
+		return err
+	}
+	// 按用户分别记录，便于按对象查询
+	for _, userId := range userIds {
+		p.auditSvc.Record(ctx, domain.AuditEntry{
+			Action:     domain.AuditPermissionUsers,
+			TargetType: domain.AuditTargetUser,
+			TargetID:   fmt.Sprint(userId),
+			After: map[string]interface{}{
+				"roleIds": roleIds,
+				"menuIds": menuIds,
+				"apiIds":  apiIds,
+			},
+		})
+	}
+	return nil
 }
@@ -2,34 +2,68 @@ package service
 
 import (
 	"context"
+	"errors"
+	"strconv"
+
 	"github.com/GoSimplicity/LinkMe/internal/domain"
 	"github.com/GoSimplicity/LinkMe/internal/repository"
 	"go.uber.org/zap"
 )
 
+// ErrPlateNotFound 板块不存在或已删除
+var ErrPlateNotFound = errors.New("板块不存在")
+
 type PlateService interface {
 	CreatePlate(ctx context.Context, plate domain.Plate) error
 	ListPlate(ctx context.Context, pagination domain.Pagination) ([]domain.Plate, error)
 	UpdatePlate(ctx context.Context, plate domain.Plate) error
 	DeletePlate(ctx context.Context, plateId int64, uid int64) error
+	Subscribe(ctx context.Context, plateId int64, uid int64) error
+	Unsubscribe(ctx context.Context, plateId int64, uid int64) error
+	ListSubscribedPlates(ctx context.Context, uid int64, pagination domain.Pagination) ([]domain.Plate, error)
+	// PlateFeed 获取板块帖子流，plateId 为 0 时合并用户订阅的全部板块
+	PlateFeed(ctx context.Context, uid int64, plateId int64, cursor uint, limit int) (domain.Timeline, error)
 }
 
 type plateService struct {
-	l    *zap.Logger
-	repo repository.PlateRepository
+	l            *zap.Logger
+	repo         repository.PlateRepository
+	postRepo     repository.PostRepository
+	relationRepo repository.RelationRepository
+	userRepo     repository.UserRepository
+	moderatorSvc ModeratorService
+	auditSvc     AuditService
 }
 
-func NewPlateService(l *zap.Logger, repo repository.PlateRepository) PlateService {
+func NewPlateService(l *zap.Logger, repo repository.PlateRepository, postRepo repository.PostRepository, relationRepo repository.RelationRepository, userRepo repository.UserRepository, moderatorSvc ModeratorService, auditSvc AuditService) PlateService {
 	return &plateService{
-		l:    l,
-		repo: repo,
+		l:            l,
+		repo:         repo,
+		postRepo:     postRepo,
+		relationRepo: relationRepo,
+		userRepo:     userRepo,
+		moderatorSvc: moderatorSvc,
+		auditSvc:     auditSvc,
 	}
 }
 
 func (p *plateService) CreatePlate(ctx context.Context, plate domain.Plate) error {
-	return p.repo.CreatePlate(ctx, plate)
+	id, err := p.repo.CreatePlate(ctx, plate)
+	if err != nil {
+		return err
+	}
+	plate.ID = id
+	p.auditSvc.Record(ctx, domain.AuditEntry{
+		ActorID:    plate.Uid,
+		Action:     domain.AuditPlateCreate,
+		TargetType: domain.AuditTargetPlate,
+		TargetID:   strconv.FormatInt(id, 10),
+		After:      plate,
+	})
+	return nil
 }
 
+// ListPlate 获取板块列表，pagination.Uid 不为 0 时标记当前用户是否已订阅
 func (p *plateService) ListPlate(ctx context.Context, pagination domain.Pagination) ([]domain.Plate, error) {
 	offset := int64(pagination.Page-1) * *pagination.Size
 	pagination.Offset = &offset
@@ -37,13 +71,144 @@ func (p *plateService) ListPlate(ctx context.Context, pagination domain.Paginati
 	if err != nil {
 		return nil, err
 	}
-	return plates, err
+	if pagination.Uid <= 0 || len(plates) == 0 {
+		return plates, nil
+	}
+
+	ids, err := p.repo.ListSubscribedPlateIDs(ctx, pagination.Uid)
+	if err != nil {
+		return nil, err
+	}
+	subscribed := make(map[int64]struct{}, len(ids))
+	for _, id := range ids {
+		subscribed[id] = struct{}{}
+	}
+	for i := range plates {
+		_, plates[i].Subscribed = subscribed[plates[i].ID]
+	}
+	return plates, nil
 }
 
 func (p *plateService) UpdatePlate(ctx context.Context, plate domain.Plate) error {
-	return p.repo.UpdatePlate(ctx, plate)
+	before, _ := p.repo.GetPlate(ctx, plate.ID)
+	if err := p.repo.UpdatePlate(ctx, plate); err != nil {
+		return err
+	}
+	p.auditSvc.Record(ctx, domain.AuditEntry{
+		ActorID:    plate.Uid,
+		Action:     domain.AuditPlateUpdate,
+		TargetType: domain.AuditTargetPlate,
+		TargetID:   strconv.FormatInt(plate.ID, 10),
+		Before:     before,
+		After:      plate,
+	})
+	return nil
 }
 
+// DeletePlate 删除板块，同时移除该板块的全部版主
 func (p *plateService) DeletePlate(ctx context.Context, plateId int64, uid int64) error {
-	return p.repo.DeletePlate(ctx, plateId, uid)
+	before, _ := p.repo.GetPlate(ctx, plateId)
+	if err := p.repo.DeletePlate(ctx, plateId, uid); err != nil {
+		return err
+	}
+	if err := p.moderatorSvc.RemovePlate(ctx, plateId); err != nil {
+		p.l.Warn("移除板块版主失败", zap.Int64("plateId", plateId), zap.Error(err))
+	}
+	p.auditSvc.Record(ctx, domain.AuditEntry{
+		ActorID:    uid,
+		Action:     domain.AuditPlateDelete,
+		TargetType: domain.AuditTargetPlate,
+		TargetID:   strconv.FormatInt(plateId, 10),
+		Before:     before,
+	})
+	return nil
+}
+
+// Subscribe 订阅板块
+func (p *plateService) Subscribe(ctx context.Context, plateId int64, uid int64) error {
+	exists, err := p.repo.ExistsPlate(ctx, plateId)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrPlateNotFound
+	}
+	return p.repo.Subscribe(ctx, plateId, uid)
+}
+
+// Unsubscribe 取消订阅板块
+func (p *plateService) Unsubscribe(ctx context.Context, plateId int64, uid int64) error {
+	return p.repo.Unsubscribe(ctx, plateId, uid)
+}
+
+// ListSubscribedPlates 获取用户订阅的板块
+func (p *plateService) ListSubscribedPlates(ctx context.Context, uid int64, pagination domain.Pagination) ([]domain.Plate, error) {
+	if pagination.Page <= 0 || pagination.Size == nil || *pagination.Size <= 0 {
+		return nil, errors.New("无效的分页参数")
+	}
+	offset := int64(pagination.Page-1) * *pagination.Size
+	pagination.Offset = &offset
+	plates, err := p.repo.ListSubscribedPlates(ctx, uid, pagination)
+	if err != nil {
+		return nil, err
+	}
+	for i := range plates {
+		plates[i].Subscribed = true
+	}
+	return plates, nil
+}
+
+// PlateFeed 获取板块帖子流，按帖子ID倒序并使用游标分页
+func (p *plateService) PlateFeed(ctx context.Context, uid int64, plateId int64, cursor uint, limit int) (domain.Timeline, error) {
+	if limit <= 0 {
+		limit = defaultTimelineLimit
+	}
+	if limit > maxTimelineLimit {
+		limit = maxTimelineLimit
+	}
+
+	var plateIds []int64
+	if plateId > 0 {
+		exists, err := p.repo.ExistsPlate(ctx, plateId)
+		if err != nil {
+			return domain.Timeline{}, err
+		}
+		if !exists {
+			return domain.Timeline{}, ErrPlateNotFound
+		}
+		plateIds = []int64{plateId}
+	} else {
+		ids, err := p.repo.ListSubscribedPlateIDs(ctx, uid)
+		if err != nil {
+			return domain.Timeline{}, err
+		}
+		plateIds = ids
+	}
+
+	timeline := domain.Timeline{Posts: []domain.Post{}}
+	posts, err := p.postRepo.ListPublishPostsByPlates(ctx, plateIds, cursor, limit)
+	if err != nil {
+		return domain.Timeline{}, err
+	}
+	if len(posts) == 0 {
+		return timeline, nil
+	}
+	if len(posts) == limit {
+		timeline.Cursor = posts[len(posts)-1].ID
+	}
+
+	if posts, err = filterPrivatePosts(ctx, p.userRepo, p.relationRepo, uid, posts); err != nil {
+		return domain.Timeline{}, err
+	}
+	hidden, err := p.relationRepo.HiddenUserIDs(ctx, uid)
+	if err != nil {
+		return domain.Timeline{}, err
+	}
+	for _, post := range posts {
+		if _, ok := hidden[post.Uid]; ok {
+			continue
+		}
+		timeline.Posts = append(timeline.Posts, post)
+	}
+	return timeline, nil
 }
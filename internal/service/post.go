@@ -36,13 +36,19 @@ type postService struct {
 	incRepo       repository.InteractiveRepository
 	producer      post.Producer
 	checkProducer check.Producer
+	relationRepo  repository.RelationRepository
+	userRepo      repository.UserRepository
+	reputationSvc ReputationService
 	l             *zap.Logger
 }
 
-func NewPostService(repo repository.PostRepository, l *zap.Logger, p post.Producer, c check.Producer, incRepo repository.InteractiveRepository) PostService {
+func NewPostService(repo repository.PostRepository, l *zap.Logger, p post.Producer, c check.Producer, incRepo repository.InteractiveRepository, relationRepo repository.RelationRepository, userRepo repository.UserRepository, reputationSvc ReputationService) PostService {
 	return &postService{
 		repo:          repo,
 		incRepo:       incRepo,
+		relationRepo:  relationRepo,
+		userRepo:      userRepo,
+		reputationSvc: reputationSvc,
 		l:             l,
 		producer:      p,
 		checkProducer: c,
@@ -71,6 +77,17 @@ func (p *postService) Publish(ctx context.Context, postId uint, uid int64) error
 		return errors.New("帖子已提交审核，请勿重复提交")
 	}
 
+	// 外链需达到对应等级才能发布
+	if domain.ContainsLink(dp.Title) || domain.ContainsLink(dp.Content) {
+		allowed, err := p.reputationSvc.HasPrivilege(ctx, uid, domain.PrivilegePostLinks)
+		if err != nil {
+			return fmt.Errorf("获取用户等级失败: %w", err)
+		}
+		if !allowed {
+			return ErrLinksNotAllowed
+		}
+	}
+
 	// 设置超时上下文
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
@@ -83,6 +100,7 @@ func (p *postService) Publish(ctx context.Context, postId uint, uid int64) error
 			Content: dp.Content,
 			Title:   dp.Title,
 			Uid:     dp.Uid,
+			PlateID: dp.PlateID,
 		})
 	})
 
@@ -137,6 +155,11 @@ func (p *postService) GetPublishPostById(ctx context.Context, postId uint, uid i
 		p.l.Error("获取已发布帖子失败", zap.Error(err))
 		return domain.Post{}, fmt.Errorf("获取已发布帖子失败: %w", err)
 	}
+	if ok, err := canViewUser(ctx, p.userRepo, p.relationRepo, uid, dp.Uid); err != nil {
+		return domain.Post{}, err
+	} else if !ok {
+		return domain.Post{}, ErrPrivateAccount
+	}
 
 	// 设置超时上下文
 	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
@@ -183,11 +206,15 @@ func (p *postService) ListPosts(ctx context.Context, pagination domain.Paginatio
 	return p.repo.ListPosts(ctx, pagination)
 }
 
-// ListPublishPosts 列出已发布的帖子
+// ListPublishPosts 列出已发布的帖子，过滤当前用户屏蔽或拉黑的作者
 func (p *postService) ListPublishPosts(ctx context.Context, pagination domain.Pagination) ([]domain.Post, error) {
 	offset := int64(pagination.Page-1) * *pagination.Size
 	pagination.Offset = &offset
-	return p.repo.ListPublishPosts(ctx, pagination)
+	posts, err := p.repo.ListPublishPosts(ctx, pagination)
+	if err != nil {
+		return nil, err
+	}
+	return p.filterHiddenPosts(ctx, pagination.Uid, posts)
 }
 
 // Delete 删除帖子
@@ -226,5 +253,35 @@ func (p *postService) GetPostsCount(ctx context.Context) (int64, error) {
 func (p *postService) GetPostsByPlate(ctx context.Context, plateId int64, pagination domain.Pagination) ([]domain.Post, error) {
 	offset := int64(pagination.Page-1) * *pagination.Size
 	pagination.Offset = &offset
-	return p.repo.GetPostsByPlate(ctx, plateId, pagination)
+	posts, err := p.repo.GetPostsByPlate(ctx, plateId, pagination)
+	if err != nil {
+		return nil, err
+	}
+	return p.filterHiddenPosts(ctx, pagination.Uid, posts)
+}
+
+// filterHiddenPosts 过滤用户屏蔽或拉黑的作者以及无权查看的私密账号发布的帖子
+func (p *postService) filterHiddenPosts(ctx context.Context, uid int64, posts []domain.Post) ([]domain.Post, error) {
+	if len(posts) == 0 {
+		return posts, nil
+	}
+	posts, err := filterPrivatePosts(ctx, p.userRepo, p.relationRepo, uid, posts)
+	if err != nil || uid <= 0 {
+		return posts, err
+	}
+	hidden, err := p.relationRepo.HiddenUserIDs(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	if len(hidden) == 0 {
+		return posts, nil
+	}
+	result := make([]domain.Post, 0, len(posts))
+	for _, post := range posts {
+		if _, ok := hidden[post.Uid]; ok {
+			continue
+		}
+		result = append(result, post)
+	}
+	return result, nil
 }
@@ -21,7 +21,8 @@ var (
 
 type RankingService interface {
 	interfaces.RankingService
-	GetTopN(ctx context.Context) ([]domain.Post, error)
+	// GetTopN 获取热榜，过滤 uid 无权查看的私密账号帖子
+	GetTopN(ctx context.Context, uid int64) ([]domain.Post, error)
 	GetRankingConfig(ctx context.Context) (domain.RankingParameter, error)
 	ResetRankingConfig(ctx context.Context, rankingParameter domain.RankingParameter) error
 }
@@ -36,6 +37,8 @@ type rankingService struct {
 	postRepository             repository.PostRepository
 	rankingRepository          repository.RankingRepository
 	rankingParameterRepository repository.RankingParameterRepository
+	userRepository             repository.UserRepository
+	relationRepository         repository.RelationRepository
 	l                          *zap.Logger
 	batchSize                  int
 	rankSize                   int
@@ -47,6 +50,8 @@ func NewRankingService(
 	postRepo repository.PostRepository,
 	rankingRepo repository.RankingRepository,
 	rankingParameterRepository repository.RankingParameterRepository,
+	userRepo repository.UserRepository,
+	relationRepo repository.RelationRepository,
 	l *zap.Logger,
 ) RankingService {
 	return &rankingService{
@@ -54,6 +59,8 @@ func NewRankingService(
 		postRepository:             postRepo,
 		rankingRepository:          rankingRepo,
 		rankingParameterRepository: rankingParameterRepository,
+		userRepository:             userRepo,
+		relationRepository:         relationRepo,
 		l:                          l,
 		batchSize:                  100,
 		rankSize:                   100,
@@ -78,8 +85,12 @@ func (rs *rankingService) TopN(ctx context.Context) error {
 }
 
 // GetTopN 获取排名前 N 的帖子
-func (rs *rankingService) GetTopN(ctx context.Context) ([]domain.Post, error) {
-	return rs.rankingRepository.GetTopN(ctx)
+func (rs *rankingService) GetTopN(ctx context.Context, uid int64) ([]domain.Post, error) {
+	posts, err := rs.rankingRepository.GetTopN(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return filterPrivatePosts(ctx, rs.userRepository, rs.relationRepository, uid, posts)
 }
 
 // 榜单配置相关方法
@@ -0,0 +1,334 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/job/interfaces"
+	"github.com/GoSimplicity/LinkMe/internal/repository"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+const (
+	// 构建兴趣画像时读取的行为条数
+	recommendProfileSize = 200
+	// 每批扫描的活跃用户数
+	recommendScanBatch = 100
+)
+
+type RecommendService interface {
+	interfaces.RecommendService
+	// ForYou 获取个性化推荐流，离线结果不存在时回退到热榜
+	ForYou(ctx context.Context, uid int64, pagination domain.Pagination) ([]domain.Post, error)
+}
+
+type recommendService struct {
+	repo            repository.RecommendRepository
+	historyRepo     repository.HistoryRepository
+	interactiveRepo repository.InteractiveRepository
+	postRepo        repository.PostRepository
+	rankingRepo     repository.RankingRepository
+	paramRepo       repository.RankingParameterRepository
+	relationRepo    repository.RelationRepository
+	userRepo        repository.UserRepository
+	l               *zap.Logger
+}
+
+func NewRecommendService(
+	repo repository.RecommendRepository,
+	historyRepo repository.HistoryRepository,
+	interactiveRepo repository.InteractiveRepository,
+	postRepo repository.PostRepository,
+	rankingRepo repository.RankingRepository,
+	paramRepo repository.RankingParameterRepository,
+	relationRepo repository.RelationRepository,
+	userRepo repository.UserRepository,
+	l *zap.Logger,
+) RecommendService {
+	return &recommendService{
+		repo:            repo,
+		historyRepo:     historyRepo,
+		interactiveRepo: interactiveRepo,
+		postRepo:        postRepo,
+		rankingRepo:     rankingRepo,
+		paramRepo:       paramRepo,
+		relationRepo:    relationRepo,
+		userRepo:        userRepo,
+		l:               l,
+	}
+}
+
+// recommendCandidate 候选帖子及其归一化后的热度
+type recommendCandidate struct {
+	post domain.Post
+	hot  float64
+}
+
+// ComputeRecommendations 离线计算近期活跃用户的推荐结果
+func (r *recommendService) ComputeRecommendations(ctx context.Context) error {
+	candidates, err := r.loadCandidates(ctx)
+	if err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		r.l.Info("没有可推荐的帖子")
+		return nil
+	}
+
+	weights := loadRecommendWeights()
+	resultSize := viper.GetInt("recommend.result_size")
+	now := time.Now()
+
+	var cursor uint64
+	for {
+		uids, next, err := r.historyRepo.ScanActiveUsers(ctx, cursor, recommendScanBatch)
+		if err != nil {
+			return err
+		}
+		for _, uid := range uids {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := r.computeForUser(ctx, uid, candidates, weights, resultSize, now); err != nil {
+				r.l.Warn("计算用户推荐失败", zap.Int64("uid", uid), zap.Error(err))
+			}
+		}
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+// computeForUser 计算单个用户的推荐结果
+func (r *recommendService) computeForUser(ctx context.Context, uid int64, candidates []recommendCandidate, weights domain.RecommendWeights, resultSize int, now time.Time) error {
+	profile, err := r.buildProfile(ctx, uid)
+	if err != nil {
+		return err
+	}
+	if profile.Empty() {
+		return nil
+	}
+
+	scores := make(map[uint]float64, len(candidates))
+	for _, c := range candidates {
+		if _, seen := profile.Seen[c.post.ID]; seen || c.post.Uid == uid {
+			continue
+		}
+		scores[c.post.ID] = weights.Interest*profile.Match(c.post) +
+			weights.Hot*c.hot +
+			weights.Freshness*domain.Freshness(c.post.CreatedAt, now, weights.FreshnessHalfLife)
+	}
+
+	return r.repo.SaveRecommendations(ctx, uid, topScores(scores, resultSize))
+}
+
+// buildProfile 根据阅读历史、点赞与收藏构建兴趣画像
+func (r *recommendService) buildProfile(ctx context.Context, uid int64) (*domain.InterestProfile, error) {
+	profile := domain.NewInterestProfile()
+
+	var offset int64
+	size := int64(recommendProfileSize)
+	histories, err := r.historyRepo.GetHistory(ctx, domain.Pagination{Uid: uid, Offset: &offset, Size: &size})
+	if err != nil {
+		return nil, err
+	}
+	liked, err := r.interactiveRepo.ListLikedPostIds(ctx, uid, recommendProfileSize)
+	if err != nil {
+		return nil, err
+	}
+	collected, err := r.interactiveRepo.ListCollectedPostIds(ctx, uid, recommendProfileSize)
+	if err != nil {
+		return nil, err
+	}
+
+	weights := make(map[uint]float64)
+	for _, h := range histories {
+		weights[h.PostID] += domain.InterestWeightRead
+	}
+	for _, id := range liked {
+		weights[id] += domain.InterestWeightLike
+	}
+	for _, id := range collected {
+		weights[id] += domain.InterestWeightCollect
+	}
+	if len(weights) == 0 {
+		return profile, nil
+	}
+
+	ids := make([]uint, 0, len(weights))
+	for id := range weights {
+		ids = append(ids, id)
+	}
+	posts, err := r.postRepo.GetPublishPostsByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, post := range posts {
+		profile.Add(post, weights[post.ID])
+	}
+	// 已删除或撤回的帖子只记录为已读，阅读历史中的标签字段记录的是板块ID
+	for _, h := range histories {
+		if _, ok := profile.Seen[h.PostID]; ok {
+			continue
+		}
+		plateID, _ := strconv.ParseInt(h.Tags, 10, 64)
+		profile.Add(domain.Post{ID: h.PostID, PlateID: plateID}, domain.InterestWeightRead)
+	}
+	for _, id := range ids {
+		profile.Seen[id] = struct{}{}
+	}
+	return profile, nil
+}
+
+// loadCandidates 候选集为近期发布的帖子与热榜帖子，并计算归一化热度
+func (r *recommendService) loadCandidates(ctx context.Context) ([]recommendCandidate, error) {
+	days := viper.GetInt("recommend.candidate_days")
+	recent, err := r.postRepo.ListRecentPublishPosts(ctx, time.Now().AddDate(0, 0, -days), viper.GetInt("recommend.candidate_size"))
+	if err != nil {
+		return nil, err
+	}
+	hot, err := r.rankingRepo.GetTopN(ctx)
+	if err != nil {
+		r.l.Warn("获取热榜失败", zap.Error(err))
+	}
+
+	posts := make(map[uint]domain.Post, len(recent)+len(hot))
+	for _, p := range append(recent, hot...) {
+		posts[p.ID] = p
+	}
+	if len(posts) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uint, 0, len(posts))
+	for id := range posts {
+		ids = append(ids, id)
+	}
+	interactions, err := r.interactiveRepo.GetById(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	config, err := r.paramRepo.FindLastParameter(ctx)
+	if err != nil {
+		// 未配置时使用默认参数
+		config = domain.RankingParameter{}
+	}
+
+	hotScores := make(map[uint]float64, len(interactions))
+	var maxHot float64
+	for _, in := range interactions {
+		post, ok := posts[in.BizID]
+		if !ok {
+			continue
+		}
+		score := calculateScore(in.LikeCount, in.ReadCount, in.CollectCount, post.UpdatedAt, config)
+		hotScores[in.BizID] = score
+		maxHot = max(maxHot, score)
+	}
+
+	candidates := make([]recommendCandidate, 0, len(posts))
+	for id, post := range posts {
+		c := recommendCandidate{post: post}
+		if maxHot > 0 {
+			c.hot = hotScores[id] / maxHot
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates, nil
+}
+
+// ForYou 获取个性化推荐流
+func (r *recommendService) ForYou(ctx context.Context, uid int64, pagination domain.Pagination) ([]domain.Post, error) {
+	if pagination.Page <= 0 || pagination.Size == nil || *pagination.Size <= 0 {
+		return nil, errors.New("无效的分页参数")
+	}
+	offset := int64(pagination.Page-1) * *pagination.Size
+
+	hidden, err := r.relationRepo.HiddenUserIDs(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok, err := r.repo.GetRecommendations(ctx, uid, offset, *pagination.Size)
+	if err != nil {
+		r.l.Warn("获取推荐结果失败，回退到热榜", zap.Int64("uid", uid), zap.Error(err))
+	}
+	var posts []domain.Post
+	if err == nil && ok {
+		if posts, err = r.postRepo.GetPublishPostsByIds(ctx, ids); err != nil {
+			return nil, err
+		}
+	} else if posts, err = r.hotFallback(ctx, uid, offset, *pagination.Size); err != nil {
+		return nil, err
+	}
+
+	if posts, err = filterPrivatePosts(ctx, r.userRepo, r.relationRepo, uid, posts); err != nil {
+		return nil, err
+	}
+	result := make([]domain.Post, 0, len(posts))
+	for _, post := range posts {
+		if _, ok := hidden[post.Uid]; ok {
+			continue
+		}
+		result = append(result, post)
+	}
+	return result, nil
+}
+
+// hotFallback 离线结果不存在时回退到热榜，与离线推荐一样跳过已读、已互动及自己发布的帖子
+func (r *recommendService) hotFallback(ctx context.Context, uid, offset, size int64) ([]domain.Post, error) {
+	hot, err := r.rankingRepo.GetTopN(ctx)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := r.buildProfile(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	unseen := make([]domain.Post, 0, len(hot))
+	for _, post := range hot {
+		if _, seen := profile.Seen[post.ID]; seen || post.Uid == uid {
+			continue
+		}
+		unseen = append(unseen, post)
+	}
+	if offset >= int64(len(unseen)) {
+		return nil, nil
+	}
+	return unseen[offset:min(offset+size, int64(len(unseen)))], nil
+}
+
+// loadRecommendWeights 读取推荐得分权重配置
+func loadRecommendWeights() domain.RecommendWeights {
+	return domain.RecommendWeights{
+		Interest:          viper.GetFloat64("recommend.weights.interest"),
+		Hot:               viper.GetFloat64("recommend.weights.hot"),
+		Freshness:         viper.GetFloat64("recommend.weights.freshness"),
+		FreshnessHalfLife: viper.GetDuration("recommend.freshness_half_life"),
+	}
+}
+
+// topScores 保留得分最高的 n 个帖子
+func topScores(scores map[uint]float64, n int) map[uint]float64 {
+	if n <= 0 || len(scores) <= n {
+		return scores
+	}
+	ids := make([]uint, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return scores[ids[i]] > scores[ids[j]]
+	})
+	result := make(map[uint]float64, n)
+	for _, id := range ids[:n] {
+		result[id] = scores[id]
+	}
+	return result
+}
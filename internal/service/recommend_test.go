@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/repository"
+)
+
+// hotRankingRepo 只实现热榜查询
+type hotRankingRepo struct {
+	repository.RankingRepository
+	posts []domain.Post
+}
+
+func (r hotRankingRepo) GetTopN(context.Context) ([]domain.Post, error) {
+	return r.posts, nil
+}
+
+// readHistoryRepo 只实现阅读历史查询
+type readHistoryRepo struct {
+	repository.HistoryRepository
+	read []uint
+}
+
+func (r readHistoryRepo) GetHistory(context.Context, domain.Pagination) ([]domain.History, error) {
+	res := make([]domain.History, len(r.read))
+	for i, id := range r.read {
+		res[i] = domain.History{PostID: id}
+	}
+	return res, nil
+}
+
+// likedInteractiveRepo 只实现点赞与收藏列表查询
+type likedInteractiveRepo struct {
+	repository.InteractiveRepository
+	liked []uint
+}
+
+func (r likedInteractiveRepo) ListLikedPostIds(context.Context, int64, int) ([]uint, error) {
+	return r.liked, nil
+}
+
+func (r likedInteractiveRepo) ListCollectedPostIds(context.Context, int64, int) ([]uint, error) {
+	return nil, nil
+}
+
+// publishedPostRepo 只实现按ID批量查询已发布帖子
+type publishedPostRepo struct {
+	repository.PostRepository
+}
+
+func (publishedPostRepo) GetPublishPostsByIds(_ context.Context, ids []uint) ([]domain.Post, error) {
+	res := make([]domain.Post, len(ids))
+	for i, id := range ids {
+		res[i] = domain.Post{ID: id}
+	}
+	return res, nil
+}
+
+func TestHotFallbackSkipsSeenPosts(t *testing.T) {
+	const uid = 1
+	r := &recommendService{
+		rankingRepo: hotRankingRepo{posts: []domain.Post{
+			{ID: 1, Uid: 2}, {ID: 2, Uid: 2}, {ID: 3, Uid: uid}, {ID: 4, Uid: 3}, {ID: 5, Uid: 3}, {ID: 6, Uid: 3},
+		}},
+		historyRepo:     readHistoryRepo{read: []uint{1}},
+		interactiveRepo: likedInteractiveRepo{liked: []uint{4}},
+		postRepo:        publishedPostRepo{},
+	}
+
+	// 跳过已读(1)、已点赞(4)与自己发布(3)的帖子后按剩余热榜分页
+	tests := []struct {
+		offset, size int64
+		want         []uint
+	}{
+		{0, 2, []uint{2, 5}},
+		{2, 2, []uint{6}},
+		{4, 2, nil},
+	}
+	for _, tt := range tests {
+		got, err := r.hotFallback(context.Background(), uid, tt.offset, tt.size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := make([]uint, len(got))
+		for i, post := range got {
+			ids[i] = post.ID
+		}
+		if len(ids) != len(tt.want) {
+			t.Fatalf("hotFallback(%d, %d) = %v, want %v", tt.offset, tt.size, ids, tt.want)
+		}
+		for i := range ids {
+			if ids[i] != tt.want[i] {
+				t.Fatalf("hotFallback(%d, %d) = %v, want %v", tt.offset, tt.size, ids, tt.want)
+			}
+		}
+	}
+}
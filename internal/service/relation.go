@@ -2,26 +2,81 @@ package service
 
 import (
 	"context"
+	"errors"
+	"time"
+
 	"github.com/GoSimplicity/LinkMe/internal/domain"
 	"github.com/GoSimplicity/LinkMe/internal/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrUserBlocked 表示双方存在拉黑关系
+	ErrUserBlocked = errors.New("对方已将你拉黑或你已拉黑对方")
+	// ErrInvalidTarget 表示操作对象无效
+	ErrInvalidTarget = errors.New("无效的用户")
+	// ErrPrivateAccount 表示对方为私密账号且当前用户不是其关注者
+	ErrPrivateAccount = errors.New("该账号为私密账号，关注后可查看")
+)
+
+const (
+	// 主页展示的共同关注用户数
+	socialProofSize = 3
+	// 推荐关注的默认数量与上限
+	defaultSuggestLimit = 20
+	maxSuggestLimit     = 50
+	// 每个来源读取的候选人数
+	suggestCandidateSize = 200
+	// 板块活跃作者的统计窗口
+	suggestPlateWindow = 30 * 24 * time.Hour
 )
 
 type RelationService interface {
 	ListFollowerRelations(ctx context.Context, followerID int64, pagination domain.Pagination) ([]domain.Relation, error)
 	ListFolloweeRelations(ctx context.Context, followeeID int64, pagination domain.Pagination) ([]domain.Relation, error)
-	FollowUser(ctx context.Context, followerID, followeeID int64) error
+	// FollowUser 关注用户，对方为私密账号时发起关注请求并返回 pending 为 true
+	FollowUser(ctx context.Context, followerID, followeeID int64) (pending bool, err error)
 	CancelFollowUser(ctx context.Context, followerID, followeeID int64) error
 	GetFolloweeCount(ctx context.Context, UserID int64) (int64, error)
 	GetFollowerCount(ctx context.Context, UserID int64) (int64, error)
+	BlockUser(ctx context.Context, uid, targetID int64) error
+	UnblockUser(ctx context.Context, uid, targetID int64) error
+	MuteUser(ctx context.Context, uid, targetID int64) error
+	UnmuteUser(ctx context.Context, uid, targetID int64) error
+	ListBlockedUsers(ctx context.Context, uid int64, pagination domain.Pagination) ([]domain.UserBlock, error)
+	ListMutedUsers(ctx context.Context, uid int64, pagination domain.Pagination) ([]domain.UserBlock, error)
+	// ListFriends 分页获取互相关注的好友
+	ListFriends(ctx context.Context, uid int64, pagination domain.Pagination) ([]domain.Relation, error)
+	// GetSocialProof 获取 viewerID 关注的人中也关注了 targetID 的用户
+	GetSocialProof(ctx context.Context, viewerID, targetID int64) (domain.SocialProof, error)
+	// SuggestUsers 根据二度关系与共同板块推荐关注
+	SuggestUsers(ctx context.Context, uid int64, limit int) ([]domain.UserSuggestion, error)
+	// SetPrivate 设置私密账号，取消私密时自动通过全部待处理的关注请求
+	SetPrivate(ctx context.Context, uid int64, isPrivate bool) error
+	ListFollowRequests(ctx context.Context, uid int64, pagination domain.Pagination) ([]domain.FollowRequest, error)
+	ApproveFollowRequest(ctx context.Context, uid, requesterID int64) error
+	RejectFollowRequest(ctx context.Context, uid, requesterID int64) error
 }
 
 type relationService struct {
-	repo repository.RelationRepository
+	repo      repository.RelationRepository
+	feedRepo  repository.FeedRepository
+	plateRepo repository.PlateRepository
+	postRepo  repository.PostRepository
+	userRepo  repository.UserRepository
+	notifySvc NotificationService
+	l         *zap.Logger
 }
 
-func NewRelationService(repo repository.RelationRepository) RelationService {
+func NewRelationService(repo repository.RelationRepository, feedRepo repository.FeedRepository, plateRepo repository.PlateRepository, postRepo repository.PostRepository, userRepo repository.UserRepository, notifySvc NotificationService, l *zap.Logger) RelationService {
 	return &relationService{
-		repo: repo,
+		repo:      repo,
+		feedRepo:  feedRepo,
+		plateRepo: plateRepo,
+		postRepo:  postRepo,
+		userRepo:  userRepo,
+		notifySvc: notifySvc,
+		l:         l,
 	}
 }
 
@@ -30,7 +85,24 @@ func (r *relationService) ListFollowerRelations(ctx context.Context, followerID
 	// 计算偏移量
 	offset := int64(pagination.Page-1) * *pagination.Size
 	pagination.Offset = &offset
-	return r.repo.ListFollowerRelations(ctx, followerID, pagination)
+	relations, err := r.repo.ListFollowerRelations(ctx, followerID, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	// followerID 关注的用户中回关了 followerID 的即为互关
+	ids := make([]int64, len(relations))
+	for i, rel := range relations {
+		ids[i] = rel.FolloweeId
+	}
+	mutual, err := r.repo.FilterFollowers(ctx, followerID, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range relations {
+		_, relations[i].IsMutual = mutual[relations[i].FolloweeId]
+	}
+	return relations, nil
 }
 
 // ListFolloweeRelations 获取特定的关注关系信息
@@ -38,17 +110,106 @@ func (r *relationService) ListFolloweeRelations(ctx context.Context, followeeID
 	// 计算偏移量
 	offset := int64(pagination.Page-1) * *pagination.Size
 	pagination.Offset = &offset
-	return r.repo.ListFolloweeRelations(ctx, followeeID, pagination)
+	relations, err := r.repo.ListFolloweeRelations(ctx, followeeID, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	// followeeID 的粉丝中被 followeeID 回关的即为互关
+	ids := make([]int64, len(relations))
+	for i, rel := range relations {
+		ids[i] = rel.FollowerId
+	}
+	mutual, err := r.repo.FilterFollowees(ctx, followeeID, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range relations {
+		_, relations[i].IsMutual = mutual[relations[i].FollowerId]
+	}
+	return relations, nil
+}
+
+// FollowUser 关注用户，双方任一方拉黑对方时不允许关注
+func (r *relationService) FollowUser(ctx context.Context, followerID, followeeID int64) (bool, error) {
+	if followerID == followeeID {
+		return false, ErrInvalidTarget
+	}
+	if blocked, err := eitherBlocked(ctx, r.repo, followerID, followeeID); err != nil {
+		return false, err
+	} else if blocked {
+		return false, ErrUserBlocked
+	}
+
+	private, err := r.userRepo.PrivateUserIDs(ctx, []int64{followeeID})
+	if err != nil {
+		return false, err
+	}
+	if _, ok := private[followeeID]; ok {
+		if err := r.repo.RequestFollow(ctx, followerID, followeeID); err != nil {
+			return false, err
+		}
+		go r.notifyFollow(context.Background(), followeeID, followerID, "请求关注你")
+		return true, nil
+	}
+
+	if err := r.repo.FollowUser(ctx, followerID, followeeID); err != nil {
+		return false, err
+	}
+	r.backfillFeed(followerID, followeeID)
+	go r.notifyFollow(context.Background(), followeeID, followerID, "关注了你")
+	return false, nil
+}
+
+// notifyFollow 向 uid 发送关注相关提醒，uid 屏蔽了对方时不发送
+func (r *relationService) notifyFollow(ctx context.Context, uid, actorID int64, action string) {
+	hidden, err := r.repo.HiddenUserIDs(ctx, uid)
+	if err != nil {
+		r.l.Warn("获取屏蔽列表失败", zap.Int64("uid", uid), zap.Error(err))
+		return
+	}
+	if _, ok := hidden[actorID]; ok {
+		return
+	}
+	content := "有人" + action
+	if u, err := r.userRepo.FindByID(ctx, actorID); err == nil {
+		content = u.Username + " " + action
+	}
+	if err := r.notifySvc.Notify(ctx, domain.Notification{
+		UserID:  uid,
+		Type:    domain.NotificationFollow,
+		Title:   "有人" + action,
+		Content: content,
+	}); err != nil {
+		r.l.Warn("发送关注提醒失败", zap.Int64("uid", uid), zap.Error(err))
+	}
 }
 
-// FollowUser 关注用户
-func (r *relationService) FollowUser(ctx context.Context, followerID, followeeID int64) error {
-	return r.repo.FollowUser(ctx, followerID, followeeID)
+// backfillFeed 异步回填被关注者的近期帖子到关注流
+func (r *relationService) backfillFeed(uid, followeeID int64) {
+	go func() {
+		if err := r.feedRepo.Backfill(context.Background(), uid, followeeID); err != nil {
+			r.l.Warn("回填关注流失败", zap.Int64("uid", uid), zap.Int64("followee", followeeID), zap.Error(err))
+		}
+	}()
 }
 
 // CancelFollowUser 取消关注用户
 func (r *relationService) CancelFollowUser(ctx context.Context, followerID, followeeID int64) error {
-	return r.repo.CancelFollowUser(ctx, followerID, followeeID)
+	if err := r.repo.CancelFollowUser(ctx, followerID, followeeID); err != nil {
+		return err
+	}
+	r.removeFromFeed(followerID, followeeID)
+	return nil
+}
+
+// removeFromFeed 异步从 uid 的关注流中移除 authorID 的帖子
+func (r *relationService) removeFromFeed(uid, authorID int64) {
+	go func() {
+		if err := r.feedRepo.RemoveAuthor(context.Background(), uid, authorID); err != nil {
+			r.l.Warn("清理关注流失败", zap.Int64("uid", uid), zap.Int64("author", authorID), zap.Error(err))
+		}
+	}()
 }
 
 func (r *relationService) GetFolloweeCount(ctx context.Context, UserID int64) (int64, error) {
@@ -58,3 +219,262 @@ func (r *relationService) GetFolloweeCount(ctx context.Context, UserID int64) (i
 func (r *relationService) GetFollowerCount(ctx context.Context, UserID int64) (int64, error) {
 	return r.repo.GetFollowerCount(ctx, UserID)
 }
+
+// BlockUser 拉黑用户
+func (r *relationService) BlockUser(ctx context.Context, uid, targetID int64) error {
+	if targetID <= 0 || uid == targetID {
+		return ErrInvalidTarget
+	}
+	if err := r.repo.AddBlock(ctx, uid, targetID, domain.BlockTypeBlock); err != nil {
+		return err
+	}
+	// 拉黑会解除双向关注，同步清理双方的关注流
+	r.removeFromFeed(uid, targetID)
+	r.removeFromFeed(targetID, uid)
+	return nil
+}
+
+// UnblockUser 取消拉黑
+func (r *relationService) UnblockUser(ctx context.Context, uid, targetID int64) error {
+	return r.repo.RemoveBlock(ctx, uid, targetID, domain.BlockTypeBlock)
+}
+
+// MuteUser 屏蔽用户
+func (r *relationService) MuteUser(ctx context.Context, uid, targetID int64) error {
+	if targetID <= 0 || uid == targetID {
+		return ErrInvalidTarget
+	}
+	return r.repo.AddBlock(ctx, uid, targetID, domain.BlockTypeMute)
+}
+
+// UnmuteUser 取消屏蔽
+func (r *relationService) UnmuteUser(ctx context.Context, uid, targetID int64) error {
+	return r.repo.RemoveBlock(ctx, uid, targetID, domain.BlockTypeMute)
+}
+
+// ListBlockedUsers 获取拉黑列表
+func (r *relationService) ListBlockedUsers(ctx context.Context, uid int64, pagination domain.Pagination) ([]domain.UserBlock, error) {
+	if pagination.Page <= 0 || pagination.Size == nil || *pagination.Size <= 0 {
+		return nil, errors.New("无效的分页参数")
+	}
+	offset := int64(pagination.Page-1) * *pagination.Size
+	pagination.Offset = &offset
+	return r.repo.ListBlocks(ctx, uid, domain.BlockTypeBlock, pagination)
+}
+
+// ListMutedUsers 获取屏蔽列表
+func (r *relationService) ListMutedUsers(ctx context.Context, uid int64, pagination domain.Pagination) ([]domain.UserBlock, error) {
+	if pagination.Page <= 0 || pagination.Size == nil || *pagination.Size <= 0 {
+		return nil, errors.New("无效的分页参数")
+	}
+	offset := int64(pagination.Page-1) * *pagination.Size
+	pagination.Offset = &offset
+	return r.repo.ListBlocks(ctx, uid, domain.BlockTypeMute, pagination)
+}
+
+// ListFriends 分页获取互相关注的好友
+func (r *relationService) ListFriends(ctx context.Context, uid int64, pagination domain.Pagination) ([]domain.Relation, error) {
+	if pagination.Page <= 0 || pagination.Size == nil || *pagination.Size <= 0 {
+		return nil, errors.New("无效的分页参数")
+	}
+	offset := int64(pagination.Page-1) * *pagination.Size
+	pagination.Offset = &offset
+	ids, err := r.repo.ListMutualIDs(ctx, uid, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	friends := make([]domain.Relation, len(ids))
+	for i, id := range ids {
+		friends[i] = domain.Relation{
+			FollowerId: uid,
+			FolloweeId: id,
+			IsMutual:   true,
+		}
+	}
+	return friends, nil
+}
+
+// GetSocialProof 获取共同关注，自己的主页不展示
+func (r *relationService) GetSocialProof(ctx context.Context, viewerID, targetID int64) (domain.SocialProof, error) {
+	proof := domain.SocialProof{UserIDs: []int64{}}
+	if targetID <= 0 || viewerID == targetID {
+		return proof, nil
+	}
+
+	ids, total, err := r.repo.CommonFollowees(ctx, viewerID, targetID, socialProofSize)
+	if err != nil {
+		return domain.SocialProof{}, err
+	}
+	hidden, err := r.repo.HiddenUserIDs(ctx, viewerID)
+	if err != nil {
+		return domain.SocialProof{}, err
+	}
+	for _, id := range ids {
+		if _, ok := hidden[id]; ok {
+			continue
+		}
+		proof.UserIDs = append(proof.UserIDs, id)
+	}
+	proof.Total = total
+	return proof, nil
+}
+
+// SuggestUsers 推荐关注，排除自己、已关注以及屏蔽拉黑的用户
+func (r *relationService) SuggestUsers(ctx context.Context, uid int64, limit int) ([]domain.UserSuggestion, error) {
+	if limit <= 0 {
+		limit = defaultSuggestLimit
+	}
+	if limit > maxSuggestLimit {
+		limit = maxSuggestLimit
+	}
+
+	mutual, err := r.repo.SecondDegree(ctx, uid, suggestCandidateSize)
+	if err != nil {
+		return nil, err
+	}
+	plates, err := r.activePlateAuthors(ctx, uid)
+	if err != nil {
+		// 板块来源失败时仍返回二度关系推荐
+		r.l.Warn("获取板块活跃作者失败", zap.Int64("uid", uid), zap.Error(err))
+	}
+
+	exclude, err := r.repo.HiddenUserIDs(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	followees, err := r.repo.ListFolloweeIDs(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range followees {
+		exclude[id] = struct{}{}
+	}
+	exclude[uid] = struct{}{}
+
+	return domain.RankSuggestions(mutual, plates, exclude, limit), nil
+}
+
+// activePlateAuthors 获取用户订阅或发过帖的板块中近期活跃的作者
+func (r *relationService) activePlateAuthors(ctx context.Context, uid int64) (map[int64]int64, error) {
+	subscribed, err := r.plateRepo.ListSubscribedPlateIDs(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	posted, err := r.postRepo.ListPublishPlateIdsByAuthor(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	plateIds := append(subscribed, posted...)
+	if len(plateIds) == 0 {
+		return nil, nil
+	}
+	return r.postRepo.ActiveAuthorsByPlates(ctx, plateIds, time.Now().Add(-suggestPlateWindow), suggestCandidateSize)
+}
+
+// SetPrivate 设置私密账号
+func (r *relationService) SetPrivate(ctx context.Context, uid int64, isPrivate bool) error {
+	if err := r.userRepo.UpdatePrivacy(ctx, uid, isPrivate); err != nil {
+		return err
+	}
+	if isPrivate {
+		return nil
+	}
+
+	approved, err := r.repo.ApproveAllFollowRequests(ctx, uid)
+	if err != nil {
+		return err
+	}
+	for _, id := range approved {
+		r.backfillFeed(id, uid)
+	}
+	return nil
+}
+
+// ListFollowRequests 分页获取收到的关注请求
+func (r *relationService) ListFollowRequests(ctx context.Context, uid int64, pagination domain.Pagination) ([]domain.FollowRequest, error) {
+	if pagination.Page <= 0 || pagination.Size == nil || *pagination.Size <= 0 {
+		return nil, errors.New("无效的分页参数")
+	}
+	offset := int64(pagination.Page-1) * *pagination.Size
+	pagination.Offset = &offset
+	return r.repo.ListFollowRequests(ctx, uid, pagination)
+}
+
+// ApproveFollowRequest 通过关注请求
+func (r *relationService) ApproveFollowRequest(ctx context.Context, uid, requesterID int64) error {
+	if err := r.repo.ApproveFollowRequest(ctx, requesterID, uid); err != nil {
+		return err
+	}
+	r.backfillFeed(requesterID, uid)
+	go r.notifyFollow(context.Background(), requesterID, uid, "通过了你的关注请求")
+	return nil
+}
+
+// RejectFollowRequest 拒绝关注请求
+func (r *relationService) RejectFollowRequest(ctx context.Context, uid, requesterID int64) error {
+	return r.repo.RejectFollowRequest(ctx, requesterID, uid)
+}
+
+// canViewUser 私密账号的内容仅对本人及已通过的关注者可见
+func canViewUser(ctx context.Context, userRepo repository.UserRepository, relationRepo repository.RelationRepository, viewer, owner int64) (bool, error) {
+	if viewer == owner {
+		return true, nil
+	}
+	visible, err := filterPrivatePosts(ctx, userRepo, relationRepo, viewer, []domain.Post{{Uid: owner}})
+	if err != nil {
+		return false, err
+	}
+	return len(visible) > 0, nil
+}
+
+// filterPrivatePosts 过滤 viewer 无权查看的私密账号帖子，viewer 为 0 时视为未登录
+func filterPrivatePosts(ctx context.Context, userRepo repository.UserRepository, relationRepo repository.RelationRepository, viewer int64, posts []domain.Post) ([]domain.Post, error) {
+	authors := make([]int64, 0, len(posts))
+	seen := make(map[int64]struct{}, len(posts))
+	for _, post := range posts {
+		if _, ok := seen[post.Uid]; ok || post.Uid == viewer {
+			continue
+		}
+		seen[post.Uid] = struct{}{}
+		authors = append(authors, post.Uid)
+	}
+	if len(authors) == 0 {
+		return posts, nil
+	}
+
+	private, err := userRepo.PrivateUserIDs(ctx, authors)
+	if err != nil || len(private) == 0 {
+		return posts, err
+	}
+	followed := map[int64]struct{}{}
+	if viewer > 0 {
+		ids := make([]int64, 0, len(private))
+		for id := range private {
+			ids = append(ids, id)
+		}
+		if followed, err = relationRepo.FilterFollowees(ctx, viewer, ids); err != nil {
+			return nil, err
+		}
+	}
+
+	result := make([]domain.Post, 0, len(posts))
+	for _, post := range posts {
+		if _, ok := private[post.Uid]; ok && post.Uid != viewer {
+			if _, ok := followed[post.Uid]; !ok {
+				continue
+			}
+		}
+		result = append(result, post)
+	}
+	return result, nil
+}
+
+// eitherBlocked 判断两个用户之间是否存在任意方向的拉黑
+func eitherBlocked(ctx context.Context, repo repository.RelationRepository, a, b int64) (bool, error) {
+	blocked, err := repo.IsBlocked(ctx, a, b)
+	if err != nil || blocked {
+		return blocked, err
+	}
+	return repo.IsBlocked(ctx, b, a)
+}
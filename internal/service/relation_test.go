@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/repository"
+)
+
+// privateUserRepo 只实现私密账号查询
+type privateUserRepo struct {
+	repository.UserRepository
+	private map[int64]struct{}
+}
+
+func (r privateUserRepo) PrivateUserIDs(_ context.Context, uids []int64) (map[int64]struct{}, error) {
+	res := map[int64]struct{}{}
+	for _, uid := range uids {
+		if _, ok := r.private[uid]; ok {
+			res[uid] = struct{}{}
+		}
+	}
+	return res, nil
+}
+
+// followeeRelationRepo 只实现关注关系查询
+type followeeRelationRepo struct {
+	repository.RelationRepository
+	follows map[int64][]int64
+}
+
+func (r followeeRelationRepo) FilterFollowees(_ context.Context, userID int64, ids []int64) (map[int64]struct{}, error) {
+	res := map[int64]struct{}{}
+	for _, followee := range r.follows[userID] {
+		for _, id := range ids {
+			if id == followee {
+				res[id] = struct{}{}
+			}
+		}
+	}
+	return res, nil
+}
+
+func TestPrivatePostVisibility(t *testing.T) {
+	const (
+		owner    = 1 // 私密账号
+		public   = 2
+		follower = 3
+		stranger = 4
+	)
+	userRepo := privateUserRepo{private: map[int64]struct{}{owner: {}}}
+	relationRepo := followeeRelationRepo{follows: map[int64][]int64{follower: {owner}}}
+	ctx := context.Background()
+
+	posts := []domain.Post{{ID: 10, Uid: owner}, {ID: 11, Uid: public}, {ID: 12, Uid: owner}}
+	searched := []domain.PostSearch{{Id: 10, AuthorId: owner}, {Id: 11, AuthorId: public}}
+
+	tests := []struct {
+		name        string
+		viewer      int64
+		wantPosts   int
+		wantSearch  int
+		wantCanView bool
+	}{
+		{"owner", owner, 3, 2, true},
+		{"follower", follower, 3, 2, true},
+		{"non-follower", stranger, 1, 1, false},
+		{"anonymous", 0, 1, 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := filterPrivatePosts(ctx, userRepo, relationRepo, tt.viewer, posts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != tt.wantPosts {
+				t.Fatalf("filterPrivatePosts() returned %d posts, want %d", len(got), tt.wantPosts)
+			}
+
+			found, err := filterPrivateSearchPosts(ctx, userRepo, relationRepo, tt.viewer, searched)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(found) != tt.wantSearch {
+				t.Fatalf("filterPrivateSearchPosts() returned %d posts, want %d", len(found), tt.wantSearch)
+			}
+			if tt.wantSearch == 1 && found[0].AuthorId != public {
+				t.Fatalf("unexpected search result %+v", found[0])
+			}
+
+			ok, err := canViewUser(ctx, userRepo, relationRepo, tt.viewer, owner)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != tt.wantCanView {
+				t.Fatalf("canViewUser() = %v, want %v", ok, tt.wantCanView)
+			}
+		})
+	}
+}
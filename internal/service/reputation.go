@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/repository"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrLinksNotAllowed 表示当前等级不允许发布外链
+	ErrLinksNotAllowed = errors.New("当前等级暂不能发布包含链接的内容")
+	// ErrLotteryNotAllowed 表示当前等级不允许创建活动
+	ErrLotteryNotAllowed = errors.New("当前等级暂不能创建活动")
+)
+
+type ReputationService interface {
+	// Record 按来源记录积分，同一来源只记录一次，积分为0的来源忽略
+	Record(ctx context.Context, uid int64, event domain.ReputationEvent, bizId, actorId int64) error
+	// RecordPostReceived 帖子被点赞或收藏时为作者记录积分，作者本人操作不计
+	RecordPostReceived(ctx context.Context, event domain.ReputationEvent, postId uint, actorId int64) error
+	GetReputation(ctx context.Context, uid int64) (domain.UserReputation, error)
+	ListLogs(ctx context.Context, uid int64, pagination domain.Pagination) ([]domain.ReputationLog, error)
+	// HasPrivilege 判断用户当前等级是否拥有指定权限
+	HasPrivilege(ctx context.Context, uid int64, p domain.Privilege) (bool, error)
+	ListLevels(ctx context.Context) ([]domain.ReputationLevel, error)
+	SaveLevels(ctx context.Context, levels []domain.ReputationLevel) error
+}
+
+type reputationService struct {
+	repo     repository.ReputationRepository
+	postRepo repository.PostRepository
+	auditSvc AuditService
+	l        *zap.Logger
+}
+
+func NewReputationService(repo repository.ReputationRepository, postRepo repository.PostRepository, auditSvc AuditService, l *zap.Logger) ReputationService {
+	return &reputationService{
+		repo:     repo,
+		postRepo: postRepo,
+		auditSvc: auditSvc,
+		l:        l,
+	}
+}
+
+// Record 记录积分
+func (r *reputationService) Record(ctx context.Context, uid int64, event domain.ReputationEvent, bizId, actorId int64) error {
+	points := reputationPoints(event)
+	if uid <= 0 || points == 0 {
+		return nil
+	}
+
+	added, err := r.repo.AddPoints(ctx, domain.ReputationLog{
+		UserID:  uid,
+		Event:   event,
+		BizID:   bizId,
+		ActorID: actorId,
+		Points:  points,
+	})
+	if err != nil {
+		return err
+	}
+	if added {
+		r.l.Debug("记录积分", zap.Int64("uid", uid), zap.String("event", string(event)), zap.Int64("points", points))
+	}
+	return nil
+}
+
+// RecordPostReceived 为帖子作者记录积分
+func (r *reputationService) RecordPostReceived(ctx context.Context, event domain.ReputationEvent, postId uint, actorId int64) error {
+	post, err := r.postRepo.GetPublishPostById(ctx, postId)
+	if err != nil {
+		return err
+	}
+	if post.Uid == actorId {
+		return nil
+	}
+	return r.Record(ctx, post.Uid, event, int64(postId), actorId)
+}
+
+// GetReputation 获取用户积分与等级
+func (r *reputationService) GetReputation(ctx context.Context, uid int64) (domain.UserReputation, error) {
+	points, err := r.repo.GetPoints(ctx, uid)
+	if err != nil {
+		return domain.UserReputation{}, err
+	}
+	levels, err := r.repo.ListLevels(ctx)
+	if err != nil {
+		return domain.UserReputation{}, err
+	}
+
+	level, next := domain.LevelFor(points, levels)
+	return domain.UserReputation{
+		UserID:        uid,
+		Points:        points,
+		Level:         level,
+		NextMinPoints: next,
+	}, nil
+}
+
+// ListLogs 获取积分流水
+func (r *reputationService) ListLogs(ctx context.Context, uid int64, pagination domain.Pagination) ([]domain.ReputationLog, error) {
+	if pagination.Page <= 0 || pagination.Size == nil || *pagination.Size <= 0 {
+		return nil, errors.New("无效的分页参数")
+	}
+	offset := int64(pagination.Page-1) * *pagination.Size
+	pagination.Offset = &offset
+	return r.repo.ListLogs(ctx, uid, pagination)
+}
+
+// HasPrivilege 判断等级权限
+func (r *reputationService) HasPrivilege(ctx context.Context, uid int64, p domain.Privilege) (bool, error) {
+	rep, err := r.GetReputation(ctx, uid)
+	if err != nil {
+		return false, err
+	}
+	return rep.Level.Allows(p), nil
+}
+
+// ListLevels 获取等级配置
+func (r *reputationService) ListLevels(ctx context.Context) ([]domain.ReputationLevel, error) {
+	return r.repo.ListLevels(ctx)
+}
+
+// SaveLevels 保存管理员配置的等级
+func (r *reputationService) SaveLevels(ctx context.Context, levels []domain.ReputationLevel) error {
+	sort.SliceStable(levels, func(i, j int) bool {
+		return levels[i].MinPoints < levels[j].MinPoints
+	})
+	if err := domain.ValidateLevels(levels); err != nil {
+		return err
+	}
+	before, _ := r.repo.ListLevels(ctx)
+	if err := r.repo.SaveLevels(ctx, levels); err != nil {
+		return err
+	}
+	r.auditSvc.Record(ctx, domain.AuditEntry{
+		Action:     domain.AuditReputationLevels,
+		TargetType: domain.AuditTargetReputation,
+		Before:     before,
+		After:      levels,
+	})
+	return nil
+}
+
+// reputationPoints 读取来源对应的积分，默认值统一在 config/viper.go 中设置
+func reputationPoints(event domain.ReputationEvent) int64 {
+	return viper.GetInt64("reputation.points." + string(event))
+}
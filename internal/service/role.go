@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/GoSimplicity/LinkMe/internal/domain"
 	"github.com/GoSimplicity/LinkMe/internal/repository"
@@ -28,13 +29,15 @@ type RoleService interface {
 type roleService struct {
 	repo           repository.RoleRepository
 	permissionRepo repository.PermissionRepository
+	auditSvc       AuditService
 	l              *zap.Logger
 }
 
-func NewRoleService(repo repository.RoleRepository, permissionRepo repository.PermissionRepository, l *zap.Logger) RoleService {
+func NewRoleService(repo repository.RoleRepository, permissionRepo repository.PermissionRepository, auditSvc AuditService, l *zap.Logger) RoleService {
 	return &roleService{
 		repo:           repo,
 		permissionRepo: permissionRepo,
+		auditSvc:       auditSvc,
 		l:              l,
 	}
 }
@@ -46,7 +49,22 @@ func (r *roleService) CreateRole(ctx context.Context, role *domain.Role, menuIds
 		return ErrInvalidRole
 	}
 
-	return r.repo.CreateRole(ctx, role, menuIds, apiIds)
+	id, err := r.repo.CreateRole(ctx, role, menuIds, apiIds)
+	if err != nil {
+		return err
+	}
+	role.ID = id
+	r.auditSvc.Record(ctx, domain.AuditEntry{
+		Action:     domain.AuditRoleCreate,
+		TargetType: domain.AuditTargetRole,
+		TargetID:   fmt.Sprint(id),
+		After: map[string]interface{}{
+			"role":    role,
+			"menuIds": menuIds,
+			"apiIds":  apiIds,
+		},
+	})
+	return nil
 }
 
 // GetRoleById 根据ID获取角色信息
@@ -66,7 +84,18 @@ func (r *roleService) UpdateRole(ctx context.Context, role *domain.Role) error {
 		return ErrInvalidRole
 	}
 
-	return r.repo.UpdateRole(ctx, role)
+	before, _ := r.repo.GetRoleById(ctx, role.ID)
+	if err := r.repo.UpdateRole(ctx, role); err != nil {
+		return err
+	}
+	r.auditSvc.Record(ctx, domain.AuditEntry{
+		Action:     domain.AuditRoleUpdate,
+		TargetType: domain.AuditTargetRole,
+		TargetID:   fmt.Sprint(role.ID),
+		Before:     before,
+		After:      role,
+	})
+	return nil
 }
 
 // DeleteRole 删除角色及其相关权限
@@ -76,13 +105,24 @@ func (r *roleService) DeleteRole(ctx context.Context, id int) error {
 		return ErrInvalidID
 	}
 
+	before, _ := r.repo.GetRoleById(ctx, id)
+
 	// 删除角色前先删除相关权限
 	if err := r.permissionRepo.RemoveRolePermissions(ctx, id); err != nil {
 		r.l.Error("删除角色API权限失败", zap.Error(err))
 		return err
 	}
 
-	return r.repo.DeleteRole(ctx, id)
+	if err := r.repo.DeleteRole(ctx, id); err != nil {
+		return err
+	}
+	r.auditSvc.Record(ctx, domain.AuditEntry{
+		Action:     domain.AuditRoleDelete,
+		TargetType: domain.AuditTargetRole,
+		TargetID:   fmt.Sprint(id),
+		Before:     before,
+	})
+	return nil
 }
 
 // ListRoles 分页获取角色列表
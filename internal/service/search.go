@@ -9,18 +9,23 @@ import (
 )
 
 type searchService struct {
-	repo repository.SearchRepository
+	repo         repository.SearchRepository
+	userRepo     repository.UserRepository
+	relationRepo repository.RelationRepository
 }
 
 type SearchService interface {
-	SearchPosts(ctx context.Context, expression string) ([]domain.PostSearch, error)
+	// SearchPosts 搜索帖子，过滤 uid 无权查看的私密账号帖子
+	SearchPosts(ctx context.Context, uid int64, expression string) ([]domain.PostSearch, error)
 	SearchUsers(ctx context.Context, expression string) ([]domain.UserSearch, error)
 	SearchComments(ctx context.Context, expression string) ([]domain.CommentSearch, error)
 }
 
-func NewSearchService(repo repository.SearchRepository) SearchService {
+func NewSearchService(repo repository.SearchRepository, userRepo repository.UserRepository, relationRepo repository.RelationRepository) SearchService {
 	return &searchService{
-		repo: repo,
+		repo:         repo,
+		userRepo:     userRepo,
+		relationRepo: relationRepo,
 	}
 }
 func (s *searchService) SearchComments(ctx context.Context, expression string) ([]domain.CommentSearch, error) {
@@ -43,7 +48,7 @@ func (s *searchService) SearchComments(ctx context.Context, expression string) (
 	}
 	return comments, nil
 }
-func (s *searchService) SearchPosts(ctx context.Context, expression string) ([]domain.PostSearch, error) {
+func (s *searchService) SearchPosts(ctx context.Context, uid int64, expression string) ([]domain.PostSearch, error) {
 	// 将表达式拆分为关键字数组
 	keywords := strings.Split(expression, " ")
 
@@ -67,7 +72,7 @@ func (s *searchService) SearchPosts(ctx context.Context, expression string) ([]d
 		return nil, err
 	}
 
-	return posts, nil
+	return filterPrivateSearchPosts(ctx, s.userRepo, s.relationRepo, uid, posts)
 }
 
 func (s *searchService) SearchUsers(ctx context.Context, expression string) ([]domain.UserSearch, error) {
@@ -95,3 +100,30 @@ func (s *searchService) SearchUsers(ctx context.Context, expression string) ([]d
 
 	return users, nil
 }
+
+// filterPrivateSearchPosts 过滤 viewer 无权查看的私密账号帖子搜索结果
+func filterPrivateSearchPosts(ctx context.Context, userRepo repository.UserRepository, relationRepo repository.RelationRepository, viewer int64, posts []domain.PostSearch) ([]domain.PostSearch, error) {
+	candidates := make([]domain.Post, len(posts))
+	for i, post := range posts {
+		candidates[i] = domain.Post{ID: post.Id, Uid: post.AuthorId}
+	}
+	visible, err := filterPrivatePosts(ctx, userRepo, relationRepo, viewer, candidates)
+	if err != nil {
+		return nil, err
+	}
+	if len(visible) == len(posts) {
+		return posts, nil
+	}
+
+	allowed := make(map[uint]struct{}, len(visible))
+	for _, post := range visible {
+		allowed[post.ID] = struct{}{}
+	}
+	result := make([]domain.PostSearch, 0, len(visible))
+	for _, post := range posts {
+		if _, ok := allowed[post.Id]; ok {
+			result = append(result, post)
+		}
+	}
+	return result, nil
+}
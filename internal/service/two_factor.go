@@ -0,0 +1,379 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/repository"
+	"github.com/GoSimplicity/LinkMe/internal/repository/dao"
+	"github.com/GoSimplicity/LinkMe/pkg/totp"
+	"github.com/casbin/casbin/v2"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrTwoFactorEnabled 表示已开启两步验证
+	ErrTwoFactorEnabled = errors.New("已开启两步验证")
+	// ErrTwoFactorNotEnabled 表示未开启两步验证
+	ErrTwoFactorNotEnabled = errors.New("未开启两步验证")
+	// ErrTwoFactorRequired 表示账号被强制要求开启两步验证，不能关闭
+	ErrTwoFactorRequired = errors.New("管理员账号必须开启两步验证")
+	// ErrInvalidTwoFactorCode 表示验证码或恢复码错误
+	ErrInvalidTwoFactorCode = errors.New("验证码错误")
+	// ErrTwoFactorChallenge 表示登录挑战不存在、已过期或失败次数过多
+	ErrTwoFactorChallenge = errors.New("两步验证已过期，请重新登录")
+)
+
+const (
+	defaultTwoFactorIssuer = "LinkMe"
+	defaultChallengeTTL    = 5 * time.Minute
+	defaultRecoveryCodes   = 10
+	// 单个登录挑战允许的最大失败次数
+	maxChallengeAttempts = 5
+	// 验证码允许的前后时钟偏差周期数
+	totpSkew = 1
+)
+
+type TwoFactorService interface {
+	Status(ctx context.Context, uid int64) (domain.TwoFactorStatus, error)
+	// Setup 生成新密钥，需调用 Enable 验证后才会生效
+	Setup(ctx context.Context, uid int64) (domain.TOTPSetup, error)
+	// Enable 验证首个验证码并启用，返回一次性恢复码
+	Enable(ctx context.Context, uid int64, code string) ([]string, error)
+	// Disable 关闭两步验证，code 可以是验证码或恢复码
+	Disable(ctx context.Context, uid int64, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, uid int64, code string) ([]string, error)
+	// BeginLogin 密码校验通过后调用，需要两步验证时返回挑战
+	BeginLogin(ctx context.Context, uid int64) (domain.TwoFactorChallenge, bool, error)
+	// CompleteLogin 校验挑战与验证码，首次绑定时同时启用并返回恢复码
+	CompleteLogin(ctx context.Context, token, code string) (int64, []string, error)
+}
+
+type twoFactorService struct {
+	repo     repository.TwoFactorRepository
+	userRepo repository.UserRepository
+	ce       *casbin.SyncedEnforcer
+	l        *zap.Logger
+}
+
+func NewTwoFactorService(repo repository.TwoFactorRepository, userRepo repository.UserRepository, ce *casbin.SyncedEnforcer, l *zap.Logger) TwoFactorService {
+	return &twoFactorService{
+		repo:     repo,
+		userRepo: userRepo,
+		ce:       ce,
+		l:        l,
+	}
+}
+
+// Status 获取两步验证状态
+func (t *twoFactorService) Status(ctx context.Context, uid int64) (domain.TwoFactorStatus, error) {
+	status := domain.TwoFactorStatus{Required: t.required(uid)}
+
+	tf, err := t.repo.Find(ctx, uid)
+	if err != nil {
+		if errors.Is(err, dao.ErrTwoFactorNotFound) {
+			return status, nil
+		}
+		return status, err
+	}
+	if !tf.Enabled {
+		return status, nil
+	}
+
+	status.Enabled = true
+	status.RecoveryCodesLeft, err = t.repo.CountRecoveryCodes(ctx, uid)
+	return status, err
+}
+
+// Setup 生成密钥与绑定地址
+func (t *twoFactorService) Setup(ctx context.Context, uid int64) (domain.TOTPSetup, error) {
+	tf, err := t.repo.Find(ctx, uid)
+	if err != nil && !errors.Is(err, dao.ErrTwoFactorNotFound) {
+		return domain.TOTPSetup{}, err
+	}
+	if tf.Enabled {
+		return domain.TOTPSetup{}, ErrTwoFactorEnabled
+	}
+
+	u, err := t.userRepo.FindByID(ctx, uid)
+	if err != nil {
+		return domain.TOTPSetup{}, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return domain.TOTPSetup{}, err
+	}
+	if err := t.repo.SaveSecret(ctx, uid, secret); err != nil {
+		return domain.TOTPSetup{}, err
+	}
+
+	issuer := viper.GetString("two_factor.issuer")
+	if issuer == "" {
+		issuer = defaultTwoFactorIssuer
+	}
+	return domain.TOTPSetup{
+		Secret: secret,
+		URI:    totp.URI(issuer, u.Username, secret),
+	}, nil
+}
+
+// Enable 启用两步验证
+func (t *twoFactorService) Enable(ctx context.Context, uid int64, code string) ([]string, error) {
+	tf, err := t.repo.Find(ctx, uid)
+	if err != nil {
+		if errors.Is(err, dao.ErrTwoFactorNotFound) {
+			return nil, ErrTwoFactorNotEnabled
+		}
+		return nil, err
+	}
+	if tf.Enabled {
+		return nil, ErrTwoFactorEnabled
+	}
+	return t.enable(ctx, tf, code)
+}
+
+// Disable 关闭两步验证
+func (t *twoFactorService) Disable(ctx context.Context, uid int64, code string) error {
+	if t.required(uid) {
+		return ErrTwoFactorRequired
+	}
+
+	tf, err := t.enabled(ctx, uid)
+	if err != nil {
+		return err
+	}
+	if err := t.verify(ctx, tf, code, true); err != nil {
+		return err
+	}
+	return t.repo.Disable(ctx, uid)
+}
+
+// RegenerateRecoveryCodes 重新生成恢复码
+func (t *twoFactorService) RegenerateRecoveryCodes(ctx context.Context, uid int64, code string) ([]string, error) {
+	tf, err := t.enabled(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	// 重新生成恢复码需要认证器验证码，避免用恢复码换取新的恢复码
+	if err := t.verify(ctx, tf, code, false); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := newRecoveryCodes(t.recoveryCodeCount())
+	if err != nil {
+		return nil, err
+	}
+	if err := t.repo.ReplaceRecoveryCodes(ctx, uid, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// BeginLogin 判断登录是否需要两步验证，需要时创建登录挑战
+func (t *twoFactorService) BeginLogin(ctx context.Context, uid int64) (domain.TwoFactorChallenge, bool, error) {
+	tf, err := t.repo.Find(ctx, uid)
+	if err != nil && !errors.Is(err, dao.ErrTwoFactorNotFound) {
+		return domain.TwoFactorChallenge{}, false, err
+	}
+
+	var challenge domain.TwoFactorChallenge
+	if !tf.Enabled {
+		if !t.required(uid) {
+			return domain.TwoFactorChallenge{}, false, nil
+		}
+		// 被强制要求但尚未绑定，在登录流程中完成绑定
+		setup, err := t.Setup(ctx, uid)
+		if err != nil {
+			return domain.TwoFactorChallenge{}, false, err
+		}
+		challenge.SetupRequired = true
+		challenge.Setup = &setup
+	}
+
+	token, err := randomHex(16)
+	if err != nil {
+		return domain.TwoFactorChallenge{}, false, err
+	}
+	ttl := viper.GetDuration("two_factor.challenge_ttl")
+	if ttl <= 0 {
+		ttl = defaultChallengeTTL
+	}
+	if err := t.repo.SaveChallenge(ctx, token, uid, ttl); err != nil {
+		return domain.TwoFactorChallenge{}, false, err
+	}
+
+	challenge.Token = token
+	return challenge, true, nil
+}
+
+// CompleteLogin 完成两步验证登录
+func (t *twoFactorService) CompleteLogin(ctx context.Context, token, code string) (int64, []string, error) {
+	uid, err := t.repo.GetChallenge(ctx, token)
+	if err != nil {
+		return 0, nil, ErrTwoFactorChallenge
+	}
+
+	tf, err := t.repo.Find(ctx, uid)
+	if err != nil {
+		if errors.Is(err, dao.ErrTwoFactorNotFound) {
+			return 0, nil, ErrTwoFactorChallenge
+		}
+		return 0, nil, err
+	}
+
+	var codes []string
+	if tf.Enabled {
+		err = t.verify(ctx, tf, code, true)
+	} else {
+		codes, err = t.enable(ctx, tf, code)
+	}
+	if err != nil {
+		if errors.Is(err, ErrInvalidTwoFactorCode) {
+			attempts, incrErr := t.repo.IncrChallengeAttempts(ctx, token)
+			if incrErr == nil && attempts >= maxChallengeAttempts {
+				_ = t.repo.DelChallenge(ctx, token)
+				return 0, nil, ErrTwoFactorChallenge
+			}
+		}
+		return 0, nil, err
+	}
+
+	if err := t.repo.DelChallenge(ctx, token); err != nil {
+		t.l.Warn("删除两步验证挑战失败", zap.Int64("uid", uid), zap.Error(err))
+	}
+	return uid, codes, nil
+}
+
+func (t *twoFactorService) enabled(ctx context.Context, uid int64) (domain.TwoFactor, error) {
+	tf, err := t.repo.Find(ctx, uid)
+	if err != nil {
+		if errors.Is(err, dao.ErrTwoFactorNotFound) {
+			return domain.TwoFactor{}, ErrTwoFactorNotEnabled
+		}
+		return domain.TwoFactor{}, err
+	}
+	if !tf.Enabled {
+		return domain.TwoFactor{}, ErrTwoFactorNotEnabled
+	}
+	return tf, nil
+}
+
+// enable 校验验证码后启用并生成恢复码
+func (t *twoFactorService) enable(ctx context.Context, tf domain.TwoFactor, code string) ([]string, error) {
+	if err := t.verify(ctx, tf, code, false); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := newRecoveryCodes(t.recoveryCodeCount())
+	if err != nil {
+		return nil, err
+	}
+	if err := t.repo.Enable(ctx, tf.UserID, hashes); err != nil {
+		if errors.Is(err, dao.ErrTwoFactorNotFound) {
+			return nil, ErrTwoFactorEnabled
+		}
+		return nil, err
+	}
+	return codes, nil
+}
+
+// verify 校验认证器验证码，allowRecovery 为 true 时也接受恢复码
+func (t *twoFactorService) verify(ctx context.Context, tf domain.TwoFactor, code string, allowRecovery bool) error {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return ErrInvalidTwoFactorCode
+	}
+
+	if counter, ok := totp.Validate(tf.Secret, code, time.Now(), totpSkew); ok {
+		// 同一周期的验证码只能使用一次
+		ttl := time.Duration(totp.Period*(2*totpSkew+1)) * time.Second
+		fresh, err := t.repo.MarkCodeUsed(ctx, tf.UserID, counter, ttl)
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			return ErrInvalidTwoFactorCode
+		}
+		return nil
+	}
+
+	if !allowRecovery {
+		return ErrInvalidTwoFactorCode
+	}
+	ok, err := t.repo.UseRecoveryCode(ctx, tf.UserID, hashRecoveryCode(code))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+// required 判断用户是否拥有被强制开启两步验证的角色
+func (t *twoFactorService) required(uid int64) bool {
+	if t.ce == nil {
+		return false
+	}
+
+	sub := strconv.FormatInt(uid, 10)
+	// 拥有全部接口权限的超级管理员
+	if policies, err := t.ce.GetFilteredPolicy(0, sub, "/*"); err == nil && len(policies) > 0 {
+		return true
+	}
+
+	enforced := viper.GetStringSlice("two_factor.enforce_roles")
+	if len(enforced) == 0 {
+		return false
+	}
+	roles, err := t.ce.GetRolesForUser(sub)
+	if err != nil {
+		t.l.Warn("获取用户角色失败", zap.Int64("uid", uid), zap.Error(err))
+		return false
+	}
+	for _, role := range roles {
+		for _, name := range enforced {
+			if role == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (t *twoFactorService) recoveryCodeCount() int {
+	if n := viper.GetInt("two_factor.recovery_codes"); n > 0 {
+		return n
+	}
+	return defaultRecoveryCodes
+}
+
+// newRecoveryCodes 生成 n 个形如 xxxxx-xxxxx 的恢复码及其哈希
+func newRecoveryCodes(n int) ([]string, []string, error) {
+	codes := make([]string, n)
+	hashes := make([]string, n)
+	for i := range codes {
+		raw, err := randomHex(5)
+		if err != nil {
+			return nil, nil, err
+		}
+		codes[i] = raw[:5] + "-" + raw[5:]
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode 忽略大小写、空格与分隔符后计算哈希
+func hashRecoveryCode(code string) string {
+	normalized := strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(code)))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
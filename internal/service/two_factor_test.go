@@ -0,0 +1,45 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewRecoveryCodes(t *testing.T) {
+	codes, hashes, err := newRecoveryCodes(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(codes) != 10 || len(hashes) != 10 {
+		t.Fatalf("got %d codes and %d hashes, want 10", len(codes), len(hashes))
+	}
+
+	seen := make(map[string]struct{}, len(codes))
+	for i, code := range codes {
+		if len(code) != 11 || code[5] != '-' {
+			t.Errorf("unexpected code format %q", code)
+		}
+		if hashes[i] != hashRecoveryCode(code) {
+			t.Errorf("hash mismatch for %q", code)
+		}
+		if _, ok := seen[code]; ok {
+			t.Errorf("duplicate code %q", code)
+		}
+		seen[code] = struct{}{}
+	}
+}
+
+func TestHashRecoveryCodeNormalizes(t *testing.T) {
+	want := hashRecoveryCode("ab12c-3de45")
+	for _, input := range []string{"AB12C-3DE45", " ab12c3de45 ", "ab12c 3de45"} {
+		if got := hashRecoveryCode(input); got != want {
+			t.Errorf("hashRecoveryCode(%q) differs from canonical form", input)
+		}
+	}
+	if hashRecoveryCode("ab12c-3de46") == want {
+		t.Error("different codes produced the same hash")
+	}
+	if strings.Contains(want, "ab12c") {
+		t.Error("hash should not contain the plain code")
+	}
+}
@@ -3,9 +3,13 @@ package service
 import (
 	"context"
 	"errors"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/GoSimplicity/LinkMe/internal/domain"
 	"github.com/GoSimplicity/LinkMe/internal/repository"
+	"github.com/GoSimplicity/LinkMe/utils"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
@@ -16,14 +20,32 @@ var (
 	ErrDuplicateUsername = repository.ErrDuplicateUsername
 	// ErrInvalidUserOrPassword 表示用户名或密码错误
 	ErrInvalidUserOrPassword = errors.New("用户名或密码错误")
+	// ErrEmailExists 表示邮箱已被其他账号验证
+	ErrEmailExists = repository.ErrEmailExists
+	// ErrInvalidEmailCode 表示邮箱验证码错误或已过期
+	ErrInvalidEmailCode = errors.New("邮箱验证码错误或已过期")
+	// ErrEmailReverifyRequired 表示更换已验证的邮箱前需要验证原邮箱
+	ErrEmailReverifyRequired = errors.New("更换已验证的邮箱需先验证原邮箱")
+	// ErrEmailCodeTooFrequent 表示邮箱验证码发送过于频繁
+	ErrEmailCodeTooFrequent = errors.New("验证码发送过于频繁，请稍后再试")
 )
 
+// defaultEmailLoginCooldown 同一邮箱发送登录验证码的默认间隔
+const defaultEmailLoginCooldown = time.Minute
+
 type UserService interface {
-	SignUp(ctx context.Context, u domain.User) error
+	// SignUp 注册用户，inviteCode 非空时校验并记录邀请关系，邀请注册模式下必填
+	SignUp(ctx context.Context, u domain.User, inviteCode string) error
 	Login(ctx context.Context, username string, password string) (domain.User, error)
 	LoginBySMS(ctx context.Context, number string, code string) (domain.User, error)
-	ChangePassword(ctx context.Context, username string, password string, newPassword string, confirmPassword string) error
-	DeleteUser(ctx context.Context, username string, password string, uid int64) error
+	LoginByEmail(ctx context.Context, email string, password string) (domain.User, error)
+	LoginByEmailCode(ctx context.Context, email string, code string) (domain.User, error)
+	// VerifyEmail 验证并绑定邮箱，替换已验证的邮箱时 oldCode 为发送到原邮箱的验证码
+	VerifyEmail(ctx context.Context, uid int64, email string, code string, oldCode string) error
+	// CheckEmailCodeCooldown 校验邮箱验证码的发送频率，登录验证码无需登录即可请求，冷却期内返回 ErrEmailCodeTooFrequent
+	CheckEmailCodeCooldown(ctx context.Context, email string, purpose domain.EmailPurpose) error
+	// ChangePassword 修改密码，返回用户ID以便调用方使其会话失效
+	ChangePassword(ctx context.Context, username string, password string, newPassword string, confirmPassword string) (int64, error)
 	UpdateProfile(ctx context.Context, profile domain.Profile) error
 	GetProfileByUserID(ctx context.Context, UserID int64) (domain.Profile, error)
 	ListUser(ctx context.Context, pagination domain.Pagination) ([]domain.UserWithProfile, error)
@@ -31,23 +53,42 @@ type UserService interface {
 }
 
 type userService struct {
-	repo       repository.UserRepository
-	l          *zap.Logger
-	searchRepo repository.SearchRepository
-	smsRepo    repository.SmsRepository
+	repo          repository.UserRepository
+	l             *zap.Logger
+	searchRepo    repository.SearchRepository
+	smsRepo       repository.SmsRepository
+	emailRepo     repository.EmailRepository
+	reputationSvc ReputationService
+	badgeSvc      BadgeService
+	inviteRepo    repository.InviteRepository
+	auditSvc      AuditService
 }
 
-func NewUserService(repo repository.UserRepository, l *zap.Logger, searchRepo repository.SearchRepository, smsRepo repository.SmsRepository) UserService {
+func NewUserService(repo repository.UserRepository, l *zap.Logger, searchRepo repository.SearchRepository, smsRepo repository.SmsRepository, emailRepo repository.EmailRepository, reputationSvc ReputationService, badgeSvc BadgeService, inviteRepo repository.InviteRepository, auditSvc AuditService) UserService {
 	return &userService{
-		repo:       repo,
-		searchRepo: searchRepo,
-		smsRepo:    smsRepo,
-		l:          l,
+		repo:          repo,
+		searchRepo:    searchRepo,
+		smsRepo:       smsRepo,
+		emailRepo:     emailRepo,
+		reputationSvc: reputationSvc,
+		badgeSvc:      badgeSvc,
+		inviteRepo:    inviteRepo,
+		auditSvc:      auditSvc,
+		l:             l,
 	}
 }
 
 // SignUp 用户注册
-func (us *userService) SignUp(ctx context.Context, u domain.User) error {
+func (us *userService) SignUp(ctx context.Context, u domain.User, inviteCode string) error {
+	switch registrationMode() {
+	case domain.RegistrationClosed:
+		return ErrRegistrationClosed
+	case domain.RegistrationInvite:
+		if inviteCode == "" {
+			return ErrInviteRequired
+		}
+	}
+
 	if err := u.ValidateUsername(); err != nil {
 		return errors.New("用户名需要至少六位的字母数字组合")
 	}
@@ -75,6 +116,10 @@ func (us *userService) SignUp(ctx context.Context, u domain.User) error {
 		}
 	}()
 
+	if inviteCode != "" {
+		_, err := us.inviteRepo.RegisterWithInvite(ctx, u, strings.ToUpper(strings.TrimSpace(inviteCode)))
+		return err
+	}
 	return us.repo.CreateUser(ctx, u)
 }
 
@@ -102,12 +147,7 @@ func (us *userService) LoginBySMS(ctx context.Context, number string, code strin
 		return domain.User{}, ErrInvalidUserOrPassword
 	}
 
-	smsID := viper.GetString("sms.tencent.smsID")
-	if smsID == "" {
-		smsID = "mock-sms"
-	}
-
-	ok, err := us.smsRepo.CheckCode(ctx, smsID, number, code)
+	ok, err := us.smsRepo.CheckCode(ctx, smsTemplateID(), number, code)
 	if err != nil || !ok {
 		return domain.User{}, ErrInvalidUserOrPassword
 	}
@@ -120,57 +160,124 @@ func (us *userService) LoginBySMS(ctx context.Context, number string, code strin
 	return user, nil
 }
 
-// ChangePassword 修改密码
-func (us *userService) ChangePassword(ctx context.Context, username string, password string, newPassword string, confirmPassword string) error {
-	if newPassword != confirmPassword {
-		return errors.New("新密码与确认密码不匹配")
+// LoginByEmail 已验证邮箱+密码登录
+func (us *userService) LoginByEmail(ctx context.Context, email string, password string) (domain.User, error) {
+	email = utils.NormalizeEmail(email)
+	if email == "" || password == "" {
+		return domain.User{}, ErrInvalidUserOrPassword
 	}
 
-	u, err := us.repo.FindByUsername(ctx, username)
+	u, err := us.repo.FindByEmail(ctx, email)
 	if err != nil {
-		return err
+		return domain.User{}, ErrInvalidUserOrPassword
 	}
 
 	if err := u.VerifyPassword(password); err != nil {
-		return ErrInvalidUserOrPassword
+		return domain.User{}, ErrInvalidUserOrPassword
 	}
 
-	newHash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	return u, nil
+}
+
+// LoginByEmailCode 已验证邮箱+验证码登录
+func (us *userService) LoginByEmailCode(ctx context.Context, email string, code string) (domain.User, error) {
+	email = utils.NormalizeEmail(email)
+	if email == "" || code == "" {
+		return domain.User{}, ErrInvalidEmailCode
+	}
+
+	ok, err := us.emailRepo.CheckCode(ctx, email, domain.EmailPurposeLogin, code)
+	if err != nil || !ok {
+		return domain.User{}, ErrInvalidEmailCode
+	}
+
+	u, err := us.repo.FindByEmail(ctx, email)
 	if err != nil {
-		return err
+		return domain.User{}, ErrInvalidEmailCode
 	}
 
-	return us.repo.ChangePassword(ctx, username, string(newHash))
+	return u, nil
 }
 
-// DeleteUser 删除用户
-func (us *userService) DeleteUser(ctx context.Context, username string, password string, uid int64) error {
-	u, err := us.repo.FindByUsername(ctx, username)
+// VerifyEmail 验证并绑定邮箱
+// CheckEmailCodeCooldown 限制登录验证码的发送频率，防止被用于向任意邮箱批量发送邮件
+func (us *userService) CheckEmailCodeCooldown(ctx context.Context, email string, purpose domain.EmailPurpose) error {
+	if purpose != domain.EmailPurposeLogin {
+		return nil
+	}
+	ok, err := us.emailRepo.Cooldown(ctx, utils.NormalizeEmail(email), purpose, durationOr("email.login_cooldown", defaultEmailLoginCooldown))
 	if err != nil {
 		return err
 	}
-
-	if err := u.VerifyPassword(password); err != nil {
-		return ErrInvalidUserOrPassword
+	if !ok {
+		return ErrEmailCodeTooFrequent
 	}
+	return nil
+}
 
-	u.MarkAsDeleted()
+func (us *userService) VerifyEmail(ctx context.Context, uid int64, email string, code string, oldCode string) error {
+	email = utils.NormalizeEmail(email)
+	if email == "" || code == "" {
+		return ErrInvalidEmailCode
+	}
 
-	if err := us.repo.DeleteUser(ctx, username, uid); err != nil {
+	profile, err := us.repo.GetProfile(ctx, uid)
+	if err != nil {
 		return err
 	}
 
-	// 异步删除搜索索引
-	go func() {
-		ctx := context.Background()
-		if err := us.searchRepo.DeleteUserIndex(ctx, uid); err != nil {
-			us.l.Error("从搜索引擎中删除用户失败",
-				zap.Int64("用户ID", uid),
-				zap.Error(err))
+	// 已验证的邮箱只能在验证原邮箱后更换，防止登录态被盗用后篡改邮箱
+	if profile.EmailVerified && utils.NormalizeEmail(profile.Email) != email {
+		if oldCode == "" {
+			return ErrEmailReverifyRequired
 		}
-	}()
+		ok, err := us.emailRepo.CheckCode(ctx, utils.NormalizeEmail(profile.Email), domain.EmailPurposeChange, oldCode)
+		if err != nil || !ok {
+			return ErrInvalidEmailCode
+		}
+	}
 
-	return nil
+	ok, err := us.emailRepo.CheckCode(ctx, email, domain.EmailPurposeVerify, code)
+	if err != nil || !ok {
+		return ErrInvalidEmailCode
+	}
+
+	return us.repo.UpdateVerifiedEmail(ctx, uid, email)
+}
+
+// smsTemplateID 短信验证码缓存使用的模板ID，未配置时为模拟通道
+func smsTemplateID() string {
+	if smsID := viper.GetString("sms.tencent.smsID"); smsID != "" {
+		return smsID
+	}
+	return "mock-sms"
+}
+
+// ChangePassword 修改密码
+func (us *userService) ChangePassword(ctx context.Context, username string, password string, newPassword string, confirmPassword string) (int64, error) {
+	if newPassword != confirmPassword {
+		return 0, errors.New("新密码与确认密码不匹配")
+	}
+
+	u, err := us.repo.FindByUsername(ctx, username)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := u.VerifyPassword(password); err != nil {
+		return 0, ErrInvalidUserOrPassword
+	}
+
+	newHash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := us.repo.ChangePassword(ctx, username, string(newHash)); err != nil {
+		return 0, err
+	}
+
+	return u.ID, nil
 }
 
 // UpdateProfile 更新用户资料
@@ -205,7 +312,28 @@ func (us *userService) GetProfileByUserID(ctx context.Context, UserID int64) (do
 		return domain.Profile{}, errors.New("无效的用户ID")
 	}
 
-	return us.repo.GetProfile(ctx, UserID)
+	profile, err := us.repo.GetProfile(ctx, UserID)
+	if err != nil {
+		return domain.Profile{}, err
+	}
+
+	// 等级与勋章仅用于展示，获取失败时不影响资料返回
+	rep, err := us.reputationSvc.GetReputation(ctx, UserID)
+	if err != nil {
+		us.l.Warn("获取用户等级失败", zap.Int64("uid", UserID), zap.Error(err))
+	} else {
+		profile.Points = rep.Points
+		profile.Level = rep.Level.Level
+		profile.LevelName = rep.Level.Name
+	}
+
+	badges, err := us.badgeSvc.GetUserBadges(ctx, UserID)
+	if err != nil {
+		us.l.Warn("获取用户勋章失败", zap.Int64("uid", UserID), zap.Error(err))
+	} else {
+		profile.Badges = badges
+	}
+	return profile, nil
 }
 
 // ListUser 获取用户列表
@@ -222,5 +350,16 @@ func (us *userService) ListUser(ctx context.Context, pagination domain.Paginatio
 
 // UpdateProfileAdmin 更新用户资料(管理员)
 func (us *userService) UpdateProfileAdmin(ctx context.Context, profile domain.Profile) error {
-	return us.repo.UpdateProfileAdmin(ctx, profile)
+	before, _ := us.repo.GetProfile(ctx, profile.UserID)
+	if err := us.repo.UpdateProfileAdmin(ctx, profile); err != nil {
+		return err
+	}
+	us.auditSvc.Record(ctx, domain.AuditEntry{
+		Action:     domain.AuditUserUpdateProfile,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   strconv.FormatInt(profile.UserID, 10),
+		Before:     before,
+		After:      profile,
+	})
+	return nil
 }
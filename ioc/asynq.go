@@ -55,3 +55,19 @@ func InitScheduler() *asynq.Scheduler {
 func InitRankingService(svc service.RankingService) interfaces.RankingService {
 	return svc
 }
+
+func InitRecommendService(svc service.RecommendService) interfaces.RecommendService {
+	return svc
+}
+
+func InitAccountService(svc service.AccountService) interfaces.AccountService {
+	return svc
+}
+
+func InitBadgeService(svc service.BadgeService) interfaces.BadgeService {
+	return svc
+}
+
+func InitAuditService(svc service.AuditService) interfaces.AuditService {
+	return svc
+}
@@ -1,14 +1,21 @@
 package ioc
 
 import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/pkg/casbinp"
 	"github.com/casbin/casbin/v2"
 	gormadapter "github.com/casbin/gorm-adapter/v3"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
-	"log"
 )
 
 // InitCasbin 初始化casbin权限管理器
-func InitCasbin(db *gorm.DB) *casbin.Enforcer {
+func InitCasbin(db *gorm.DB, cmd redis.Cmdable, l *zap.Logger) *casbin.SyncedEnforcer {
 	// 创建gorm适配器,用于将权限规则存储到数据库中
 	adapter, err := gormadapter.NewAdapterByDB(db)
 	if err != nil {
@@ -16,9 +23,41 @@ func InitCasbin(db *gorm.DB) *casbin.Enforcer {
 	}
 
 	// 创建enforcer实例,使用配置文件中的模型定义和数据库适配器
-	enforcer, err := casbin.NewEnforcer("config/model.conf", adapter)
+	// 监听器会在后台重新加载策略，需使用并发安全的 SyncedEnforcer
+	enforcer, err := casbin.NewSyncedEnforcer("config/model.conf", adapter)
 	if err != nil {
 		log.Fatalf("创建enforcer失败: %v", err)
 	}
+
+	// 策略变更时通知其他实例重新加载，避免多实例部署时策略不一致
+	client, ok := cmd.(redis.UniversalClient)
+	if !ok {
+		log.Fatalf("redis 客户端不支持发布订阅")
+	}
+	watcher, err := casbinp.NewRedisWatcher(client, instanceID(), l)
+	if err != nil {
+		log.Fatalf("创建策略监听器失败: %v", err)
+	}
+	if err := enforcer.SetWatcher(watcher); err != nil {
+		log.Fatalf("设置策略监听器失败: %v", err)
+	}
+	// 加载失败时返回错误，监听器不会记录该版本，定期比对时重试
+	_ = watcher.SetReloadCallback(func(string) error {
+		return enforcer.LoadPolicy()
+	})
+
+	// 板块版主在其被任命的板块域内拥有审核权限
+	if _, err := enforcer.AddNamedPolicy("p2", domain.PlateModeratorRole, "plate:*", domain.ActModerate); err != nil {
+		log.Fatalf("初始化版主策略失败: %v", err)
+	}
 	return enforcer
 }
+
+// instanceID 当前实例标识，由主机名和进程号组成
+func instanceID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
@@ -3,6 +3,7 @@ package ioc
 import (
 	"time"
 
+	"github.com/GoSimplicity/LinkMe/internal/service"
 	"github.com/GoSimplicity/LinkMe/middleware"
 	"github.com/GoSimplicity/LinkMe/pkg/ginp/prometheus"
 	ijwt "github.com/GoSimplicity/LinkMe/utils/jwt"
@@ -13,7 +14,7 @@ import (
 )
 
 // InitMiddlewares 初始化中间件
-func InitMiddlewares(ih ijwt.Handler, l *zap.Logger) []gin.HandlerFunc {
+func InitMiddlewares(ih ijwt.Handler, accessTokenSvc service.AccessTokenService, badgeSvc service.BadgeService, l *zap.Logger) []gin.HandlerFunc {
 	prom := &prometheus.MetricsPlugin{
 		Namespace:  "linkme",
 		Subsystem:  "api",
@@ -27,7 +28,7 @@ func InitMiddlewares(ih ijwt.Handler, l *zap.Logger) []gin.HandlerFunc {
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS"},
 		AllowCredentials: true,
 		AllowHeaders:     []string{"Content-Type", "Authorization", "X-Refresh-Token", "X-Request-ID"},
-		ExposeHeaders:    []string{"x-jwt-token", "x-refresh-token"},
+		ExposeHeaders:    []string{"x-jwt-token", "x-refresh-token", "X-Request-ID"},
 		MaxAge:           12 * time.Hour,
 	}
 
@@ -55,6 +56,12 @@ func InitMiddlewares(ih ijwt.Handler, l *zap.Logger) []gin.HandlerFunc {
 		// 统计活跃请求数
 		prom.TrackResponseTimeMiddleware(),
 		middleware.NewJWTMiddleware(ih).CheckLogin(),
+		// 个人访问令牌，按接口校验令牌权限
+		middleware.NewAccessTokenMiddleware(accessTokenSvc, l).CheckToken(),
+		// 请求ID、客户端IP与操作人，用于审计记录
+		middleware.NewRequestMetaMiddleware().Inject(),
+		// 记录活跃日期，用于连续活跃勋章
+		middleware.NewActivityMiddleware(badgeSvc, l).Record(),
 		middleware.NewLogMiddleware(l).Log(),
 	}
 }
@@ -0,0 +1,44 @@
+package ioc
+
+import (
+	"context"
+	"time"
+
+	"github.com/GoSimplicity/LinkMe/pkg/oauth"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// InitOAuthProviders 根据配置初始化已启用的第三方登录方式
+func InitOAuthProviders(l *zap.Logger) oauth.Registry {
+	registry := oauth.Registry{}
+
+	if viper.GetBool("oauth.github.enabled") {
+		var cfg oauth.Config
+		if err := viper.UnmarshalKey("oauth.github", &cfg); err != nil {
+			l.Error("解析 GitHub 登录配置失败", zap.Error(err))
+		} else {
+			p := oauth.NewGitHub(cfg, nil)
+			registry[p.Name()] = p
+		}
+	}
+
+	if viper.GetBool("oauth.oidc.enabled") {
+		var cfg oauth.Config
+		if err := viper.UnmarshalKey("oauth.oidc", &cfg); err != nil {
+			l.Error("解析 OIDC 登录配置失败", zap.Error(err))
+			return registry
+		}
+		name := viper.GetString("oauth.oidc.name")
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		p, err := oauth.NewOIDC(ctx, name, viper.GetString("oauth.oidc.issuer"), cfg, nil)
+		if err != nil {
+			l.Error("初始化 OIDC 登录失败", zap.Error(err))
+			return registry
+		}
+		registry[p.Name()] = p
+	}
+
+	return registry
+}
@@ -1,9 +1,15 @@
 package ioc
 
 import (
+	"context"
+	"time"
+
 	"github.com/GoSimplicity/LinkMe/internal/api"
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/internal/service"
 	"github.com/GoSimplicity/LinkMe/pkg/apiresponse"
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
 // InitWeb 初始化web服务
@@ -23,6 +29,23 @@ func InitWeb(userHdl *api.UserHandler,
 	roleHdl *api.RoleHandler,
 	menuHdl *api.MenuHandler,
 	apiHdl *api.ApiHandler,
+	notificationHdl *api.NotificationHandler,
+	imHdl *api.IMHandler,
+	feedHdl *api.FeedHandler,
+	oauthHdl *api.OAuthHandler,
+	twoFactorHdl *api.TwoFactorHandler,
+	passwordResetHdl *api.PasswordResetHandler,
+	sessionHdl *api.SessionHandler,
+	accountHdl *api.AccountHandler,
+	reputationHdl *api.ReputationHandler,
+	badgeHdl *api.BadgeHandler,
+	inviteHdl *api.InviteHandler,
+	accessTokenHdl *api.AccessTokenHandler,
+	securityHdl *api.SecurityHandler,
+	jwksHdl *api.JWKSHandler,
+	auditHdl *api.AuditHandler,
+	apiSvc service.ApiService,
+	l *zap.Logger,
 ) *gin.Engine {
 	server := gin.Default()
 	server.Use(m...)
@@ -47,5 +70,38 @@ func InitWeb(userHdl *api.UserHandler,
 	roleHdl.RegisterRoutes(server)
 	menuHdl.RegisterRoutes(server)
 	apiHdl.RegisterRoutes(server)
+	notificationHdl.RegisterRoutes(server)
+	imHdl.RegisterRoutes(server)
+	feedHdl.RegisterRoutes(server)
+	oauthHdl.RegisterRoutes(server)
+	twoFactorHdl.RegisterRoutes(server)
+	passwordResetHdl.RegisterRoutes(server)
+	sessionHdl.RegisterRoutes(server)
+	accountHdl.RegisterRoutes(server)
+	reputationHdl.RegisterRoutes(server)
+	badgeHdl.RegisterRoutes(server)
+	inviteHdl.RegisterRoutes(server)
+	accessTokenHdl.RegisterRoutes(server)
+	securityHdl.RegisterRoutes(server)
+	jwksHdl.RegisterRoutes(server)
+	auditHdl.RegisterRoutes(server)
+	syncRoutes(server, apiSvc, l)
 	return server
 }
+
+// syncRoutes 将注册的路由同步到API注册表，失败不影响启动
+func syncRoutes(server *gin.Engine, apiSvc service.ApiService, l *zap.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	routes := make([]domain.Route, 0, len(server.Routes()))
+	for _, r := range server.Routes() {
+		routes = append(routes, domain.Route{Method: r.Method, Path: r.Path})
+	}
+	result, err := apiSvc.SyncRoutes(ctx, routes)
+	if err != nil {
+		l.Error("同步路由到API注册表失败", zap.Error(err))
+		return
+	}
+	l.Info("同步路由到API注册表", zap.Int("total", result.Total), zap.Int("created", result.Created), zap.Int64("stale", result.Stale))
+}
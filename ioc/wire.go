@@ -38,6 +38,12 @@ func InitWebServer() *Cmd {
 		InitAsynqClient,
 		InitScheduler,
 		InitRankingService,
+		InitRecommendService,
+		InitAccountService,
+		InitBadgeService,
+		InitAuditService,
+		InitOAuthProviders,
+		InitializeSnowflakeNode,
 		ijwt.NewJWTHandler,
 		api.NewUserHandler,
 		api.NewPostHandler,
@@ -54,6 +60,21 @@ func InitWebServer() *Cmd {
 		api.NewRoleHandler,
 		api.NewMenuHandler,
 		api.NewApiHandler,
+		api.NewNotificationHandler,
+		api.NewIMHandler,
+		api.NewFeedHandler,
+		api.NewOAuthHandler,
+		api.NewTwoFactorHandler,
+		api.NewPasswordResetHandler,
+		api.NewSessionHandler,
+		api.NewAccountHandler,
+		api.NewReputationHandler,
+		api.NewBadgeHandler,
+		api.NewInviteHandler,
+		api.NewAccessTokenHandler,
+		api.NewSecurityHandler,
+		api.NewJWKSHandler,
+		api.NewAuditHandler,
 		repository.NewSmsRepository,
 		service.NewUserService,
 		service.NewPostService,
@@ -71,6 +92,21 @@ func InitWebServer() *Cmd {
 		service.NewRoleService,
 		service.NewMenuService,
 		service.NewApiService,
+		service.NewNotificationService,
+		service.NewIMService,
+		service.NewFeedService,
+		service.NewRecommendService,
+		service.NewOAuthService,
+		service.NewTwoFactorService,
+		service.NewPasswordResetService,
+		service.NewAccountService,
+		service.NewReputationService,
+		service.NewBadgeService,
+		service.NewInviteService,
+		service.NewAccessTokenService,
+		service.NewLoginGuardService,
+		service.NewModeratorService,
+		service.NewAuditService,
 		repository.NewUserRepository,
 		repository.NewPostRepository,
 		repository.NewInteractiveRepository,
@@ -89,6 +125,20 @@ func InitWebServer() *Cmd {
 		repository.NewRoleRepository,
 		repository.NewMenuRepository,
 		repository.NewApiRepository,
+		repository.NewNotificationRepository,
+		repository.NewIMRepository,
+		repository.NewFeedRepository,
+		repository.NewRecommendRepository,
+		repository.NewOAuthRepository,
+		repository.NewTwoFactorRepository,
+		repository.NewPasswordResetRepository,
+		repository.NewAccountRepository,
+		repository.NewReputationRepository,
+		repository.NewBadgeRepository,
+		repository.NewInviteRepository,
+		repository.NewAccessTokenRepository,
+		repository.NewLoginGuardRepository,
+		repository.NewAuditRepository,
 		cache.NewRankingLocalCache,
 		cache.NewRankingRedisCache,
 		cache.NewUserCache,
@@ -99,6 +149,14 @@ func InitWebServer() *Cmd {
 		cache.NewPostCache,
 		cache.NewCommentCache,
 		cache.NewInteractiveCache,
+		cache.NewNotificationCache,
+		cache.NewFeedCache,
+		cache.NewRecommendCache,
+		cache.NewOAuthCache,
+		cache.NewTwoFactorCache,
+		cache.NewPasswordResetCache,
+		cache.NewBadgeCache,
+		cache.NewLoginGuardCache,
 		dao.NewUserDAO,
 		dao.NewPostDAO,
 		dao.NewInteractiveDAO,
@@ -115,6 +173,17 @@ func InitWebServer() *Cmd {
 		dao.NewMenuDAO,
 		dao.NewApiDAO,
 		dao.NewRankingParameterDAO,
+		dao.NewNotificationDAO,
+		dao.NewIMDAO,
+		dao.NewOAuthDAO,
+		dao.NewTwoFactorDAO,
+		dao.NewAccountDAO,
+		dao.NewReputationDAO,
+		dao.NewBadgeDAO,
+		dao.NewInviteDAO,
+		dao.NewAccessTokenDAO,
+		dao.NewSecurityEventDAO,
+		dao.NewAuditLogDAO,
 		post.NewSaramaSyncProducer,
 		post.NewEventConsumer,
 		post.NewPostDeadLetterConsumer,
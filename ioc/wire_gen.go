@@ -32,9 +32,12 @@ import (
 func InitWebServer() *Cmd {
 	db := InitDB()
 	logger := InitLogger()
-	enforcer := InitCasbin(db)
-	userDAO := dao.NewUserDAO(db, logger, enforcer)
 	cmdable := InitRedis()
+	enforcer := InitCasbin(db, cmdable, logger)
+	auditLogDAO := dao.NewAuditLogDAO(db, logger)
+	auditRepository := repository.NewAuditRepository(auditLogDAO, logger)
+	auditService := service.NewAuditService(auditRepository, logger)
+	userDAO := dao.NewUserDAO(db, logger, enforcer)
 	userCache := cache.NewUserCache(cmdable)
 	userRepository := repository.NewUserRepository(userDAO, userCache, logger)
 	typedClient := InitES()
@@ -43,25 +46,50 @@ func InitWebServer() *Cmd {
 	smsDAO := dao.NewSmsDAO(db, logger)
 	smsCache := cache.NewSMSCache(cmdable)
 	tencentSms := InitSms()
-	smsRepository := repository.NewSmsRepository(smsDAO, smsCache, logger, tencentSms)
-	userService := service.NewUserService(userRepository, logger, searchRepository, smsRepository)
+	notificationDAO := dao.NewNotificationDAO(db, logger)
+	notificationCache := cache.NewNotificationCache(cmdable)
+	notificationRepository := repository.NewNotificationRepository(notificationDAO, notificationCache, logger)
+	smsRepository := repository.NewSmsRepository(smsDAO, smsCache, logger, tencentSms, notificationRepository)
+	emailCache := cache.NewEmailCache(cmdable)
+	emailRepository := repository.NewEmailRepository(emailCache, logger, notificationRepository)
+	postDAO := dao.NewPostDAO(db, logger)
+	postCache := cache.NewPostCache(cmdable)
+	asynqClient := InitAsynqClient()
+	postRepository := repository.NewPostRepository(postDAO, logger, postCache, asynqClient)
+	reputationDAO := dao.NewReputationDAO(db, logger)
+	reputationRepository := repository.NewReputationRepository(reputationDAO, logger)
+	reputationService := service.NewReputationService(reputationRepository, postRepository, auditService, logger)
+	badgeDAO := dao.NewBadgeDAO(db, logger)
+	badgeCache := cache.NewBadgeCache(cmdable)
+	badgeRepository := repository.NewBadgeRepository(badgeDAO, badgeCache, logger)
+	badgeService := service.NewBadgeService(badgeRepository, postRepository, auditService, logger)
+	inviteDAO := dao.NewInviteDAO(db, logger)
+	inviteRepository := repository.NewInviteRepository(inviteDAO, logger)
+	userService := service.NewUserService(userRepository, logger, searchRepository, smsRepository, emailRepository, reputationService, badgeService, inviteRepository, auditService)
 	handler := jwt.NewJWTHandler(cmdable)
 	client := InitSaramaClient()
 	syncProducer := InitSyncProducer(client)
 	producer := sms.NewSaramaSyncProducer(syncProducer, logger)
 	emailProducer := email.NewSaramaSyncProducer(syncProducer, logger)
-	userHandler := api.NewUserHandler(userService, handler, producer, emailProducer, enforcer)
-	postDAO := dao.NewPostDAO(db, logger)
-	postCache := cache.NewPostCache(cmdable)
-	asynqClient := InitAsynqClient()
-	postRepository := repository.NewPostRepository(postDAO, logger, postCache, asynqClient)
+	twoFactorDAO := dao.NewTwoFactorDAO(db, logger)
+	twoFactorCache := cache.NewTwoFactorCache(cmdable)
+	twoFactorRepository := repository.NewTwoFactorRepository(twoFactorDAO, twoFactorCache, logger)
+	twoFactorService := service.NewTwoFactorService(twoFactorRepository, userRepository, enforcer, logger)
+	securityEventDAO := dao.NewSecurityEventDAO(db, logger)
+	loginGuardCache := cache.NewLoginGuardCache(cmdable)
+	loginGuardRepository := repository.NewLoginGuardRepository(securityEventDAO, loginGuardCache, logger)
+	notificationService := service.NewNotificationService(notificationRepository, userRepository, smsRepository, emailRepository, logger)
+	loginGuardService := service.NewLoginGuardService(loginGuardRepository, userRepository, notificationService, auditService, logger)
 	postProducer := post.NewSaramaSyncProducer(syncProducer)
 	checkProducer := check.NewSaramaCheckProducer(syncProducer)
 	interactiveDAO := dao.NewInteractiveDAO(db, logger)
 	interactiveCache := cache.NewInteractiveCache(cmdable)
 	interactiveRepository := repository.NewInteractiveRepository(interactiveDAO, logger, interactiveCache)
-	postService := service.NewPostService(postRepository, logger, postProducer, checkProducer, interactiveRepository)
-	interactiveService := service.NewInteractiveService(interactiveRepository, logger)
+	relationDAO := dao.NewRelationDAO(db, logger)
+	relationCache := cache.NewRelationCache(cmdable)
+	relationRepository := repository.NewRelationRepository(relationDAO, relationCache, logger)
+	postService := service.NewPostService(postRepository, logger, postProducer, checkProducer, interactiveRepository, relationRepository, userRepository, reputationService)
+	interactiveService := service.NewInteractiveService(interactiveRepository, reputationService, badgeService, postRepository, relationRepository, notificationService, logger)
 	postHandler := api.NewPostHandler(postService, interactiveService)
 	historyCache := cache.NewHistoryCache(logger, cmdable)
 	historyRepository := repository.NewHistoryRepository(logger, historyCache)
@@ -73,62 +101,99 @@ func InitWebServer() *Cmd {
 	activityRepository := repository.NewActivityRepository(activityDAO)
 	publishProducer := publish.NewSaramaSyncProducer(syncProducer, logger)
 	commentProducer := comment.NewSaramaCommentProducer(syncProducer)
-	checkService := service.NewCheckService(checkRepository, searchRepository, logger, activityRepository, publishProducer, commentProducer)
+	plateDAO := dao.NewPlateDAO(logger, db)
+	plateRepository := repository.NewPlateRepository(logger, plateDAO)
+	moderatorService := service.NewModeratorService(enforcer, plateRepository, userRepository, auditService, logger)
+	commentDAO := dao.NewCommentDAO(db, logger)
+	commentCache := cache.NewCommentCache(cmdable)
+	commentRepository := repository.NewCommentRepository(commentDAO, commentCache)
+	checkService := service.NewCheckService(checkRepository, searchRepository, logger, activityRepository, publishProducer, commentProducer, reputationService, badgeService, moderatorService, postRepository, commentRepository, auditService, notificationService)
 	checkHandler := api.NewCheckHandler(checkService)
-	v := InitMiddlewares(handler, logger)
+	accessTokenDAO := dao.NewAccessTokenDAO(db, logger)
+	accessTokenRepository := repository.NewAccessTokenRepository(accessTokenDAO, logger)
+	accessTokenService := service.NewAccessTokenService(accessTokenRepository, moderatorService, logger)
+	userHandler := api.NewUserHandler(userService, twoFactorService, loginGuardService, accessTokenService, handler, producer, emailProducer, enforcer, logger)
+	v := InitMiddlewares(handler, accessTokenService, badgeService, logger)
 	apiDAO := dao.NewApiDAO(db, logger)
 	permissionDAO := dao.NewPermissionDAO(db, logger, enforcer, apiDAO)
 	permissionRepository := repository.NewPermissionRepository(logger, permissionDAO)
-	permissionService := service.NewPermissionService(logger, permissionRepository)
+	permissionService := service.NewPermissionService(logger, permissionRepository, auditService)
 	permissionHandler := api.NewPermissionHandler(permissionService, logger)
 	rankingRedisCache := cache.NewRankingRedisCache(cmdable, logger)
 	rankingLocalCache := cache.NewRankingLocalCache(logger)
 	rankingRepository := repository.NewRankingRepository(rankingRedisCache, rankingLocalCache, logger)
 	rankingParameterDAO := dao.NewRankingParameterDAO(db, logger)
 	rankingParameterRepository := repository.NewRankingParameterRepository(rankingParameterDAO, logger)
-	rankingService := service.NewRankingService(interactiveRepository, postRepository, rankingRepository, rankingParameterRepository, logger)
+	rankingService := service.NewRankingService(interactiveRepository, postRepository, rankingRepository, rankingParameterRepository, userRepository, relationRepository, logger)
 	rankingHandler := api.NewRakingHandler(rankingService)
-	plateDAO := dao.NewPlateDAO(logger, db)
-	plateRepository := repository.NewPlateRepository(logger, plateDAO)
-	plateService := service.NewPlateService(logger, plateRepository)
-	plateHandler := api.NewPlateHandler(plateService, enforcer)
+	plateService := service.NewPlateService(logger, plateRepository, postRepository, relationRepository, userRepository, moderatorService, auditService)
+	plateHandler := api.NewPlateHandler(plateService, moderatorService, enforcer)
 	activityService := service.NewActivityService(activityRepository)
 	activityHandler := api.NewActivityHandler(activityService, enforcer)
-	commentDAO := dao.NewCommentDAO(db, logger)
-	commentCache := cache.NewCommentCache(cmdable)
-	commentRepository := repository.NewCommentRepository(commentDAO, commentCache)
-	commentService := service.NewCommentService(commentRepository, checkProducer)
+	commentService := service.NewCommentService(commentRepository, checkProducer, postRepository, relationRepository, userRepository, notificationService, moderatorService, auditService, logger)
 	commentHandler := api.NewCommentHandler(commentService)
-	searchService := service.NewSearchService(searchRepository)
+	searchService := service.NewSearchService(searchRepository, userRepository, relationRepository)
 	searchHandler := api.NewSearchHandler(searchService)
-	relationDAO := dao.NewRelationDAO(db, logger)
-	relationCache := cache.NewRelationCache(cmdable)
-	relationRepository := repository.NewRelationRepository(relationDAO, relationCache, logger)
-	relationService := service.NewRelationService(relationRepository)
+	feedCache := cache.NewFeedCache(cmdable)
+	feedRepository := repository.NewFeedRepository(feedCache, postDAO, relationDAO, logger)
+	relationService := service.NewRelationService(relationRepository, feedRepository, plateRepository, postRepository, userRepository, notificationService, logger)
 	relationHandler := api.NewRelationHandler(relationService)
 	lotteryDrawDAO := dao.NewLotteryDrawDAO(db, logger)
 	lotteryDrawRepository := repository.NewLotteryDrawRepository(lotteryDrawDAO, logger)
-	lotteryDrawService := service.NewLotteryDrawService(lotteryDrawRepository, logger)
-	lotteryDrawHandler := api.NewLotteryDrawHandler(lotteryDrawService)
+	lotteryDrawService := service.NewLotteryDrawService(lotteryDrawRepository, badgeService, logger)
+	lotteryDrawHandler := api.NewLotteryDrawHandler(lotteryDrawService, reputationService)
 	roleDAO := dao.NewRoleDAO(db, logger, enforcer, permissionDAO)
 	roleRepository := repository.NewRoleRepository(logger, roleDAO)
-	roleService := service.NewRoleService(roleRepository, permissionRepository, logger)
+	roleService := service.NewRoleService(roleRepository, permissionRepository, auditService, logger)
 	menuDAO := dao.NewMenuDAO(db, logger)
 	menuRepository := repository.NewMenuRepository(logger, menuDAO)
-	menuService := service.NewMenuService(logger, menuRepository)
+	menuService := service.NewMenuService(logger, menuRepository, auditService)
 	apiRepository := repository.NewApiRepository(logger, apiDAO)
-	apiService := service.NewApiService(logger, apiRepository)
+	apiService := service.NewApiService(logger, apiRepository, enforcer, auditService)
 	roleHandler := api.NewRoleHandler(roleService, menuService, apiService, permissionService, logger)
 	menuHandler := api.NewMenuHandler(menuService, logger)
-	apiHandler := api.NewApiHandler(apiService, logger)
-	engine := InitWeb(userHandler, postHandler, historyHandler, checkHandler, v, permissionHandler, rankingHandler, plateHandler, activityHandler, commentHandler, searchHandler, relationHandler, lotteryDrawHandler, roleHandler, menuHandler, apiHandler)
+	apiHandler := api.NewApiHandler(apiService, enforcer, logger)
+	notificationHandler := api.NewNotificationHandler(notificationService)
+	imdao := dao.NewIMDAO(db, logger)
+	imRepository := repository.NewIMRepository(imdao, logger)
+	node := InitializeSnowflakeNode()
+	imService := service.NewIMService(imRepository, relationRepository, userRepository, node, logger)
+	imHandler := api.NewIMHandler(imService)
+	feedService := service.NewFeedService(feedRepository, postRepository, relationRepository, logger)
+	recommendCache := cache.NewRecommendCache(cmdable)
+	recommendRepository := repository.NewRecommendRepository(recommendCache, logger)
+	recommendService := service.NewRecommendService(recommendRepository, historyRepository, interactiveRepository, postRepository, rankingRepository, rankingParameterRepository, relationRepository, userRepository, logger)
+	feedHandler := api.NewFeedHandler(feedService, recommendService)
+	oAuthDAO := dao.NewOAuthDAO(db, logger)
+	oAuthCache := cache.NewOAuthCache(cmdable)
+	oAuthRepository := repository.NewOAuthRepository(oAuthDAO, oAuthCache, logger)
+	registry := InitOAuthProviders(logger)
+	oAuthService := service.NewOAuthService(oAuthRepository, userRepository, registry, logger)
+	oAuthHandler := api.NewOAuthHandler(oAuthService, twoFactorService, handler)
+	twoFactorHandler := api.NewTwoFactorHandler(twoFactorService)
+	passwordResetCache := cache.NewPasswordResetCache(cmdable)
+	passwordResetRepository := repository.NewPasswordResetRepository(passwordResetCache, logger)
+	passwordResetService := service.NewPasswordResetService(passwordResetRepository, userRepository, smsRepository, emailRepository, producer, emailProducer, logger)
+	passwordResetHandler := api.NewPasswordResetHandler(passwordResetService, accessTokenService, handler, logger)
+	sessionHandler := api.NewSessionHandler(handler)
+	accountDAO := dao.NewAccountDAO(db, logger)
+	accountRepository := repository.NewAccountRepository(accountDAO, userCache, logger)
+	accountService := service.NewAccountService(accountRepository, userRepository, historyRepository, searchRepository, logger)
+	accountHandler := api.NewAccountHandler(accountService, accessTokenService, handler, logger)
+	reputationHandler := api.NewReputationHandler(reputationService, enforcer)
+	badgeHandler := api.NewBadgeHandler(badgeService, enforcer)
+	inviteService := service.NewInviteService(inviteRepository, reputationService, auditService, logger)
+	inviteHandler := api.NewInviteHandler(inviteService, enforcer)
+	accessTokenHandler := api.NewAccessTokenHandler(accessTokenService)
+	securityHandler := api.NewSecurityHandler(loginGuardService, enforcer)
+	jwksHandler := api.NewJWKSHandler(handler)
+	auditHandler := api.NewAuditHandler(auditService, enforcer)
+	engine := InitWeb(userHandler, postHandler, historyHandler, checkHandler, v, permissionHandler, rankingHandler, plateHandler, activityHandler, commentHandler, searchHandler, relationHandler, lotteryDrawHandler, roleHandler, menuHandler, apiHandler, notificationHandler, imHandler, feedHandler, oAuthHandler, twoFactorHandler, passwordResetHandler, sessionHandler, accountHandler, reputationHandler, badgeHandler, inviteHandler, accessTokenHandler, securityHandler, jwksHandler, auditHandler, apiService, logger)
 	eventConsumer := post.NewEventConsumer(interactiveRepository, historyRepository, client, syncProducer, logger)
 	smsConsumer := sms.NewSMSConsumer(smsRepository, client, logger, smsCache)
 	publishCommentEventConsumer := comment.NewPublishCommentEventConsumer(commentRepository, searchRepository, client, logger)
-	emailCache := cache.NewEmailCache(cmdable)
-	emailRepository := repository.NewEmailRepository(emailCache, logger)
 	emailConsumer := email.NewEmailConsumer(emailRepository, client, logger)
-	publishPostEventConsumer := publish.NewPublishPostEventConsumer(postRepository, client, syncProducer, logger)
+	publishPostEventConsumer := publish.NewPublishPostEventConsumer(postRepository, feedRepository, client, syncProducer, logger)
 	esConsumer := es.NewEsConsumer(client, logger, searchRepository)
 	checkEventConsumer := check.NewCheckEventConsumer(checkRepository, client, syncProducer, logger, publishProducer, commentProducer)
 	postDeadLetterConsumer := post.NewPostDeadLetterConsumer(interactiveRepository, historyRepository, client, logger)
@@ -137,7 +202,11 @@ func InitWebServer() *Cmd {
 	v2 := InitConsumers(eventConsumer, smsConsumer, publishCommentEventConsumer, emailConsumer, publishPostEventConsumer, esConsumer, checkEventConsumer, postDeadLetterConsumer, publishDeadLetterConsumer, checkDeadLetterConsumer)
 	refreshCacheTask := job.NewRefreshCacheTask(postCache, logger)
 	interfacesRankingService := InitRankingService(rankingService)
-	timedTask := job.NewTimedTask(logger, interfacesRankingService)
+	interfacesRecommendService := InitRecommendService(recommendService)
+	interfacesAccountService := InitAccountService(accountService)
+	interfacesBadgeService := InitBadgeService(badgeService)
+	interfacesAuditService := InitAuditService(auditService)
+	timedTask := job.NewTimedTask(logger, interfacesRankingService, interfacesRecommendService, interfacesAccountService, interfacesBadgeService, interfacesAuditService)
 	routes := job.NewRoutes(refreshCacheTask, timedTask)
 	server := InitAsynqServer()
 	scheduler := InitScheduler()
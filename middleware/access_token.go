@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	"github.com/GoSimplicity/LinkMe/pkg/apiresponse"
+	ijwt "github.com/GoSimplicity/LinkMe/utils/jwt"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// AccessTokenVerifier 校验个人访问令牌
+type AccessTokenVerifier interface {
+	Verify(ctx context.Context, token string) (domain.AccessToken, error)
+}
+
+// accessTokenRoutes 个人访问令牌可访问的接口及所需权限，未列出的接口一律拒绝
+var accessTokenRoutes = map[string]string{
+	"POST /api/posts/list":              domain.ScopePostsRead,
+	"POST /api/posts/list_pub":          domain.ScopePostsRead,
+	"POST /api/posts/get_by_plate":      domain.ScopePostsRead,
+	"GET /api/posts/get/:postId":        domain.ScopePostsRead,
+	"GET /api/posts/detail/:postId":     domain.ScopePostsRead,
+	"GET /api/posts/detail_pub/:postId": domain.ScopePostsRead,
+	"GET /api/posts/count":              domain.ScopePostsRead,
+	"POST /api/posts/edit":              domain.ScopePostsWrite,
+	"POST /api/posts/update":            domain.ScopePostsWrite,
+	"POST /api/posts/publish":           domain.ScopePostsWrite,
+	"POST /api/posts/withdraw":          domain.ScopePostsWrite,
+	"POST /api/posts/like":              domain.ScopePostsWrite,
+	"POST /api/posts/collect":           domain.ScopePostsWrite,
+	"DELETE /api/posts/delete/:postId":  domain.ScopePostsWrite,
+	"POST /api/checks/approve":          domain.ScopeModerate,
+	"POST /api/checks/reject":           domain.ScopeModerate,
+	"GET /api/checks/list":              domain.ScopeModerate,
+	"GET /api/checks/detail":            domain.ScopeModerate,
+}
+
+type AccessTokenMiddleware struct {
+	verifier AccessTokenVerifier
+	l        *zap.Logger
+}
+
+func NewAccessTokenMiddleware(verifier AccessTokenVerifier, l *zap.Logger) *AccessTokenMiddleware {
+	return &AccessTokenMiddleware{
+		verifier: verifier,
+		l:        l,
+	}
+}
+
+// CheckToken 校验个人访问令牌及其权限，通过后写入与 JWT 相同的用户信息
+func (m *AccessTokenMiddleware) CheckToken() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		// Authorization 头部格式需为 Bearer string
+		s := strings.Split(ctx.GetHeader("Authorization"), " ")
+		if len(s) != 2 || !strings.HasPrefix(s[1], domain.AccessTokenPrefix) {
+			return
+		}
+
+		token, err := m.verifier.Verify(ctx, s[1])
+		if err != nil {
+			if !errors.Is(err, domain.ErrAccessTokenInvalid) {
+				m.l.Error("校验访问令牌失败", zap.Error(err))
+			}
+			apiresponse.UnauthorizedErrorWithDetails(ctx, nil, "访问令牌无效或已过期")
+			ctx.Abort()
+			return
+		}
+
+		scope, ok := accessTokenRoutes[ctx.Request.Method+" "+ctx.FullPath()]
+		if !ok || !token.HasScope(scope) {
+			apiresponse.ForbiddenError(ctx, "访问令牌没有该接口的权限")
+			ctx.Abort()
+			return
+		}
+
+		ctx.Set("user", ijwt.UserClaims{
+			Uid:     token.UserID,
+			TokenID: token.ID,
+			Scopes:  token.Scopes,
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"context"
+
+	ijwt "github.com/GoSimplicity/LinkMe/utils/jwt"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ActivityRecorder 记录用户活跃
+type ActivityRecorder interface {
+	RecordActivity(ctx context.Context, uid int64) error
+}
+
+type ActivityMiddleware struct {
+	recorder ActivityRecorder
+	l        *zap.Logger
+}
+
+func NewActivityMiddleware(recorder ActivityRecorder, l *zap.Logger) *ActivityMiddleware {
+	return &ActivityMiddleware{
+		recorder: recorder,
+		l:        l,
+	}
+}
+
+// Record 记录已登录用户的活跃日期，需放在 CheckLogin 之后，记录失败不影响请求
+func (m *ActivityMiddleware) Record() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		val, ok := ctx.Get("user")
+		if !ok {
+			return
+		}
+		uc, ok := val.(ijwt.UserClaims)
+		if !ok || uc.Uid == 0 {
+			return
+		}
+		if err := m.recorder.RecordActivity(ctx, uc.Uid); err != nil {
+			m.l.Warn("记录用户活跃失败", zap.Int64("uid", uc.Uid), zap.Error(err))
+		}
+	}
+}
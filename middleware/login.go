@@ -1,11 +1,12 @@
 package middleware
 
 import (
+	"strings"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
 	"github.com/GoSimplicity/LinkMe/pkg/apiresponse"
 	ijwt "github.com/GoSimplicity/LinkMe/utils/jwt"
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
-	"github.com/spf13/viper"
 )
 
 type JWTMiddleware struct {
@@ -22,24 +23,17 @@ func NewJWTMiddleware(hdl ijwt.Handler) *JWTMiddleware {
 func (m *JWTMiddleware) CheckLogin() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		tokenStr := m.ExtractToken(ctx)
-		if tokenStr == "" {
+		// 个人访问令牌由 AccessTokenMiddleware 校验
+		if tokenStr == "" || strings.HasPrefix(tokenStr, domain.AccessTokenPrefix) {
 			return
 		}
 
-		var uc ijwt.UserClaims
-		token, err := jwt.ParseWithClaims(tokenStr, &uc, func(token *jwt.Token) (interface{}, error) {
-			return []byte(viper.GetString("jwt.auth_key")), nil
-		})
+		uc, err := m.ParseAccessToken(ctx, tokenStr)
 		if err != nil {
 			apiresponse.UnauthorizedErrorWithDetails(ctx, nil, "登录态无效")
 			ctx.Abort()
 			return
 		}
-		if token == nil || !token.Valid {
-			apiresponse.UnauthorizedErrorWithDetails(ctx, nil, "登录态无效")
-			ctx.Abort()
-			return
-		}
 		if uc.UserAgent == "" {
 			apiresponse.UnauthorizedErrorWithDetails(ctx, nil, "登录态无效")
 			ctx.Abort()
@@ -51,6 +45,8 @@ func (m *JWTMiddleware) CheckLogin() gin.HandlerFunc {
 			ctx.Abort()
 			return
 		}
+		// 活跃时间仅用于会话列表展示，更新失败不影响请求
+		_ = m.TouchSession(ctx, uc.Ssid)
 		ctx.Set("user", uc)
 	}
 }
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/GoSimplicity/LinkMe/internal/domain"
+	ijwt "github.com/GoSimplicity/LinkMe/utils/jwt"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// RequestIDHeader 请求ID头，客户端未传递时由服务端生成
+	RequestIDHeader = "X-Request-ID"
+	// maxRequestIDLen 客户端传递的请求ID最大长度，超出时重新生成
+	maxRequestIDLen = 64
+)
+
+type RequestMetaMiddleware struct{}
+
+func NewRequestMetaMiddleware() *RequestMetaMiddleware {
+	return &RequestMetaMiddleware{}
+}
+
+// Inject 将请求ID、客户端IP与登录用户写入上下文，供审计记录使用，需放在 CheckLogin 之后
+func (m *RequestMetaMiddleware) Inject() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > maxRequestIDLen {
+			requestID = newRequestID()
+		}
+		ctx.Header(RequestIDHeader, requestID)
+
+		meta := domain.AuditMeta{
+			IP:        ctx.ClientIP(),
+			RequestID: requestID,
+		}
+		if val, ok := ctx.Get("user"); ok {
+			if uc, ok := val.(ijwt.UserClaims); ok {
+				meta.ActorID = uc.Uid
+			}
+		}
+
+		// 处理器可能传入 *gin.Context 或请求上下文，两处都写入
+		ctx.Set(domain.AuditMetaKey, meta)
+		ctx.Request = ctx.Request.WithContext(domain.WithAuditMeta(ctx.Request.Context(), meta))
+		ctx.Next()
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
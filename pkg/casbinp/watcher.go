@@ -0,0 +1,258 @@
+package casbinp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	// policyChannel 策略变更通知频道
+	policyChannel = "linkme:casbin:policy"
+	// policyVersionKey 全局策略版本号，每次变更自增
+	policyVersionKey = "linkme:casbin:policy:version"
+	// reloadDebounce 合并短时间内的多次通知，批量授权时只重新加载一次
+	reloadDebounce = 200 * time.Millisecond
+	// versionPollInterval 定期比对版本号，补偿断线期间丢失的通知
+	versionPollInterval = 30 * time.Second
+)
+
+var (
+	// policyVersion 各实例已生效的策略版本，进程内只注册一次
+	policyVersion = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "linkme",
+		Subsystem: "casbin",
+		Name:      "policy_version",
+		Help:      "Casbin policy version applied by this instance",
+	}, []string{"instance_id"})
+	registerOnce sync.Once
+)
+
+// policyMessage 策略变更通知
+type policyMessage struct {
+	Instance string `json:"instance"`
+	Version  int64  `json:"version"`
+}
+
+// RedisWatcher 基于 Redis 发布订阅的 casbin 策略监听器
+// 收到其他实例的变更通知后整体重新加载策略，回调在后台协程中执行，
+// 必须配合 casbin.SyncedEnforcer 使用，由其读写锁保证与鉴权互斥
+type RedisWatcher struct {
+	client     redis.UniversalClient
+	instanceID string
+	l          *zap.Logger
+
+	mu       sync.Mutex
+	callback func(string) error
+	applied  int64 // 本实例已生效的策略版本
+
+	pubsub  *redis.PubSub
+	notify  chan struct{}
+	cancel  context.CancelFunc
+	version prometheus.Gauge
+}
+
+// NewRedisWatcher 订阅策略变更频道，instanceID 用于忽略本实例发出的通知并作为指标标签
+func NewRedisWatcher(client redis.UniversalClient, instanceID string, l *zap.Logger) (*RedisWatcher, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pubsub := client.Subscribe(ctx, policyChannel)
+	// 等待订阅建立，避免启动后到订阅生效之间的通知丢失
+	if _, err := pubsub.Receive(ctx); err != nil {
+		cancel()
+		_ = pubsub.Close()
+		return nil, err
+	}
+
+	registerOnce.Do(func() {
+		prometheus.MustRegister(policyVersion)
+	})
+
+	w := &RedisWatcher{
+		client:     client,
+		instanceID: instanceID,
+		l:          l,
+		pubsub:     pubsub,
+		notify:     make(chan struct{}, 1),
+		cancel:     cancel,
+		version:    policyVersion.WithLabelValues(instanceID),
+	}
+	// 启动时加载的就是当前最新的策略
+	current, err := w.currentVersion(ctx)
+	if err != nil {
+		l.Warn("获取策略版本失败", zap.Error(err))
+	}
+	w.setApplied(current)
+
+	go w.subscribe(ctx)
+	go w.run(ctx)
+	return w, nil
+}
+
+// SetUpdateCallback 实现 persist.Watcher，回调无法报告失败，加载失败时版本仍会被记为已生效，
+// 应使用 SetReloadCallback
+func (w *RedisWatcher) SetUpdateCallback(callback func(string)) error {
+	return w.SetReloadCallback(func(id string) error {
+		callback(id)
+		return nil
+	})
+}
+
+// SetReloadCallback 设置收到变更通知后的回调，通常为 Enforcer.LoadPolicy，
+// 回调返回错误时不记录版本，由下一次版本比对重试
+func (w *RedisWatcher) SetReloadCallback(callback func(string) error) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.callback = callback
+	return nil
+}
+
+// Update 本实例修改策略后自增版本号并通知其他实例
+func (w *RedisWatcher) Update() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	version, err := w.client.Incr(ctx, policyVersionKey).Result()
+	if err != nil {
+		w.l.Error("更新策略版本失败", zap.Error(err))
+		return err
+	}
+	// 本实例的策略已经在内存中修改过，直接记为已生效
+	w.setApplied(version)
+
+	data, err := json.Marshal(policyMessage{Instance: w.instanceID, Version: version})
+	if err != nil {
+		return err
+	}
+	if err := w.client.Publish(ctx, policyChannel, data).Err(); err != nil {
+		w.l.Error("发布策略变更通知失败", zap.Int64("version", version), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Close 停止订阅，之后不再触发回调
+func (w *RedisWatcher) Close() {
+	w.cancel()
+	if err := w.pubsub.Close(); err != nil {
+		w.l.Warn("关闭策略变更订阅失败", zap.Error(err))
+	}
+}
+
+// subscribe 接收其他实例发出的变更通知
+func (w *RedisWatcher) subscribe(ctx context.Context) {
+	ch := w.pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			var pm policyMessage
+			if err := json.Unmarshal([]byte(msg.Payload), &pm); err != nil {
+				w.l.Warn("解析策略变更通知失败", zap.String("payload", msg.Payload), zap.Error(err))
+				continue
+			}
+			if pm.Instance == w.instanceID || pm.Version <= w.appliedVersion() {
+				continue
+			}
+			w.trigger()
+		}
+	}
+}
+
+// run 合并通知后重新加载策略，并定期比对版本号
+func (w *RedisWatcher) run(ctx context.Context) {
+	ticker := time.NewTicker(versionPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			current, err := w.currentVersion(ctx)
+			if err != nil {
+				w.l.Warn("获取策略版本失败", zap.Error(err))
+				continue
+			}
+			if current > w.appliedVersion() {
+				w.reload(ctx)
+			}
+		case <-w.notify:
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(reloadDebounce):
+			}
+			// 等待期间到达的通知已包含在本次加载中
+			select {
+			case <-w.notify:
+			default:
+			}
+			w.reload(ctx)
+		}
+	}
+}
+
+// reload 调用回调重新加载策略，先读取版本号，保证记录的版本不会超前于实际加载的策略
+func (w *RedisWatcher) reload(ctx context.Context) {
+	current, err := w.currentVersion(ctx)
+	if err != nil {
+		w.l.Warn("获取策略版本失败", zap.Error(err))
+	}
+
+	w.mu.Lock()
+	callback := w.callback
+	w.mu.Unlock()
+	if callback == nil {
+		return
+	}
+	if err := callback(w.instanceID); err != nil {
+		w.l.Error("重新加载权限策略失败，等待下次重试", zap.Int64("version", current), zap.Error(err))
+		return
+	}
+
+	w.setApplied(current)
+	w.l.Info("重新加载权限策略", zap.Int64("version", current))
+}
+
+// trigger 请求重新加载，已有待处理的请求时直接合并
+func (w *RedisWatcher) trigger() {
+	select {
+	case w.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (w *RedisWatcher) currentVersion(ctx context.Context) (int64, error) {
+	version, err := w.client.Get(ctx, policyVersionKey).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return version, err
+}
+
+func (w *RedisWatcher) appliedVersion() int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.applied
+}
+
+// setApplied 记录已生效的版本，版本号只增不减
+func (w *RedisWatcher) setApplied(version int64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if version <= w.applied {
+		return
+	}
+	w.applied = version
+	w.version.Set(float64(version))
+}
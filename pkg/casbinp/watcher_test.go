@@ -0,0 +1,108 @@
+package casbinp
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+func TestRedisWatcherReloadsOtherInstances(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	// 同一进程内创建多个监听器不能重复注册指标
+	a, err := NewRedisWatcher(client, "a", zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.Close()
+	b, err := NewRedisWatcher(client, "b", zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+
+	var reloadA, reloadB atomic.Int32
+	_ = a.SetUpdateCallback(func(string) { reloadA.Add(1) })
+	_ = b.SetUpdateCallback(func(string) { reloadB.Add(1) })
+
+	// 连续多次变更合并为一次加载
+	for i := 0; i < 3; i++ {
+		if err := a.Update(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deadline := time.Now().Add(3 * time.Second)
+	for reloadB.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(20 * time.Millisecond)
+	}
+	time.Sleep(2 * reloadDebounce)
+
+	if got := reloadB.Load(); got != 1 {
+		t.Fatalf("instance b reloaded %d times, want 1", got)
+	}
+	if got := reloadA.Load(); got != 0 {
+		t.Fatalf("instance a reloaded its own update %d times", got)
+	}
+	if a.appliedVersion() != 3 || b.appliedVersion() != 3 {
+		t.Fatalf("applied versions a=%d b=%d, want 3", a.appliedVersion(), b.appliedVersion())
+	}
+}
+
+func TestRedisWatcherRetriesFailedReload(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	a, err := NewRedisWatcher(client, "a", zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.Close()
+	b, err := NewRedisWatcher(client, "b", zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+
+	var attempts atomic.Int32
+	var fail atomic.Bool
+	fail.Store(true)
+	_ = b.SetReloadCallback(func(string) error {
+		attempts.Add(1)
+		if fail.Load() {
+			return errors.New("load policy failed")
+		}
+		return nil
+	})
+
+	if err := a.Update(); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(3 * time.Second)
+	for attempts.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(20 * time.Millisecond)
+	}
+	if attempts.Load() == 0 {
+		t.Fatal("instance b did not try to reload")
+	}
+	// 加载失败的版本不能记为已生效
+	if got := b.appliedVersion(); got != 0 {
+		t.Fatalf("applied version after failed reload = %d, want 0", got)
+	}
+
+	// 模拟定期比对触发的重试
+	fail.Store(false)
+	b.reload(context.Background())
+	if got := b.appliedVersion(); got != 1 {
+		t.Fatalf("applied version after retry = %d, want 1", got)
+	}
+}
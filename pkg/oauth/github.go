@@ -0,0 +1,73 @@
+package oauth
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+const (
+	githubAuthURL     = "https://github.com/login/oauth/authorize"
+	githubTokenURL    = "https://github.com/login/oauth/access_token"
+	githubUserInfoURL = "https://api.github.com/user"
+)
+
+// GitHub GitHub 登录
+type GitHub struct {
+	client
+}
+
+// NewGitHub 创建 GitHub 登录，未配置的端点使用 GitHub 官方地址
+func NewGitHub(cfg Config, hc *http.Client) *GitHub {
+	if cfg.AuthURL == "" {
+		cfg.AuthURL = githubAuthURL
+	}
+	if cfg.TokenURL == "" {
+		cfg.TokenURL = githubTokenURL
+	}
+	if cfg.UserInfoURL == "" {
+		cfg.UserInfoURL = githubUserInfoURL
+	}
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"read:user", "user:email"}
+	}
+	return &GitHub{client: newClient(cfg, hc)}
+}
+
+func (g *GitHub) Name() string {
+	return "github"
+}
+
+func (g *GitHub) AuthCodeURL(state string) string {
+	return g.authCodeURL(state)
+}
+
+func (g *GitHub) Exchange(ctx context.Context, code string) (Identity, error) {
+	token, err := g.exchange(ctx, code)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	var user struct {
+		ID        int64  `json:"id"`
+		Login     string `json:"login"`
+		Name      string `json:"name"`
+		Email     string `json:"email"`
+		AvatarURL string `json:"avatar_url"`
+	}
+	if err := g.userInfo(ctx, token, &user); err != nil {
+		return Identity{}, err
+	}
+	if user.ID == 0 {
+		return Identity{}, ErrExchangeFailed
+	}
+
+	return Identity{
+		Provider: g.Name(),
+		Subject:  strconv.FormatInt(user.ID, 10),
+		Username: user.Login,
+		Name:     user.Name,
+		Email:    user.Email,
+		Avatar:   user.AvatarURL,
+	}, nil
+}
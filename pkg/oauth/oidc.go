@@ -0,0 +1,95 @@
+package oauth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// OIDC 通用 OpenID Connect 登录，通过 userinfo 端点获取账号信息
+type OIDC struct {
+	client
+	name string
+}
+
+// NewOIDC 创建 OIDC 登录，配置了 issuer 且未配置端点时通过发现文档获取端点
+func NewOIDC(ctx context.Context, name, issuer string, cfg Config, hc *http.Client) (*OIDC, error) {
+	c := newClient(cfg, hc)
+	if issuer != "" && (cfg.AuthURL == "" || cfg.TokenURL == "" || cfg.UserInfoURL == "") {
+		if err := c.discover(ctx, issuer); err != nil {
+			return nil, fmt.Errorf("获取 OIDC 发现文档失败: %w", err)
+		}
+	}
+	if c.cfg.AuthURL == "" || c.cfg.TokenURL == "" || c.cfg.UserInfoURL == "" {
+		return nil, fmt.Errorf("OIDC 登录 %s 缺少端点配置", name)
+	}
+	if len(c.cfg.Scopes) == 0 {
+		c.cfg.Scopes = []string{"openid", "profile", "email"}
+	}
+	return &OIDC{client: c, name: name}, nil
+}
+
+// discover 读取 issuer 的发现文档，仅补全未配置的端点
+func (c *client) discover(ctx context.Context, issuer string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(issuer, "/")+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return err
+	}
+	var doc struct {
+		AuthorizationEndpoint string `json:"authorization_endpoint"`
+		TokenEndpoint         string `json:"token_endpoint"`
+		UserInfoEndpoint      string `json:"userinfo_endpoint"`
+	}
+	if err := c.do(req, &doc); err != nil {
+		return err
+	}
+	if c.cfg.AuthURL == "" {
+		c.cfg.AuthURL = doc.AuthorizationEndpoint
+	}
+	if c.cfg.TokenURL == "" {
+		c.cfg.TokenURL = doc.TokenEndpoint
+	}
+	if c.cfg.UserInfoURL == "" {
+		c.cfg.UserInfoURL = doc.UserInfoEndpoint
+	}
+	return nil
+}
+
+func (o *OIDC) Name() string {
+	return o.name
+}
+
+func (o *OIDC) AuthCodeURL(state string) string {
+	return o.authCodeURL(state)
+}
+
+func (o *OIDC) Exchange(ctx context.Context, code string) (Identity, error) {
+	token, err := o.exchange(ctx, code)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	var claims struct {
+		Subject           string `json:"sub"`
+		PreferredUsername string `json:"preferred_username"`
+		Name              string `json:"name"`
+		Email             string `json:"email"`
+		Picture           string `json:"picture"`
+	}
+	if err := o.userInfo(ctx, token, &claims); err != nil {
+		return Identity{}, err
+	}
+	if claims.Subject == "" {
+		return Identity{}, ErrExchangeFailed
+	}
+
+	return Identity{
+		Provider: o.name,
+		Subject:  claims.Subject,
+		Username: claims.PreferredUsername,
+		Name:     claims.Name,
+		Email:    claims.Email,
+		Avatar:   claims.Picture,
+	}, nil
+}
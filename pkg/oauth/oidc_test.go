@@ -0,0 +1,99 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// newMockOIDCServer 模拟 OIDC 服务，授权码固定为 good-code
+func newMockOIDCServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	var srv *httptest.Server
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"authorization_endpoint": srv.URL + "/authorize",
+			"token_endpoint":         srv.URL + "/token",
+			"userinfo_endpoint":      srv.URL + "/userinfo",
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("code") != "good-code" || r.PostForm.Get("client_secret") != "secret" {
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "token-1", "token_type": "Bearer"})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"sub":                "user-42",
+			"preferred_username": "alice",
+			"email":              "alice@example.com",
+		})
+	})
+	srv = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestOIDCDiscoveryAndExchange(t *testing.T) {
+	srv := newMockOIDCServer(t)
+	p, err := NewOIDC(context.Background(), "oidc", srv.URL, Config{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		RedirectURL:  "http://localhost/callback",
+	}, srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u, err := url.Parse(p.AuthCodeURL("state-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(u.String(), srv.URL+"/authorize?") || u.Query().Get("state") != "state-1" || u.Query().Get("scope") != "openid profile email" {
+		t.Errorf("unexpected auth url: %s", u)
+	}
+
+	id, err := p.Exchange(context.Background(), "good-code")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.Provider != "oidc" || id.Subject != "user-42" || id.Username != "alice" || id.Email != "alice@example.com" {
+		t.Errorf("unexpected identity: %+v", id)
+	}
+
+	if _, err := p.Exchange(context.Background(), "bad-code"); err == nil {
+		t.Error("expected error for invalid code")
+	}
+}
+
+func TestGitHubConfigurableEndpoints(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "gh-token"})
+	})
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": 7, "login": "octocat"})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	p := NewGitHub(Config{TokenURL: srv.URL + "/token", UserInfoURL: srv.URL + "/user"}, srv.Client())
+	id, err := p.Exchange(context.Background(), "code")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.Subject != "7" || id.Username != "octocat" || id.Provider != "github" {
+		t.Errorf("unexpected identity: %+v", id)
+	}
+}
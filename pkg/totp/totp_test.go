@@ -0,0 +1,73 @@
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret RFC 6238 附录B中 SHA1 测试向量使用的密钥
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestGenerateCodeRFC6238(t *testing.T) {
+	cases := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, c := range cases {
+		got, err := GenerateCode(rfcSecret, Counter(time.Unix(c.unix, 0)))
+		if err != nil {
+			t.Fatalf("GenerateCode(%d): %v", c.unix, err)
+		}
+		if got != c.code {
+			t.Errorf("GenerateCode(%d) = %s, want %s", c.unix, got, c.code)
+		}
+	}
+}
+
+func TestValidateSkew(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	prev, _ := GenerateCode(rfcSecret, Counter(now)-1)
+
+	if counter, ok := Validate(rfcSecret, prev, now, 1); !ok || counter != Counter(now)-1 {
+		t.Fatalf("Validate with skew 1 = (%d, %v), want (%d, true)", counter, ok, Counter(now)-1)
+	}
+	if _, ok := Validate(rfcSecret, prev, now, 0); ok {
+		t.Fatal("Validate with skew 0 accepted previous code")
+	}
+	if _, ok := Validate(rfcSecret, "12345", now, 1); ok {
+		t.Fatal("Validate accepted short code")
+	}
+	if _, ok := Validate("not base32!", "123456", now, 1); ok {
+		t.Fatal("Validate accepted invalid secret")
+	}
+}
+
+func TestGenerateSecretAndURI(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GenerateCode(secret, 1); err != nil {
+		t.Fatalf("generated secret is not usable: %v", err)
+	}
+
+	u, err := url.Parse(URI("LinkMe", "alice", secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Scheme != "otpauth" || u.Host != "totp" || !strings.HasSuffix(u.Path, "LinkMe:alice") {
+		t.Fatalf("unexpected uri %s", u)
+	}
+	if u.Query().Get("secret") != secret || u.Query().Get("issuer") != "LinkMe" {
+		t.Fatalf("unexpected query %s", u.RawQuery)
+	}
+}
@@ -0,0 +1,17 @@
+package utils
+
+import (
+	"net/mail"
+	"strings"
+)
+
+// IsValidEmail 检查给定的字符串是否为单个合法的邮箱地址
+func IsValidEmail(email string) bool {
+	addr, err := mail.ParseAddress(email)
+	return err == nil && addr.Address == email
+}
+
+// NormalizeEmail 统一邮箱格式，保证验证码与查询使用同一个地址
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
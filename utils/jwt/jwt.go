@@ -28,6 +28,8 @@ package jwt
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -42,10 +44,26 @@ type Handler interface {
 	SetLoginToken(ctx *gin.Context, uid int64) (string, string, error)
 	SetJWTToken(ctx *gin.Context, uid int64, ssid string) (string, error)
 	ExtractToken(ctx *gin.Context) string
+	// ParseAccessToken 校验短Token的签名与有效期
+	ParseAccessToken(ctx *gin.Context, token string) (UserClaims, error)
+	// JWKS 获取用于校验短Token的公钥集合，HS512 模式下为空
+	JWKS(ctx *gin.Context) (JWKS, error)
 	CheckSession(ctx *gin.Context, ssid string) error
-	VerifyRefreshToken(ctx *gin.Context, token string) (bool, *RefreshClaims, error)
+	// RotateRefreshToken 校验长Token并签发新的长Token，旧Token随即失效；
+	// 已轮换的旧Token再次使用时使整个会话失效，返回 ErrRefreshTokenReused
+	RotateRefreshToken(ctx *gin.Context, token string) (*RefreshClaims, string, error)
 	ClearToken(ctx *gin.Context) error
-	setRefreshToken(ctx *gin.Context, uid int64, ssid string) (string, error)
+	// ClearUserSessions 使用户的全部会话失效，用于重置密码等场景
+	ClearUserSessions(ctx *gin.Context, uid int64) error
+	// TouchSession 更新会话的最近活跃时间
+	TouchSession(ctx *gin.Context, ssid string) error
+	// ListSessions 获取用户仍然有效的会话，按最近活跃时间倒序
+	ListSessions(ctx *gin.Context, uid int64) ([]Session, error)
+	// RevokeSession 使用户的指定会话失效
+	RevokeSession(ctx *gin.Context, uid int64, ssid string) error
+	// RevokeOtherSessions 使用户除 keepSsid 外的全部会话失效
+	RevokeOtherSessions(ctx *gin.Context, uid int64, keepSsid string) error
+	setRefreshToken(ctx *gin.Context, uid int64, ssid string, jti string) (string, error)
 }
 
 type UserClaims struct {
@@ -54,6 +72,9 @@ type UserClaims struct {
 	Ssid        string
 	UserAgent   string
 	ContentType string
+	// TokenID 个人访问令牌ID，通过 JWT 登录时为0
+	TokenID int64    `json:",omitempty"`
+	Scopes  []string `json:",omitempty"`
 }
 
 type RefreshClaims struct {
@@ -62,12 +83,68 @@ type RefreshClaims struct {
 	Ssid string
 }
 
+// Session 用户的登录会话
+type Session struct {
+	Ssid      string `json:"ssid"`
+	Device    string `json:"device"`
+	UserAgent string `json:"userAgent"`
+	IP        string `json:"ip"`
+	CreatedAt int64  `json:"createdAt"` // 登录时间，毫秒时间戳
+	LastSeen  int64  `json:"lastSeen"`  // 最近活跃时间，毫秒时间戳
+	Current   bool   `json:"current"`   // 是否为发起请求的会话
+}
+
+var (
+	// ErrSessionNotFound 表示会话不存在或不属于该用户
+	ErrSessionNotFound = errors.New("会话不存在或已失效")
+	// ErrInvalidRefreshToken 表示长Token无效、已过期或会话已失效
+	ErrInvalidRefreshToken = errors.New("无效的refresh token")
+	// ErrRefreshTokenReused 表示已轮换的长Token被再次使用，会话已被强制下线
+	ErrRefreshTokenReused = errors.New("refresh token已被使用，请重新登录")
+)
+
+// refreshConcurrentLeeway 轮换后短时间内旧Token再次使用视为客户端并发刷新，仅拒绝不下线
+const refreshConcurrentLeeway = 10 * time.Second
+
+// touchScript 仅在会话存在时更新活跃时间，避免为已过期的会话重建记录
+var touchScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	redis.call('HSET', KEYS[1], 'last_seen', ARGV[1])
+	return 1
+end
+return 0
+`)
+
+// rotateScript 校验长Token是否为令牌族中的最新一个并完成轮换
+// 返回 1 轮换成功；0 令牌族不存在；-1 旧Token被重复使用；-2 并发刷新
+// 令牌族不存在且 ARGV[4] 为 1 时为升级前签发的无 jti 的长Token，直接建立令牌族
+var rotateScript = redis.NewScript(`
+local cur = redis.call('HGET', KEYS[1], 'jti')
+if not cur then
+	if ARGV[4] ~= '1' then
+		return 0
+	end
+elseif cur ~= ARGV[1] then
+	local prev = redis.call('HGET', KEYS[1], 'prev')
+	local rotatedAt = tonumber(redis.call('HGET', KEYS[1], 'rotated_at') or '0')
+	if prev == ARGV[1] and tonumber(ARGV[5]) - rotatedAt < tonumber(ARGV[6]) then
+		return -2
+	end
+	return -1
+end
+redis.call('HSET', KEYS[1], 'jti', ARGV[2], 'prev', ARGV[1], 'rotated_at', ARGV[5])
+redis.call('PEXPIRE', KEYS[1], ARGV[3])
+return 1
+`)
+
 type handler struct {
-	client        redis.Cmdable
+	client redis.Cmdable
+	// keys 短Token的签名密钥，其他服务可通过 JWKS 校验
+	keys keyRing
+	// signingMethod 长Token仅由本服务校验，始终使用 jwt.refresh_key 对称签名
 	signingMethod jwt.SigningMethod
 	jwtExpiration time.Duration
 	rcExpiration  time.Duration
-	key1          []byte
 	key2          []byte
 	issuer        string
 }
@@ -85,12 +162,34 @@ func NewJWTHandler(c redis.Cmdable) Handler {
 		refreshExpireHours = 150
 	}
 
+	jwtExpiration := time.Minute * time.Duration(authExpireMinutes)
+
+	var keys keyRing
+	switch alg := viper.GetString("jwt.algorithm"); alg {
+	case "", AlgHS512:
+		keys = newHMACKeyRing([]byte(key1))
+	case AlgRS256, AlgEdDSA:
+		// 宽限期不短于短Token有效期，保证轮换前签发的令牌在过期前都能通过校验
+		grace := viper.GetDuration("jwt.rotation_grace")
+		if grace < jwtExpiration {
+			grace = jwtExpiration
+		}
+		// 私钥使用 jwt.key_encryption_key 加密后保存到 Redis
+		aead, err := newKeyCipher([]byte(viper.GetString("jwt.key_encryption_key")))
+		if err != nil {
+			panic(err)
+		}
+		keys = newRedisKeyRing(c, aead, alg, viper.GetDuration("jwt.rotation_interval"), grace, []byte(key1))
+	default:
+		panic(fmt.Errorf("不支持的 jwt.algorithm: %s", alg))
+	}
+
 	return &handler{
 		client:        c,
+		keys:          keys,
 		signingMethod: jwt.SigningMethodHS512,
-		jwtExpiration: time.Minute * time.Duration(authExpireMinutes),
+		jwtExpiration: jwtExpiration,
 		rcExpiration:  time.Hour * time.Duration(refreshExpireHours),
-		key1:          []byte(key1),
 		key2:          []byte(key2),
 		issuer:        issuer,
 	}
@@ -99,7 +198,8 @@ func NewJWTHandler(c redis.Cmdable) Handler {
 // SetLoginToken 设置长短Token
 func (h *handler) SetLoginToken(ctx *gin.Context, uid int64) (string, string, error) {
 	ssid := uuid.New().String()
-	refreshToken, err := h.setRefreshToken(ctx, uid, ssid)
+	jti := uuid.New().String()
+	refreshToken, err := h.setRefreshToken(ctx, uid, ssid, jti)
 	if err != nil {
 		return "", "", err
 	}
@@ -109,6 +209,11 @@ func (h *handler) SetLoginToken(ctx *gin.Context, uid int64) (string, string, er
 		return "", "", err
 	}
 
+	// 记录用户的会话与长Token令牌族，便于统一失效
+	if err := h.addUserSession(ctx, uid, ssid, jti); err != nil {
+		return "", "", err
+	}
+
 	return jwtToken, refreshToken, nil
 }
 
@@ -121,13 +226,21 @@ func (h *handler) SetJWTToken(ctx *gin.Context, uid int64, ssid string) (string,
 		ContentType: ctx.GetHeader("Content-Type"),
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(h.jwtExpiration)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Issuer:    h.issuer,
 		},
 	}
 
-	token := jwt.NewWithClaims(h.signingMethod, uc)
+	key, err := h.keys.signingKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	token := jwt.NewWithClaims(key.method, uc)
+	if key.kid != "" {
+		token.Header["kid"] = key.kid
+	}
 	// 进行签名
-	signedString, err := token.SignedString(h.key1)
+	signedString, err := token.SignedString(key.private)
 	if err != nil {
 		return "", err
 	}
@@ -135,12 +248,34 @@ func (h *handler) SetJWTToken(ctx *gin.Context, uid int64, ssid string) (string,
 	return signedString, nil
 }
 
-// setRefreshToken 设置长Token
-func (h *handler) setRefreshToken(_ *gin.Context, uid int64, ssid string) (string, error) {
+// ParseAccessToken 根据 kid 选择验签密钥，并限制可接受的算法
+func (h *handler) ParseAccessToken(ctx *gin.Context, tokenStr string) (UserClaims, error) {
+	var uc UserClaims
+	token, err := jwt.ParseWithClaims(tokenStr, &uc, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return h.keys.verifyKey(ctx, kid, token.Method.Alg())
+	}, jwt.WithValidMethods([]string{AlgHS512, AlgRS256, AlgEdDSA}))
+	if err != nil {
+		return UserClaims{}, err
+	}
+	if token == nil || !token.Valid {
+		return UserClaims{}, errors.New("invalid authorization token")
+	}
+	return uc, nil
+}
+
+// JWKS 获取公钥集合
+func (h *handler) JWKS(ctx *gin.Context) (JWKS, error) {
+	return h.keys.jwks(ctx)
+}
+
+// setRefreshToken 设置长Token，jti 用于识别令牌族中的最新Token
+func (h *handler) setRefreshToken(_ *gin.Context, uid int64, ssid string, jti string) (string, error) {
 	rc := RefreshClaims{
 		Uid:  uid,
 		Ssid: ssid,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID: jti,
 			// 设置刷新时间为一周
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(h.rcExpiration)),
 		},
@@ -194,12 +329,8 @@ func (h *handler) ClearToken(ctx *gin.Context) error {
 	}
 
 	// 提取 token 的 claims 信息
-	claims := &UserClaims{}
-	token, err := jwt.ParseWithClaims(authToken, claims, func(token *jwt.Token) (interface{}, error) {
-		return h.key1, nil
-	})
-
-	if err != nil || !token.Valid {
+	claims, err := h.ParseAccessToken(ctx, authToken)
+	if err != nil {
 		return errors.New("invalid authorization token")
 	}
 
@@ -208,11 +339,7 @@ func (h *handler) ClearToken(ctx *gin.Context) error {
 		return err
 	}
 
-	if err := h.invalidateSession(ctx, claims.Ssid); err != nil {
-		return err
-	}
-
-	return nil
+	return h.revokeSession(ctx, claims.Uid, claims.Ssid)
 }
 
 // extractBearerToken 提取 Bearer Token
@@ -246,29 +373,211 @@ func (h *handler) invalidateSession(ctx *gin.Context, ssid string) error {
 	return h.client.Set(ctx, fmt.Sprintf("linkme:user:ssid:%s", ssid), "invalid", h.rcExpiration).Err()
 }
 
-// VerifyRefreshToken 验证refresh token
-func (h *handler) VerifyRefreshToken(ctx *gin.Context, token string) (bool, *RefreshClaims, error) {
+func userSessionsKey(uid int64) string {
+	return fmt.Sprintf("linkme:user:sessions:%d", uid)
+}
+
+func sessionKey(ssid string) string {
+	return fmt.Sprintf("linkme:user:session:%s", ssid)
+}
+
+// refreshFamilyKey 会话的长Token令牌族，记录最新Token的 jti
+func refreshFamilyKey(ssid string) string {
+	return fmt.Sprintf("linkme:user:refresh:%s", ssid)
+}
+
+// addUserSession 记录会话信息与令牌族并加入用户的会话集合，集合随最新的长Token一起过期
+func (h *handler) addUserSession(ctx *gin.Context, uid int64, ssid string, jti string) error {
+	now := time.Now().UnixMilli()
+	ua := ctx.GetHeader("User-Agent")
+	key := userSessionsKey(uid)
+	pipe := h.client.TxPipeline()
+	pipe.HSet(ctx, sessionKey(ssid),
+		"uid", uid,
+		"device", deviceName(ctx.GetHeader("X-Device-Name"), ua),
+		"user_agent", ua,
+		"ip", ctx.ClientIP(),
+		"created_at", now,
+		"last_seen", now,
+	)
+	pipe.Expire(ctx, sessionKey(ssid), h.rcExpiration)
+	pipe.HSet(ctx, refreshFamilyKey(ssid), "jti", jti)
+	pipe.Expire(ctx, refreshFamilyKey(ssid), h.rcExpiration)
+	pipe.SAdd(ctx, key, ssid)
+	pipe.Expire(ctx, key, h.rcExpiration)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// revokeSession 使会话失效并删除会话信息
+func (h *handler) revokeSession(ctx *gin.Context, uid int64, ssid string) error {
+	if err := h.invalidateSession(ctx, ssid); err != nil {
+		return err
+	}
+	pipe := h.client.TxPipeline()
+	pipe.SRem(ctx, userSessionsKey(uid), ssid)
+	pipe.Del(ctx, sessionKey(ssid), refreshFamilyKey(ssid))
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// ClearUserSessions 使用户的全部会话失效
+func (h *handler) ClearUserSessions(ctx *gin.Context, uid int64) error {
+	return h.RevokeOtherSessions(ctx, uid, "")
+}
+
+// RevokeOtherSessions 使用户除当前会话外的全部会话失效
+func (h *handler) RevokeOtherSessions(ctx *gin.Context, uid int64, keepSsid string) error {
+	ssids, err := h.client.SMembers(ctx, userSessionsKey(uid)).Result()
+	if err != nil {
+		return err
+	}
+
+	for _, ssid := range ssids {
+		if ssid == keepSsid {
+			continue
+		}
+		if err := h.revokeSession(ctx, uid, ssid); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RevokeSession 使用户的指定会话失效
+func (h *handler) RevokeSession(ctx *gin.Context, uid int64, ssid string) error {
+	ok, err := h.client.SIsMember(ctx, userSessionsKey(uid), ssid).Result()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrSessionNotFound
+	}
+
+	return h.revokeSession(ctx, uid, ssid)
+}
+
+// TouchSession 更新会话的最近活跃时间
+func (h *handler) TouchSession(ctx *gin.Context, ssid string) error {
+	return touchScript.Run(ctx, h.client, []string{sessionKey(ssid)}, time.Now().UnixMilli()).Err()
+}
+
+// ListSessions 获取用户的有效会话，顺带清理已过期的会话
+func (h *handler) ListSessions(ctx *gin.Context, uid int64) ([]Session, error) {
+	key := userSessionsKey(uid)
+	ssids, err := h.client.SMembers(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]Session, 0, len(ssids))
+	for _, ssid := range ssids {
+		fields, err := h.client.HGetAll(ctx, sessionKey(ssid)).Result()
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) == 0 {
+			h.client.SRem(ctx, key, ssid)
+			continue
+		}
+
+		createdAt, _ := strconv.ParseInt(fields["created_at"], 10, 64)
+		lastSeen, _ := strconv.ParseInt(fields["last_seen"], 10, 64)
+		sessions = append(sessions, Session{
+			Ssid:      ssid,
+			Device:    fields["device"],
+			UserAgent: fields["user_agent"],
+			IP:        fields["ip"],
+			CreatedAt: createdAt,
+			LastSeen:  lastSeen,
+		})
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeen > sessions[j].LastSeen
+	})
+	return sessions, nil
+}
+
+// deviceName 优先使用客户端上报的设备名，否则根据 User-Agent 粗略识别
+func deviceName(reported, ua string) string {
+	if reported = strings.TrimSpace(reported); reported != "" {
+		if r := []rune(reported); len(r) > 64 {
+			reported = string(r[:64])
+		}
+		return reported
+	}
+
+	platforms := []struct{ keyword, name string }{
+		{"iPhone", "iPhone"},
+		{"iPad", "iPad"},
+		{"Android", "Android"},
+		{"Windows", "Windows"},
+		{"Macintosh", "Mac"},
+		{"Linux", "Linux"},
+	}
+	for _, p := range platforms {
+		if strings.Contains(ua, p.keyword) {
+			return p.name
+		}
+	}
+	return "Unknown"
+}
+
+// RotateRefreshToken 轮换长Token
+func (h *handler) RotateRefreshToken(ctx *gin.Context, token string) (*RefreshClaims, string, error) {
 	// 解析refresh token
 	refreshClaims := &RefreshClaims{}
 	refreshToken, err := jwt.ParseWithClaims(token, refreshClaims, func(token *jwt.Token) (interface{}, error) {
 		return h.key2, nil
-	})
+	}, jwt.WithValidMethods([]string{h.signingMethod.Alg()}))
 
 	// 检查解析和验证结果
 	if err != nil || !refreshToken.Valid {
-		return false, nil, errors.New("无效的refresh token")
+		return nil, "", ErrInvalidRefreshToken
+	}
+
+	// 检查会话是否已经失效
+	if err := h.CheckSession(ctx, refreshClaims.Ssid); err != nil {
+		return nil, "", ErrInvalidRefreshToken
+	}
+
+	// 先签发新Token，轮换成功后才返回给客户端
+	jti := uuid.New().String()
+	newToken, err := h.setRefreshToken(ctx, refreshClaims.Uid, refreshClaims.Ssid, jti)
+	if err != nil {
+		return nil, "", err
 	}
 
-	// 检查token是否在黑名单中
-	exists, err := h.client.Exists(ctx, fmt.Sprintf("linkme:user:ssid:%s", refreshClaims.Ssid)).Result()
+	legacy := "0"
+	if refreshClaims.ID == "" {
+		legacy = "1"
+	}
+	res, err := rotateScript.Run(ctx, h.client, []string{refreshFamilyKey(refreshClaims.Ssid)},
+		refreshClaims.ID, jti, h.rcExpiration.Milliseconds(), legacy,
+		time.Now().UnixMilli(), refreshConcurrentLeeway.Milliseconds()).Int()
 	if err != nil {
-		return false, nil, fmt.Errorf("检查token状态失败: %v", err)
+		return nil, "", fmt.Errorf("轮换refresh token失败: %v", err)
 	}
 
-	// 如果token在黑名单中,说明已经失效
-	if exists > 0 {
-		return false, nil, errors.New("refresh token已失效")
+	if res == -1 {
+		// 旧Token被重复使用，说明Token可能已泄露，使整个会话失效
+		if err := h.revokeSession(ctx, refreshClaims.Uid, refreshClaims.Ssid); err != nil {
+			return nil, "", err
+		}
+		return refreshClaims, "", ErrRefreshTokenReused
+	}
+	if res != 1 {
+		return nil, "", ErrInvalidRefreshToken
 	}
 
-	return true, refreshClaims, nil
+	// 会话随最新的长Token续期
+	pipe := h.client.TxPipeline()
+	pipe.Expire(ctx, sessionKey(refreshClaims.Ssid), h.rcExpiration)
+	pipe.Expire(ctx, userSessionsKey(refreshClaims.Uid), h.rcExpiration)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, "", err
+	}
+	return refreshClaims, newToken, nil
 }
@@ -0,0 +1,271 @@
+package jwt
+
+import (
+	"context"
+	"crypto/cipher"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestDeviceName(t *testing.T) {
+	cases := []struct {
+		reported, ua, want string
+	}{
+		{"", "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X)", "iPhone"},
+		{"", "Mozilla/5.0 (Linux; Android 14; Pixel 8)", "Android"},
+		{"", "Mozilla/5.0 (Macintosh; Intel Mac OS X 14_0)", "Mac"},
+		{"", "curl/8.0", "Unknown"},
+		{" 我的笔记本 ", "Mozilla/5.0 (Windows NT 10.0)", "我的笔记本"},
+	}
+	for _, c := range cases {
+		if got := deviceName(c.reported, c.ua); got != c.want {
+			t.Errorf("deviceName(%q, %q) = %q, want %q", c.reported, c.ua, got, c.want)
+		}
+	}
+
+	long := strings.Repeat("设", 100)
+	if got := []rune(deviceName(long, "")); len(got) != 64 {
+		t.Errorf("reported device name should be truncated to 64 runes, got %d", len(got))
+	}
+}
+
+func newTestCipher(t *testing.T, kek string) cipher.AEAD {
+	t.Helper()
+	aead, err := newKeyCipher([]byte(kek))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return aead
+}
+
+func TestSigningKeyRoundTrip(t *testing.T) {
+	aead := newTestCipher(t, "kek")
+	for _, alg := range []string{AlgRS256, AlgEdDSA} {
+		key, err := generateKey(alg)
+		if err != nil {
+			t.Fatalf("generateKey(%s): %v", alg, err)
+		}
+		val, err := encodeKey(key, aead)
+		if err != nil {
+			t.Fatalf("encodeKey(%s): %v", alg, err)
+		}
+		decoded, err := decodeKey(val, aead)
+		if err != nil {
+			t.Fatalf("decodeKey(%s): %v", alg, err)
+		}
+		if _, err := decodeKey(val, newTestCipher(t, "other")); err == nil {
+			t.Fatalf("decodeKey(%s) with wrong key encryption key should fail", alg)
+		}
+		if decoded.kid != key.kid || decoded.method.Alg() != alg {
+			t.Fatalf("decoded key = %s/%s, want %s/%s", decoded.kid, decoded.method.Alg(), key.kid, alg)
+		}
+
+		token := jwt.NewWithClaims(key.method, jwt.RegisteredClaims{Subject: "1"})
+		signed, err := token.SignedString(key.private)
+		if err != nil {
+			t.Fatalf("sign with %s: %v", alg, err)
+		}
+		if _, err := jwt.Parse(signed, func(*jwt.Token) (interface{}, error) {
+			return decoded.public, nil
+		}); err != nil {
+			t.Errorf("verify %s token with decoded key: %v", alg, err)
+		}
+
+		jwk, ok := decoded.jwk()
+		if !ok || jwk.Kid != key.kid || jwk.Alg != alg || jwk.Use != "sig" {
+			t.Errorf("jwk(%s) = %+v", alg, jwk)
+		}
+	}
+}
+
+func TestHMACKeyRing(t *testing.T) {
+	ring := newHMACKeyRing([]byte("secret"))
+	if _, err := ring.verifyKey(context.Background(), "", AlgHS512); err != nil {
+		t.Errorf("HS512 token without kid should verify: %v", err)
+	}
+	if _, err := ring.verifyKey(context.Background(), "", AlgRS256); err == nil {
+		t.Errorf("RS256 token should be rejected by HMAC key ring")
+	}
+	if _, ok := ring.key.jwk(); ok {
+		t.Errorf("symmetric key should not be published in JWKS")
+	}
+}
+
+func TestRedisKeyRingLegacyWindow(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+	ctx := context.Background()
+
+	// 从 HS512 切换后，旧令牌只在宽限期内有效
+	ring := newRedisKeyRing(client, newTestCipher(t, "kek"), AlgEdDSA, 0, 200*time.Millisecond, []byte("secret"))
+	if _, err := ring.signingKey(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ring.verifyKey(ctx, "", AlgHS512); err != nil {
+		t.Fatalf("HS512 token within grace period should verify: %v", err)
+	}
+	time.Sleep(250 * time.Millisecond)
+	if _, err := ring.verifyKey(ctx, "", AlgHS512); !errors.Is(err, ErrUnknownSigningKey) {
+		t.Fatalf("HS512 token after grace period = %v, want ErrUnknownSigningKey", err)
+	}
+
+	// 其他实例启动时密钥已存在，同样不接受 HS512 令牌
+	other := newRedisKeyRing(client, newTestCipher(t, "kek"), AlgEdDSA, 0, time.Hour, []byte("secret"))
+	if _, err := other.verifyKey(ctx, "", AlgHS512); !errors.Is(err, ErrUnknownSigningKey) {
+		t.Fatalf("HS512 token on existing key ring = %v, want ErrUnknownSigningKey", err)
+	}
+}
+
+func TestRedisKeyRingRejectsUnencryptedKeys(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	// 缺少加密算法的记录视为损坏，不能作为明文私钥加载
+	key, err := generateKey(AlgEdDSA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key.private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	val, _ := json.Marshal(keyRecord{Kid: key.kid, Alg: AlgEdDSA, Private: der, CreatedAt: key.createdAt})
+	if _, err := decodeKey(string(val), newTestCipher(t, "kek")); err == nil {
+		t.Fatal("decodeKey should reject a record without encryption")
+	}
+
+	mr.HSet(jwtKeysKey, key.kid, string(val))
+	ring := newRedisKeyRing(client, newTestCipher(t, "kek"), AlgEdDSA, 0, time.Hour, nil)
+	if err := ring.reload(context.Background()); err == nil {
+		t.Fatal("reload should fail on a corrupt key record")
+	}
+	if got := mr.HGet(jwtKeysKey, key.kid); got != string(val) {
+		t.Fatalf("corrupt key record should be left untouched, got %s", got)
+	}
+}
+
+// newTestHandler 创建使用 miniredis 的处理器与请求上下文
+func newTestHandler(t *testing.T) (*handler, *miniredis.Miniredis, *gin.Context) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	gin.SetMode(gin.TestMode)
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/api/user/refresh_token", nil)
+	return &handler{
+		client:        client,
+		keys:          newHMACKeyRing([]byte("auth")),
+		signingMethod: jwt.SigningMethodHS512,
+		jwtExpiration: time.Minute,
+		rcExpiration:  time.Hour,
+		key2:          []byte("refresh"),
+	}, mr, ctx
+}
+
+func TestRotateRefreshToken(t *testing.T) {
+	t.Run("rotation", func(t *testing.T) {
+		h, _, ctx := newTestHandler(t)
+		_, first, err := h.SetLoginToken(ctx, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		claims, second, err := h.RotateRefreshToken(ctx, first)
+		if err != nil {
+			t.Fatalf("rotate: %v", err)
+		}
+		if claims.Uid != 1 || second == "" || second == first {
+			t.Fatalf("unexpected rotation result uid=%d token=%q", claims.Uid, second)
+		}
+		// 新Token可以继续轮换
+		if _, _, err := h.RotateRefreshToken(ctx, second); err != nil {
+			t.Fatalf("rotate new token: %v", err)
+		}
+	})
+
+	t.Run("reuse within leeway", func(t *testing.T) {
+		h, _, ctx := newTestHandler(t)
+		_, first, _ := h.SetLoginToken(ctx, 1)
+		claims, second, err := h.RotateRefreshToken(ctx, first)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// 并发刷新时旧Token仅被拒绝，会话保持有效
+		if _, _, err := h.RotateRefreshToken(ctx, first); !errors.Is(err, ErrInvalidRefreshToken) {
+			t.Fatalf("reuse within leeway = %v, want ErrInvalidRefreshToken", err)
+		}
+		if err := h.CheckSession(ctx, claims.Ssid); err != nil {
+			t.Fatalf("session should not be revoked: %v", err)
+		}
+		if _, _, err := h.RotateRefreshToken(ctx, second); err != nil {
+			t.Fatalf("latest token should still rotate: %v", err)
+		}
+	})
+
+	t.Run("reuse after leeway", func(t *testing.T) {
+		h, mr, ctx := newTestHandler(t)
+		_, first, _ := h.SetLoginToken(ctx, 1)
+		claims, second, err := h.RotateRefreshToken(ctx, first)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rotatedAt := time.Now().Add(-refreshConcurrentLeeway - time.Second).UnixMilli()
+		mr.HSet(refreshFamilyKey(claims.Ssid), "rotated_at", strconv.FormatInt(rotatedAt, 10))
+
+		// 超过并发窗口后重复使用旧Token，整个会话失效
+		if _, _, err := h.RotateRefreshToken(ctx, first); !errors.Is(err, ErrRefreshTokenReused) {
+			t.Fatalf("reuse after leeway = %v, want ErrRefreshTokenReused", err)
+		}
+		if err := h.CheckSession(ctx, claims.Ssid); err == nil {
+			t.Fatal("session should be revoked after token reuse")
+		}
+		if _, _, err := h.RotateRefreshToken(ctx, second); !errors.Is(err, ErrInvalidRefreshToken) {
+			t.Fatalf("latest token after reuse = %v, want ErrInvalidRefreshToken", err)
+		}
+	})
+
+	t.Run("legacy token without jti", func(t *testing.T) {
+		h, mr, ctx := newTestHandler(t)
+		// 升级前签发的长Token没有 jti，也没有令牌族
+		legacy, err := h.setRefreshToken(ctx, 1, "legacy-ssid", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, next, err := h.RotateRefreshToken(ctx, legacy)
+		if err != nil {
+			t.Fatalf("rotate legacy token: %v", err)
+		}
+		if mr.HGet(refreshFamilyKey("legacy-ssid"), "jti") == "" {
+			t.Fatal("legacy token rotation should start a token family")
+		}
+		if _, _, err := h.RotateRefreshToken(ctx, next); err != nil {
+			t.Fatalf("rotate token issued for legacy session: %v", err)
+		}
+	})
+
+	t.Run("revoked session", func(t *testing.T) {
+		h, _, ctx := newTestHandler(t)
+		_, token, _ := h.SetLoginToken(ctx, 1)
+		if err := h.ClearUserSessions(ctx, 1); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := h.RotateRefreshToken(ctx, token); !errors.Is(err, ErrInvalidRefreshToken) {
+			t.Fatalf("rotate token of revoked session = %v, want ErrInvalidRefreshToken", err)
+		}
+	})
+}
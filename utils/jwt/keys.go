@@ -0,0 +1,507 @@
+package jwt
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
+)
+
+// 访问令牌支持的签名算法
+const (
+	AlgHS512 = "HS512" // 使用 jwt.auth_key 对称签名，不对外公开密钥
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+)
+
+var (
+	// ErrUnknownSigningKey 表示令牌的 kid 不存在、已过宽限期或与算法不匹配
+	ErrUnknownSigningKey = errors.New("unknown signing key")
+	// ErrSigningKeyNotReady 表示其他实例正在生成首个签名密钥
+	ErrSigningKeyNotReady = errors.New("signing key not ready")
+)
+
+const (
+	jwtKeysKey     = "linkme:jwt:keys"
+	jwtKeysLockKey = "linkme:jwt:keys:lock"
+	// jwtLegacyUntilKey 切换到非对称算法后仍接受 HS512 令牌的截止时间
+	jwtLegacyUntilKey = "linkme:jwt:legacy_until"
+	// keyReloadInterval 本地密钥缓存的刷新间隔，用于感知其他实例完成的轮换
+	keyReloadInterval = time.Minute
+	// keyMissReloadInterval 遇到未知 kid 时重新加载的最小间隔，避免伪造的 kid 频繁访问 Redis
+	keyMissReloadInterval = 10 * time.Second
+	rotateLockTTL         = 30 * time.Second
+)
+
+// JWK 单个公钥，字段遵循 RFC 7517
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS 公钥集合，供其他服务校验 LinkMe 签发的访问令牌
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// signingKey 签名密钥
+type signingKey struct {
+	kid       string
+	method    jwt.SigningMethod
+	private   interface{} // []byte、*rsa.PrivateKey 或 ed25519.PrivateKey
+	public    interface{}
+	createdAt int64
+	retireAt  int64 // 停止签名后仍可验签的截止时间，0 表示仍用于签名
+}
+
+// jwk 转换为公开的 JWK，对称密钥不公开
+func (k *signingKey) jwk() (JWK, bool) {
+	switch pub := k.public.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			Kid: k.kid,
+			Use: "sig",
+			Alg: AlgRS256,
+			N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}, true
+	case ed25519.PublicKey:
+		return JWK{
+			Kty: "OKP",
+			Kid: k.kid,
+			Use: "sig",
+			Alg: AlgEdDSA,
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(pub),
+		}, true
+	}
+	return JWK{}, false
+}
+
+// keyEncA256GCM 私钥使用 AES-256-GCM 加密保存
+const keyEncA256GCM = "A256GCM"
+
+// keyRecord Redis 中保存的密钥
+type keyRecord struct {
+	Kid       string `json:"kid"`
+	Alg       string `json:"alg"`
+	Enc       string `json:"enc"`     // 私钥加密算法，目前只支持 A256GCM
+	Private   []byte `json:"private"` // 加密后的 PKCS#8 DER，格式为 nonce || 密文
+	CreatedAt int64  `json:"createdAt"`
+	RetireAt  int64  `json:"retireAt"`
+}
+
+// newKeyCipher 根据 jwt.key_encryption_key 创建私钥的加密器
+func newKeyCipher(kek []byte) (cipher.AEAD, error) {
+	if len(kek) == 0 {
+		return nil, errors.New("jwt.key_encryption_key is required for asymmetric algorithms")
+	}
+	sum := sha256.Sum256(kek)
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// generateKey 生成新的非对称签名密钥
+func generateKey(alg string) (*signingKey, error) {
+	kid := make([]byte, 8)
+	if _, err := rand.Read(kid); err != nil {
+		return nil, err
+	}
+	key := &signingKey{
+		kid:       hex.EncodeToString(kid),
+		createdAt: time.Now().UnixMilli(),
+	}
+	switch alg {
+	case AlgRS256:
+		priv, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, err
+		}
+		key.method, key.private, key.public = jwt.SigningMethodRS256, priv, &priv.PublicKey
+	case AlgEdDSA:
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		key.method, key.private, key.public = jwt.SigningMethodEdDSA, priv, pub
+	default:
+		return nil, fmt.Errorf("unsupported jwt algorithm %q", alg)
+	}
+	return key, nil
+}
+
+// encodeKey 加密私钥后序列化，kid 作为附加数据，防止记录之间互换
+func encodeKey(k *signingKey, aead cipher.AEAD) (string, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(k.private)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	val, err := json.Marshal(keyRecord{
+		Kid:       k.kid,
+		Alg:       k.method.Alg(),
+		Enc:       keyEncA256GCM,
+		Private:   aead.Seal(nonce, nonce, der, []byte(k.kid)),
+		CreatedAt: k.createdAt,
+		RetireAt:  k.retireAt,
+	})
+	return string(val), err
+}
+
+// decodeKey 解密并解析私钥
+func decodeKey(val string, aead cipher.AEAD) (*signingKey, error) {
+	var rec keyRecord
+	if err := json.Unmarshal([]byte(val), &rec); err != nil {
+		return nil, err
+	}
+	if rec.Enc != keyEncA256GCM {
+		return nil, fmt.Errorf("corrupt key record %s: unsupported key encryption %q", rec.Kid, rec.Enc)
+	}
+	if len(rec.Private) < aead.NonceSize() {
+		return nil, errors.New("encrypted key too short")
+	}
+	nonce, sealed := rec.Private[:aead.NonceSize()], rec.Private[aead.NonceSize():]
+	der, err := aead.Open(nil, nonce, sealed, []byte(rec.Kid))
+	if err != nil {
+		return nil, fmt.Errorf("decrypt key %s: %w", rec.Kid, err)
+	}
+
+	priv, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, err
+	}
+	key := &signingKey{
+		kid:       rec.Kid,
+		private:   priv,
+		createdAt: rec.CreatedAt,
+		retireAt:  rec.RetireAt,
+	}
+	switch p := priv.(type) {
+	case *rsa.PrivateKey:
+		key.method, key.public = jwt.SigningMethodRS256, &p.PublicKey
+	case ed25519.PrivateKey:
+		key.method, key.public = jwt.SigningMethodEdDSA, p.Public()
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", priv)
+	}
+	if key.method.Alg() != rec.Alg {
+		return nil, fmt.Errorf("key %s algorithm mismatch", rec.Kid)
+	}
+	return key, nil
+}
+
+// keyRing 访问令牌的签名密钥集合
+type keyRing interface {
+	// signingKey 当前用于签名的密钥
+	signingKey(ctx context.Context) (*signingKey, error)
+	// verifyKey 根据 kid 与算法查找验签密钥
+	verifyKey(ctx context.Context, kid, alg string) (interface{}, error)
+	jwks(ctx context.Context) (JWKS, error)
+}
+
+// hmacKeyRing 兼容原有的 HS512 对称签名
+type hmacKeyRing struct {
+	key *signingKey
+}
+
+func newHMACKeyRing(secret []byte) *hmacKeyRing {
+	return &hmacKeyRing{
+		key: &signingKey{
+			method:  jwt.SigningMethodHS512,
+			private: secret,
+			public:  secret,
+		},
+	}
+}
+
+func (r *hmacKeyRing) signingKey(_ context.Context) (*signingKey, error) {
+	return r.key, nil
+}
+
+func (r *hmacKeyRing) verifyKey(_ context.Context, kid, alg string) (interface{}, error) {
+	if kid != "" || alg != AlgHS512 {
+		return nil, ErrUnknownSigningKey
+	}
+	return r.key.public, nil
+}
+
+func (r *hmacKeyRing) jwks(_ context.Context) (JWKS, error) {
+	return JWKS{Keys: []JWK{}}, nil
+}
+
+// redisKeyRing 保存在 Redis 中的非对称密钥，多实例共享，按周期自动轮换，
+// 旧密钥停止签名后在宽限期内仍可验签并保留在 JWKS 中。私钥加密保存，
+// 仅能读取 Redis 无法签发令牌
+type redisKeyRing struct {
+	client   redis.Cmdable
+	aead     cipher.AEAD
+	alg      string
+	rotation time.Duration // 0 表示不自动轮换
+	grace    time.Duration
+	legacy   *hmacKeyRing // 切换算法前签发的 HS512 令牌在过期前仍可验签
+
+	mu          sync.RWMutex
+	keys        map[string]*signingKey
+	active      *signingKey
+	legacyUntil int64 // 接受 HS512 令牌的截止时间，毫秒时间戳，0 表示不再接受
+	loadedAt    time.Time
+}
+
+func newRedisKeyRing(client redis.Cmdable, aead cipher.AEAD, alg string, rotation, grace time.Duration, legacy []byte) *redisKeyRing {
+	r := &redisKeyRing{
+		client:   client,
+		aead:     aead,
+		alg:      alg,
+		rotation: rotation,
+		grace:    grace,
+		keys:     make(map[string]*signingKey),
+	}
+	if len(legacy) > 0 {
+		r.legacy = newHMACKeyRing(legacy)
+	}
+	return r
+}
+
+// signingKey 获取签名密钥，到期时轮换
+func (r *redisKeyRing) signingKey(ctx context.Context) (*signingKey, error) {
+	r.mu.RLock()
+	active, fresh := r.active, time.Since(r.loadedAt) < keyReloadInterval
+	r.mu.RUnlock()
+	if active != nil && fresh && !r.due(active) {
+		return active, nil
+	}
+
+	if err := r.reload(ctx); err != nil {
+		if active != nil {
+			return active, nil
+		}
+		return nil, err
+	}
+	r.mu.RLock()
+	active = r.active
+	r.mu.RUnlock()
+	if active != nil && !r.due(active) {
+		return active, nil
+	}
+
+	if err := r.rotate(ctx); err != nil {
+		if active != nil {
+			return active, nil
+		}
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.active == nil {
+		return nil, ErrSigningKeyNotReady
+	}
+	return r.active, nil
+}
+
+// verifyKey 查找验签密钥，未知的 kid 会触发一次限频的重新加载
+func (r *redisKeyRing) verifyKey(ctx context.Context, kid, alg string) (interface{}, error) {
+	if kid == "" {
+		if r.legacy == nil || !r.acceptLegacy(ctx) {
+			return nil, ErrUnknownSigningKey
+		}
+		return r.legacy.verifyKey(ctx, kid, alg)
+	}
+
+	r.mu.RLock()
+	key, stale := r.keys[kid], time.Since(r.loadedAt) > keyMissReloadInterval
+	r.mu.RUnlock()
+	if key == nil && stale {
+		if err := r.reload(ctx); err != nil {
+			return nil, err
+		}
+		r.mu.RLock()
+		key = r.keys[kid]
+		r.mu.RUnlock()
+	}
+	if key == nil || key.method.Alg() != alg || r.expired(key) {
+		return nil, ErrUnknownSigningKey
+	}
+	return key.public, nil
+}
+
+// acceptLegacy 判断是否仍在切换算法后的宽限期内，超过宽限期后持有 jwt.auth_key 也无法伪造令牌
+func (r *redisKeyRing) acceptLegacy(ctx context.Context) bool {
+	r.mu.RLock()
+	loaded := !r.loadedAt.IsZero()
+	r.mu.RUnlock()
+	if !loaded {
+		if err := r.reload(ctx); err != nil {
+			return false
+		}
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return time.Now().UnixMilli() < r.legacyUntil
+}
+
+// jwks 获取当前签名密钥与宽限期内的旧密钥
+func (r *redisKeyRing) jwks(ctx context.Context) (JWKS, error) {
+	if _, err := r.signingKey(ctx); err != nil {
+		return JWKS{}, err
+	}
+	r.mu.RLock()
+	keys := make([]*signingKey, 0, len(r.keys))
+	for _, k := range r.keys {
+		if !r.expired(k) {
+			keys = append(keys, k)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].createdAt > keys[j].createdAt
+	})
+	res := JWKS{Keys: make([]JWK, 0, len(keys))}
+	for _, k := range keys {
+		if jwk, ok := k.jwk(); ok {
+			res.Keys = append(res.Keys, jwk)
+		}
+	}
+	return res, nil
+}
+
+// reload 从 Redis 加载全部密钥，并清理已过宽限期的旧密钥
+func (r *redisKeyRing) reload(ctx context.Context) error {
+	vals, err := r.client.HGetAll(ctx, jwtKeysKey).Result()
+	if err != nil {
+		return err
+	}
+	legacyUntil, err := r.client.Get(ctx, jwtLegacyUntilKey).Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return err
+	}
+
+	keys := make(map[string]*signingKey, len(vals))
+	var (
+		active  *signingKey
+		expired []string
+	)
+	for kid, val := range vals {
+		key, err := decodeKey(val, r.aead)
+		if err != nil {
+			return fmt.Errorf("decode jwt key %s: %w", kid, err)
+		}
+		if r.expired(key) {
+			expired = append(expired, kid)
+			continue
+		}
+		keys[kid] = key
+		if key.retireAt == 0 && key.method.Alg() == r.alg && (active == nil || key.createdAt > active.createdAt) {
+			active = key
+		}
+	}
+	if len(expired) > 0 {
+		if err := r.client.HDel(ctx, jwtKeysKey, expired...).Err(); err != nil {
+			return fmt.Errorf("delete expired jwt keys: %w", err)
+		}
+	}
+
+	r.mu.Lock()
+	r.keys, r.active, r.legacyUntil, r.loadedAt = keys, active, legacyUntil, time.Now()
+	r.mu.Unlock()
+	return nil
+}
+
+// rotate 生成新密钥并使当前密钥进入宽限期，通过分布式锁保证只有一个实例执行
+func (r *redisKeyRing) rotate(ctx context.Context) error {
+	ok, err := r.client.SetNX(ctx, jwtKeysLockKey, 1, rotateLockTTL).Result()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrSigningKeyNotReady
+	}
+	defer r.client.Del(ctx, jwtKeysLockKey)
+
+	// 加锁后重新确认，其他实例可能刚完成轮换
+	if err := r.reload(ctx); err != nil {
+		return err
+	}
+	r.mu.RLock()
+	active := r.active
+	r.mu.RUnlock()
+	if active != nil && !r.due(active) {
+		return nil
+	}
+
+	key, err := generateKey(r.alg)
+	if err != nil {
+		return err
+	}
+	val, err := encodeKey(key, r.aead)
+	if err != nil {
+		return err
+	}
+	fields := map[string]interface{}{key.kid: val}
+	r.mu.RLock()
+	first := len(r.keys) == 0
+	r.mu.RUnlock()
+	// 首次生成密钥即从 HS512 切换过来，此前签发的 HS512 令牌只在宽限期内有效
+	if first && r.legacy != nil {
+		if err := r.client.Set(ctx, jwtLegacyUntilKey, time.Now().Add(r.grace).UnixMilli(), r.grace).Err(); err != nil {
+			return err
+		}
+	}
+	// 算法切换时当前算法下没有签名密钥，其他算法的密钥同样进入宽限期
+	r.mu.RLock()
+	for _, k := range r.keys {
+		if k.retireAt != 0 {
+			continue
+		}
+		retired := *k
+		retired.retireAt = time.Now().Add(r.grace).UnixMilli()
+		if fields[k.kid], err = encodeKey(&retired, r.aead); err != nil {
+			r.mu.RUnlock()
+			return err
+		}
+	}
+	r.mu.RUnlock()
+	if err := r.client.HSet(ctx, jwtKeysKey, fields).Err(); err != nil {
+		return err
+	}
+	return r.reload(ctx)
+}
+
+// due 判断签名密钥是否到达轮换时间
+func (r *redisKeyRing) due(k *signingKey) bool {
+	return r.rotation > 0 && time.Since(time.UnixMilli(k.createdAt)) >= r.rotation
+}
+
+// expired 判断旧密钥是否已过宽限期
+func (r *redisKeyRing) expired(k *signingKey) bool {
+	return k.retireAt > 0 && time.Now().UnixMilli() >= k.retireAt
+}